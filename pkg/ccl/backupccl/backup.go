@@ -173,13 +173,17 @@ func getRelevantDescChanges(
 			return nil, err
 		}
 		for _, i := range starting {
+			// We need to add to interestingIDs so that if we later see a delete for
+			// this ID we still know it is interesting to us, even though we will not
+			// have a parentID at that point (since the delete is a nil desc).
 			if table := i.GetTable(); table != nil {
-				// We need to add to interestingIDs so that if we later see a delete for
-				// this ID we still know it is interesting to us, even though we will not
-				// have a parentID at that point (since the delete is a nil desc).
 				if _, ok := interestingParents[table.ParentID]; ok {
 					interestingIDs[table.ID] = struct{}{}
 				}
+			} else if typ := i.GetType(); typ != nil {
+				if _, ok := interestingParents[typ.ParentID]; ok {
+					interestingIDs[typ.ID] = struct{}{}
+				}
 			}
 			if _, ok := interestingIDs[i.GetID()]; ok {
 				desc := i
@@ -197,10 +201,10 @@ func getRelevantDescChanges(
 
 	for _, change := range allChanges {
 		// A change to an ID that we are interested in is obviously interesting --
-		// a change is also interesting if it is to a table that has a parent that
-		// we are interested and thereafter it also becomes an ID in which we are
-		// interested in changes (since, as mentioned above, to decide if deletes
-		// are interesting).
+		// a change is also interesting if it is to a table or type that has a
+		// parent that we are interested and thereafter it also becomes an ID in
+		// which we are interested in changes (since, as mentioned above, to decide
+		// if deletes are interesting).
 		if _, ok := interestingIDs[change.ID]; ok {
 			interestingChanges = append(interestingChanges, change)
 		} else if change.Desc != nil {
@@ -209,6 +213,11 @@ func getRelevantDescChanges(
 					interestingIDs[table.ID] = struct{}{}
 					interestingChanges = append(interestingChanges, change)
 				}
+			} else if typ := change.Desc.GetType(); typ != nil {
+				if _, ok := interestingParents[typ.ParentID]; ok {
+					interestingIDs[typ.ID] = struct{}{}
+					interestingChanges = append(interestingChanges, change)
+				}
 			}
 		}
 	}
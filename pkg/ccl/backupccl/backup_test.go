@@ -2249,6 +2249,30 @@ func TestRestoredPrivileges(t *testing.T) {
 	})
 }
 
+func TestBackupRestoreComments(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 1
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `COMMENT ON TABLE data.bank IS 'bank accounts'`)
+	sqlDB.Exec(t, `COMMENT ON COLUMN data.bank.balance IS 'current balance'`)
+	sqlDB.Exec(t, `COMMENT ON INDEX data.bank@primary IS 'account id'`)
+
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	sqlDB.Exec(t, `CREATE DATABASE data2`)
+	sqlDB.Exec(t, `RESTORE data.bank FROM $1 WITH into_db = 'data2'`, localFoo)
+
+	sqlDB.CheckQueryResults(t, `SHOW TABLES FROM data2 WITH COMMENT`,
+		[][]string{{"bank", "bank accounts"}})
+	sqlDB.CheckQueryResults(t, `SHOW COLUMNS FROM data2.bank WITH COMMENT`,
+		sqlDB.QueryStr(t, `SHOW COLUMNS FROM data.bank WITH COMMENT`))
+	sqlDB.CheckQueryResults(t,
+		`SELECT create_statement FROM [SHOW CREATE TABLE data2.bank]`,
+		sqlDB.QueryStr(t, `SELECT create_statement FROM [SHOW CREATE TABLE data.bank]`))
+}
+
 func TestRestoreInto(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -2854,6 +2878,73 @@ func TestBackupRestoreSequence(t *testing.T) {
 	})
 }
 
+func TestBackupRestoreEnum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	const numAccounts = 1
+	_, _, origDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+
+	origDB.Exec(t, `CREATE TYPE data.mood AS ENUM ('sad', 'ok', 'happy')`)
+	origDB.Exec(t, `CREATE TABLE data.t (name STRING PRIMARY KEY, m mood)`)
+	origDB.Exec(t, `INSERT INTO data.t VALUES ('moe', 'happy'), ('larry', 'sad'), ('curly', 'ok')`)
+
+	origDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+
+	t.Run("restore the database to a new cluster", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `RESTORE DATABASE data FROM $1`, localFoo)
+		newDB.Exec(t, `USE data`)
+
+		// The values are ordered by the labels of the restored type.
+		newDB.CheckQueryResults(t, `SELECT * FROM t ORDER BY m`, [][]string{
+			{"larry", "sad"},
+			{"curly", "ok"},
+			{"moe", "happy"},
+		})
+		newDB.Exec(t, `INSERT INTO t VALUES ('shemp', 'ok'::mood)`)
+
+		// Verify that the type <=> table dependency is still in place.
+		if _, err := newDB.DB.Exec(`DROP TYPE mood`); !testutils.IsError(err, `pq: cannot drop type "mood" because other objects depend on it`) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("restore just the table to a new cluster", func(t *testing.T) {
+		tc := testcluster.StartTestCluster(t, singleNode, base.TestClusterArgs{ServerArgs: args})
+		defer tc.Stopper().Stop(context.TODO())
+		newDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+		newDB.Exec(t, `CREATE DATABASE data`)
+		newDB.Exec(t, `USE data`)
+
+		// The type is restored along with the table using it.
+		newDB.Exec(t, `RESTORE TABLE t FROM $1`, localFoo)
+		newDB.CheckQueryResults(t, `SELECT name FROM t WHERE m > 'sad' ORDER BY name`, [][]string{
+			{"curly"},
+			{"moe"},
+		})
+		newDB.Exec(t, `CREATE TABLE u (m mood)`)
+		newDB.Exec(t, `INSERT INTO u SELECT m FROM t`)
+
+		if _, err := newDB.DB.Exec(`DROP TYPE mood`); !testutils.IsError(err, `pq: cannot drop type "mood" because other objects depend on it`) {
+			t.Fatal(err)
+		}
+
+		// The type cannot be restored over an existing one.
+		newDB.Exec(t, `CREATE DATABASE d2`)
+		newDB.Exec(t, `CREATE TYPE d2.mood AS ENUM ('a')`)
+		if _, err := newDB.DB.Exec(
+			`RESTORE TABLE t FROM $1 WITH OPTIONS ('into_db'='d2')`, localFoo,
+		); !testutils.IsError(err, `pq: relation "mood" already exists`) {
+			t.Fatal(err)
+		}
+	})
+}
+
 func TestBackupRestoreShowJob(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
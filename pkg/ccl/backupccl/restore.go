@@ -115,6 +115,8 @@ func loadSQLDescsFromBackupsAtTime(
 			if byID[t.ParentID] == nil {
 				continue
 			}
+		} else if t := desc.GetType(); t != nil && byID[t.ParentID] == nil {
+			continue
 		}
 		allDescs = append(allDescs, *desc)
 	}
@@ -184,7 +186,7 @@ func rewriteViewQueryDBNames(table *sqlbase.TableDescriptor, newDB string) error
 }
 
 // allocateTableRewrites determines the new ID and parentID (a "TableRewrite")
-// for each table in sqlDescs and returns a mapping from old ID to said
+// for each table and type in sqlDescs and returns a mapping from old ID to said
 // TableRewrite. It first validates that the provided sqlDescs can be restored
 // into their original database (or the database specified in opst) to avoid
 // leaking table IDs if we can be sure the restore would fail.
@@ -209,11 +211,14 @@ func allocateTableRewrites(
 
 	databasesByID := make(map[sqlbase.ID]*sqlbase.DatabaseDescriptor)
 	tablesByID := make(map[sqlbase.ID]*sqlbase.TableDescriptor)
+	typesByID := make(map[sqlbase.ID]*sqlbase.TypeDescriptor)
 	for _, desc := range sqlDescs {
 		if dbDesc := desc.GetDatabase(); dbDesc != nil {
 			databasesByID[dbDesc.ID] = dbDesc
 		} else if tableDesc := desc.GetTable(); tableDesc != nil {
 			tablesByID[tableDesc.ID] = tableDesc
+		} else if typeDesc := desc.GetType(); typeDesc != nil {
+			typesByID[typeDesc.ID] = typeDesc
 		}
 	}
 
@@ -254,6 +259,15 @@ func allocateTableRewrites(
 				}
 			}
 		}
+
+		// Check that referenced types exist.
+		for _, colType := range enumColumnTypes(table) {
+			if _, ok := typesByID[colType.EnumTypeID]; !ok {
+				return nil, errors.Errorf(
+					"cannot restore table %q without referenced type %d", table.Name, colType.EnumTypeID,
+				)
+			}
+		}
 	}
 
 	needsNewParentIDs := make(map[string][]sqlbase.ID)
@@ -272,21 +286,23 @@ func allocateTableRewrites(
 			}
 		}
 
-		for _, table := range tablesByID {
+		// allocateParent determines the database into which the table or type
+		// with the given ID is restored.
+		allocateParent := func(kind string, id, oldParentID sqlbase.ID, name string) error {
 			var targetDB string
 			if renaming {
 				targetDB = overrideDB
 			} else {
-				database, ok := databasesByID[table.ParentID]
+				database, ok := databasesByID[oldParentID]
 				if !ok {
-					return errors.Errorf("no database with ID %d in backup for table %q",
-						table.ParentID, table.Name)
+					return errors.Errorf("no database with ID %d in backup for %s %q",
+						oldParentID, kind, name)
 				}
 				targetDB = database.Name
 			}
 
 			if _, ok := restoreDBNames[targetDB]; ok {
-				needsNewParentIDs[targetDB] = append(needsNewParentIDs[targetDB], table.ID)
+				needsNewParentIDs[targetDB] = append(needsNewParentIDs[targetDB], id)
 			} else {
 				var parentID sqlbase.ID
 				{
@@ -295,8 +311,8 @@ func allocateTableRewrites(
 						return err
 					}
 					if existingDatabaseID.Value == nil {
-						return errors.Errorf("a database named %q needs to exist to restore table %q",
-							targetDB, table.Name)
+						return errors.Errorf("a database named %q needs to exist to restore %s %q",
+							targetDB, kind, name)
 					}
 
 					newParentID, err := existingDatabaseID.Value.GetInt()
@@ -306,9 +322,9 @@ func allocateTableRewrites(
 					parentID = sqlbase.ID(newParentID)
 				}
 
-				// Check that the table name is _not_ in use.
+				// Check that the name is _not_ in use.
 				// This would fail the CPut later anyway, but this yields a prettier error.
-				if err := CheckTableExists(ctx, txn, parentID, table.Name); err != nil {
+				if err := CheckTableExists(ctx, txn, parentID, name); err != nil {
 					return err
 				}
 
@@ -324,9 +340,21 @@ func allocateTableRewrites(
 						return err
 					}
 				}
-				// Create the table rewrite with the new parent ID. We've done all the
+				// Create the rewrite with the new parent ID. We've done all the
 				// up-front validation that we can.
-				tableRewrites[table.ID] = &jobspb.RestoreDetails_TableRewrite{ParentID: parentID}
+				tableRewrites[id] = &jobspb.RestoreDetails_TableRewrite{ParentID: parentID}
+			}
+			return nil
+		}
+
+		for _, table := range tablesByID {
+			if err := allocateParent("table", table.ID, table.ParentID, table.Name); err != nil {
+				return err
+			}
+		}
+		for _, typ := range typesByID {
+			if err := allocateParent("type", typ.ID, typ.ParentID, typ.Name); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -334,7 +362,7 @@ func allocateTableRewrites(
 		return nil, err
 	}
 
-	// Allocate new IDs for each database and table.
+	// Allocate new IDs for each database, table and type.
 	//
 	// NB: we do this in a standalone transaction, not one that covers the
 	// entire restore since restarts would be terrible (and our bulk import
@@ -371,6 +399,19 @@ func allocateTableRewrites(
 		tableRewrites[table.ID].TableID = newTableID
 	}
 
+	typeIDs := make([]sqlbase.ID, 0, len(typesByID))
+	for id := range typesByID {
+		typeIDs = append(typeIDs, id)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	for _, id := range typeIDs {
+		newTypeID, err := sql.GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+		if err != nil {
+			return nil, err
+		}
+		tableRewrites[id].TableID = newTypeID
+	}
+
 	return tableRewrites, nil
 }
 
@@ -505,9 +546,41 @@ func RewriteTableDescs(
 				}
 			}
 			col.UsesSequenceIds = newSeqRefs
+
+			// Ownership of sequences that aren't being restored is dropped.
+			var newOwnedSeqs []sqlbase.ID
+			for _, seqID := range col.OwnsSequenceIds {
+				if rewrite, ok := tableRewrites[seqID]; ok {
+					newOwnedSeqs = append(newOwnedSeqs, rewrite.TableID)
+				}
+			}
+			col.OwnsSequenceIds = newOwnedSeqs
 			table.Columns[idx] = col
 		}
 
+		// Rewrite the owner of sequences, which is dropped if the owning table
+		// isn't being restored.
+		if opts := table.SequenceOpts; opts != nil && opts.OwnerTableID != sqlbase.InvalidID {
+			if rewrite, ok := tableRewrites[opts.OwnerTableID]; ok {
+				opts.OwnerTableID = rewrite.TableID
+			} else {
+				opts.OwnerTableID = sqlbase.InvalidID
+				opts.OwnerColumnID = 0
+			}
+		}
+
+		// Rewrite the references to types, which are always restored with the
+		// tables using them.
+		for _, colType := range enumColumnTypes(table) {
+			typeRewrite, ok := tableRewrites[colType.EnumTypeID]
+			if !ok {
+				return errors.Errorf(
+					"cannot restore table %q without referenced type %d", table.Name, colType.EnumTypeID,
+				)
+			}
+			colType.EnumTypeID = typeRewrite.TableID
+		}
+
 		// since this is a "new" table in eyes of new cluster, any leftover change
 		// lease is obviously bogus (plus the nodeID is relative to backup cluster).
 		table.Lease = nil
@@ -515,6 +588,28 @@ func RewriteTableDescs(
 	return nil
 }
 
+// rewriteTypeDescs mutates types to match the ID and parent specified in
+// tableRewrites. References from tables that are not restored are dropped.
+func rewriteTypeDescs(typeDescs []*sqlbase.TypeDescriptor, tableRewrites TableRewriteMap) error {
+	for _, typ := range typeDescs {
+		typeRewrite, ok := tableRewrites[typ.ID]
+		if !ok {
+			return errors.Errorf("missing type rewrite for type %d", typ.ID)
+		}
+		typ.ID = typeRewrite.TableID
+		typ.ParentID = typeRewrite.ParentID
+
+		origRefs := typ.ReferencingDescriptorIDs
+		typ.ReferencingDescriptorIDs = nil
+		for _, ref := range origRefs {
+			if refRewrite, ok := tableRewrites[ref]; ok {
+				typ.ReferencingDescriptorIDs = append(typ.ReferencingDescriptorIDs, refRewrite.TableID)
+			}
+		}
+	}
+	return nil
+}
+
 type intervalSpan roachpb.Span
 
 var _ interval.Interface = intervalSpan{}
@@ -870,13 +965,14 @@ func splitAndScatter(
 
 // WriteTableDescs writes all the the new descriptors: First the ID ->
 // TableDescriptor for the new table, then flip (or initialize) the name -> ID
-// entry so any new queries will use the new one. The tables are assigned the
-// permissions of their parent database and the user must have CREATE permission
-// on that database at the time this function is called.
+// entry so any new queries will use the new one. The tables and types are
+// assigned the permissions of their parent database and the user must have
+// CREATE permission on that database at the time this function is called.
 func WriteTableDescs(
 	ctx context.Context,
 	txn *client.Txn,
 	databases []*sqlbase.DatabaseDescriptor,
+	typeDescs []*sqlbase.TypeDescriptor,
 	tables []*sqlbase.TableDescriptor,
 	user string,
 	settings *cluster.Settings,
@@ -894,22 +990,37 @@ func WriteTableDescs(
 			b.CPut(sqlbase.MakeDescMetadataKey(desc.ID), sqlbase.WrapDescriptor(desc), nil)
 			b.CPut(sqlbase.MakeNameMetadataKey(keys.RootNamespaceID, desc.Name), desc.ID, nil)
 		}
+		parentPrivileges := func(parentID sqlbase.ID) (*sqlbase.PrivilegeDescriptor, error) {
+			if wrote, ok := wroteDBs[parentID]; ok {
+				return wrote.GetPrivileges(), nil
+			}
+			parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, parentID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to lookup parent DB %d", parentID)
+			}
+			// TODO(mberhault): CheckPrivilege wants a planner.
+			if err := sql.CheckPrivilegeForUser(ctx, user, parentDB, privilege.CREATE); err != nil {
+				return nil, err
+			}
+			// Default is to copy privs from restoring parent db, like CREATE TABLE.
+			// TODO(dt): Make this more configurable.
+			return parentDB.GetPrivileges(), nil
+		}
+		for _, typ := range typeDescs {
+			privs, err := parentPrivileges(typ.ParentID)
+			if err != nil {
+				return err
+			}
+			typ.Privileges = privs
+			b.CPut(sqlbase.MakeDescMetadataKey(typ.ID), sqlbase.WrapDescriptor(typ), nil)
+			b.CPut(sqlbase.MakeNameMetadataKey(typ.ParentID, typ.Name), typ.ID, nil)
+		}
 		for _, table := range tables {
-			if wrote, ok := wroteDBs[table.ParentID]; ok {
-				table.Privileges = wrote.GetPrivileges()
-			} else {
-				parentDB, err := sqlbase.GetDatabaseDescFromID(ctx, txn, table.ParentID)
-				if err != nil {
-					return errors.Wrapf(err, "failed to lookup parent DB %d", table.ParentID)
-				}
-				// TODO(mberhault): CheckPrivilege wants a planner.
-				if err := sql.CheckPrivilegeForUser(ctx, user, parentDB, privilege.CREATE); err != nil {
-					return err
-				}
-				// Default is to copy privs from restoring parent db, like CREATE TABLE.
-				// TODO(dt): Make this more configurable.
-				table.Privileges = parentDB.GetPrivileges()
+			privs, err := parentPrivileges(table.ParentID)
+			if err != nil {
+				return err
 			}
+			table.Privileges = privs
 			b.CPut(table.GetDescMetadataKey(), sqlbase.WrapDescriptor(table), nil)
 			b.CPut(table.GetNameMetadataKey(), table.ID, nil)
 		}
@@ -923,6 +1034,11 @@ func WriteTableDescs(
 			return err
 		}
 
+		for _, typ := range typeDescs {
+			if err := typ.Validate(); err != nil {
+				return errors.Wrapf(err, "validate type %d", typ.ID)
+			}
+		}
 		for _, table := range tables {
 			if err := table.Validate(ctx, txn, settings); err != nil {
 				return errors.Wrapf(err, "validate table %d", table.ID)
@@ -967,7 +1083,13 @@ func restore(
 	overrideDB string,
 	job *jobs.Job,
 	resultsCh chan<- tree.Datums,
-) (roachpb.BulkOpSummary, []*sqlbase.DatabaseDescriptor, []*sqlbase.TableDescriptor, error) {
+) (
+	roachpb.BulkOpSummary,
+	[]*sqlbase.DatabaseDescriptor,
+	[]*sqlbase.TypeDescriptor,
+	[]*sqlbase.TableDescriptor,
+	error,
+) {
 	// A note about contexts and spans in this method: the top-level context
 	// `restoreCtx` is used for orchestration logging. All operations that carry
 	// out work get their individual contexts.
@@ -982,6 +1104,7 @@ func restore(
 	}
 
 	var databases []*sqlbase.DatabaseDescriptor
+	var typeDescs []*sqlbase.TypeDescriptor
 	var tables []*sqlbase.TableDescriptor
 	var oldTableIDs []sqlbase.ID
 	for _, desc := range sqlDescs {
@@ -989,6 +1112,9 @@ func restore(
 			tables = append(tables, tableDesc)
 			oldTableIDs = append(oldTableIDs, tableDesc.ID)
 		}
+		if typeDesc := desc.GetType(); typeDesc != nil {
+			typeDescs = append(typeDescs, typeDesc)
+		}
 		if dbDesc := desc.GetDatabase(); dbDesc != nil {
 			if rewrite, ok := tableRewrites[dbDesc.ID]; ok {
 				dbDesc.ID = rewrite.TableID
@@ -1006,7 +1132,10 @@ func restore(
 	// Assign new IDs and privileges to the tables, and update all references to
 	// use the new IDs.
 	if err := RewriteTableDescs(tables, tableRewrites, overrideDB); err != nil {
-		return mu.res, nil, nil, err
+		return mu.res, nil, nil, nil, err
+	}
+	if err := rewriteTypeDescs(typeDescs, tableRewrites); err != nil {
+		return mu.res, nil, nil, nil, err
 	}
 
 	// Get TableRekeys to use when importing raw data.
@@ -1014,7 +1143,7 @@ func restore(
 	for i := range tables {
 		newDescBytes, err := protoutil.Marshal(sqlbase.WrapDescriptor(tables[i]))
 		if err != nil {
-			return mu.res, nil, nil, errors.Wrap(err, "marshaling descriptor")
+			return mu.res, nil, nil, nil, errors.Wrap(err, "marshaling descriptor")
 		}
 		rekeys = append(rekeys, roachpb.ImportRequest_TableRekey{
 			OldID:   uint32(oldTableIDs[i]),
@@ -1023,7 +1152,7 @@ func restore(
 	}
 	kr, err := storageccl.MakeKeyRewriterFromRekeys(rekeys)
 	if err != nil {
-		return mu.res, nil, nil, err
+		return mu.res, nil, nil, nil, err
 	}
 
 	// Pivot the backups, which are grouped by time, into requests for import,
@@ -1031,7 +1160,7 @@ func restore(
 	highWaterMark := job.Progress().Details.(*jobspb.Progress_Restore).Restore.HighWater
 	importSpans, _, err := makeImportSpans(spans, backupDescs, highWaterMark, errOnMissingRange)
 	if err != nil {
-		return mu.res, nil, nil, errors.Wrapf(err, "making import requests for %d backups", len(backupDescs))
+		return mu.res, nil, nil, nil, errors.Wrapf(err, "making import requests for %d backups", len(backupDescs))
 	}
 
 	for i := range importSpans {
@@ -1104,7 +1233,7 @@ func restore(
 	for readyForImportSpan := range readyForImportCh {
 		newSpan, err := kr.RewriteSpan(readyForImportSpan.Span)
 		if err != nil {
-			return mu.res, nil, nil, err
+			return mu.res, nil, nil, nil, err
 		}
 		idx := readyForImportSpan.progressIdx
 
@@ -1124,7 +1253,7 @@ func restore(
 		select {
 		case importsSem <- struct{}{}:
 		case <-g.Done:
-			return mu.res, nil, nil, errors.Wrapf(g.Wait(), "importing %d ranges", len(importSpans))
+			return mu.res, nil, nil, nil, errors.Wrapf(g.Wait(), "importing %d ranges", len(importSpans))
 		}
 
 		g.GoCtx(func(ctx context.Context) error {
@@ -1165,10 +1294,10 @@ func restore(
 		// This leaves the data that did get imported in case the user wants to
 		// retry.
 		// TODO(dan): Build tooling to allow a user to restart a failed restore.
-		return mu.res, nil, nil, errors.Wrapf(err, "importing %d ranges", len(importSpans))
+		return mu.res, nil, nil, nil, errors.Wrapf(err, "importing %d ranges", len(importSpans))
 	}
 
-	return mu.res, databases, tables, nil
+	return mu.res, databases, typeDescs, tables, nil
 }
 
 // RestoreHeader is the header for RESTORE stmt results.
@@ -1376,6 +1505,7 @@ type restoreResumer struct {
 	settings  *cluster.Settings
 	res       roachpb.BulkOpSummary
 	databases []*sqlbase.DatabaseDescriptor
+	types     []*sqlbase.TypeDescriptor
 	tables    []*sqlbase.TableDescriptor
 }
 
@@ -1390,7 +1520,7 @@ func (r *restoreResumer) Resume(
 		return err
 	}
 
-	res, databases, tables, err := restore(
+	res, databases, typeDescs, tables, err := restore(
 		ctx,
 		p.ExecCfg().DB,
 		p.ExecCfg().Gossip,
@@ -1404,6 +1534,7 @@ func (r *restoreResumer) Resume(
 	)
 	r.res = res
 	r.databases = databases
+	r.types = typeDescs
 	r.tables = tables
 	return err
 }
@@ -1433,7 +1564,7 @@ func (r *restoreResumer) OnSuccess(ctx context.Context, txn *client.Txn, job *jo
 	// Write the new TableDescriptors and flip the namespace entries over to
 	// them. After this call, any queries on a table will be served by the newly
 	// restored data.
-	if err := WriteTableDescs(ctx, txn, r.databases, r.tables, job.Payload().Username, r.settings, nil); err != nil {
+	if err := WriteTableDescs(ctx, txn, r.databases, r.types, r.tables, job.Payload().Username, r.settings, nil); err != nil {
 		return errors.Wrapf(err, "restoring %d TableDescriptors", len(r.tables))
 	}
 
@@ -18,7 +18,8 @@ import (
 )
 
 type descriptorsMatched struct {
-	// all tables that match targets plus their parent databases.
+	// all tables that match targets plus their parent databases and the enum
+	// types they use.
 	descs []sqlbase.Descriptor
 
 	// the databases from which all tables were matched (eg a.* or DATABASE a).
@@ -119,7 +120,8 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 	// Now on to the tables.
 	for _, desc := range descs {
 		if tbDesc := desc.GetTable(); tbDesc != nil {
-			if tbDesc.Dropped() {
+			// Temporary tables belong to a session and are not backed up.
+			if tbDesc.Dropped() || tbDesc.IsTemporary() {
 				continue
 			}
 			parentDesc, ok := r.descByID[tbDesc.ParentID]
@@ -148,7 +150,9 @@ func newDescriptorResolver(descs []sqlbase.Descriptor) (*descriptorResolver, err
 
 // descriptorsMatchingTargets returns the descriptors that match the targets. A
 // database descriptor is included in this set if it matches the targets (or the
-// session database) or if one of its tables matches the targets. All expanded
+// session database) or if one of its tables matches the targets. A type
+// descriptor is included if one of the matched tables uses it or if its
+// database is expanded. All expanded
 // DBs, via either `foo.*` or `DATABASE foo` are noted, as are those explicitly
 // named as DBs (e.g. with `DATABASE foo`, not `foo.*`). These distinctions are
 // used e.g. by RESTORE.
@@ -260,5 +264,48 @@ func descriptorsMatchingTargets(
 		}
 	}
 
+	// Finally pull in the types, which are needed to restore the columns that
+	// use them. A type missing from the descriptors is reported by RESTORE.
+	alreadyRequestedTypes := make(map[sqlbase.ID]struct{})
+	requestType := func(desc sqlbase.Descriptor) {
+		if _, ok := alreadyRequestedTypes[desc.GetID()]; !ok {
+			alreadyRequestedTypes[desc.GetID()] = struct{}{}
+			ret.descs = append(ret.descs, desc)
+		}
+	}
+	for _, desc := range ret.descs {
+		if tbDesc := desc.GetTable(); tbDesc != nil {
+			for _, colType := range enumColumnTypes(tbDesc) {
+				if typDesc, ok := resolver.descByID[colType.EnumTypeID]; ok && typDesc.GetType() != nil {
+					requestType(typDesc)
+				}
+			}
+		}
+	}
+	for _, desc := range resolver.descByID {
+		if typDesc := desc.GetType(); typDesc != nil {
+			if _, ok := alreadyExpandedDBs[typDesc.ParentID]; ok {
+				requestType(desc)
+			}
+		}
+	}
+
 	return ret, nil
 }
+
+// enumColumnTypes returns the types of all the enum columns of the table,
+// including the columns of its mutations.
+func enumColumnTypes(table *sqlbase.TableDescriptor) []*sqlbase.ColumnType {
+	var res []*sqlbase.ColumnType
+	for i := range table.Columns {
+		if table.Columns[i].Type.SemanticType == sqlbase.ColumnType_ENUM {
+			res = append(res, &table.Columns[i].Type)
+		}
+	}
+	for i := range table.Mutations {
+		if col := table.Mutations[i].GetColumn(); col != nil && col.Type.SemanticType == sqlbase.ColumnType_ENUM {
+			res = append(res, &col.Type)
+		}
+	}
+	return res
+}
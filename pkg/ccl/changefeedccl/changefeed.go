@@ -71,10 +71,11 @@ type emitEntry struct {
 func kvsToRows(
 	leaseMgr *sql.LeaseManager,
 	tableHist *tableHistory,
+	evalCtx *tree.EvalContext,
 	details jobspb.ChangefeedDetails,
 	inputFn func(context.Context) (bufferEntry, error),
 ) func(context.Context) ([]emitEntry, error) {
-	rfCache := newRowFetcherCache(leaseMgr, tableHist)
+	rfCache := newRowFetcherCache(leaseMgr, tableHist, evalCtx)
 
 	var kvs sqlbase.SpanKVFetcher
 	appendEmitEntryForKV := func(
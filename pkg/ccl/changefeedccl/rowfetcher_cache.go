@@ -13,6 +13,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -28,15 +29,20 @@ type rowFetcherCache struct {
 	leaseMgr  *sql.LeaseManager
 	tableHist *tableHistory
 	fetchers  map[*sqlbase.TableDescriptor]*sqlbase.RowFetcher
+	// evalCtx is used to compute virtual computed columns.
+	evalCtx *tree.EvalContext
 
 	a sqlbase.DatumAlloc
 }
 
-func newRowFetcherCache(leaseMgr *sql.LeaseManager, tableHist *tableHistory) *rowFetcherCache {
+func newRowFetcherCache(
+	leaseMgr *sql.LeaseManager, tableHist *tableHistory, evalCtx *tree.EvalContext,
+) *rowFetcherCache {
 	return &rowFetcherCache{
 		leaseMgr:  leaseMgr,
 		tableHist: tableHist,
 		fetchers:  make(map[*sqlbase.TableDescriptor]*sqlbase.RowFetcher),
+		evalCtx:   evalCtx,
 	}
 }
 
@@ -104,7 +110,7 @@ func (c *rowFetcherCache) RowFetcherForTableDesc(
 
 	var rf sqlbase.RowFetcher
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &c.a,
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &c.a, c.evalCtx,
 		sqlbase.RowFetcherTableArgs{
 			Spans:            tableDesc.AllIndexSpans(),
 			Desc:             tableDesc,
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 )
 
 // dumpCmd dumps SQL tables.
@@ -658,6 +659,8 @@ func dumpTableData(w io.Writer, conn *sqlConn, clusterTS string, bmd basicMetada
 					case coltypes.Time:
 						// pq awkwardly represents TIME as a time.Time with date 0000-01-01.
 						d = tree.MakeDTime(timeofday.FromTime(t))
+					case coltypes.TimeTZ:
+						d = tree.NewDTimeTZ(timetz.MakeTimeTZFromTime(t))
 					case coltypes.Timestamp:
 						d = tree.MakeDTimestamp(t, time.Nanosecond)
 					case coltypes.TimestampWithTZ:
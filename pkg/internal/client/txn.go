@@ -317,6 +317,39 @@ func (txn *Txn) DisablePipelining() error {
 	return txn.mu.sender.DisablePipelining()
 }
 
+// CreateSavepoint establishes a savepoint at the current point of the
+// transaction. The writes performed after it can be rolled back with
+// RollbackToSavepoint.
+func (txn *Txn) CreateSavepoint(ctx context.Context) (SavepointToken, error) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.CreateSavepoint(ctx)
+}
+
+// RollbackToSavepoint rolls back the writes performed since the savepoint was
+// created. The transaction can be used again afterwards even if it
+// encountered a non-retriable error since then.
+func (txn *Txn) RollbackToSavepoint(ctx context.Context, s SavepointToken) error {
+	txn.mu.Lock()
+	sender := txn.mu.sender
+	txn.mu.Unlock()
+	err := sender.RollbackToSavepoint(ctx, s)
+	if err != nil {
+		txn.mu.Lock()
+		txn.handleErrIfRetryableLocked(ctx, err)
+		txn.mu.Unlock()
+	}
+	return err
+}
+
+// ReleaseSavepoint releases the savepoint. The writes performed since it was
+// created can no longer be rolled back to it.
+func (txn *Txn) ReleaseSavepoint(ctx context.Context, s SavepointToken) error {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.ReleaseSavepoint(ctx, s)
+}
+
 // NewBatch creates and returns a new empty batch object for use with the Txn.
 func (txn *Txn) NewBatch() *Batch {
 	return &Batch{txn: txn}
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -266,6 +267,11 @@ func (r *RSG) GenerateRandomArg(typ types.T) string {
 		i := r.Int63n(int64(timeofday.Max))
 		d := tree.MakeDTime(timeofday.FromInt(i))
 		v = fmt.Sprintf(`'%s'`, d)
+	case types.TimeTZ:
+		r.lock.Lock()
+		t := timetz.Random(r.src)
+		r.lock.Unlock()
+		v = fmt.Sprintf(`'%s'`, t)
 	case types.Interval:
 		d := duration.Duration{Nanos: r.Int63()}
 		v = fmt.Sprintf(`'%s'`, &tree.DInterval{Duration: d})
@@ -131,6 +131,10 @@ type TxnCoordSender struct {
 
 		// onFinishFn is a closure invoked when state changes to done or aborted.
 		onFinishFn func(error)
+
+		// activeSavepoints is the number of savepoints of the current epoch
+		// that have been created and not yet released.
+		activeSavepoints int
 	}
 
 	// A pointer member to the creating factory provides access to
@@ -837,6 +841,7 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	for _, reqInt := range tc.interceptorStack {
 		reqInt.epochBumpedLocked()
 	}
+	tc.resetSavepointsLocked()
 	return retErr
 }
 
@@ -1026,6 +1031,7 @@ func (tc *TxnCoordSender) ManualRestart(
 	for _, reqInt := range tc.interceptorStack {
 		reqInt.epochBumpedLocked()
 	}
+	tc.resetSavepointsLocked()
 
 	// The txn might have entered the txnError state after the epoch was bumped.
 	// Reset the state for the retry.
@@ -0,0 +1,149 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// savepoint is the client.SavepointToken used by the TxnCoordSender. It
+// captures the value of the transaction's sequence number counter at the
+// time the savepoint was created: the writes performed with a higher
+// sequence number are the ones rolled back by a rollback to the savepoint.
+type savepoint struct {
+	txnID  uuid.UUID
+	epoch  uint32
+	seqNum int32
+}
+
+var _ client.SavepointToken = &savepoint{}
+
+// CreateSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) CreateSavepoint(ctx context.Context) (client.SavepointToken, error) {
+	if tc.typ != client.RootTxn {
+		return nil, errors.New("cannot create savepoint in a leaf txn")
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if pErr := tc.maybeRejectClientLocked(ctx, nil /* ba */); pErr != nil {
+		return nil, pErr.GoError()
+	}
+
+	// Once the transaction has a savepoint, the intents it overwrites need to
+	// remember the values they replace so that they can be restored.
+	tc.mu.activeSavepoints++
+	tc.mu.txn.HasSavepoints = true
+	return &savepoint{
+		txnID:  tc.mu.txn.ID,
+		epoch:  tc.mu.txn.Epoch,
+		seqNum: tc.interceptorAlloc.txnSeqNumAllocator.seqNumCounter,
+	}, nil
+}
+
+// RollbackToSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) RollbackToSavepoint(
+	ctx context.Context, s client.SavepointToken,
+) error {
+	sp := s.(*savepoint)
+
+	tc.mu.Lock()
+	if tc.mu.txnState == txnFinalized {
+		tc.mu.Unlock()
+		return errors.New("cannot roll back to savepoint of a committed or rolled back txn")
+	}
+	if sp.txnID != tc.mu.txn.ID || sp.epoch != tc.mu.txn.Epoch {
+		tc.mu.Unlock()
+		return errors.New("cannot roll back to savepoint of a previous txn attempt")
+	}
+
+	// Rolling back to the savepoint undoes the writes that may have caused a
+	// non-retriable error, so the transaction can be used again.
+	if tc.mu.txnState == txnError {
+		tc.mu.txnState = txnPending
+	}
+
+	// The writes after the savepoint are rolled back by resolving the
+	// transaction's own intents, ignoring the values written at the sequence
+	// numbers allocated since the savepoint was created.
+	var ba roachpb.BatchRequest
+	ignored := enginepb.IgnoredSeqNumRange{
+		Start: sp.seqNum + 1,
+		End:   tc.interceptorAlloc.txnSeqNumAllocator.seqNumCounter,
+	}
+	if ignored.Start <= ignored.End {
+		intentSpans, _ := roachpb.MergeSpans(
+			append([]roachpb.Span(nil), tc.interceptorAlloc.txnIntentCollector.intents...),
+		)
+		for _, span := range intentSpans {
+			if len(span.EndKey) == 0 {
+				span.EndKey = span.Key.Next()
+			}
+			ba.Add(&roachpb.ResolveIntentRangeRequest{
+				RequestHeader:  roachpb.RequestHeaderFromSpan(span),
+				IntentTxn:      tc.mu.txn.TxnMeta,
+				Status:         roachpb.PENDING,
+				IgnoredSeqNums: []enginepb.IgnoredSeqNumRange{ignored},
+			})
+		}
+	}
+	tc.mu.Unlock()
+
+	if len(ba.Requests) == 0 {
+		return nil
+	}
+	// The batch goes through the interceptor stack like any other request of
+	// the transaction. In particular, the pipeliner makes it wait for all the
+	// outstanding writes, some of which may be among the ones rolled back.
+	_, pErr := tc.Send(ctx, ba)
+	return pErr.GoError()
+}
+
+// ReleaseSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) ReleaseSavepoint(ctx context.Context, s client.SavepointToken) error {
+	sp := s.(*savepoint)
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	// The savepoints of previous transaction attempts were already discarded
+	// when the epoch was bumped.
+	if sp.txnID != tc.mu.txn.ID || sp.epoch != tc.mu.txn.Epoch {
+		return nil
+	}
+	if tc.mu.activeSavepoints > 0 {
+		tc.mu.activeSavepoints--
+	}
+	if tc.mu.activeSavepoints == 0 {
+		tc.mu.txn.HasSavepoints = false
+	}
+	return nil
+}
+
+// resetSavepointsLocked discards the savepoints of the transaction. It is
+// called when the transaction's epoch is bumped, since the writes of the
+// previous epoch can no longer be rolled back to.
+func (tc *TxnCoordSender) resetSavepointsLocked() {
+	tc.mu.activeSavepoints = 0
+	tc.mu.txn.HasSavepoints = false
+}
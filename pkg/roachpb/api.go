@@ -1006,9 +1006,22 @@ func (drr *DeleteRangeRequest) flags() int {
 // Note that ClearRange commands cannot be part of a transaction as
 // they clear all MVCC versions.
 func (*ClearRangeRequest) flags() int { return isWrite | isRange | isAlone }
-func (*ScanRequest) flags() int       { return isRead | isRange | isTxn | updatesReadTSCache | needsRefresh }
-func (*ReverseScanRequest) flags() int {
-	return isRead | isRange | isReverse | isTxn | updatesReadTSCache | needsRefresh
+
+// Locking scans lay down intents on the keys they return, so they are
+// evaluated like transactional writes in addition to reads.
+func (sr *ScanRequest) flags() int {
+	flags := isRead | isRange | isTxn | updatesReadTSCache | needsRefresh
+	if sr.KeyLocking {
+		flags |= isWrite | isTxnWrite | consultsTSCache
+	}
+	return flags
+}
+func (rsr *ReverseScanRequest) flags() int {
+	flags := isRead | isRange | isReverse | isTxn | updatesReadTSCache | needsRefresh
+	if rsr.KeyLocking {
+		flags |= isWrite | isTxnWrite | consultsTSCache
+	}
+	return flags
 }
 func (*BeginTransactionRequest) flags() int { return isWrite | isTxn | consultsTSCache }
 
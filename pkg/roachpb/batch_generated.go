@@ -70,6 +70,8 @@ func (ru ErrorDetail) GetInner() error {
 		return t.MergeInProgress
 	case *ErrorDetail_RangefeedRetry:
 		return t.RangefeedRetry
+	case *ErrorDetail_LockNotAvailable:
+		return t.LockNotAvailable
 	default:
 		return nil
 	}
@@ -311,6 +313,8 @@ func (ru *ErrorDetail) SetInner(r error) bool {
 		union = &ErrorDetail_MergeInProgress{t}
 	case *RangeFeedRetryError:
 		union = &ErrorDetail_RangefeedRetry{t}
+	case *LockNotAvailableError:
+		union = &ErrorDetail_LockNotAvailable{t}
 	default:
 		return false
 	}
@@ -703,3 +703,20 @@ func (e *RangeFeedRetryError) message(pErr *Error) string {
 }
 
 var _ ErrorDetailInterface = &RangeFeedRetryError{}
+
+// NewLockNotAvailableError initializes a new LockNotAvailableError.
+func NewLockNotAvailableError(intent Intent) *LockNotAvailableError {
+	return &LockNotAvailableError{
+		Intent: intent,
+	}
+}
+
+func (e *LockNotAvailableError) Error() string {
+	return e.message(nil)
+}
+
+func (e *LockNotAvailableError) message(_ *Error) string {
+	return fmt.Sprintf("conflicting lock on %s held by txn %s", e.Intent.Key, e.Intent.Txn.ID.Short())
+}
+
+var _ ErrorDetailInterface = &LockNotAvailableError{}
@@ -592,6 +592,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		StatusServer:            s.status,
 		SessionRegistry:         s.sessionRegistry,
 		JobRegistry:             s.jobRegistry,
+		NotificationRegistry:    sql.NewNotificationRegistry(s.cfg.AmbientCtx, s.st, s.db),
 		VirtualSchemas:          virtualSchemas,
 		HistogramWindowInterval: s.cfg.HistogramWindowInterval(),
 		RangeDescriptorCache:    s.distSender.RangeDescriptorCache(),
@@ -1530,6 +1531,9 @@ func (s *Server) Start(ctx context.Context) error {
 		s.execCfg.DistSQLPlanner,
 	).Start(s.stopper)
 
+	sql.NewTemporaryObjectCleaner(s.execCfg, s.nodeLiveness.IsLive).Start(s.stopper)
+	s.execCfg.NotificationRegistry.Start(s.stopper)
+
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, s.stopper)
 
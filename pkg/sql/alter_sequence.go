@@ -56,6 +56,9 @@ func (n *alterSequenceNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if err := params.p.processSequenceOwnedBy(params.ctx, desc, n.n.Options); err != nil {
+		return err
+	}
 
 	if err := params.p.writeSchemaChange(params.ctx, n.seqDesc, sqlbase.InvalidMutationID); err != nil {
 		return err
@@ -66,6 +66,22 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 		return nil, err
 	}
 
+	// Resolve the user-defined types of new and altered columns.
+	for _, cmd := range n.Cmds {
+		var typ coltypes.T
+		switch t := cmd.(type) {
+		case *tree.AlterTableAddColumn:
+			typ = t.ColumnDef.Type
+		case *tree.AlterTableAlterColumnType:
+			typ = t.ToType
+		default:
+			continue
+		}
+		if err := p.resolveColumnType(ctx, typ, tableDesc.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	// See if there's any "inject statistics" in the query and type check the
 	// expressions.
 	statsData := make(map[int]tree.TypedExpr)
@@ -95,6 +111,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 	// the list.
 	descriptorChanged := false
 	origNumMutations := len(n.tableDesc.Mutations)
+	origTypeIDs := n.tableDesc.EnumTypeIDs()
 	var droppedViews []string
 	tn := n.n.Table.TableName()
 
@@ -195,7 +212,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 					Unique:           true,
 					StoreColumnNames: d.Storing.ToStrings(),
 				}
-				if err := idx.FillColumns(d.Columns); err != nil {
+				elems, newCols, err := replaceIndexExprs(
+					params.ctx, n.tableDesc, tn, d.Columns, &params.p.semaCtx, params.EvalContext(),
+				)
+				if err != nil {
+					return err
+				}
+				for _, col := range newCols {
+					n.tableDesc.AddColumnMutation(col, sqlbase.DescriptorMutation_ADD)
+				}
+				if err := idx.FillColumns(elems); err != nil {
 					return err
 				}
 				if d.PartitionBy != nil {
@@ -258,6 +284,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 				if err != nil {
 					return err
 				}
+				if err := checkTemporaryForeignKeyTargets(n.tableDesc.IsTemporary(), affected); err != nil {
+					return err
+				}
 				descriptorChanged = true
 				for _, updated := range affected {
 					if err := params.p.writeSchemaChange(params.ctx, updated, sqlbase.InvalidMutationID); err != nil {
@@ -285,6 +314,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if dropped {
 				continue
 			}
+			if n.tableDesc.PendingNotNullMutation(col.ID) != nil {
+				return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+					"column %q has a NOT NULL constraint in the middle of being added, try again later",
+					col.Name)
+			}
 
 			// If the dropped column uses a sequence, remove references to it from that sequence.
 			if len(col.UsesSequenceIds) > 0 {
@@ -293,6 +327,29 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+			// Sequences owned by the dropped column are dropped along with it.
+			if len(col.OwnsSequenceIds) > 0 {
+				if err := params.p.canDropOwnedSequences(
+					params.ctx, &col, "column", string(t.Column),
+					func(ref sqlbase.TableDescriptor_Reference) bool {
+						if ref.ID != n.tableDesc.ID {
+							return false
+						}
+						for _, colID := range ref.ColumnIDs {
+							if colID != col.ID {
+								return false
+							}
+						}
+						return len(ref.ColumnIDs) > 0
+					},
+				); err != nil {
+					return err
+				}
+				if err := params.p.dropOwnedSequences(params.ctx, &col); err != nil {
+					return err
+				}
+			}
+
 			// You can't drop a column depended on by a view unless CASCADE was
 			// specified.
 			for _, ref := range n.tableDesc.DependedOnBy {
@@ -327,6 +384,12 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if n.tableDesc.PrimaryIndex.ContainsColumnID(col.ID) {
 				return fmt.Errorf("column %q is referenced by the primary key", col.Name)
 			}
+			// Indexes on expressions that reference the column are handled
+			// like indexes on the column.
+			exprCols, err := indexExprColumnsReferencing(n.tableDesc, col.ID)
+			if err != nil {
+				return err
+			}
 			for _, idx := range n.tableDesc.AllNonDropIndexes() {
 				// We automatically drop indexes on that column that only
 				// index that column (and no other columns). If CASCADE is
@@ -351,7 +414,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 
 				// Analyze the index.
 				for _, id := range idx.ColumnIDs {
-					if id == col.ID {
+					if _, ok := exprCols[id]; ok || id == col.ID {
 						containsThisColumn = true
 					} else {
 						containsOnlyThisColumn = false
@@ -378,6 +441,17 @@ func (n *alterTableNode) startExec(params runParams) error {
 						containsThisColumn = true
 					}
 				}
+				// The predicate of a partial index cannot be evaluated
+				// without the columns it references.
+				predCols, err := idx.PredicateColumnIDs(n.tableDesc)
+				if err != nil {
+					return err
+				}
+				for _, id := range predCols {
+					if id == col.ID {
+						containsThisColumn = true
+					}
+				}
 
 				// Perform the DROP.
 				if containsThisColumn {
@@ -436,6 +510,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 				return fmt.Errorf("constraint %q does not exist", t.Constraint)
 			}
+			if n.tableDesc.HasPendingConstraintMutation(name) {
+				return errConstraintInProgress(name)
+			}
 			switch details.Kind {
 			case sqlbase.ConstraintTypePK:
 				return fmt.Errorf("cannot drop primary key")
@@ -499,32 +576,57 @@ func (n *alterTableNode) startExec(params runParams) error {
 				descriptorChanged = true
 
 			case sqlbase.ConstraintTypeFK:
-				found := false
-				var id sqlbase.IndexID
-				for _, idx := range n.tableDesc.AllNonDropIndexes() {
-					if idx.ForeignKey.IsSet() && idx.ForeignKey.Name == name {
-						found = true
-						id = idx.ID
-						break
-					}
-				}
-				if !found {
-					panic("constraint returned by GetConstraintInfo not found")
-				}
-				idx, err := n.tableDesc.FindIndexByID(id)
-				if err != nil {
-					panic(err)
+				if n.tableDesc.HasPendingConstraintMutation(name) {
+					return errConstraintInProgress(name)
 				}
-				if err := params.p.validateForeignKey(params.ctx, n.tableDesc, idx); err != nil {
-					return err
-				}
-				idx.ForeignKey.Validity = sqlbase.ConstraintValidity_Validated
-				descriptorChanged = true
+				// The existing rows are validated by the schema changer, in a
+				// job: writes are not blocked in the meantime, since the
+				// foreign key is already enforced on them.
+				n.tableDesc.AddForeignKeyValidationMutation(name)
 
 			default:
 				return errors.Errorf("validating %s constraint %q unsupported", constraint.Kind, t.Constraint)
 			}
 
+		case *tree.AlterTableRenameConstraint:
+			info, err := n.tableDesc.GetConstraintInfo(params.ctx, params.p.txn)
+			if err != nil {
+				return err
+			}
+			details, ok := info[string(t.Constraint)]
+			if !ok {
+				return pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+					"constraint %q does not exist", tree.ErrString(&t.Constraint))
+			}
+			if t.Constraint == t.NewName {
+				// Noop.
+				continue
+			}
+			if _, ok := info[string(t.NewName)]; ok {
+				return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+					"duplicate constraint name: %q", tree.ErrString(&t.NewName))
+			}
+			if n.tableDesc.HasPendingConstraintMutation(string(t.Constraint)) {
+				return errConstraintInProgress(string(t.Constraint))
+			}
+			if details.Kind == sqlbase.ConstraintTypePK || details.Kind == sqlbase.ConstraintTypeUnique {
+				// The constraint is renamed along with its index.
+				if _, _, err := n.tableDesc.FindIndexByName(string(t.NewName)); err == nil {
+					return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+						"index name %q already exists", tree.ErrString(&t.NewName))
+				}
+			}
+			depViewRenameError := func(typeName string, refTableID sqlbase.ID) error {
+				return params.p.dependentViewRenameError(params.ctx,
+					typeName, tree.ErrString(&t.Constraint), n.tableDesc.ParentID, refTableID)
+			}
+			if err := n.tableDesc.RenameConstraint(
+				details, string(t.Constraint), string(t.NewName), depViewRenameError,
+			); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		case tree.ColumnMutationCmd:
 			// Column mutations
 			col, dropped, err := n.tableDesc.FindColumnByName(t.GetColumn())
@@ -612,6 +714,12 @@ func (n *alterTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.updateTypeBackReferences(
+		params.ctx, n.tableDesc.ID, origTypeIDs, n.tableDesc.EnumTypeIDs(),
+	); err != nil {
+		return err
+	}
+
 	// Record this table alteration in the event log. This is an auditable log
 	// event and is recorded in the same transaction as the table descriptor
 	// update.
@@ -748,15 +856,75 @@ func applyColumnMutation(
 			}
 		}
 
+	case *tree.AlterTableSetNotNull:
+		if !col.Nullable {
+			return nil
+		}
+		if tableDesc.PendingNotNullMutation(col.ID) != nil {
+			return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"constraint in the middle of being added, try again later")
+		}
+		// The values of a virtual column are not stored, so they cannot be
+		// validated by scanning the primary index.
+		if col.IsVirtual() {
+			return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"column %q is a virtual computed column", col.Name)
+		}
+		isPublic := false
+		for i := range tableDesc.Columns {
+			if tableDesc.Columns[i].ID == col.ID {
+				isPublic = true
+				break
+			}
+		}
+		if !isPublic {
+			return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"column %q in the middle of being added, try again later", col.Name)
+		}
+
+		// The NOT NULL constraint is enforced on new writes through a hidden
+		// check constraint until the existing rows are validated by the
+		// schema changer, which then marks the column as not nullable.
+		info, err := tableDesc.GetConstraintInfo(params.ctx, nil)
+		if err != nil {
+			return err
+		}
+		inuseNames := make(map[string]struct{}, len(info)+len(tableDesc.Checks))
+		for k := range info {
+			inuseNames[k] = struct{}{}
+		}
+		for _, ck := range tableDesc.Checks {
+			inuseNames[ck.Name] = struct{}{}
+		}
+		ck := sqlbase.MakeNotNullCheckConstraint(col.Name, col.ID, inuseNames)
+		tableDesc.AddNotNullMutation(ck, col.ID)
+
 	case *tree.AlterTableDropNotNull:
+		if tableDesc.PendingNotNullMutation(col.ID) != nil {
+			return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"constraint in the middle of being added, try again later")
+		}
 		col.Nullable = true
 
 	case *tree.AlterTableDropStored:
+		// The values of a virtual column are not stored in the primary index,
+		// so it cannot be turned into a regular column without a backfill.
+		if col.IsVirtual() {
+			return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"column %q is a virtual computed column", col.Name)
+		}
 		col.ComputeExpr = nil
 	}
 	return nil
 }
 
+// errConstraintInProgress is returned when a constraint cannot be changed
+// because a schema change is adding or validating it.
+func errConstraintInProgress(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+		"constraint %q in the middle of being added or validated, try again later", name)
+}
+
 func labeledRowValues(cols []sqlbase.ColumnDescriptor, values tree.Datums) string {
 	var s bytes.Buffer
 	for i := range cols {
@@ -0,0 +1,116 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type alterTypeNode struct {
+	n    *tree.AlterType
+	tn   *tree.TableName
+	desc *sqlbase.TypeDescriptor
+}
+
+// AlterType adds a value to an enum type.
+// Privileges: CREATE on type.
+//   notes: postgres requires ownership of the type.
+func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, error) {
+	tn, err := n.Name.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	desc, err := p.getTypeDesc(ctx, tn, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if err := checkEnumLabel(n.Label); err != nil {
+		return nil, err
+	}
+	return &alterTypeNode{n: n, tn: tn, desc: desc}, nil
+}
+
+func (n *alterTypeNode) startExec(params runParams) error {
+	for _, m := range n.desc.EnumMembers {
+		if m.Label == n.n.Label {
+			if n.n.IfNotExists {
+				return nil
+			}
+			return newEnumLabelAlreadyExistsError(n.n.Label)
+		}
+	}
+
+	n.desc.EnumMembers = append(n.desc.EnumMembers, sqlbase.EnumMember{Label: n.n.Label})
+	if err := params.p.writeTypeDesc(params.ctx, n.desc); err != nil {
+		return err
+	}
+
+	// The columns that use the type carry a copy of its values. The new
+	// value is added to them as read-only: it cannot be written until all
+	// the nodes know about it, which the schema changer waits for before
+	// making the value writable.
+	for _, id := range n.desc.ReferencingDescriptorIDs {
+		tableDesc, err := sqlbase.GetTableDescFromID(params.ctx, params.p.txn, id)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if tableDesc.Dropped() {
+			continue
+		}
+		colTypes := tableDesc.EnumColumnTypes(n.desc.ID)
+		if len(colTypes) == 0 {
+			continue
+		}
+		member := sqlbase.EnumMember{
+			Label:    n.n.Label,
+			ReadOnly: !params.p.Tables().isCreatedTable(tableDesc.ID),
+		}
+		for _, t := range colTypes {
+			t.EnumMembers = append(t.EnumMembers, member)
+		}
+		if err := params.p.writeSchemaChange(
+			params.ctx, tableDesc, sqlbase.InvalidMutationID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogAlterType,
+		int32(n.desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TypeName  string
+			Statement string
+			User      string
+		}{n.tn.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *alterTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterTypeNode) Close(context.Context)        {}
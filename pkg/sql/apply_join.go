@@ -0,0 +1,161 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// applyJoinPlanRightSideFn is used by applyJoinNode to produce a new plan for
+// the right side of the join for every row of the left side. The values of
+// the left row are substituted for the references to the left columns.
+type applyJoinPlanRightSideFn func(params runParams, leftRow tree.Datums) (planNode, error)
+
+// applyJoinNode implements a join in which the right side refers to the
+// columns of the left side; this is the case for LATERAL subqueries and
+// set-returning functions that the optimizer could not decorrelate. For every
+// row of the left side, a new plan for the right side is produced and run,
+// and its rows are joined with the left row.
+//
+// Only inner and left outer joins are supported.
+type applyJoinNode struct {
+	joinType sqlbase.JoinType
+
+	// input is the left side of the join.
+	input planNode
+
+	// right is the plan of the right side built at planning time, with NULL
+	// values in place of the left columns. It is only used to describe the
+	// query (e.g. by EXPLAIN) and is never executed: each left row runs a
+	// fresh plan produced by planRightSideFn.
+	right planNode
+
+	planRightSideFn applyJoinPlanRightSideFn
+
+	// pred holds the ON condition, which can refer to the columns of both
+	// sides (first the left columns, then the right columns).
+	pred *joinPredicate
+
+	columns sqlbase.ResultColumns
+
+	run applyJoinRun
+}
+
+// applyJoinRun contains the run-time state of applyJoinNode during local
+// execution.
+type applyJoinRun struct {
+	// leftRow is the current row of the left side.
+	leftRow tree.Datums
+	// leftRowMatched is set once a row of the right side was joined with the
+	// current left row.
+	leftRowMatched bool
+	// rightPlan is the plan of the right side for the current left row, if
+	// any.
+	rightPlan planNode
+
+	// emptyRight contains NULL values for the right columns; it is used for the
+	// unmatched left rows of a left outer join.
+	emptyRight tree.Datums
+
+	out tree.Datums
+}
+
+func (n *applyJoinNode) startExec(params runParams) error {
+	// The children of this node are not started by startExec() on the
+	// enclosing plan; the left side is started here and the plans for the
+	// right side are started as needed by Next().
+	if err := startExec(params, n.input); err != nil {
+		return err
+	}
+	n.run.out = make(tree.Datums, len(n.columns))
+	if n.joinType == sqlbase.LeftOuterJoin {
+		n.run.emptyRight = make(tree.Datums, n.pred.numRightCols)
+		for i := range n.run.emptyRight {
+			n.run.emptyRight[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+func (n *applyJoinNode) Next(params runParams) (bool, error) {
+	for {
+		if err := params.p.cancelChecker.Check(); err != nil {
+			return false, err
+		}
+
+		if n.run.rightPlan == nil {
+			next, err := n.input.Next(params)
+			if err != nil || !next {
+				return false, err
+			}
+			n.run.leftRow = n.input.Values()
+			n.run.leftRowMatched = false
+
+			plan, err := n.planRightSideFn(params, n.run.leftRow)
+			if err != nil {
+				return false, err
+			}
+			if err := startPlan(params, plan); err != nil {
+				plan.Close(params.ctx)
+				return false, err
+			}
+			n.run.rightPlan = plan
+		}
+
+		next, err := n.run.rightPlan.Next(params)
+		if err != nil {
+			return false, err
+		}
+		if !next {
+			n.run.rightPlan.Close(params.ctx)
+			n.run.rightPlan = nil
+			if n.joinType == sqlbase.LeftOuterJoin && !n.run.leftRowMatched {
+				n.pred.prepareRow(n.run.out, n.run.leftRow, n.run.emptyRight)
+				return true, nil
+			}
+			continue
+		}
+
+		rightRow := n.run.rightPlan.Values()
+		pass, err := n.pred.eval(params.EvalContext(), n.run.leftRow, rightRow)
+		if err != nil {
+			return false, err
+		}
+		if !pass {
+			continue
+		}
+		n.run.leftRowMatched = true
+		n.pred.prepareRow(n.run.out, n.run.leftRow, rightRow)
+		return true, nil
+	}
+}
+
+func (n *applyJoinNode) Values() tree.Datums {
+	return n.run.out
+}
+
+func (n *applyJoinNode) Close(ctx context.Context) {
+	n.input.Close(ctx)
+	if n.right != nil {
+		n.right.Close(ctx)
+	}
+	if n.run.rightPlan != nil {
+		n.run.rightPlan.Close(ctx)
+		n.run.rightPlan = nil
+	}
+}
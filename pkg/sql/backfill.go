@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -114,9 +115,12 @@ func (sc *SchemaChanger) runBackfill(
 	// mutations. Collect the elements that are part of the mutation.
 	var droppedIndexDescs []sqlbase.IndexDescriptor
 	var addedIndexDescs []sqlbase.IndexDescriptor
+	var addedConstraints []sqlbase.ConstraintToUpdate
 	// Indexes within the Mutations slice for checkpointing.
 	mutationSentinel := -1
 	var droppedIndexMutationIdx int
+	var refresh *sqlbase.DescriptorMutation
+	var refreshMutationIdx int
 
 	var tableDesc *sqlbase.TableDescriptor
 	if err := sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
@@ -149,6 +153,10 @@ func (sc *SchemaChanger) runBackfill(
 				}
 			case *sqlbase.DescriptorMutation_Index:
 				addedIndexDescs = append(addedIndexDescs, *t.Index)
+			case *sqlbase.DescriptorMutation_Constraint:
+				addedConstraints = append(addedConstraints, *t.Constraint)
+			case *sqlbase.DescriptorMutation_MaterializedViewRefresh:
+				refresh, refreshMutationIdx = &tableDesc.Mutations[i], i
 			default:
 				return errors.Errorf("unsupported mutation: %+v", m)
 			}
@@ -156,12 +164,18 @@ func (sc *SchemaChanger) runBackfill(
 		case sqlbase.DescriptorMutation_DROP:
 			switch t := m.Descriptor_.(type) {
 			case *sqlbase.DescriptorMutation_Column:
-				needColumnBackfill = true
+				if !t.Column.IsVirtual() {
+					needColumnBackfill = true
+				}
 			case *sqlbase.DescriptorMutation_Index:
 				droppedIndexDescs = append(droppedIndexDescs, *t.Index)
 				if droppedIndexMutationIdx == mutationSentinel {
 					droppedIndexMutationIdx = i
 				}
+			case *sqlbase.DescriptorMutation_Constraint:
+				// The constraint was never in effect: there is nothing to undo.
+			case *sqlbase.DescriptorMutation_MaterializedViewRefresh:
+				refresh, refreshMutationIdx = &tableDesc.Mutations[i], i
 			default:
 				return errors.Errorf("unsupported mutation: %+v", m)
 			}
@@ -200,9 +214,133 @@ func (sc *SchemaChanger) runBackfill(
 		}
 	}
 
+	// Validate the existing rows against the new constraints.
+	if len(addedConstraints) > 0 {
+		if err := sc.validateConstraints(ctx, lease, addedConstraints); err != nil {
+			return err
+		}
+	}
+
+	// Refresh a materialized view.
+	if refresh != nil {
+		if err := sc.refreshMaterializedView(
+			ctx, lease, tableDesc, *refresh, refreshMutationIdx,
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validateConstraints checks that the existing rows of the table satisfy the
+// given constraints. All the nodes enforce the constraints on new writes by
+// the time this runs, so the rows are read at a single timestamp, without
+// blocking the writes that happen in the meantime.
+func (sc *SchemaChanger) validateConstraints(
+	ctx context.Context,
+	lease *sqlbase.TableDescriptor_SchemaChangeLease,
+	constraints []sqlbase.ConstraintToUpdate,
+) error {
+	if err := sc.ExtendLease(ctx, lease); err != nil {
+		return err
+	}
+	readAsOf := sc.clock.Now()
+	return sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		txn.SetFixedTimestamp(ctx, readAsOf)
+		tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, sc.tableID)
+		if err != nil {
+			return err
+		}
+		for i := range constraints {
+			if err := validateConstraintInTxn(
+				ctx, txn, sc.execCfg, tableDesc, &constraints[i], false, /* traceKV */
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// validateConstraintInTxn checks that the rows of the table visible to the
+// transaction satisfy the given constraint.
+func validateConstraintInTxn(
+	ctx context.Context,
+	txn *client.Txn,
+	execCfg *ExecutorConfig,
+	tableDesc *sqlbase.TableDescriptor,
+	constraint *sqlbase.ConstraintToUpdate,
+	traceKV bool,
+) error {
+	switch constraint.ConstraintType {
+	case sqlbase.ConstraintToUpdate_NOT_NULL:
+		return validateNotNullInTxn(ctx, txn, tableDesc, constraint.NotNullColumn, traceKV)
+	case sqlbase.ConstraintToUpdate_FOREIGN_KEY:
+		info, err := tableDesc.GetConstraintInfo(ctx, nil)
+		if err != nil {
+			return err
+		}
+		detail, ok := info[constraint.Name]
+		if !ok || detail.Kind != sqlbase.ConstraintTypeFK {
+			return errors.Errorf("foreign key %q does not exist", constraint.Name)
+		}
+		return validateForeignKey(ctx, tableDesc, detail.Index, execCfg.InternalExecutor, txn)
+	default:
+		return errors.Errorf("unsupported constraint: %+v", constraint)
+	}
+}
+
+// validateNotNullInTxn returns a not-null violation error if the given column
+// is NULL in any of the rows of the table visible to the transaction.
+func validateNotNullInTxn(
+	ctx context.Context,
+	txn *client.Txn,
+	tableDesc *sqlbase.TableDescriptor,
+	colID sqlbase.ColumnID,
+	traceKV bool,
+) error {
+	colIdxMap := tableDesc.ColumnIdxMap()
+	colIdx, ok := colIdxMap[colID]
+	if !ok {
+		return errors.Errorf("column %d does not exist", colID)
+	}
+	var valNeededForCol util.FastIntSet
+	valNeededForCol.Add(colIdx)
+	tableArgs := sqlbase.RowFetcherTableArgs{
+		Desc:            tableDesc,
+		Index:           &tableDesc.PrimaryIndex,
+		ColIdxMap:       colIdxMap,
+		Cols:            tableDesc.Columns,
+		ValNeededForCol: valNeededForCol,
+	}
+	var rf sqlbase.RowFetcher
+	if err := rf.Init(
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &sqlbase.DatumAlloc{},
+		nil /* evalCtx */, tableArgs,
+	); err != nil {
+		return err
+	}
+	if err := rf.StartScan(
+		ctx, txn, roachpb.Spans{tableDesc.PrimaryIndexSpan()}, true /* limitBatches */, 0, /* limitHint */
+		traceKV,
+	); err != nil {
+		return err
+	}
+	for {
+		datums, _, _, err := rf.NextRowDecoded(ctx)
+		if err != nil {
+			return err
+		}
+		if datums == nil {
+			return nil
+		}
+		if datums[colIdx] == tree.DNull {
+			return sqlbase.NewNonNullViolationError(tableDesc.Columns[colIdx].Name)
+		}
+	}
+}
+
 func (sc *SchemaChanger) getTableVersion(
 	ctx context.Context, txn *client.Txn, tc *TableCollection, version sqlbase.DescriptorVersion,
 ) (*sqlbase.TableDescriptor, error) {
@@ -266,7 +404,8 @@ func (sc *SchemaChanger) truncateIndexes(
 					return err
 				}
 				td := tableDeleter{rd: rd, alloc: alloc}
-				if err := td.init(txn, nil /* *tree.EvalContext */); err != nil {
+				evalCtx := createSchemaChangeEvalCtx(sc.clock.Now(), &SessionTracing{})
+				if err := td.init(txn, &evalCtx.EvalContext); err != nil {
 					return err
 				}
 				resume, err = td.deleteIndex(
@@ -549,7 +688,7 @@ func runSchemaChangesInTxn(
 	for _, m := range tableDesc.Mutations {
 		switch m.Direction {
 		case sqlbase.DescriptorMutation_ADD:
-			switch m.Descriptor_.(type) {
+			switch t := m.Descriptor_.(type) {
 			case *sqlbase.DescriptorMutation_Column:
 				if doneColumnBackfill || !sqlbase.ColumnNeedsBackfill(m.GetColumn()) {
 					break
@@ -560,7 +699,14 @@ func runSchemaChangesInTxn(
 				doneColumnBackfill = true
 
 			case *sqlbase.DescriptorMutation_Index:
-				if err := indexBackfillInTxn(ctx, txn, tableDesc, traceKV); err != nil {
+				if err := indexBackfillInTxn(ctx, txn, evalCtx, tableDesc, traceKV); err != nil {
+					return err
+				}
+
+			case *sqlbase.DescriptorMutation_Constraint:
+				if err := validateConstraintInTxn(
+					ctx, txn, execCfg, tableDesc, t.Constraint, traceKV,
+				); err != nil {
 					return err
 				}
 
@@ -585,6 +731,9 @@ func runSchemaChangesInTxn(
 					return err
 				}
 
+			case *sqlbase.DescriptorMutation_Constraint:
+				// The constraint was never in effect: there is nothing to undo.
+
 			default:
 				return errors.Errorf("unsupported mutation: %+v", m)
 			}
@@ -653,10 +802,14 @@ func columnBackfillInTxn(
 }
 
 func indexBackfillInTxn(
-	ctx context.Context, txn *client.Txn, tableDesc *sqlbase.TableDescriptor, traceKV bool,
+	ctx context.Context,
+	txn *client.Txn,
+	evalCtx *tree.EvalContext,
+	tableDesc *sqlbase.TableDescriptor,
+	traceKV bool,
 ) error {
 	var backfiller backfill.IndexBackfiller
-	if err := backfiller.Init(*tableDesc); err != nil {
+	if err := backfiller.Init(evalCtx, *tableDesc); err != nil {
 		return err
 	}
 	sp := tableDesc.PrimaryIndexSpan()
@@ -689,7 +842,8 @@ func indexTruncateInTxn(
 			return err
 		}
 		td := tableDeleter{rd: rd, alloc: alloc}
-		if err := td.init(txn, nil /* *tree.EvalContext */); err != nil {
+		evalCtx := createSchemaChangeEvalCtx(execCfg.Clock.Now(), &SessionTracing{})
+		if err := td.init(txn, &evalCtx.EvalContext); err != nil {
 			return err
 		}
 		sp, err = td.deleteIndex(
@@ -71,6 +71,10 @@ func (cb *ColumnBackfiller) Init(evalCtx *tree.EvalContext, desc sqlbase.TableDe
 		for _, m := range desc.Mutations {
 			if ColumnMutationFilter(m) {
 				desc := *m.GetColumn()
+				if desc.IsVirtual() {
+					// Virtual columns have no stored data to backfill.
+					continue
+				}
 				switch m.Direction {
 				case sqlbase.DescriptorMutation_ADD:
 					cb.added = append(cb.added, desc)
@@ -121,7 +125,8 @@ func (cb *ColumnBackfiller) Init(evalCtx *tree.EvalContext, desc sqlbase.TableDe
 		ValNeededForCol: valNeededForCol,
 	}
 	return cb.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &cb.alloc, tableArgs,
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &cb.alloc, cb.evalCtx,
+		tableArgs,
 	)
 }
 
@@ -290,10 +295,15 @@ type IndexBackfiller struct {
 
 	types   []sqlbase.ColumnType
 	rowVals tree.Datums
+
+	// partialIndexes determines which rows are added to the partial indexes
+	// among added.
+	partialIndexes sqlbase.PartialIndexPredicates
 }
 
-// Init initializes an IndexBackfiller.
-func (ib *IndexBackfiller) Init(desc sqlbase.TableDescriptor) error {
+// Init initializes an IndexBackfiller. The evalCtx is used to compute the
+// virtual computed columns that are stored in the added indexes.
+func (ib *IndexBackfiller) Init(evalCtx *tree.EvalContext, desc sqlbase.TableDescriptor) error {
 	numCols := len(desc.Columns)
 	cols := desc.Columns
 	if len(desc.Mutations) > 0 {
@@ -315,10 +325,21 @@ func (ib *IndexBackfiller) Init(desc sqlbase.TableDescriptor) error {
 		if IndexMutationFilter(m) {
 			idx := m.GetIndex()
 			ib.added = append(ib.added, *idx)
+			// The columns referenced by the predicate of a partial index
+			// determine which rows are added to it.
+			predCols, err := idx.PredicateColumnIDs(&desc)
+			if err != nil {
+				return err
+			}
 			for i, col := range cols {
 				if idx.ContainsColumnID(col.ID) {
 					valNeededForCol.Add(i)
 				}
+				for _, id := range predCols {
+					if id == col.ID {
+						valNeededForCol.Add(i)
+					}
+				}
 			}
 		}
 	}
@@ -332,6 +353,7 @@ func (ib *IndexBackfiller) Init(desc sqlbase.TableDescriptor) error {
 	for i, c := range cols {
 		ib.colIdxMap[c.ID] = i
 	}
+	ib.partialIndexes = sqlbase.MakePartialIndexPredicates(&desc)
 
 	tableArgs := sqlbase.RowFetcherTableArgs{
 		Desc:            &desc,
@@ -341,7 +363,8 @@ func (ib *IndexBackfiller) Init(desc sqlbase.TableDescriptor) error {
 		ValNeededForCol: valNeededForCol,
 	}
 	return ib.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &ib.alloc, tableArgs,
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &ib.alloc, evalCtx,
+		tableArgs,
 	)
 }
 
@@ -395,10 +418,16 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 		buffer = buffer[:len(ib.added)]
 		if buffer, err = sqlbase.EncodeSecondaryIndexes(
 			&tableDesc, ib.added, ib.colIdxMap,
-			ib.rowVals, buffer); err != nil {
+			ib.rowVals, buffer, &ib.partialIndexes); err != nil {
 			return nil, nil, err
 		}
-		entries = append(entries, buffer...)
+		for _, entry := range buffer {
+			// Skip the partial indexes whose predicate the row does not
+			// satisfy.
+			if entry.Key != nil {
+				entries = append(entries, entry)
+			}
+		}
 	}
 	return entries, ib.fetcher.Key(), nil
 }
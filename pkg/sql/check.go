@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -46,7 +47,7 @@ func (p *planner) validateCheckExpr(
 	// use the tableDesc we have, but this is a rare operation and be benefit
 	// would be marginal compared to the work of the actual query, so the added
 	// complexity seems unjustified.
-	rows, err := p.SelectClause(ctx, sel, nil, lim, nil, nil, publicColumns)
+	rows, err := p.SelectClause(ctx, sel, nil, lim, nil, nil, nil, publicColumns)
 	if err != nil {
 		return err
 	}
@@ -73,10 +74,17 @@ func (p *planner) validateCheckExpr(
 	return nil
 }
 
-func (p *planner) validateForeignKey(
-	ctx context.Context, srcTable *sqlbase.TableDescriptor, srcIdx *sqlbase.IndexDescriptor,
+// validateForeignKey checks that all the rows of srcTable visible to the
+// transaction have a match in the table referenced by the foreign key of
+// srcIdx.
+func validateForeignKey(
+	ctx context.Context,
+	srcTable *sqlbase.TableDescriptor,
+	srcIdx *sqlbase.IndexDescriptor,
+	ie *InternalExecutor,
+	txn *client.Txn,
 ) error {
-	targetTable, err := sqlbase.GetTableDescFromID(ctx, p.txn, srcIdx.ForeignKey.Table)
+	targetTable, err := sqlbase.GetTableDescFromID(ctx, txn, srcIdx.ForeignKey.Table)
 	if err != nil {
 		return err
 	}
@@ -85,12 +93,12 @@ func (p *planner) validateForeignKey(
 		return err
 	}
 
-	srcName, err := p.getQualifiedTableName(ctx, srcTable)
+	srcName, err := getQualifiedTableName(ctx, txn, srcTable)
 	if err != nil {
 		return err
 	}
 
-	targetName, err := p.getQualifiedTableName(ctx, targetTable)
+	targetName, err := getQualifiedTableName(ctx, txn, targetTable)
 	if err != nil {
 		return err
 	}
@@ -125,9 +133,7 @@ func (p *planner) validateForeignKey(
 		query,
 	)
 
-	values, _ /* cols */, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.Query(
-		ctx, "validate-fk", p.txn, query,
-	)
+	values, _ /* cols */, err := ie.Query(ctx, "validate-fk", txn, query)
 	if err != nil {
 		return err
 	}
@@ -63,6 +63,8 @@ var (
 
 	// Time is an immutable T instance.
 	Time = &TTime{}
+	// TimeTZ is an immutable T instance.
+	TimeTZ = &TTimeTZ{}
 
 	// Timestamp is an immutable T instance.
 	Timestamp = &TTimestamp{}
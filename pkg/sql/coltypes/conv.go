@@ -94,6 +94,8 @@ func DatumTypeToColumnType(t types.T) (T, error) {
 		return Date, nil
 	case types.Time:
 		return Time, nil
+	case types.TimeTZ:
+		return TimeTZ, nil
 	case types.String:
 		return String, nil
 	case types.Name:
@@ -131,6 +133,8 @@ func DatumTypeToColumnType(t types.T) (T, error) {
 			colTyp[i] = elemTyp
 		}
 		return colTyp, nil
+	case *types.TEnum:
+		return &TUserDefined{Name: typ.TypeName, Typ: typ}, nil
 	case types.TOidWrapper:
 		return DatumTypeToColumnType(typ.T)
 	}
@@ -172,6 +176,8 @@ func CastTargetToDatumType(t CastTargetType) types.T {
 		return types.Date
 	case *TTime:
 		return types.Time
+	case *TTimeTZ:
+		return types.TimeTZ
 	case *TTimestamp:
 		return types.Timestamp
 	case *TTimestampTZ:
@@ -205,6 +211,12 @@ func CastTargetToDatumType(t CastTargetType) types.T {
 		return ret
 	case *TOid:
 		return TOidToType(ct)
+	case *TUserDefined:
+		if ct.Typ == nil {
+			// The type name has not been resolved yet.
+			return types.Unknown
+		}
+		return ct.Typ
 	default:
 		panic(fmt.Sprintf("unexpected CastTarget %T", t))
 	}
@@ -74,9 +74,11 @@ func (*TOid) columnType()            {}
 func (*TSerial) columnType()         {}
 func (*TString) columnType()         {}
 func (*TTime) columnType()           {}
+func (*TTimeTZ) columnType()         {}
 func (*TTimestamp) columnType()      {}
 func (*TTimestampTZ) columnType()    {}
 func (*TUUID) columnType()           {}
+func (*TUserDefined) columnType()    {}
 func (*TVector) columnType()         {}
 func (TTuple) columnType()           {}
 
@@ -98,9 +100,11 @@ func (*TOid) castTargetType()            {}
 func (*TSerial) castTargetType()         {}
 func (*TString) castTargetType()         {}
 func (*TTime) castTargetType()           {}
+func (*TTimeTZ) castTargetType()         {}
 func (*TTimestamp) castTargetType()      {}
 func (*TTimestampTZ) castTargetType()    {}
 func (*TUUID) castTargetType()           {}
+func (*TUserDefined) castTargetType()    {}
 func (*TVector) castTargetType()         {}
 func (TTuple) castTargetType()           {}
 
@@ -121,8 +125,10 @@ func (node *TOid) String() string            { return ColTypeAsString(node) }
 func (node *TSerial) String() string         { return ColTypeAsString(node) }
 func (node *TString) String() string         { return ColTypeAsString(node) }
 func (node *TTime) String() string           { return ColTypeAsString(node) }
+func (node *TTimeTZ) String() string         { return ColTypeAsString(node) }
 func (node *TTimestamp) String() string      { return ColTypeAsString(node) }
 func (node *TTimestampTZ) String() string    { return ColTypeAsString(node) }
 func (node *TUUID) String() string           { return ColTypeAsString(node) }
+func (node *TUserDefined) String() string    { return ColTypeAsString(node) }
 func (node *TVector) String() string         { return ColTypeAsString(node) }
 func (node TTuple) String() string           { return ColTypeAsString(node) }
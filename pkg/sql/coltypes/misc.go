@@ -18,6 +18,7 @@ import (
 	"bytes"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 )
 
 // This file contains column type definitions that don't fit
@@ -77,3 +78,18 @@ func (node *TOid) TypeName() string { return node.Name }
 func (node *TOid) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.Name)
 }
+
+// TUserDefined represents a user-defined type, which is referred to by
+// name. Typ is nil until the name has been resolved during type checking.
+type TUserDefined struct {
+	Name string
+	Typ  *types.TEnum
+}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TUserDefined) TypeName() string { return node.Name }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TUserDefined) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
+	lex.EncodeRestrictedSQLIdent(buf, node.Name, f)
+}
@@ -42,6 +42,17 @@ func (node *TTime) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
 	buf.WriteString(node.TypeName())
 }
 
+// TTimeTZ represents a TIME WITH TIME ZONE type.
+type TTimeTZ struct{}
+
+// TypeName implements the ColTypeFormatter interface.
+func (node *TTimeTZ) TypeName() string { return "TIMETZ" }
+
+// Format implements the ColTypeFormatter interface.
+func (node *TTimeTZ) Format(buf *bytes.Buffer, f lex.EncodeFlags) {
+	buf.WriteString(node.TypeName())
+}
+
 // TTimestamp represents a TIMESTAMP type.
 type TTimestamp struct{}
 
@@ -0,0 +1,223 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type commentOnTableNode struct {
+	n         *tree.CommentOnTable
+	tableDesc *sqlbase.TableDescriptor
+}
+
+// CommentOnTable sets the comment of a table. The comment is stored in
+// the table descriptor.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) CommentOnTable(ctx context.Context, n *tree.CommentOnTable) (planNode, error) {
+	tn, err := n.Table.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &commentOnTableNode{n: n, tableDesc: tableDesc}, nil
+}
+
+func (n *commentOnTableNode) startExec(params runParams) error {
+	comment := commentOrEmpty(n.n.Comment)
+	if n.tableDesc.Comment == comment {
+		return nil
+	}
+	n.tableDesc.Comment = comment
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnTable,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName string
+			Statement string
+			User      string
+			Comment   *string
+		}{
+			n.n.Table.TableName().FQString(), n.n.String(),
+			params.SessionData().User, n.n.Comment,
+		},
+	)
+}
+
+func (n *commentOnTableNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnTableNode) Close(context.Context)        {}
+
+type commentOnColumnNode struct {
+	n         *tree.CommentOnColumn
+	tableName *tree.TableName
+	tableDesc *sqlbase.TableDescriptor
+	colDesc   *sqlbase.ColumnDescriptor
+}
+
+// CommentOnColumn sets the comment of a column. The comment is stored in
+// the column descriptor.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) CommentOnColumn(
+	ctx context.Context, n *tree.CommentOnColumn,
+) (planNode, error) {
+	table := tree.NormalizableTableName{TableNameReference: &n.ColumnItem.TableName}
+	tn, err := table.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	col, err := tableDesc.FindActiveColumnByName(string(n.ColumnName))
+	if err != nil {
+		return nil, err
+	}
+	// Get a pointer to the column descriptor that is actually in tableDesc.
+	colDesc, err := tableDesc.FindColumnByID(col.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &commentOnColumnNode{n: n, tableName: tn, tableDesc: tableDesc, colDesc: colDesc}, nil
+}
+
+func (n *commentOnColumnNode) startExec(params runParams) error {
+	comment := commentOrEmpty(n.n.Comment)
+	if n.colDesc.Comment == comment {
+		return nil
+	}
+	n.colDesc.Comment = comment
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnColumn,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName  string
+			ColumnName string
+			Statement  string
+			User       string
+			Comment    *string
+		}{
+			n.tableName.FQString(), n.colDesc.Name, n.n.String(),
+			params.SessionData().User, n.n.Comment,
+		},
+	)
+}
+
+func (n *commentOnColumnNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnColumnNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnColumnNode) Close(context.Context)        {}
+
+type commentOnIndexNode struct {
+	n         *tree.CommentOnIndex
+	tableDesc *sqlbase.TableDescriptor
+	indexDesc *sqlbase.IndexDescriptor
+}
+
+// CommentOnIndex sets the comment of an index. The comment is stored in
+// the index descriptor.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the index.
+func (p *planner) CommentOnIndex(ctx context.Context, n *tree.CommentOnIndex) (planNode, error) {
+	tableDesc, indexDesc, err := p.getTableAndIndex(ctx, &n.Index.Table, &n.Index, privilege.CREATE)
+	if err != nil {
+		return nil, err
+	}
+	// As an artifact of finding the index by name, we get a pointer to a
+	// different copy than the one in the tableDesc. Get a pointer to the index
+	// descriptor that's actually in tableDesc.
+	indexDesc, err = tableDesc.FindIndexByID(indexDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &commentOnIndexNode{n: n, tableDesc: tableDesc, indexDesc: indexDesc}, nil
+}
+
+func (n *commentOnIndexNode) startExec(params runParams) error {
+	comment := commentOrEmpty(n.n.Comment)
+	if n.indexDesc.Comment == comment {
+		return nil
+	}
+	n.indexDesc.Comment = comment
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnIndex,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName string
+			IndexName string
+			Statement string
+			User      string
+			Comment   *string
+		}{
+			n.n.Index.Table.TableName().FQString(), n.indexDesc.Name, n.n.String(),
+			params.SessionData().User, n.n.Comment,
+		},
+	)
+}
+
+func (n *commentOnIndexNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnIndexNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnIndexNode) Close(context.Context)        {}
+
+// commentOrEmpty returns the comment to store in a descriptor. As in
+// postgres, both IS NULL and IS '' remove the comment.
+func commentOrEmpty(comment *string) string {
+	if comment == nil {
+		return ""
+	}
+	return *comment
+}
@@ -409,6 +409,7 @@ func (s *Server) ServeConn(
 	ex := s.newConnExecutor(
 		ctx, sessionParams{args: &args}, stmtBuf, clientComm, s.pool, reserved, memMetrics,
 	)
+	ex.notifications = newNotificationListener(s.cfg.NotificationRegistry, stmtBuf)
 	defer func() {
 		r := recover()
 		ex.closeWrapper(ctx, r)
@@ -711,6 +712,16 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		log.Warningf(ctx, "error while cleaning up connExecutor: %s", err)
 	}
 
+	if schemaIDs := ex.extraTxnState.tables.temporarySchemaIDs; len(schemaIDs) > 0 {
+		if err := dropSessionTemporaryTables(ctx, ex.server.cfg, schemaIDs); err != nil {
+			log.Warningf(ctx, "error while dropping temporary tables: %s", err)
+		}
+	}
+
+	if ex.notifications != nil {
+		ex.notifications.unlistenAll()
+	}
+
 	if closeType != panicClose {
 		// Close all statements and prepared portals by first unifying the namespaces
 		// and the closing what remains.
@@ -824,6 +835,11 @@ type connExecutor struct {
 		// txnRewindPos is advanced. Prepared statements are shared between the two
 		// collections, but these collections are periodically reconciled.
 		prepStmtsNamespaceAtTxnRewindPos prepStmtNamespace
+
+		// listens accumulates the LISTEN and UNLISTEN statements of the
+		// transaction. They are applied to ex.notifications when the transaction
+		// commits.
+		listens listenCollection
 	}
 
 	// sessionData contains the user-configurable connection variables.
@@ -879,6 +895,10 @@ type connExecutor struct {
 	curStmt tree.Statement
 
 	sessionID ClusterWideID
+
+	// notifications receives the notifications for the channels the session
+	// LISTENs on. It is nil for internal sessions, which cannot listen.
+	notifications *notificationListener
 }
 
 // ctxHolder contains a connection's context and, while session tracing is
@@ -981,6 +1001,7 @@ func (ex *connExecutor) resetExtraTxnState(
 	ctx context.Context, dbCacheHolder *databaseCacheHolder,
 ) error {
 	ex.extraTxnState.schemaChangers.reset()
+	ex.extraTxnState.listens.reset()
 
 	ex.extraTxnState.tables.releaseTables(ctx)
 
@@ -1030,6 +1051,7 @@ func (ex *connExecutor) run(ctx context.Context, cancel context.CancelFunc) erro
 
 	ex.sessionID = ex.generateID()
 	ex.server.cfg.SessionRegistry.register(ex.sessionID, ex)
+	ex.initTemporarySchema()
 	defer ex.server.cfg.SessionRegistry.deregister(ex.sessionID)
 
 	pinfo := &tree.PlaceholderInfo{}
@@ -1173,6 +1195,11 @@ func (ex *connExecutor) run(ctx context.Context, cancel context.CancelFunc) erro
 					return nil
 				}
 			}
+			// The notifications received while the session was inside a
+			// transaction are delivered after the end of the batch.
+			if _, ok := ex.machine.CurState().(stateNoTxn); ok && ex.notifications != nil {
+				ex.notifications.signal(ex.Ctx())
+			}
 		case CopyIn:
 			res = ex.clientComm.CreateCopyInResult(pos)
 			var err error
@@ -1193,6 +1220,10 @@ func (ex *connExecutor) run(ctx context.Context, cancel context.CancelFunc) erro
 		case Flush:
 			// Closing the res will flush the connection's buffer.
 			res = ex.clientComm.CreateFlushResult(pos)
+		case DeliverNotifications:
+			notificationRes := ex.clientComm.CreateNotificationResult(pos)
+			res = notificationRes
+			ex.deliverNotifications(notificationRes)
 		default:
 			panic(fmt.Sprintf("unsupported command type: %T", cmd))
 		}
@@ -1271,6 +1302,22 @@ func (ex *connExecutor) run(ctx context.Context, cancel context.CancelFunc) erro
 	}
 }
 
+// deliverNotifications buffers the pending notifications of the session on
+// res. The notifications are only delivered outside of transactions; inside a
+// transaction they stay pending until the next Sync.
+func (ex *connExecutor) deliverNotifications(res NotificationResult) {
+	if ex.notifications == nil {
+		return
+	}
+	if _, ok := ex.machine.CurState().(stateNoTxn); !ok {
+		ex.notifications.clearSignal()
+		return
+	}
+	for _, n := range ex.notifications.takePending() {
+		res.BufferNotification(n)
+	}
+}
+
 // updateTxnRewindPosMaybe checks whether the ex.extraTxnState.txnRewindPos
 // should be advanced, based on the advInfo produced by running cmd at position
 // pos.
@@ -1349,6 +1396,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(fmt.Sprintf("unsupported cmd: %T", cmd))
 			}
@@ -1379,7 +1428,7 @@ func (ex *connExecutor) setTxnRewindPos(ctx context.Context, pos CmdPos) {
 // statement do not change with retries.
 func (ex *connExecutor) stmtDoesntNeedRetry(stmt tree.Statement) bool {
 	wrap := Statement{AST: stmt}
-	return isSavepoint(wrap) || isSetTransaction(wrap)
+	return isRestartSavepoint(wrap) || isSetTransaction(wrap)
 }
 
 // tryReusePreparedState checks whether it's possible to reuse information that
@@ -1527,8 +1576,9 @@ func (ex *connExecutor) getRewindTxnCapability() (rewindCapability, bool) {
 	cl := ex.clientComm.LockCommunication()
 
 	// If we already delivered results at or past the start position, we can't
-	// rewind.
-	if cl.ClientPos() >= ex.extraTxnState.txnRewindPos {
+	// rewind. We also don't rewind transactions with savepoints, as the
+	// savepoints established before the rewind position would be lost.
+	if cl.ClientPos() >= ex.extraTxnState.txnRewindPos || len(ex.state.savepoints) > 0 {
 		cl.Close()
 		return rewindCapability{}, false
 	}
@@ -1733,6 +1783,11 @@ func (ex *connExecutor) evalCtx(
 		ex.server.cfg.Settings,
 	)
 
+	var listens *listenCollection
+	if ex.notifications != nil {
+		listens = &ex.extraTxnState.listens
+	}
+
 	return extendedEvalContext{
 		EvalContext: tree.EvalContext{
 			Planner:       p,
@@ -1764,6 +1819,7 @@ func (ex *connExecutor) evalCtx(
 		DistSQLPlanner:  ex.server.cfg.DistSQLPlanner,
 		TxnModesSetter:  ex,
 		SchemaChangers:  &ex.extraTxnState.schemaChangers,
+		Listens:         listens,
 		schemaAccessors: scInterface,
 	}
 }
@@ -1819,6 +1875,8 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx = tree.MakeSemaContext(ex.sessionData.User == security.RootUser)
 	p.semaCtx.Location = &ex.sessionData.DataConversion.Location
 	p.semaCtx.SearchPath = ex.sessionData.SearchPath
+	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.AsOfTimestamp = nil
 
 	p.extendedEvalCtx = ex.evalCtx(ctx, p, stmtTS)
@@ -1886,6 +1944,10 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		// Wait for the cache to reflect the dropped databases if any.
 		ex.extraTxnState.tables.waitForCacheToDropDatabases(ex.Ctx())
 
+		if ex.notifications != nil {
+			ex.extraTxnState.listens.apply(ex.notifications)
+		}
+
 		fallthrough
 	case txnRestart, txnAborted:
 		if err := ex.resetExtraTxnState(ex.Ctx(), ex.server.dbCache); err != nil {
@@ -220,8 +220,11 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.ReleaseSavepoint:
-		if err := tree.ValidateRestartCheckpoint(s.Savepoint); err != nil {
-			return makeErrEvent(err)
+		if !tree.IsRestartSavepoint(s.Savepoint) {
+			if err := ex.releaseSavepoint(ctx, s.Savepoint); err != nil {
+				return makeErrEvent(err)
+			}
+			return nil, nil, nil
 		}
 		if !ex.machine.CurState().(stateOpen).RetryIntent.Get() {
 			return makeErrEvent(errSavepointNotUsed)
@@ -238,14 +241,20 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.Savepoint:
-		if err := tree.ValidateRestartCheckpoint(s.Name); err != nil {
-			return makeErrEvent(err)
+		if !tree.IsRestartSavepoint(s.Name) {
+			// Note that Savepoint doesn't have a corresponding plan node.
+			// This here is all the execution there is.
+			if err := ex.createSavepoint(ctx, s.Name); err != nil {
+				return makeErrEvent(err)
+			}
+			return nil, nil, nil
 		}
-		// We want to disallow SAVEPOINTs to be issued after a transaction has
-		// started running. The client txn's statement count indicates how many
-		// statements have been executed as part of this transaction.
+		// We want to disallow SAVEPOINT cockroach_restart to be issued after a
+		// transaction has started running. The client txn's statement count
+		// indicates how many statements have been executed as part of this
+		// transaction.
 		meta := ex.state.mu.txn.GetTxnCoordMeta(ctx)
-		if meta.CommandCount > 0 {
+		if meta.CommandCount > 0 || len(ex.state.savepoints) > 0 {
 			err := fmt.Errorf("SAVEPOINT %s needs to be the first statement in a "+
 				"transaction", tree.RestartSavepointName)
 			return makeErrEvent(err)
@@ -255,8 +264,11 @@ func (ex *connExecutor) execStmtInOpenState(
 		return eventRetryIntentSet{}, nil /* payload */, nil
 
 	case *tree.RollbackToSavepoint:
-		if err := tree.ValidateRestartCheckpoint(s.Savepoint); err != nil {
-			return makeErrEvent(err)
+		if !tree.IsRestartSavepoint(s.Savepoint) {
+			if err := ex.rollbackToSavepoint(ctx, s.Savepoint); err != nil {
+				return makeErrEvent(err)
+			}
+			return eventSavepointRollback{}, nil /* payload */, nil
 		}
 		if !os.RetryIntent.Get() {
 			return makeErrEvent(errSavepointNotUsed)
@@ -319,6 +331,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	// For regular statements (the ones that get to this point), we don't return
 	// any event unless an an error happens.
 
+	if tree.CanModifySchema(stmt.AST) {
+		// Remember that the transaction performed a schema change, which can't
+		// be rolled back to an earlier savepoint.
+		ex.state.numDDL++
+	}
+
 	var p *planner
 	stmtTS := ex.server.cfg.Clock.PhysicalTime()
 	// Only run statements asynchronously through the parallelize queue if the
@@ -1039,7 +1057,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				iso, pri, ex.readWriteModeWithSessionDefault(s.Modes.ReadWriteMode),
 				ex.server.cfg.Clock.PhysicalTime(),
 				ex.transitionCtx)
-	case *tree.CommitTransaction, *tree.ReleaseSavepoint,
+	case *tree.CommitTransaction, *tree.ReleaseSavepoint, *tree.RollbackToSavepoint,
 		*tree.RollbackTransaction, *tree.SetTransaction, *tree.Savepoint:
 		return ex.makeErrEvent(errNoTransactionInProgress, stmt.AST)
 	default:
@@ -1060,8 +1078,10 @@ func (ex *connExecutor) execStmtInNoTxnState(
 // execStmtInAbortedState executes a statement in a txn that's in state
 // Aborted or RestartWait. All statements result in error events except:
 // - COMMIT / ROLLBACK: aborts the current transaction.
-// - ROLLBACK TO SAVEPOINT / SAVEPOINT: reopens the current transaction,
-//   allowing it to be retried.
+// - ROLLBACK TO SAVEPOINT / SAVEPOINT cockroach_restart: reopens the current
+//   transaction, allowing it to be retried.
+// - ROLLBACK TO SAVEPOINT of another savepoint: rolls back the writes performed
+//   since the savepoint and reopens the current transaction.
 func (ex *connExecutor) execStmtInAbortedState(
 	ctx context.Context, stmt Statement, res RestrictedCommandResult,
 ) (fsm.Event, fsm.EventPayload) {
@@ -1092,12 +1112,22 @@ func (ex *connExecutor) execStmtInAbortedState(
 		default:
 			panic("unreachable")
 		}
-		if err := tree.ValidateRestartCheckpoint(spName); err != nil {
-			ev := eventNonRetriableErr{IsCommit: fsm.False}
-			payload := eventNonRetriableErrPayload{
-				err: err,
+		if !tree.IsRestartSavepoint(spName) {
+			if _, ok := s.(*tree.Savepoint); ok {
+				ev := eventNonRetriableErr{IsCommit: fsm.False}
+				payload := eventNonRetriableErrPayload{
+					err: sqlbase.NewTransactionAbortedError("" /* customMsg */),
+				}
+				return ev, payload
 			}
-			return ev, payload
+			if err := ex.rollbackToSavepoint(ctx, spName); err != nil {
+				ev := eventNonRetriableErr{IsCommit: fsm.False}
+				payload := eventNonRetriableErrPayload{
+					err: err,
+				}
+				return ev, payload
+			}
+			return eventSavepointRollback{}, nil
 		}
 
 		if !(inRestartWait || ex.machine.CurState().(stateAborted).RetryIntent.Get()) {
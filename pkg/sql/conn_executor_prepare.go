@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/lib/pq/oid"
@@ -336,6 +337,13 @@ func (ex *connExecutor) execBind(
 			if arg == nil {
 				// nil indicates a NULL argument value.
 				qargs[k] = tree.DNull
+			} else if enumTyp, ok := ps.Types[k].(*types.TEnum); ok {
+				// Enum values are sent as their labels in both formats.
+				d, err := tree.ParseDEnum(enumTyp, string(arg))
+				if err != nil {
+					return retErr(err)
+				}
+				qargs[k] = d
 			} else {
 				d, err := pgwirebase.DecodeOidDatum(t, qArgFormatCodes[i], arg)
 				if err != nil {
@@ -0,0 +1,107 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// savepoint is a savepoint established in a SQL transaction with the SAVEPOINT
+// statement. The cockroach_restart savepoint is not represented by one.
+type savepoint struct {
+	name string
+	// kvToken identifies the savepoint in the KV transaction.
+	kvToken client.SavepointToken
+	// numDDL is the number of schema changing statements that had been
+	// executed in the transaction when the savepoint was established.
+	numDDL int
+}
+
+// savepointStack is the stack of the active savepoints of a SQL transaction,
+// from the oldest to the most recent.
+type savepointStack []savepoint
+
+// find returns the index of the most recent savepoint with the given name.
+func (s savepointStack) find(name string) (int, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i].name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func errSavepointDoesNotExist(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeInvalidSavepointSpecificationError,
+		"savepoint %q does not exist", name)
+}
+
+// createSavepoint establishes a new savepoint with the given name.
+func (ex *connExecutor) createSavepoint(ctx context.Context, name string) error {
+	token, err := ex.state.mu.txn.CreateSavepoint(ctx)
+	if err != nil {
+		return err
+	}
+	ex.state.savepoints = append(ex.state.savepoints, savepoint{
+		name:    name,
+		kvToken: token,
+		numDDL:  ex.state.numDDL,
+	})
+	return nil
+}
+
+// releaseSavepoint releases the named savepoint, along with all the savepoints
+// established after it.
+func (ex *connExecutor) releaseSavepoint(ctx context.Context, name string) error {
+	idx, ok := ex.state.savepoints.find(name)
+	if !ok {
+		return errSavepointDoesNotExist(name)
+	}
+	return ex.releaseSavepointsFrom(ctx, idx)
+}
+
+// rollbackToSavepoint rolls back the writes performed since the named
+// savepoint was established, and releases the savepoints established after it.
+// The savepoint itself remains active.
+func (ex *connExecutor) rollbackToSavepoint(ctx context.Context, name string) error {
+	idx, ok := ex.state.savepoints.find(name)
+	if !ok {
+		return errSavepointDoesNotExist(name)
+	}
+	sp := ex.state.savepoints[idx]
+	if ex.state.numDDL > sp.numDDL {
+		return pgerror.Unimplemented("rollback-to-savepoint-ddl",
+			"ROLLBACK TO SAVEPOINT not supported after schema changes in the transaction")
+	}
+	if err := ex.releaseSavepointsFrom(ctx, idx+1); err != nil {
+		return err
+	}
+	return ex.state.mu.txn.RollbackToSavepoint(ctx, sp.kvToken)
+}
+
+// releaseSavepointsFrom releases the savepoints starting at the given position
+// of the stack.
+func (ex *connExecutor) releaseSavepointsFrom(ctx context.Context, idx int) error {
+	for i := len(ex.state.savepoints) - 1; i >= idx; i-- {
+		if err := ex.state.mu.txn.ReleaseSavepoint(ctx, ex.state.savepoints[i].kvToken); err != nil {
+			return err
+		}
+		ex.state.savepoints = ex.state.savepoints[:i]
+	}
+	return nil
+}
@@ -216,18 +216,24 @@ var _ payloadWithError = eventRetriableErrPayload{}
 // cockroach_restart. It moves the state to CommitWait.
 type eventTxnReleased struct{}
 
+// eventSavepointRollback is generated after a successful ROLLBACK TO SAVEPOINT
+// of a savepoint other than cockroach_restart. It moves the state back to
+// Open.
+type eventSavepointRollback struct{}
+
 // payloadWithError is a common interface for the payloads that wrap an error.
 type payloadWithError interface {
 	errorCause() error
 }
 
-func (eventRetryIntentSet) Event()  {}
-func (eventTxnStart) Event()        {}
-func (eventTxnFinish) Event()       {}
-func (eventTxnRestart) Event()      {}
-func (eventNonRetriableErr) Event() {}
-func (eventRetriableErr) Event()    {}
-func (eventTxnReleased) Event()     {}
+func (eventRetryIntentSet) Event()    {}
+func (eventTxnStart) Event()          {}
+func (eventTxnFinish) Event()         {}
+func (eventTxnRestart) Event()        {}
+func (eventNonRetriableErr) Event()   {}
+func (eventRetriableErr) Event()      {}
+func (eventTxnReleased) Event()       {}
+func (eventSavepointRollback) Event() {}
 
 // TxnStateTransitions describe the transitions used by a connExecutor's
 // fsm.Machine. Args.Extended is a txnState, which is muted by the Actions.
@@ -333,12 +339,27 @@ var TxnStateTransitions = Compile(Pattern{
 			Next: stateAborted{RetryIntent: Var("retryIntent")},
 			Action: func(args Args) error {
 				ts := args.Extended.(*txnState)
-				ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
-				ts.setAdvanceInfo(skipBatch, noRewind, txnAborted)
+				if len(ts.savepoints) > 0 {
+					// The KV txn is kept open so that a ROLLBACK TO SAVEPOINT can
+					// undo the writes performed since the savepoint and resume it.
+					ts.setAdvanceInfo(skipBatch, noRewind, noEvent)
+				} else {
+					ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
+					ts.setAdvanceInfo(skipBatch, noRewind, txnAborted)
+				}
 				ts.txnAbortCount.Inc(1)
 				return nil
 			},
 		},
+		// ROLLBACK TO SAVEPOINT of a savepoint other than cockroach_restart.
+		eventSavepointRollback{}: {
+			Description: "ROLLBACK TO SAVEPOINT (not cockroach_restart)",
+			Next:        stateOpen{ImplicitTxn: False, RetryIntent: Var("retryIntent")},
+			Action: func(args Args) error {
+				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, noEvent)
+				return nil
+			},
+		},
 		// SAVEPOINT cockroach_restart: we just change the state (RetryIntent) if it
 		// wasn't set already.
 		eventRetryIntentSet{}: {
@@ -360,6 +381,9 @@ var TxnStateTransitions = Compile(Pattern{
 			Next:        stateAborted{RetryIntent: False},
 			Action: func(args Args) error {
 				ts := args.Extended.(*txnState)
+				// The savepoints can't be rolled back to once the KV txn is
+				// restarted.
+				ts.savepoints = nil
 				ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
 				ts.setAdvanceInfo(skipBatch, noRewind, txnAborted)
 				ts.txnAbortCount.Inc(1)
@@ -371,9 +395,11 @@ var TxnStateTransitions = Compile(Pattern{
 		eventRetriableErr{CanAutoRetry: False, IsCommit: False}: {
 			Next: stateRestartWait{},
 			Action: func(args Args) error {
+				ts := args.Extended.(*txnState)
 				// Note: Preparing the KV txn for restart has already happened by this
-				// point.
-				args.Extended.(*txnState).setAdvanceInfo(skipBatch, noRewind, txnRestart)
+				// point. The savepoints can't be rolled back to after the restart.
+				ts.savepoints = nil
+				ts.setAdvanceInfo(skipBatch, noRewind, txnRestart)
 				return nil
 			},
 		},
@@ -397,6 +423,7 @@ var TxnStateTransitions = Compile(Pattern{
 				// timestamp in that case. In the special case of the cockroach_restart
 				// savepoint, it's not clear to me what a user's expectation might be.
 				state.mu.txn.ManualRestart(args.Ctx, hlc.Timestamp{})
+				state.savepoints = nil
 				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, txnRestart)
 				return nil
 			},
@@ -413,13 +440,14 @@ var TxnStateTransitions = Compile(Pattern{
 			Next:        stateNoTxn{},
 			Action: func(args Args) error {
 				ts := args.Extended.(*txnState)
+				ts.rollbackKVTxnKeptForSavepoints()
 				ts.finishSQLTxn()
 				ts.setAdvanceInfo(
 					advanceOne, noRewind, args.Payload.(eventTxnFinishPayload).toEvent())
 				return nil
 			},
 		},
-		eventNonRetriableErr{IsCommit: Any}: {
+		eventNonRetriableErr{IsCommit: False}: {
 			// This event doesn't change state, but it returns a skipBatch code.
 			Description: "any other statement",
 			Next:        stateAborted{RetryIntent: Var("retryIntent")},
@@ -428,6 +456,26 @@ var TxnStateTransitions = Compile(Pattern{
 				return nil
 			},
 		},
+		eventNonRetriableErr{IsCommit: True}: {
+			// This event doesn't change state, but it returns a skipBatch code.
+			Description: "connExecutor closing",
+			Next:        stateAborted{RetryIntent: Var("retryIntent")},
+			Action: func(args Args) error {
+				ts := args.Extended.(*txnState)
+				ts.rollbackKVTxnKeptForSavepoints()
+				ts.setAdvanceInfo(skipBatch, noRewind, noEvent)
+				return nil
+			},
+		},
+		// ROLLBACK TO SAVEPOINT of a savepoint other than cockroach_restart.
+		eventSavepointRollback{}: {
+			Description: "ROLLBACK TO SAVEPOINT (not cockroach_restart)",
+			Next:        stateOpen{ImplicitTxn: False, RetryIntent: Var("retryIntent")},
+			Action: func(args Args) error {
+				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, noEvent)
+				return nil
+			},
+		},
 	},
 	stateAborted{RetryIntent: True}: {
 		// ROLLBACK TO SAVEPOINT. We accept this in the Aborted state for the
@@ -438,6 +486,7 @@ var TxnStateTransitions = Compile(Pattern{
 			Next:        stateOpen{ImplicitTxn: False, RetryIntent: True},
 			Action: func(args Args) error {
 				ts := args.Extended.(*txnState)
+				ts.rollbackKVTxnKeptForSavepoints()
 				ts.finishSQLTxn()
 
 				payload := args.Payload.(eventTxnStartPayload)
@@ -333,6 +333,23 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is a command asking for the notifications received by
+// the session for the channels it LISTENs on to be delivered to the client.
+// It is pushed by the session's notificationListener when notifications
+// arrive. The notifications are only delivered outside of transactions; if
+// the session is inside a transaction, they are delivered after the next Sync
+// processed outside of a transaction.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() {}
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -585,6 +602,9 @@ type ClientComm interface {
 	CreateCopyInResult(pos CmdPos) CopyInResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateNotificationResult creates a result for a DeliverNotifications
+	// command.
+	CreateNotificationResult(pos CmdPos) NotificationResult
 
 	// lockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -761,6 +781,16 @@ type DrainResult interface {
 	ResultBase
 }
 
+// NotificationResult represents the result of a DeliverNotifications command.
+// The notifications buffered on the result are flushed to the client when the
+// result is closed.
+type NotificationResult interface {
+	ResultBase
+
+	// BufferNotification buffers a NotificationResponse message for n.
+	BufferNotification(n Notification)
+}
+
 // EmptyQueryResult represents the result of an empty query (a query
 // representing a blank string).
 type EmptyQueryResult interface {
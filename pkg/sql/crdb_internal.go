@@ -303,6 +303,11 @@ CREATE TABLE crdb_internal.schema_changes (
 					mutType = "INDEX"
 					targetID = tree.NewDInt(tree.DInt(int64(d.Index.ID)))
 					targetName = tree.NewDString(d.Index.Name)
+				case *sqlbase.DescriptorMutation_Constraint:
+					mutType = "CONSTRAINT"
+					targetName = tree.NewDString(d.Constraint.Name)
+				case *sqlbase.DescriptorMutation_MaterializedViewRefresh:
+					mutType = "REFRESH"
 				}
 				if err := addRow(
 					tableID,
@@ -0,0 +1,548 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type createFunctionNode struct {
+	n        *tree.CreateFunction
+	tn       *tree.TableName
+	dbDesc   *sqlbase.DatabaseDescriptor
+	overload sqlbase.FunctionDescriptor_Overload
+}
+
+// CreateFunction creates a user-defined function, or adds an overload to
+// an existing one.
+// Privileges: CREATE on database.
+//   notes: postgres requires CREATE on the schema.
+func (p *planner) CreateFunction(ctx context.Context, n *tree.CreateFunction) (planNode, error) {
+	tn, err := n.Name.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	overload, err := p.makeFunctionOverload(ctx, n, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Check the body of the function.
+	if overload.ReturnsTrigger {
+		if _, err := parseTriggerFunctionBody(overload.Body); err != nil {
+			return nil, err
+		}
+	} else if _, err := p.makeUserDefinedOverload(tn.Table(), &overload); err != nil {
+		return nil, err
+	}
+
+	return &createFunctionNode{n: n, tn: tn, dbDesc: dbDesc, overload: overload}, nil
+}
+
+// makeFunctionOverload makes the descriptor of the overload defined by a
+// CREATE FUNCTION statement.
+func (p *planner) makeFunctionOverload(
+	ctx context.Context, n *tree.CreateFunction, dbID sqlbase.ID,
+) (sqlbase.FunctionDescriptor_Overload, error) {
+	var o sqlbase.FunctionDescriptor_Overload
+	var lang string
+	var hasBody, hasVolatility bool
+	for _, opt := range n.Options {
+		var seen bool
+		switch opt.Name {
+		case tree.FuncOptAs:
+			seen, hasBody = hasBody, true
+			o.Body = opt.StrVal
+		case tree.FuncOptLanguage:
+			seen = lang != ""
+			lang = opt.StrVal
+		case tree.FuncOptImmutable, tree.FuncOptStable, tree.FuncOptVolatile:
+			seen, hasVolatility = hasVolatility, true
+			o.Volatility = sqlbase.FunctionDescriptor_Overload_Volatility(
+				sqlbase.FunctionDescriptor_Overload_Volatility_value[opt.Name])
+		}
+		if seen {
+			return o, pgerror.NewError(pgerror.CodeSyntaxError, "conflicting or redundant options")
+		}
+	}
+	if !hasBody {
+		return o, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+			"no function body specified")
+	}
+	if lang == "" {
+		return o, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+			"no language specified")
+	}
+	if !strings.EqualFold(lang, "sql") {
+		return o, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"language %q is not supported", lang).SetHintf("Only LANGUAGE SQL is supported.")
+	}
+
+	if n.ReturnsTrigger {
+		if len(n.Args) != 0 {
+			return o, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+				"trigger functions cannot have declared arguments")
+		}
+		o.ReturnsTrigger = true
+		return o, nil
+	}
+
+	columnType := func(t coltypes.T) (sqlbase.ColumnType, error) {
+		if err := p.resolveColumnType(ctx, t, dbID); err != nil {
+			return sqlbase.ColumnType{}, err
+		}
+		return sqlbase.DatumTypeToColumnType(coltypes.CastTargetToDatumType(t))
+	}
+
+	o.Args = make([]sqlbase.FunctionDescriptor_Overload_Arg, len(n.Args))
+	for i, arg := range n.Args {
+		if arg.Name != "" {
+			for _, other := range n.Args[:i] {
+				if other.Name == arg.Name {
+					return o, pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+						"parameter name %q used more than once", arg.Name)
+				}
+			}
+		}
+		typ, err := columnType(arg.Type)
+		if err != nil {
+			return o, err
+		}
+		o.Args[i] = sqlbase.FunctionDescriptor_Overload_Arg{Name: string(arg.Name), Type: typ}
+	}
+
+	var err error
+	o.ReturnType, err = columnType(n.ReturnType)
+	return o, err
+}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	key := tableKey{parentID: n.dbDesc.ID, name: n.tn.Table()}.Key()
+	desc, err := params.p.getFunctionDesc(params.ctx, n.tn, false /* required */)
+	if err != nil {
+		return err
+	}
+	if desc == nil {
+		if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
+			return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+				"cannot create function %q: a relation or type with the same name already exists",
+				n.tn.Table())
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if desc != nil {
+		if err := params.p.CheckPrivilege(params.ctx, desc, privilege.CREATE); err != nil {
+			return err
+		}
+		if i := desc.FindOverload(n.overload.ArgTypes()); i < 0 {
+			desc.Overloads = append(desc.Overloads, n.overload)
+		} else if !n.n.Replace {
+			return pgerror.NewErrorf(pgerror.CodeDuplicateFunctionError,
+				"function %s%s already exists", desc.Name, n.overload.Signature())
+		} else if old := &desc.Overloads[i]; old.ReturnsTrigger != n.overload.ReturnsTrigger ||
+			!old.ReturnType.Equal(n.overload.ReturnType) {
+			return pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+				"cannot change return type of existing function").SetHintf(
+				"Use DROP FUNCTION %s%s first.", desc.Name, n.overload.Signature())
+		} else {
+			desc.Overloads[i] = n.overload
+		}
+		if err := params.p.writeFunctionDesc(params.ctx, desc); err != nil {
+			return err
+		}
+	} else {
+		id, err := GenerateUniqueDescID(params.ctx, params.extendedEvalCtx.ExecCfg.DB)
+		if err != nil {
+			return err
+		}
+		desc = &sqlbase.FunctionDescriptor{
+			Name:       n.tn.Table(),
+			ID:         id,
+			ParentID:   n.dbDesc.ID,
+			Privileges: n.dbDesc.GetPrivileges(),
+			Overloads:  []sqlbase.FunctionDescriptor_Overload{n.overload},
+		}
+		if err := desc.Validate(); err != nil {
+			return err
+		}
+		if err := params.p.createDescriptorWithID(
+			params.ctx, key, id, desc, params.EvalContext().Settings); err != nil {
+			return err
+		}
+		params.p.Tables().addUncommittedFunction(desc)
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCreateFunction,
+		int32(desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			FunctionName string
+			Statement    string
+			User         string
+		}{n.tn.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createFunctionNode) Close(context.Context)        {}
+
+// getFunctionDesc looks up the descriptor of the function with the given
+// name. Returns nil if the function doesn't exist and required is false.
+func (p *planner) getFunctionDesc(
+	ctx context.Context, tn *tree.TableName, required bool,
+) (*sqlbase.FunctionDescriptor, error) {
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+	if err != nil {
+		return nil, err
+	}
+	return p.getFunctionDescInDatabase(ctx, dbDesc.ID, tn.Table(), required)
+}
+
+func (p *planner) getFunctionDescInDatabase(
+	ctx context.Context, dbID sqlbase.ID, name string, required bool,
+) (*sqlbase.FunctionDescriptor, error) {
+	desc := &sqlbase.FunctionDescriptor{}
+	found, err := getDescriptor(ctx, p.txn, tableKey{parentID: dbID, name: name}, desc)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if required {
+			return nil, pgerror.NewErrorf(pgerror.CodeUndefinedFunctionError,
+				"function %s does not exist", name)
+		}
+		return nil, nil
+	}
+	return desc, nil
+}
+
+// writeFunctionDesc writes an updated function descriptor. Like types,
+// functions are not leased.
+func (p *planner) writeFunctionDesc(ctx context.Context, desc *sqlbase.FunctionDescriptor) error {
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descDesc := sqlbase.WrapDescriptor(desc)
+	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descDesc)
+	}
+	p.Tables().addUncommittedFunction(desc)
+	return p.txn.Put(ctx, descKey, descDesc)
+}
+
+var _ tree.FunctionResolver = &planner{}
+
+// ResolveFunction implements the tree.FunctionResolver interface. The
+// functions of a database are in its public schema; unqualified names are
+// looked up in the current database.
+func (p *planner) ResolveFunction(name *tree.UnresolvedName) (*tree.FunctionDefinition, error) {
+	_, desc, err := p.lookupUserDefinedFunction(p.EvalContext().Context, name)
+	if err != nil || desc == nil {
+		return nil, err
+	}
+	// Register the dependency to the planner, if requested.
+	if p.curPlan.funcDeps != nil {
+		p.curPlan.funcDeps[desc.ID] = struct{}{}
+	}
+	return p.makeUserDefinedFunction(desc)
+}
+
+// lookupUserDefinedFunction returns the descriptor of the user-defined
+// function with the given name and the name of its database. The
+// descriptor is nil if there is no such function.
+func (p *planner) lookupUserDefinedFunction(
+	ctx context.Context, name *tree.UnresolvedName,
+) (string, *sqlbase.FunctionDescriptor, error) {
+	if p.txn == nil || name.Star {
+		return "", nil, nil
+	}
+	dbName := p.CurrentDatabase()
+	switch name.NumParts {
+	case 1:
+	case 2:
+		if name.Parts[1] != tree.PublicSchema {
+			dbName = name.Parts[1]
+		}
+	case 3:
+		if name.Parts[1] != tree.PublicSchema {
+			return "", nil, nil
+		}
+		dbName = name.Parts[2]
+	default:
+		return "", nil, nil
+	}
+	if dbName == "" {
+		return "", nil, nil
+	}
+
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(dbName,
+		p.CommonLookupFlags(ctx, false /* required */))
+	if err != nil || dbDesc == nil {
+		return "", nil, err
+	}
+	desc, err := p.Tables().getFunctionDesc(
+		ctx, p.txn, dbDesc.ID, name.Parts[0], p.avoidCachedDescriptors)
+	if err != nil || desc == nil {
+		return "", nil, err
+	}
+	return dbName, desc, nil
+}
+
+// qualifyUserDefinedFunctionName qualifies the name of the user-defined
+// function referenced by fn with its database and schema, so that the name
+// refers to the same function in any current database. Other function
+// names are left unchanged.
+func (p *planner) qualifyUserDefinedFunctionName(
+	ctx context.Context, fn *tree.ResolvableFunctionReference,
+) error {
+	name, ok := fn.FunctionReference.(*tree.UnresolvedName)
+	if !ok {
+		return nil
+	}
+	if _, err := name.ResolveFunction(p.CurrentSearchPath()); !tree.MaybeUserDefinedFunction(err) {
+		return nil
+	}
+	dbName, desc, err := p.lookupUserDefinedFunction(ctx, name)
+	if err != nil || desc == nil {
+		return err
+	}
+	*name = tree.UnresolvedName{
+		NumParts: 3,
+		Parts:    tree.NameParts{desc.Name, tree.PublicSchema, dbName},
+	}
+	return nil
+}
+
+// makeUserDefinedFunction makes the definition of a user-defined function
+// from its descriptor. Trigger overloads cannot be called.
+func (p *planner) makeUserDefinedFunction(
+	desc *sqlbase.FunctionDescriptor,
+) (*tree.FunctionDefinition, error) {
+	props := tree.FunctionProperties{
+		// Like in postgres, functions are called on NULL input by default.
+		NullableArgs: true,
+		// The overloads cannot be resolved again on other nodes.
+		DistsqlBlacklist: true,
+	}
+	overloads := make([]tree.Overload, 0, len(desc.Overloads))
+	for i := range desc.Overloads {
+		if desc.Overloads[i].ReturnsTrigger {
+			continue
+		}
+		o, err := p.makeUserDefinedOverload(desc.Name, &desc.Overloads[i])
+		if err != nil {
+			return nil, err
+		}
+		overloads = append(overloads, o)
+		// The properties are shared by all the overloads.
+		if desc.Overloads[i].Volatility == sqlbase.FunctionDescriptor_Overload_VOLATILE {
+			props.Impure = true
+			props.NeedsRepeatedEvaluation = true
+		}
+	}
+	if len(overloads) == 0 {
+		return nil, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+			"trigger functions can only be called as triggers")
+	}
+	return tree.NewFunctionDefinition(desc.Name, &props, overloads), nil
+}
+
+// udfArgs is the IndexedVarContainer of the body of a user-defined
+// function. The arguments of the function are IndexedVars in the body.
+type udfArgs struct {
+	names  []string
+	types  []types.T
+	values tree.Datums
+}
+
+var _ tree.IndexedVarContainer = &udfArgs{}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (a *udfArgs) IndexedVarEval(idx int, ctx *tree.EvalContext) (tree.Datum, error) {
+	return a.values[idx], nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (a *udfArgs) IndexedVarResolvedType(idx int) types.T {
+	return a.types[idx]
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (a *udfArgs) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	if a.names[idx] == "" {
+		return nil
+	}
+	n := tree.Name(a.names[idx])
+	return &n
+}
+
+// makeUserDefinedOverload makes the overload of a user-defined function
+// from its descriptor. The body of the overload is parsed and type checked.
+func (p *planner) makeUserDefinedOverload(
+	name string, o *sqlbase.FunctionDescriptor_Overload,
+) (tree.Overload, error) {
+	args := &udfArgs{
+		names: make([]string, len(o.Args)),
+		types: make([]types.T, len(o.Args)),
+	}
+	argTypes := make(tree.ArgTypes, len(o.Args))
+	for i := range o.Args {
+		args.names[i] = o.Args[i].Name
+		args.types[i] = o.Args[i].Type.ToDatumType()
+		argTypes[i].Name = o.Args[i].Name
+		argTypes[i].Typ = args.types[i]
+	}
+	returnType := o.ReturnType.ToDatumType()
+
+	expr, err := parseFunctionBody(o.Body)
+	if err != nil {
+		return tree.Overload{}, err
+	}
+	ivarHelper := tree.MakeIndexedVarHelper(args, len(o.Args))
+	expr, err = tree.SimpleVisit(expr, func(expr tree.Expr) (error, bool, tree.Expr) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && !t.Star {
+				for i := range args.names {
+					if args.names[i] != "" && args.names[i] == t.Parts[0] {
+						return nil, false, ivarHelper.IndexedVar(i)
+					}
+				}
+			}
+			return pgerror.NewErrorf(pgerror.CodeUndefinedColumnError,
+				"column %q does not exist", tree.ErrString(t)), false, expr
+		case *tree.Placeholder:
+			idx, err := strconv.Atoi(t.Name)
+			if err != nil || idx < 1 || idx > len(args.types) {
+				return pgerror.NewErrorf(pgerror.CodeUndefinedParameterError,
+					"there is no parameter $%s", t.Name), false, expr
+			}
+			return nil, false, ivarHelper.IndexedVar(idx - 1)
+		}
+		return nil, true, expr
+	})
+	if err != nil {
+		return tree.Overload{}, err
+	}
+
+	// The body is type checked without a FunctionResolver, so that a
+	// user-defined function cannot call itself.
+	semaCtx := tree.MakeSemaContext(false /* privileged */)
+	semaCtx.IVarContainer = args
+	semaCtx.SearchPath = p.SessionData().SearchPath
+	semaCtx.Properties.Require("function body", tree.RejectSpecial|tree.RejectSubqueries)
+	typedExpr, err := tree.TypeCheck(expr, &semaCtx, returnType)
+	if err != nil {
+		return tree.Overload{}, err
+	}
+	actualType := typedExpr.ResolvedType()
+	if actualType != types.Unknown && !returnType.Equivalent(actualType) {
+		return tree.Overload{}, pgerror.NewErrorf(pgerror.CodeInvalidFunctionDefinitionError,
+			"return type mismatch in function declared to return %s", returnType).SetDetailf(
+			"Actual return type is %s.", actualType)
+	}
+
+	res := tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(returnType),
+		Info:       o.Body,
+		Fn: func(evalCtx *tree.EvalContext, values tree.Datums) (tree.Datum, error) {
+			evalCtx.PushIVarContainer(&udfArgs{names: args.names, types: args.types, values: values})
+			defer evalCtx.PopIVarContainer()
+			return typedExpr.Eval(evalCtx)
+		},
+	}
+	if o.Volatility != sqlbase.FunctionDescriptor_Overload_VOLATILE && actualType != types.Unknown {
+		res.InlineBody = typedExpr
+	}
+	return res, nil
+}
+
+// parseFunctionBody parses the body of a user-defined function, which must
+// be a SELECT statement computing a single expression without a FROM
+// clause, and returns the expression.
+func parseFunctionBody(body string) (tree.Expr, error) {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	errUnsupported := pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+		"unsupported function body: %s", body).SetHintf(
+		"The body of a function must be of the form SELECT <expr>.")
+	if len(stmts) != 1 {
+		return nil, errUnsupported
+	}
+	sel, ok := stmts[0].(*tree.Select)
+	if !ok || sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, errUnsupported
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || len(clause.Exprs) != 1 || (clause.From != nil && len(clause.From.Tables) != 0) ||
+		clause.Where != nil || clause.GroupBy != nil || clause.Having != nil ||
+		clause.Window != nil || clause.Distinct || clause.DistinctOn != nil {
+		return nil, errUnsupported
+	}
+	return clause.Exprs[0].Expr, nil
+}
+
+// parseTriggerFunctionBody parses the body of a trigger function, which
+// must be one or more INSERT, UPDATE or DELETE statements.
+func parseTriggerFunctionBody(body string) (tree.StatementList, error) {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) == 0 {
+		return nil, pgerror.NewError(pgerror.CodeInvalidFunctionDefinitionError,
+			"trigger function body is empty")
+	}
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case *tree.Insert, *tree.Update, *tree.Delete:
+		default:
+			return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"unsupported statement in trigger function: %s", stmt).SetHintf(
+				"The body of a trigger function must be INSERT, UPDATE or DELETE statements.")
+		}
+	}
+	return stmts, nil
+}
@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 )
 
@@ -39,7 +41,8 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, err
 	}
 
-	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /*required*/, requireTableDesc)
+	tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, tn, true /*required*/, requireTableOrMaterializedViewDesc)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +51,22 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, err
 	}
 
+	if tableDesc.IsMaterializedView() {
+		// The rows of a materialized view only consist of the columns of its
+		// query: there is no room for the computed columns of index
+		// expressions, nor for a parent table.
+		if n.Interleave != nil {
+			return nil, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+				"indexes on materialized views cannot be interleaved")
+		}
+		for _, elem := range n.Columns {
+			if elem.Expr != nil {
+				return nil, pgerror.NewError(pgerror.CodeFeatureNotSupportedError,
+					"index expressions are not supported on materialized views")
+			}
+		}
+	}
+
 	return &createIndexNode{tableDesc: tableDesc, n: n}, nil
 }
 
@@ -84,6 +103,184 @@ func MakeIndexDescriptor(n *tree.CreateIndex) (*sqlbase.IndexDescriptor, error)
 	return &indexDesc, nil
 }
 
+// indexExprColumnName is the name of the hidden computed columns that store
+// the result of index expressions. A numeric suffix is added to it when the
+// table already has a column with that name.
+const indexExprColumnName = "crdb_internal_idx_expr"
+
+// replaceIndexExprs replaces the expressions of the given index elements
+// with references to hidden computed columns that store their result. It
+// returns the new elements along with the columns that need to be added to
+// the table. A hidden column of the table that already stores the same
+// expression is reused.
+func replaceIndexExprs(
+	ctx context.Context,
+	desc *sqlbase.TableDescriptor,
+	tn *tree.TableName,
+	elems tree.IndexElemList,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+) (tree.IndexElemList, []sqlbase.ColumnDescriptor, error) {
+	var newCols []sqlbase.ColumnDescriptor
+	res := make(tree.IndexElemList, len(elems))
+	for i, elem := range elems {
+		res[i] = elem
+		if elem.Expr == nil {
+			continue
+		}
+		// A parenthesized column reference is the column itself.
+		if n, ok := elem.Expr.(*tree.UnresolvedName); ok && n.NumParts == 1 && !n.Star {
+			res[i] = tree.IndexElem{Column: tree.Name(n.Parts[0]), Direction: elem.Direction}
+			continue
+		}
+		col, isNew, err := makeIndexExprColumn(ctx, desc, tn, elem.Expr, newCols, semaCtx, evalCtx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isNew {
+			newCols = append(newCols, col)
+		}
+		res[i] = tree.IndexElem{Column: tree.Name(col.Name), Direction: elem.Direction}
+	}
+	return res, newCols, nil
+}
+
+// makeIndexExprColumn returns the hidden computed column that stores the
+// result of the given index expression. The column is either an existing
+// column of the table or of newCols, or a new column, in which case isNew
+// is true.
+func makeIndexExprColumn(
+	ctx context.Context,
+	desc *sqlbase.TableDescriptor,
+	tn *tree.TableName,
+	expr tree.Expr,
+	newCols []sqlbase.ColumnDescriptor,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+) (col sqlbase.ColumnDescriptor, isNew bool, _ error) {
+	if err := iterColDescriptorsInExpr(*desc, expr, func(c sqlbase.ColumnDescriptor) error {
+		if c.IsComputed() {
+			return pgerror.NewError(pgerror.CodeInvalidTableDefinitionError,
+				"index expressions cannot reference computed columns")
+		}
+		return nil
+	}); err != nil {
+		return col, false, err
+	}
+
+	// Replace column references with typed dummies to allow typechecking.
+	replacedExpr, _, err := replaceVars(*desc, expr)
+	if err != nil {
+		return col, false, err
+	}
+	typedExpr, err := sqlbase.SanitizeVarFreeExpr(
+		replacedExpr, types.Any, "index expression", semaCtx, evalCtx, false, /* allowImpure */
+	)
+	if err != nil {
+		return col, false, err
+	}
+	colType, err := sqlbase.DatumTypeToColumnType(typedExpr.ResolvedType())
+	if err != nil {
+		return col, false, err
+	}
+
+	sourceInfo := sqlbase.NewSourceInfoForSingleTable(
+		*tn, sqlbase.ResultColumnsFromColDescs(desc.Columns),
+	)
+	expr, err = dequalifyColumnRefs(ctx, sqlbase.MultiSourceInfo{sourceInfo}, expr)
+	if err != nil {
+		return col, false, err
+	}
+	serialized := tree.Serialize(expr)
+
+	cols := append(append([]sqlbase.ColumnDescriptor(nil), desc.Columns...), newCols...)
+	for _, c := range cols {
+		if c.IsIndexExpr() && *c.ComputeExpr == serialized {
+			return c, false, nil
+		}
+	}
+
+	name := indexExprColumnName
+	exists := func(name string) bool {
+		for _, c := range newCols {
+			if c.Name == name {
+				return true
+			}
+		}
+		_, _, err := desc.FindColumnByName(tree.Name(name))
+		return err == nil
+	}
+	for i := 1; exists(name); i++ {
+		name = fmt.Sprintf("%s_%d", indexExprColumnName, i)
+	}
+	return sqlbase.ColumnDescriptor{
+		Name:        name,
+		Type:        colType,
+		Nullable:    true,
+		Hidden:      true,
+		ComputeExpr: &serialized,
+	}, true, nil
+}
+
+// makeIndexPredicate checks that the given expression is a valid predicate
+// for a partial index of the table and returns its serialized form. The
+// predicate must be a boolean expression that only references columns of the
+// table and immutable functions.
+func makeIndexPredicate(
+	ctx context.Context,
+	desc *sqlbase.TableDescriptor,
+	tn *tree.TableName,
+	pred tree.Expr,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+) (string, error) {
+	// Replace column references with typed dummies to allow typechecking.
+	replacedExpr, _, err := replaceVars(*desc, pred)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sqlbase.SanitizeVarFreeExpr(
+		replacedExpr, types.Bool, "index predicate", semaCtx, evalCtx, false, /* allowImpure */
+	); err != nil {
+		return "", err
+	}
+
+	sourceInfo := sqlbase.NewSourceInfoForSingleTable(
+		*tn, sqlbase.ResultColumnsFromColDescs(desc.Columns),
+	)
+	pred, err = dequalifyColumnRefs(ctx, sqlbase.MultiSourceInfo{sourceInfo}, pred)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(pred), nil
+}
+
+// indexExprColumnsReferencing returns the IDs of the hidden columns of desc
+// that store index expressions which reference the given column.
+func indexExprColumnsReferencing(
+	desc *sqlbase.TableDescriptor, colID sqlbase.ColumnID,
+) (map[sqlbase.ColumnID]struct{}, error) {
+	res := make(map[sqlbase.ColumnID]struct{})
+	for _, c := range desc.Columns {
+		if !c.IsIndexExpr() {
+			continue
+		}
+		expr, err := parser.ParseExpr(*c.ComputeExpr)
+		if err != nil {
+			return nil, err
+		}
+		if err := iterColDescriptorsInExpr(*desc, expr, func(ref sqlbase.ColumnDescriptor) error {
+			if ref.ID == colID {
+				res[c.ID] = struct{}{}
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (n *createIndexNode) startExec(params runParams) error {
 	_, dropped, err := n.tableDesc.FindIndexByName(string(n.n.Name))
 	if err == nil {
@@ -95,10 +292,33 @@ func (n *createIndexNode) startExec(params runParams) error {
 		}
 	}
 
-	indexDesc, err := MakeIndexDescriptor(n.n)
+	tn, err := n.n.Table.Normalize()
+	if err != nil {
+		return err
+	}
+	elems, newCols, err := replaceIndexExprs(
+		params.ctx, n.tableDesc, tn, n.n.Columns, &params.p.semaCtx, params.EvalContext(),
+	)
 	if err != nil {
 		return err
 	}
+	for _, col := range newCols {
+		n.tableDesc.AddColumnMutation(col, sqlbase.DescriptorMutation_ADD)
+	}
+	def := *n.n
+	def.Columns = elems
+	indexDesc, err := MakeIndexDescriptor(&def)
+	if err != nil {
+		return err
+	}
+	if n.n.Predicate != nil {
+		indexDesc.Predicate, err = makeIndexPredicate(
+			params.ctx, n.tableDesc, tn, n.n.Predicate, &params.p.semaCtx, params.EvalContext(),
+		)
+		if err != nil {
+			return err
+		}
+	}
 
 	if n.n.PartitionBy != nil {
 		partitioning, err := CreatePartitioning(params.ctx, params.p.ExecCfg().Settings,
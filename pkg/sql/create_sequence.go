@@ -97,6 +97,10 @@ func doCreateSequence(
 	// makeSequenceTableDesc already validates the table. No call to
 	// desc.ValidateTable() needed here.
 
+	if err := params.p.processSequenceOwnedBy(params.ctx, &desc, opts); err != nil {
+		return err
+	}
+
 	key := getSequenceKey(dbDesc, name.Table()).Key()
 	if err = params.p.createDescriptorWithID(params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
 		return err
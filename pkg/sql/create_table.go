@@ -36,6 +36,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+var errTemporaryInterleave = pgerror.NewError(pgerror.CodeInvalidTableDefinitionError,
+	"temporary tables cannot be interleaved")
+
 type createTableNode struct {
 	n          *tree.CreateTable
 	dbDesc     *sqlbase.DatabaseDescriptor
@@ -53,7 +56,13 @@ func (p *planner) CreateTable(ctx context.Context, n *tree.CreateTable) (planNod
 		return nil, err
 	}
 
-	dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+	var dbDesc *DatabaseDescriptor
+	if n.Temporary || (tn.ExplicitSchema && p.Tables().isTemporarySchema(tn.Schema())) {
+		n.Temporary = true
+		dbDesc, err = p.resolveTemporaryTableTarget(ctx, tn)
+	} else {
+		dbDesc, err = p.ResolveUncachedDatabase(ctx, tn)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,8 +72,23 @@ func (p *planner) CreateTable(ctx context.Context, n *tree.CreateTable) (planNod
 	}
 
 	n.HoistConstraints()
+	if n.Temporary && n.Interleave != nil {
+		return nil, errTemporaryInterleave
+	}
 	for _, def := range n.Defs {
 		switch t := def.(type) {
+		case *tree.IndexTableDef:
+			if n.Temporary && t.Interleave != nil {
+				return nil, errTemporaryInterleave
+			}
+		case *tree.UniqueConstraintTableDef:
+			if n.Temporary && t.Interleave != nil {
+				return nil, errTemporaryInterleave
+			}
+		case *tree.ColumnTableDef:
+			if err := p.resolveColumnType(ctx, t.Type, dbDesc.ID); err != nil {
+				return nil, err
+			}
 		case *tree.ForeignKeyConstraintTableDef:
 			// Just check the target name has the right structure. We'll do
 			// name resolution later, after the descriptor has been
@@ -107,7 +131,16 @@ type createTableRun struct {
 }
 
 func (n *createTableNode) startExec(params runParams) error {
-	tKey := tableKey{parentID: n.dbDesc.ID, name: n.n.Table.TableName().Table()}
+	// The names of temporary tables are stored under the temporary schema
+	// of the session.
+	parentID := n.dbDesc.ID
+	if n.n.Temporary {
+		var err error
+		if parentID, err = params.p.getOrCreateTemporarySchemaID(params.ctx, n.dbDesc.ID); err != nil {
+			return err
+		}
+	}
+	tKey := tableKey{parentID: parentID, name: n.n.Table.TableName().Table()}
 	key := tKey.Key()
 	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
 		if n.n.IfNotExists {
@@ -145,6 +178,14 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if n.n.Temporary {
+		desc.TemporarySchemaID = parentID
+		desc.TemporarySessionID = params.p.Tables().temporarySessionID.GetBytes()
+	}
+	if err := checkTemporaryForeignKeyTargets(desc.IsTemporary(), affected); err != nil {
+		return err
+	}
+
 	if desc.Adding() {
 		// if this table and all its references are created in the same
 		// transaction it can be made PUBLIC.
@@ -323,6 +364,10 @@ const (
 func matchesIndex(
 	cols []sqlbase.ColumnDescriptor, idx sqlbase.IndexDescriptor, exact indexMatch,
 ) bool {
+	// A partial index does not contain all the rows of its table.
+	if idx.IsPartial() {
+		return false
+	}
 	if len(cols) > len(idx.ColumnIDs) || (exact && len(cols) != len(idx.ColumnIDs)) {
 		return false
 	}
@@ -522,6 +567,10 @@ func ResolveFK(
 		}
 	}
 
+	if d.Match == tree.MatchPartial {
+		return pgerror.Unimplemented("match partial", "MATCH PARTIAL is not supported")
+	}
+
 	ref := sqlbase.ForeignKeyReference{
 		Table:           target.ID,
 		Index:           targetIdxID,
@@ -529,6 +578,7 @@ func ResolveFK(
 		SharedPrefixLen: int32(len(srcCols)),
 		OnDelete:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Update],
+		Match:           sqlbase.ForeignKeyReferenceMatchValue[d.Match],
 	}
 
 	if mode == sqlbase.ConstraintValidity_Unvalidated {
@@ -1039,9 +1089,22 @@ func MakeTableDesc(
 			if d.Inverted {
 				idx.Type = sqlbase.IndexDescriptor_INVERTED
 			}
-			if err := idx.FillColumns(d.Columns); err != nil {
+			elems, newCols, err := replaceIndexExprs(ctx, &desc, tableName, d.Columns, semaCtx, evalCtx)
+			if err != nil {
+				return desc, err
+			}
+			for _, col := range newCols {
+				desc.AddColumn(col)
+			}
+			if err := idx.FillColumns(elems); err != nil {
 				return desc, err
 			}
+			if d.Predicate != nil {
+				idx.Predicate, err = makeIndexPredicate(ctx, &desc, tableName, d.Predicate, semaCtx, evalCtx)
+				if err != nil {
+					return desc, err
+				}
+			}
 			if d.PartitionBy != nil {
 				partitioning, err := CreatePartitioning(ctx, st, evalCtx, &desc, &idx, d.PartitionBy)
 				if err != nil {
@@ -1061,9 +1124,26 @@ func MakeTableDesc(
 				Unique:           true,
 				StoreColumnNames: d.Storing.ToStrings(),
 			}
-			if err := idx.FillColumns(d.Columns); err != nil {
+			elems := d.Columns
+			if !d.PrimaryKey {
+				var newCols []sqlbase.ColumnDescriptor
+				elems, newCols, err = replaceIndexExprs(ctx, &desc, tableName, d.Columns, semaCtx, evalCtx)
+				if err != nil {
+					return desc, err
+				}
+				for _, col := range newCols {
+					desc.AddColumn(col)
+				}
+			}
+			if err := idx.FillColumns(elems); err != nil {
 				return desc, err
 			}
+			if d.Predicate != nil {
+				idx.Predicate, err = makeIndexPredicate(ctx, &desc, tableName, d.Predicate, semaCtx, evalCtx)
+				if err != nil {
+					return desc, err
+				}
+			}
 			if d.PartitionBy != nil {
 				partitioning, err := CreatePartitioning(ctx, st, evalCtx, &desc, &idx, d.PartitionBy)
 				if err != nil {
@@ -0,0 +1,164 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableName *tree.TableName
+	tableDesc *sqlbase.TableDescriptor
+	trigger   sqlbase.TriggerDescriptor
+}
+
+// CreateTrigger creates a row-level trigger on a table. The trigger is
+// stored in the table descriptor.
+// Privileges: CREATE on table.
+//   notes: postgres requires TRIGGER on table, EXECUTE on function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	tn, err := n.Table.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if tableDesc.FindTriggerByName(string(n.Name)) >= 0 {
+		return nil, pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+			"trigger %q for relation %q already exists", n.Name, tableDesc.Name)
+	}
+
+	trigger := sqlbase.TriggerDescriptor{Name: string(n.Name)}
+	switch n.Timing {
+	case tree.TriggerBefore:
+		trigger.Timing = sqlbase.TriggerDescriptor_BEFORE
+	case tree.TriggerAfter:
+		trigger.Timing = sqlbase.TriggerDescriptor_AFTER
+	default:
+		return nil, pgerror.NewErrorf(pgerror.CodeInternalError,
+			"programming error: unknown trigger timing: %s", n.Timing)
+	}
+	for _, event := range n.Events {
+		var e sqlbase.TriggerDescriptor_Event
+		switch event {
+		case tree.TriggerInsert:
+			e = sqlbase.TriggerDescriptor_INSERT
+		case tree.TriggerUpdate:
+			e = sqlbase.TriggerDescriptor_UPDATE
+		case tree.TriggerDelete:
+			e = sqlbase.TriggerDescriptor_DELETE
+		default:
+			return nil, pgerror.NewErrorf(pgerror.CodeInternalError,
+				"programming error: unknown trigger event: %s", event)
+		}
+		if trigger.FiresOn(e) {
+			return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
+				"duplicate trigger events specified at or near %q", event)
+		}
+		trigger.Events = append(trigger.Events, e)
+	}
+
+	funcName, err := n.FuncName.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	funcDesc, err := p.getFunctionDesc(ctx, funcName, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if funcDesc.ParentID != tableDesc.ParentID {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"the function of trigger %q must be in the database of table %q",
+			n.Name, tableDesc.Name)
+	}
+	idx := funcDesc.FindOverload(nil /* argTypes */)
+	if idx < 0 {
+		return nil, pgerror.NewErrorf(pgerror.CodeUndefinedFunctionError,
+			"function %s() does not exist", funcDesc.Name)
+	}
+	if !funcDesc.Overloads[idx].ReturnsTrigger {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidObjectDefinitionError,
+			"function %s must return type trigger", funcDesc.Name)
+	}
+	trigger.FunctionID = funcDesc.ID
+
+	return &createTriggerNode{n: n, tableName: tn, tableDesc: tableDesc, trigger: trigger}, nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	oldFunctionIDs := n.tableDesc.TriggerFunctionIDs()
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers, n.trigger)
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+	if err := params.p.updateFunctionBackReferences(
+		params.ctx, n.tableDesc.ID, oldFunctionIDs, n.tableDesc.TriggerFunctionIDs(),
+	); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCreateTrigger,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName   string
+			TriggerName string
+			Statement   string
+			User        string
+		}{n.tableName.FQString(), n.trigger.Name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
+
+// updateFunctionBackReferences maintains the list of tables that have
+// triggers calling each function, so that a function in use cannot be
+// dropped. oldFunctionIDs and newFunctionIDs are the functions called by
+// the triggers of the table before and after a change.
+func (p *planner) updateFunctionBackReferences(
+	ctx context.Context, tableID sqlbase.ID, oldFunctionIDs, newFunctionIDs []sqlbase.ID,
+) error {
+	return forEachChangedID(oldFunctionIDs, newFunctionIDs, func(funcID sqlbase.ID, add bool) error {
+		desc := &sqlbase.FunctionDescriptor{}
+		if err := getDescriptorByID(ctx, p.txn, funcID, desc); err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				// The function was dropped in the meantime.
+				return nil
+			}
+			return err
+		}
+		desc.ReferencingDescriptorIDs = updateReferencingIDs(
+			desc.ReferencingDescriptorIDs, tableID, add)
+		return p.writeFunctionDesc(ctx, desc)
+	})
+}
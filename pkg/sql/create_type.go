@@ -0,0 +1,282 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// maxEnumLabelLength is the maximum length of an enum label in bytes,
+// as in postgres.
+const maxEnumLabelLength = 63
+
+type createTypeNode struct {
+	n      *tree.CreateType
+	tn     *tree.TableName
+	dbDesc *sqlbase.DatabaseDescriptor
+}
+
+// CreateType creates an enum type.
+// Privileges: CREATE on database.
+//   notes: postgres requires CREATE on the schema.
+func (p *planner) CreateType(ctx context.Context, n *tree.CreateType) (planNode, error) {
+	tn, err := n.Name.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(n.EnumLabels))
+	for _, label := range n.EnumLabels {
+		if err := checkEnumLabel(label); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[label]; ok {
+			return nil, newEnumLabelAlreadyExistsError(label)
+		}
+		seen[label] = struct{}{}
+	}
+
+	return &createTypeNode{n: n, tn: tn, dbDesc: dbDesc}, nil
+}
+
+func (n *createTypeNode) startExec(params runParams) error {
+	key := tableKey{parentID: n.dbDesc.ID, name: n.tn.Table()}.Key()
+	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
+		return newTypeAlreadyExistsError(n.tn.Table())
+	} else if err != nil {
+		return err
+	}
+
+	id, err := GenerateUniqueDescID(params.ctx, params.extendedEvalCtx.ExecCfg.DB)
+	if err != nil {
+		return err
+	}
+
+	desc := sqlbase.TypeDescriptor{
+		Name:        n.tn.Table(),
+		ID:          id,
+		ParentID:    n.dbDesc.ID,
+		Privileges:  n.dbDesc.GetPrivileges(),
+		EnumMembers: make([]sqlbase.EnumMember, len(n.n.EnumLabels)),
+	}
+	for i, label := range n.n.EnumLabels {
+		desc.EnumMembers[i].Label = label
+	}
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+
+	if err := params.p.createDescriptorWithID(
+		params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCreateType,
+		int32(desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TypeName  string
+			Statement string
+			User      string
+		}{n.tn.FQString(), n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *createTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTypeNode) Close(context.Context)        {}
+
+// checkEnumLabel verifies that label can be used as the label of an enum
+// value.
+func checkEnumLabel(label string) error {
+	if len(label) == 0 || len(label) > maxEnumLabelLength {
+		return pgerror.NewErrorf(pgerror.CodeInvalidNameError,
+			"invalid enum label %q", label).SetDetailf(
+			"Labels must be between 1 and %d bytes long.", maxEnumLabelLength)
+	}
+	return nil
+}
+
+func newEnumLabelAlreadyExistsError(label string) error {
+	return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError,
+		"enum label %q already exists", label)
+}
+
+func newTypeAlreadyExistsError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeDuplicateObjectError, "type %q already exists", name)
+}
+
+// getTypeDesc looks up the descriptor of the type with the given name.
+// Returns nil if the type doesn't exist and required is false.
+func (p *planner) getTypeDesc(
+	ctx context.Context, tn *tree.TableName, required bool,
+) (*sqlbase.TypeDescriptor, error) {
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+	if err != nil {
+		return nil, err
+	}
+	desc := &sqlbase.TypeDescriptor{}
+	found, err := getDescriptor(ctx, p.txn, tableKey{parentID: dbDesc.ID, name: tn.Table()}, desc)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if required {
+			return nil, tree.NewUndefinedTypeError(tn.Table())
+		}
+		return nil, nil
+	}
+	return desc, nil
+}
+
+// writeTypeDesc writes an updated type descriptor. Types are not leased,
+// so unlike tables the new version is visible as soon as the transaction
+// commits.
+func (p *planner) writeTypeDesc(ctx context.Context, desc *sqlbase.TypeDescriptor) error {
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descDesc := sqlbase.WrapDescriptor(desc)
+	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descDesc)
+	}
+	return p.txn.Put(ctx, descKey, descDesc)
+}
+
+// updateTypeBackReferences maintains the list of tables that refer to each
+// type, so that a type in use cannot be dropped and so that new values of
+// a type can be added to the columns that use it. oldTypeIDs and
+// newTypeIDs are the types used by the table before and after a change.
+func (p *planner) updateTypeBackReferences(
+	ctx context.Context, tableID sqlbase.ID, oldTypeIDs, newTypeIDs []sqlbase.ID,
+) error {
+	return forEachChangedID(oldTypeIDs, newTypeIDs, func(typeID sqlbase.ID, add bool) error {
+		desc := &sqlbase.TypeDescriptor{}
+		if err := getDescriptorByID(ctx, p.txn, typeID, desc); err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				// The type was dropped in the meantime.
+				return nil
+			}
+			return err
+		}
+		desc.ReferencingDescriptorIDs = updateReferencingIDs(
+			desc.ReferencingDescriptorIDs, tableID, add)
+		return p.writeTypeDesc(ctx, desc)
+	})
+}
+
+// forEachChangedID calls fn with add set to false for the IDs of oldIDs
+// that are not in newIDs, then with add set to true for the IDs of newIDs
+// that are not in oldIDs.
+func forEachChangedID(
+	oldIDs, newIDs []sqlbase.ID, fn func(id sqlbase.ID, add bool) error,
+) error {
+	contains := func(ids []sqlbase.ID, id sqlbase.ID) bool {
+		for _, other := range ids {
+			if other == id {
+				return true
+			}
+		}
+		return false
+	}
+	for _, id := range oldIDs {
+		if !contains(newIDs, id) {
+			if err := fn(id, false /* add */); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range newIDs {
+		if !contains(oldIDs, id) {
+			if err := fn(id, true /* add */); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateReferencingIDs removes id from the list of referencing descriptor
+// IDs refs, then adds it back at the end if add is set.
+func updateReferencingIDs(refs []sqlbase.ID, id sqlbase.ID, add bool) []sqlbase.ID {
+	res := refs[:0]
+	for _, other := range refs {
+		if other != id {
+			res = append(res, other)
+		}
+	}
+	if add {
+		res = append(res, id)
+	}
+	return res
+}
+
+var _ tree.TypeResolver = &planner{}
+
+// ResolveType implements the tree.TypeResolver interface. User-defined
+// types are looked up in the current database.
+func (p *planner) ResolveType(name string) (*types.TEnum, error) {
+	if p.txn == nil {
+		return nil, tree.NewUndefinedTypeError(name)
+	}
+	tn := tree.MakeTableName(tree.Name(p.CurrentDatabase()), tree.Name(name))
+	desc, err := p.getTypeDesc(p.EvalContext().Context, &tn, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	return desc.EnumType(), nil
+}
+
+// resolveColumnType resolves the name of a user-defined column type in
+// the database with the given ID. Other column types are left unchanged.
+func (p *planner) resolveColumnType(
+	ctx context.Context, t coltypes.T, dbID sqlbase.ID,
+) error {
+	ud, ok := t.(*coltypes.TUserDefined)
+	if !ok {
+		return nil
+	}
+	desc := &sqlbase.TypeDescriptor{}
+	found, err := getDescriptor(ctx, p.txn, tableKey{parentID: dbID, name: ud.Name}, desc)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return tree.NewUndefinedTypeError(ud.Name)
+	}
+	ud.Typ = desc.EnumType()
+	return nil
+}
@@ -19,8 +19,10 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -30,11 +32,18 @@ import (
 type createViewNode struct {
 	n             *tree.CreateView
 	dbDesc        *sqlbase.DatabaseDescriptor
+	viewQuery     string
 	sourceColumns sqlbase.ResultColumns
 	// planDeps tracks which tables and views the view being created
 	// depends on. This is collected during the construction of
 	// the view query's logical plan.
 	planDeps planDependencies
+	// funcDeps tracks which user-defined functions the view being created
+	// depends on.
+	funcDeps map[sqlbase.ID]struct{}
+	// sourcePlan is the plan of the view query for a materialized view,
+	// whose result populates the view.
+	sourcePlan planNode
 }
 
 // CreateView creates a view.
@@ -79,6 +88,14 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 				tn.ExplicitCatalog = true
 			},
 		)
+		// Likewise for the names of user-defined functions.
+		f.WithReformatFunctionNames(
+			func(_ *tree.FmtCtx, fn *tree.ResolvableFunctionReference) {
+				if err := p.qualifyUserDefinedFunctionName(ctx, fn); err != nil && fmtErr == nil {
+					fmtErr = err
+				}
+			},
+		)
 		f.FormatNode(n.AsSource)
 		f.Close() // We don't need the string.
 	}
@@ -86,18 +103,30 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 	if fmtErr != nil {
 		return nil, fmtErr
 	}
+	// The analysis below replaces the names of the functions by their
+	// definitions, so the query is saved beforehand.
+	viewQuery := tree.AsStringWithFlags(n.AsSource, tree.FmtParsable)
 
 	var planDeps planDependencies
+	var funcDeps map[sqlbase.ID]struct{}
 	var sourceColumns sqlbase.ResultColumns
 	// To avoid races with ongoing schema changes to tables that the view
 	// depends on, make sure we use the most recent versions of table
 	// descriptors rather than the copies in the lease cache.
 	p.runWithOptions(resolveFlags{skipCache: true}, func() {
-		planDeps, sourceColumns, err = p.analyzeViewQuery(ctx, n.AsSource)
+		planDeps, funcDeps, sourceColumns, err = p.analyzeViewQuery(ctx, n.AsSource)
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, dep := range planDeps {
+		// The view would be dropped along with the table at the end of
+		// the session.
+		if dep.desc.IsTemporary() {
+			return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"views on temporary tables are not supported")
+		}
+	}
 
 	numColNames := len(n.ColumnNames)
 	numColumns := len(sourceColumns)
@@ -110,11 +139,22 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 
 	log.VEventf(ctx, 2, "collected view dependencies:\n%s", planDeps.String())
 
+	var sourcePlan planNode
+	if n.Materialized {
+		sourcePlan, err = p.Select(ctx, n.AsSource, []types.T{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &createViewNode{
 		n:             n,
 		dbDesc:        dbDesc,
+		viewQuery:     viewQuery,
 		sourceColumns: sourceColumns,
 		planDeps:      planDeps,
+		funcDeps:      funcDeps,
+		sourcePlan:    sourcePlan,
 	}, nil
 }
 
@@ -159,6 +199,11 @@ func (n *createViewNode) startExec(params runParams) error {
 		params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
 		return err
 	}
+	if desc.IsMaterializedView() {
+		// Like a table created in this transaction, the view is invisible to
+		// the rest of the cluster: its schema changes can run immediately.
+		params.p.Tables().addCreatedTable(id)
+	}
 
 	// Persist the back-references in all referenced table descriptors.
 	for _, updated := range n.planDeps {
@@ -177,10 +222,33 @@ func (n *createViewNode) startExec(params runParams) error {
 		}
 	}
 
+	// Persist the back-references in all referenced function descriptors.
+	for funcID := range n.funcDeps {
+		funcDesc := &sqlbase.FunctionDescriptor{}
+		if err := getDescriptorByID(params.ctx, params.p.txn, funcID, funcDesc); err != nil {
+			return err
+		}
+		funcDesc.ReferencingDescriptorIDs = updateReferencingIDs(
+			funcDesc.ReferencingDescriptorIDs, desc.ID, true /* add */)
+		if err := params.p.writeFunctionDesc(params.ctx, funcDesc); err != nil {
+			return err
+		}
+	}
+
 	if err := desc.Validate(params.ctx, params.p.txn, params.EvalContext().Settings); err != nil {
 		return err
 	}
 
+	if desc.IsMaterializedView() {
+		if err := populateMaterializedView(
+			params.ctx, params.p.txn, &desc, params.extendedEvalCtx.NodeID,
+			planRowSource(params, n.sourcePlan),
+			params.extendedEvalCtx.Tracing.KVTracingEnabled(),
+		); err != nil {
+			return err
+		}
+	}
+
 	// Log Create View event. This is an auditable log event and is
 	// recorded in the same transaction as the table descriptor update.
 	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
@@ -199,7 +267,13 @@ func (n *createViewNode) startExec(params runParams) error {
 
 func (*createViewNode) Next(runParams) (bool, error) { return false, nil }
 func (*createViewNode) Values() tree.Datums          { return tree.Datums{} }
-func (n *createViewNode) Close(ctx context.Context)  {}
+
+func (n *createViewNode) Close(ctx context.Context) {
+	if n.sourcePlan != nil {
+		n.sourcePlan.Close(ctx)
+		n.sourcePlan = nil
+	}
+}
 
 // makeViewTableDesc returns the table descriptor for a new view.
 //
@@ -219,7 +293,8 @@ func (n *createViewNode) makeViewTableDesc(
 ) (sqlbase.TableDescriptor, error) {
 	desc := InitTableDescriptor(id, parentID, viewName,
 		params.p.txn.CommitTimestamp(), privileges)
-	desc.ViewQuery = tree.AsStringWithFlags(n.n.AsSource, tree.FmtParsable)
+	desc.ViewQuery = n.viewQuery
+	desc.MaterializedView = n.n.Materialized
 	for i, colRes := range resultColumns {
 		colType, err := coltypes.DatumTypeToColumnType(colRes.Typ)
 		if err != nil {
@@ -127,7 +127,11 @@ func (p *planner) getDataSource(
 		}
 
 		colCfg := scanColumnsConfig{visibility: scanVisibility}
-		return p.getPlanForDesc(ctx, desc, tn, indexFlags, colCfg)
+		ds, err = p.getPlanForDesc(ctx, desc, tn, indexFlags, colCfg)
+		if err != nil {
+			return ds, err
+		}
+		return ds, p.maybeLockScan(ctx, ds, desc, tn.TableName)
 
 	case *tree.RowsFromExpr:
 		return p.getPlanForRowsFrom(ctx, t.Items...)
@@ -171,10 +175,22 @@ func (p *planner) getDataSource(
 	case *tree.AliasedTableExpr:
 		// Alias clause: source AS alias(cols...)
 
+		if t.Lateral {
+			// LATERAL data sources are only supported by the optimizer.
+			return planDataSource{}, pgerror.UnimplementedWithIssueError(24560,
+				"LATERAL is only supported by the cost-based optimizer")
+		}
+
 		if t.IndexFlags != nil {
 			indexFlags = t.IndexFlags
 		}
 
+		if _, ok := t.Expr.(*tree.NormalizableTableName); ok && p.curLocking != nil && t.As.Alias != "" {
+			// The locking clause refers to aliased tables by their alias.
+			defer func(alias tree.Name) { p.curLocking.alias = alias }(p.curLocking.alias)
+			p.curLocking.alias = t.As.Alias
+		}
+
 		src, err := p.getDataSource(ctx, t.Expr, indexFlags, scanVisibility)
 		if err != nil {
 			return src, err
@@ -246,6 +262,9 @@ func (p *planner) getTableScanByRef(
 	if err != nil {
 		return src, err
 	}
+	if err := p.maybeLockScan(ctx, src, desc, tn.TableName); err != nil {
+		return src, err
+	}
 
 	return renameSource(src, tref.As, true)
 }
@@ -315,7 +334,7 @@ func (p *planner) getPlanForDesc(
 	indexFlags *tree.IndexFlags,
 	colCfg scanColumnsConfig,
 ) (planDataSource, error) {
-	if desc.IsView() {
+	if desc.IsView() && !desc.IsMaterializedView() {
 		if colCfg.wantedColumns != nil {
 			return planDataSource{},
 				errors.Errorf("cannot specify an explicit column list when accessing a view by reference")
@@ -325,12 +344,13 @@ func (p *planner) getPlanForDesc(
 	if desc.IsSequence() {
 		return p.getSequenceSource(ctx, *tn, desc)
 	}
-	if !desc.IsTable() {
+	if !desc.IsTable() && !desc.IsMaterializedView() {
 		return planDataSource{}, errors.Errorf(
 			"unexpected table descriptor of type %s for %q", desc.TypeName(), tree.ErrString(tn))
 	}
 
-	// This name designates a real table.
+	// This name designates a real table, or a materialized view whose
+	// contents are stored like one.
 	scan := p.Scan()
 	if err := scan.initTable(ctx, p, desc, indexFlags, colCfg); err != nil {
 		return planDataSource{}, err
@@ -387,6 +407,8 @@ func (p *planner) getViewPlan(
 		// further dependency by the view's query should not be tracked in this planner.
 		defer func(prev planDependencies) { p.curPlan.deps = prev }(p.curPlan.deps)
 		p.curPlan.deps = nil
+		defer func(prev map[sqlbase.ID]struct{}) { p.curPlan.funcDeps = prev }(p.curPlan.funcDeps)
+		p.curPlan.funcDeps = nil
 	}
 
 	plan, err := p.newPlan(ctx, sel, nil)
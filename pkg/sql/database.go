@@ -185,6 +185,36 @@ func (dc *databaseCache) getCachedDatabaseDescByID(
 	return database, database.Validate()
 }
 
+// getCachedFunctionDesc looks up the descriptor of a user-defined function
+// in the system config, given its namespace key. Returns nil if the
+// function is not in the cache.
+func (dc *databaseCache) getCachedFunctionDesc(key tableKey) (*sqlbase.FunctionDescriptor, error) {
+	nameVal := dc.systemConfig.GetValue(key.Key())
+	if nameVal == nil {
+		return nil, nil
+	}
+	id, err := nameVal.GetInt()
+	if err != nil {
+		return nil, err
+	}
+
+	descVal := dc.systemConfig.GetValue(sqlbase.MakeDescMetadataKey(sqlbase.ID(id)))
+	if descVal == nil {
+		return nil, nil
+	}
+	desc := &sqlbase.Descriptor{}
+	if err := descVal.GetProto(desc); err != nil {
+		return nil, err
+	}
+
+	// Functions share the namespace with tables and types.
+	function := desc.GetFunction()
+	if function == nil {
+		return nil, nil
+	}
+	return function, function.Validate()
+}
+
 // getDatabaseDesc returns the database descriptor given its name
 // if it exists in the cache, otherwise falls back to KV operations.
 func (dc *databaseCache) getDatabaseDesc(
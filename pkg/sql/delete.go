@@ -58,6 +58,10 @@ func (p *planner) Delete(
 	if n.Where == nil && p.SessionData().SafeUpdates {
 		return nil, pgerror.NewDangerousStatementErrorf("DELETE without WHERE clause")
 	}
+	if len(n.Using) > 0 && (n.OrderBy != nil || n.Limit != nil) {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"ORDER BY and LIMIT are not supported with DELETE ... USING")
+	}
 
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
@@ -87,6 +91,12 @@ func (p *planner) Delete(
 		return nil, err
 	}
 
+	// Determine which triggers fire on the deletion.
+	triggers, err := p.makeRowTriggers(ctx, tn, desc, sqlbase.TriggerDescriptor_DELETE)
+	if err != nil {
+		return nil, err
+	}
+
 	// Determine what are the foreign key tables that are involved in the deletion.
 	fkTables, err := sqlbase.TablesNeededForFKs(
 		ctx,
@@ -107,7 +117,7 @@ func (p *planner) Delete(
 	// Also, rowsNeeded determines which rows of the source we need
 	// in the table deleter.
 	var requestedCols []sqlbase.ColumnDescriptor
-	if rowsNeeded {
+	if rowsNeeded || triggers != nil {
 		// Note: in contrast to INSERT and UPDATE which also require the
 		// data if there are CHECK expressions, DELETE does not care about
 		// constraint checking (because the rows are being deleted after
@@ -132,15 +142,20 @@ func (p *planner) Delete(
 	// filtered, limited, ordered, etc, prior to the deletion. One would
 	// think there is only so much one wants to do with rows prior to a
 	// deletion, but ORDER BY / LIMIT really determines which rows are
-	// being deleted. Also RETURNING will expose this.
+	// being deleted. Also RETURNING will expose this. The tables of the
+	// USING clause, if any, are joined with the table so that the WHERE
+	// clause can refer to their columns.
 	rows, err := p.SelectClause(ctx, &tree.SelectClause{
-		Exprs: sqlbase.ColumnsSelectors(rd.FetchCols, true /* forUpdateOrDelete */),
-		From:  &tree.From{Tables: []tree.TableExpr{n.Table}},
+		Exprs: mutationSourceSelectors(rd.FetchCols, alias, len(n.Using) > 0),
+		From:  &tree.From{Tables: append(tree.TableExprs{n.Table}, n.Using...)},
 		Where: n.Where,
-	}, n.OrderBy, n.Limit, nil /*with*/, nil /*desiredTypes*/, publicAndNonPublicColumns)
+	}, n.OrderBy, n.Limit, nil /*with*/, nil /*locking*/, nil /*desiredTypes*/, publicAndNonPublicColumns)
 	if err != nil {
 		return nil, err
 	}
+	if len(n.Using) > 0 {
+		rows = distinctMutationRows(rows, desc, rd.FetchColIDtoRowIndex)
+	}
 
 	var columns sqlbase.ResultColumns
 	if rowsNeeded {
@@ -154,6 +169,7 @@ func (p *planner) Delete(
 		columns: columns,
 		run: deleteRun{
 			td:         tableDeleter{rd: rd, alloc: &p.alloc},
+			triggers:   triggers,
 			rowsNeeded: rowsNeeded,
 		},
 	}
@@ -174,6 +190,9 @@ type deleteRun struct {
 	td         tableDeleter
 	rowsNeeded bool
 
+	// triggers are the triggers that fire on the deleted rows, if any.
+	triggers *rowTriggers
+
 	// fastPath indicates whether the delete operation is running to
 	// completion during startExec.
 	fastPath bool
@@ -309,11 +328,34 @@ func (d *deleteNode) BatchedNext(params runParams) (bool, error) {
 // processSourceRow processes one row from the source for deletion and, if
 // result rows are needed, saves it in the result row container
 func (d *deleteNode) processSourceRow(params runParams, sourceVals tree.Datums) error {
+	// Run the BEFORE triggers, if any.
+	colIDtoRowIndex := d.run.td.rd.FetchColIDtoRowIndex
+	if d.run.triggers != nil {
+		if err := d.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_BEFORE, sourceVals, nil /* newRow */, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// Queue the deletion in the KV batch.
 	if _, err := d.run.td.row(params.ctx, sourceVals, d.run.traceKV); err != nil {
 		return err
 	}
 
+	// Run the AFTER triggers, if any. The row is deleted first so that the
+	// triggers can observe the deletion.
+	if d.run.triggers != nil {
+		if err := d.run.td.flushAndStartNewBatch(params.ctx); err != nil {
+			return err
+		}
+		if err := d.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_AFTER, sourceVals, nil /* newRow */, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if d.run.rows != nil {
 		if _, err := d.run.rows.AddRow(params.ctx, sourceVals); err != nil {
@@ -438,6 +480,11 @@ func canDeleteFast(ctx context.Context, source planNode, r *deleteRun) (*scanNod
 		return nil, false
 	}
 
+	// If there are triggers, they need to run for every row.
+	if r.triggers != nil {
+		return nil, false
+	}
+
 	// Check whether the source plan is "simple": that it contains no
 	// remaining filtering, limiting, sorting, etc.
 	// TODO(dt): We could probably be smarter when presented with an
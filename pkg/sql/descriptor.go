@@ -137,6 +137,9 @@ func (p *planner) createDescriptorWithID(
 	if ok && desc.Adding() {
 		p.queueSchemaChange(desc, sqlbase.InvalidMutationID)
 	}
+	if ok {
+		return p.updateTypeBackReferences(ctx, desc.ID, nil /* oldTypeIDs */, desc.EnumTypeIDs())
+	}
 	return nil
 }
 
@@ -161,6 +164,11 @@ func getDescriptor(
 	}
 
 	if err := getDescriptorByID(ctx, txn, sqlbase.ID(gr.ValueInt()), descriptor); err != nil {
+		if err == sqlbase.ErrDescriptorNotFound {
+			// Tables, types and functions share the same namespace; the
+			// name refers to an object of another kind.
+			return false, nil
+		}
 		return false, err
 	}
 	return true, nil
@@ -184,6 +192,9 @@ func getDescriptorByID(
 	case *sqlbase.TableDescriptor:
 		table := desc.GetTable()
 		if table == nil {
+			if desc.GetType() != nil || desc.GetFunction() != nil {
+				return sqlbase.ErrDescriptorNotFound
+			}
 			return errors.Errorf("%q is not a table", desc.String())
 		}
 		table.MaybeFillInDescriptor()
@@ -202,6 +213,32 @@ func getDescriptorByID(
 			return err
 		}
 		*t = *database
+	case *sqlbase.TypeDescriptor:
+		typ := desc.GetType()
+		if typ == nil {
+			if desc.GetTable() != nil || desc.GetFunction() != nil {
+				return sqlbase.ErrDescriptorNotFound
+			}
+			return errors.Errorf("%q is not a type", desc.String())
+		}
+
+		if err := typ.Validate(); err != nil {
+			return err
+		}
+		*t = *typ
+	case *sqlbase.FunctionDescriptor:
+		fn := desc.GetFunction()
+		if fn == nil {
+			if desc.GetTable() != nil || desc.GetType() != nil {
+				return sqlbase.ErrDescriptorNotFound
+			}
+			return errors.Errorf("%q is not a function", desc.String())
+		}
+
+		if err := fn.Validate(); err != nil {
+			return err
+		}
+		*t = *fn
 	}
 	return nil
 }
@@ -225,6 +262,10 @@ func GetAllDescriptors(ctx context.Context, txn *client.Txn) ([]sqlbase.Descript
 			descs[i] = desc.GetTable()
 		case *sqlbase.Descriptor_Database:
 			descs[i] = desc.GetDatabase()
+		case *sqlbase.Descriptor_Type:
+			descs[i] = desc.GetType()
+		case *sqlbase.Descriptor_Function:
+			descs[i] = desc.GetFunction()
 		default:
 			return nil, errors.Errorf("Descriptor.Union has unexpected type %T", t)
 		}
@@ -42,9 +42,27 @@ func (p *planner) Discard(ctx context.Context, s *tree.Discard) (planNode, error
 
 		// DEALLOCATE ALL
 		p.preparedStatements.DeleteAll(ctx)
+
+		// DISCARD TEMP
+		if err := p.discardTemporaryTables(ctx); err != nil {
+			return nil, err
+		}
+	case tree.DiscardModeTemp:
+		if err := p.discardTemporaryTables(ctx); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, pgerror.NewErrorf(pgerror.CodeInternalError,
 			"unknown mode for DISCARD: %d", s.Mode)
 	}
 	return newZeroNode(nil /* columns */), nil
 }
+
+// discardTemporaryTables drops all the temporary tables of the session.
+func (p *planner) discardTemporaryTables(ctx context.Context) error {
+	ids, err := getTemporaryTableIDs(ctx, p.txn, p.Tables().temporarySchemaIDs)
+	if err != nil {
+		return err
+	}
+	return p.dropTemporaryTables(ctx, ids)
+}
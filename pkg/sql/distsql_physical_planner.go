@@ -194,9 +194,12 @@ func (v *distSQLExprCheckVisitor) VisitPre(expr tree.Expr) (recurse bool, newExp
 	case *tree.DOid:
 		v.err = newQueryNotSupportedError("OID expressions are not supported by distsql")
 		return false, expr
+	case *tree.DEnum:
+		v.err = newQueryNotSupportedError("enum expressions are not supported by distsql")
+		return false, expr
 	case *tree.CastExpr:
 		switch t.Type.(type) {
-		case *coltypes.TOid:
+		case *coltypes.TOid, *coltypes.TUserDefined:
 			v.err = newQueryNotSupportedErrorf("cast to %s is not supported by distsql", t.Type)
 			return false, expr
 		}
@@ -273,9 +276,13 @@ var setNotSupportedError = newQueryNotSupportedError("SET / SET CLUSTER SETTING
 // This must be kept in sync with createPlanForNode.
 // TODO(jordan): refactor these to use the observer pattern to avoid duplication.
 func (dsp *DistSQLPlanner) mustWrapNode(node planNode) bool {
-	switch node.(type) {
+	switch n := node.(type) {
 	case *scanNode:
+		// Locking scans acquire their locks through the root transaction,
+		// which the TableReader processor doesn't use.
+		return n.lockingStrength != tree.ForNone
 	case *indexJoinNode:
+		return n.index.lockingStrength != tree.ForNone
 	case *lookupJoinNode:
 	case *joinNode:
 	case *renderNode:
@@ -350,6 +357,9 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 		return rec, nil
 
 	case *scanNode:
+		if n.lockingStrength != tree.ForNone {
+			return cannotDistribute, newQueryNotSupportedError("locking scans cannot be distributed")
+		}
 		rec := canDistribute
 		if n.softLimit != 0 {
 			// We don't yet recommend distributing plans where soft limits propagate
@@ -1266,6 +1276,7 @@ func (dsp *DistSQLPlanner) addAggregators(
 		}
 		aggregations[i].Func = distsqlrun.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct()
+		aggregations[i].WithinGroupDescending = fholder.withinGroupDescending
 		if fholder.argRenderIdx != noRenderIdx {
 			aggregations[i].ColIdx = []uint32{uint32(p.PlanToStreamColMap[fholder.argRenderIdx])}
 		}
@@ -2239,6 +2250,10 @@ func verifyLookupJoin(
 		return false, nil, "scan node was generated by the optimizer"
 	}
 
+	if lookupJoinScan.lockingStrength != tree.ForNone {
+		return false, nil, "scan node locks the rows it returns"
+	}
+
 	// Check if rightEqCols are prefix of index columns in scanNode lookupJoinScan.
 	rightEqColsMap := make(map[int]bool, len(n.pred.rightEqualityIndices))
 	for _, rightColID := range n.pred.rightEqualityIndices {
@@ -2445,6 +2460,10 @@ func (dsp *DistSQLPlanner) wrapPlan(planCtx *PlanningCtx, n planNode) (PhysicalP
 				// deleteNodes.
 				// TODO(jordan): fix deleteNode to stop doing that.
 				return false, nil
+			case *recursiveCTENode, *applyJoinNode:
+				// recursiveCTENode and applyJoinNode start and run their children
+				// themselves, locally.
+				return false, nil
 			}
 			if !seenTop {
 				// We know we're wrapping the first node, so ignore it.
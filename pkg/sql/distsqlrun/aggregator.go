@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -137,6 +138,13 @@ type aggregatorBase struct {
 	row              sqlbase.EncDatumRow
 	scratch          []byte
 
+	// sortedInputMonitor and diskMonitor are used by the row containers of
+	// ordered-set aggregations. diskMonitor is only set if the containers can
+	// spill to disk.
+	sortedInputMonitor *mon.BytesMonitor
+	diskMonitor        *mon.BytesMonitor
+	sortedInputRow     sqlbase.EncDatumRow
+
 	cancelChecker *sqlbase.CancelChecker
 }
 
@@ -239,13 +247,83 @@ func (ag *aggregatorBase) init(
 		ag.outputTypes[i] = retType
 	}
 
-	return ag.ProcessorBase.Init(
+	if err := ag.ProcessorBase.Init(
 		self, post, ag.outputTypes, flowCtx, processorID, output, memMonitor,
 		ProcStateOpts{
 			InputsToDrain:        []RowSource{ag.input},
 			TrailingMetaCallback: trailingMetaCallback,
 		},
-	)
+	); err != nil {
+		return err
+	}
+	ag.initSortedInputs(flowCtx)
+	return nil
+}
+
+// initSortedInputs sets up the row containers into which the values of
+// ordered-set aggregations are accumulated. The values of all the groups are
+// sorted by group key and by the WITHIN GROUP ordering before the results are
+// computed, spilling to disk if they don't fit in the memory budget.
+func (ag *aggregatorBase) initSortedInputs(flowCtx *FlowCtx) {
+	ctx := flowCtx.EvalCtx.Ctx()
+	useTempStorage := settingUseTempStorageSorts.Get(&flowCtx.Settings.SV) ||
+		flowCtx.testingKnobs.MemoryLimitBytes > 0
+	for i, aggInfo := range ag.aggregations {
+		props, _ := builtins.GetBuiltinProperties(strings.ToLower(aggInfo.Func.String()))
+		if props == nil || !props.OrderedSetAggregate {
+			continue
+		}
+		if ag.sortedInputMonitor == nil {
+			if useTempStorage {
+				// Limit the memory use by creating a child monitor with a hard
+				// limit. The values will overflow to disk if this limit is not
+				// enough.
+				limit := flowCtx.testingKnobs.MemoryLimitBytes
+				if limit <= 0 {
+					limit = settingWorkMemBytes.Get(&flowCtx.Settings.SV)
+				}
+				limitedMon := mon.MakeMonitorInheritWithLimit(
+					"aggregator-limited", limit, flowCtx.EvalCtx.Mon,
+				)
+				limitedMon.Start(ctx, flowCtx.EvalCtx.Mon, mon.BoundAccount{})
+				ag.sortedInputMonitor = &limitedMon
+				ag.diskMonitor = NewMonitor(ctx, flowCtx.diskMonitor, "aggregator-disk")
+			} else {
+				ag.sortedInputMonitor = NewMonitor(ctx, flowCtx.EvalCtx.Mon, "aggregator-sorted-input")
+			}
+			ag.sortedInputRow = make(sqlbase.EncDatumRow, 2)
+		}
+
+		// The rows are made of the group key and the aggregated value.
+		direction := encoding.Ascending
+		if aggInfo.WithinGroupDescending {
+			direction = encoding.Descending
+		}
+		ordering := sqlbase.ColumnOrdering{
+			{ColIdx: 0, Direction: encoding.Ascending},
+			{ColIdx: 1, Direction: direction},
+		}
+		colTypes := []sqlbase.ColumnType{
+			{SemanticType: sqlbase.ColumnType_BYTES},
+			ag.inputTypes[aggInfo.ColIdx[0]],
+		}
+		if useTempStorage {
+			rc := diskBackedRowContainer{}
+			rc.init(
+				ordering,
+				colTypes,
+				ag.evalCtx,
+				flowCtx.TempStorage,
+				ag.sortedInputMonitor,
+				ag.diskMonitor,
+			)
+			ag.funcs[i].sortedInput = &rc
+		} else {
+			rc := memRowContainer{}
+			rc.initWithMon(ordering, colTypes, ag.evalCtx, ag.sortedInputMonitor)
+			ag.funcs[i].sortedInput = &rc
+		}
+	}
 }
 
 var _ DistSQLSpanStats = &AggregatorStats{}
@@ -423,6 +501,7 @@ func (ag *hashAggregator) close() {
 				ag.buckets[bucket].close(ag.Ctx)
 			}
 		}
+		ag.closeSortedInputs()
 		ag.MemMonitor.Stop(ag.Ctx)
 	}
 }
@@ -434,10 +513,27 @@ func (ag *orderedAggregator) close() {
 		if ag.bucket != nil {
 			ag.bucket.close(ag.Ctx)
 		}
+		ag.closeSortedInputs()
 		ag.MemMonitor.Stop(ag.Ctx)
 	}
 }
 
+// closeSortedInputs releases the row containers of ordered-set aggregations
+// and their monitors.
+func (ag *aggregatorBase) closeSortedInputs() {
+	for _, f := range ag.funcs {
+		if f.sortedInput != nil {
+			f.sortedInput.Close(ag.Ctx)
+		}
+	}
+	if ag.sortedInputMonitor != nil {
+		ag.sortedInputMonitor.Stop(ag.Ctx)
+	}
+	if ag.diskMonitor != nil {
+		ag.diskMonitor.Stop(ag.Ctx)
+	}
+}
+
 // matchLastOrdGroupCols takes a row and matches it with the row stored by
 // lastOrdGroupCols. It returns true if the two rows are equal on the grouping
 // columns, and false otherwise.
@@ -503,6 +599,13 @@ func (ag *hashAggregator) accumulateRows() (aggregatorState, sqlbase.EncDatumRow
 		ag.buckets[""] = bucket
 	}
 
+	if err := ag.computeSortedInputs(func(groupKey string) aggregateFuncs {
+		return ag.buckets[groupKey]
+	}); err != nil {
+		ag.MoveToDraining(err)
+		return aggStateUnknown, nil, nil
+	}
+
 	ag.bucketsIter = make([]string, 0, len(ag.buckets))
 	for bucket := range ag.buckets {
 		ag.bucketsIter = append(ag.bucketsIter, bucket)
@@ -562,6 +665,13 @@ func (ag *orderedAggregator) accumulateRows() (aggregatorState, sqlbase.EncDatum
 		}
 	}
 
+	if err := ag.computeSortedInputs(func(string) aggregateFuncs {
+		return ag.bucket
+	}); err != nil {
+		ag.MoveToDraining(err)
+		return aggStateUnknown, nil, nil
+	}
+
 	// Transition to aggEmittingRows, and let it generate the next row/meta.
 	return aggEmittingRows, nil, nil
 }
@@ -783,6 +893,12 @@ func (ag *aggregatorBase) accumulateRowIntoBucket(
 		if !canAdd {
 			continue
 		}
+		if ag.funcs[i].sortedInput != nil {
+			if err := ag.addSortedInput(ag.funcs[i], bucket[i], groupKey, row[a.ColIdx[0]]); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := bucket[i].Add(ag.Ctx, firstArg, otherArgs...); err != nil {
 			return err
 		}
@@ -790,6 +906,122 @@ func (ag *aggregatorBase) accumulateRowIntoBucket(
 	return nil
 }
 
+// addSortedInput adds a value of an ordered-set aggregation to the row
+// container of the aggregation, instead of adding it to the aggregate
+// directly. NULL values are ignored by ordered-set aggregates.
+func (ag *aggregatorBase) addSortedInput(
+	f *aggregateFuncHolder, agg tree.AggregateFunc, groupKey []byte, value sqlbase.EncDatum,
+) error {
+	if value.Datum == tree.DNull {
+		return nil
+	}
+	ag.sortedInputRow[0] = sqlbase.DatumToEncDatum(
+		sqlbase.ColumnType{SemanticType: sqlbase.ColumnType_BYTES},
+		tree.NewDBytes(tree.DBytes(groupKey)),
+	)
+	ag.sortedInputRow[1] = value
+	if err := f.sortedInput.AddRow(ag.Ctx, ag.sortedInputRow); err != nil {
+		return err
+	}
+	agg.(*sortedInputAggregate).count++
+	return nil
+}
+
+// computeSortedInputs sorts the values accumulated for the ordered-set
+// aggregations and computes the result of the aggregate of every group, which
+// is looked up by its group key with bucket. The row containers are reset
+// afterwards so that they can be reused for the next groups.
+func (ag *aggregatorBase) computeSortedInputs(bucket func(groupKey string) aggregateFuncs) error {
+	for i, f := range ag.funcs {
+		if f.sortedInput == nil {
+			continue
+		}
+		if err := ag.computeSortedInput(i, bucket); err != nil {
+			return err
+		}
+		if err := f.sortedInput.UnsafeReset(ag.Ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ag *aggregatorBase) computeSortedInput(
+	aggIdx int, bucket func(groupKey string) aggregateFuncs,
+) error {
+	rows := ag.funcs[aggIdx].sortedInput
+	rows.Sort(ag.Ctx)
+	typ := &ag.inputTypes[ag.aggregations[aggIdx].ColIdx[0]]
+
+	it := rows.NewFinalIterator(ag.Ctx)
+	defer it.Close()
+	it.Rewind()
+	for {
+		if ok, err := it.Valid(); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		row, err := it.Row()
+		if err != nil {
+			return err
+		}
+		if err := row[0].EnsureDecoded(
+			&sqlbase.ColumnType{SemanticType: sqlbase.ColumnType_BYTES}, &ag.datumAlloc,
+		); err != nil {
+			return err
+		}
+		agg := bucket(string(*row[0].Datum.(*tree.DBytes)))[aggIdx].(*sortedInputAggregate)
+
+		// The values of the group are the rows starting at the current one.
+		read := 0
+		next := func() (tree.Datum, error) {
+			if ok, err := it.Valid(); err != nil {
+				return nil, err
+			} else if !ok {
+				return nil, errors.Errorf("expected %d values, found %d", agg.count, read)
+			}
+			row, err := it.Row()
+			if err != nil {
+				return nil, err
+			}
+			d, err := ag.decodeSortedInputValue(typ, row[1])
+			if err != nil {
+				return nil, err
+			}
+			it.Next()
+			read++
+			return d, nil
+		}
+		if err := agg.SetSortedInput(agg.count, next); err != nil {
+			return err
+		}
+		// Skip the values of the group that the aggregate didn't need.
+		for ; read < agg.count; read++ {
+			it.Next()
+		}
+	}
+}
+
+// decodeSortedInputValue decodes a value read from the row container of an
+// ordered-set aggregation. The value is only valid until the iterator moves,
+// so any encoded bytes are copied before decoding.
+func (ag *aggregatorBase) decodeSortedInputValue(
+	typ *sqlbase.ColumnType, value sqlbase.EncDatum,
+) (tree.Datum, error) {
+	if enc, ok := value.Encoding(); ok {
+		encoded, err := value.Encode(typ, &ag.datumAlloc, enc, nil /* appendTo */)
+		if err != nil {
+			return nil, err
+		}
+		value = sqlbase.EncDatumFromEncoded(enc, encoded)
+	}
+	if err := value.EnsureDecoded(typ, &ag.datumAlloc); err != nil {
+		return nil, err
+	}
+	return value.Datum, nil
+}
+
 // accumulateRow accumulates a single row, returning an error if accumulation
 // failed for any reason.
 func (ag *hashAggregator) accumulateRow(row sqlbase.EncDatumRow) error {
@@ -845,10 +1077,30 @@ type aggregateFuncHolder struct {
 	group     *aggregatorBase
 	seen      map[string]struct{}
 	arena     *stringarena.Arena
+
+	// sortedInput is only set for ordered-set aggregations. The values of all
+	// the groups are accumulated into it rather than into the aggregates.
+	sortedInput sortableRowContainer
 }
 
 const sizeOfAggregateFunc = int64(unsafe.Sizeof(tree.AggregateFunc(nil)))
 
+// sortedInputAggregate wraps an ordered-set aggregate whose values are
+// accumulated into the sortedInput row container of its aggregateFuncHolder.
+type sortedInputAggregate struct {
+	tree.OrderedSetAggregateFunc
+
+	// count is the number of values of the group in the row container.
+	count int
+}
+
+const sizeOfSortedInputAggregate = int64(unsafe.Sizeof(sortedInputAggregate{}))
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *sortedInputAggregate) Size() int64 {
+	return sizeOfSortedInputAggregate + a.OrderedSetAggregateFunc.Size()
+}
+
 func (ag *aggregatorBase) newAggregateFuncHolder(
 	create func(*tree.EvalContext, tree.Datums) tree.AggregateFunc, arguments tree.Datums,
 ) *aggregateFuncHolder {
@@ -912,6 +1164,9 @@ func (ag *aggregatorBase) createAggregateFuncs() (aggregateFuncs, error) {
 	bucket := make(aggregateFuncs, len(ag.funcs))
 	for i, f := range ag.funcs {
 		agg := f.create(ag.flowCtx.EvalCtx, f.arguments)
+		if f.sortedInput != nil {
+			agg = &sortedInputAggregate{OrderedSetAggregateFunc: agg.(tree.OrderedSetAggregateFunc)}
+		}
 		if err := ag.bucketsAcc.Grow(ag.Ctx, agg.Size()); err != nil {
 			return nil, err
 		}
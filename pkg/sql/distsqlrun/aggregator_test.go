@@ -16,16 +16,20 @@ package distsqlrun
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 )
 
 // TODO(irfansharif): Add tests to verify the following aggregation functions:
@@ -456,6 +460,116 @@ func TestAggregator(t *testing.T) {
 	}
 }
 
+// TestOrderedSetAggregator verifies that the values of ordered-set
+// aggregations are sorted per group, including when the row containers
+// holding them spill to disk.
+func TestOrderedSetAggregator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	v := [6]sqlbase.EncDatum{}
+	null := sqlbase.EncDatum{Datum: tree.DNull}
+	for i := range v {
+		v[i] = sqlbase.DatumToEncDatum(intType, tree.NewDInt(tree.DInt(i)))
+	}
+
+	// SELECT @1, percentile_disc(0.5) WITHIN GROUP (ORDER BY @2),
+	// mode() WITHIN GROUP (ORDER BY @2 DESC) GROUP BY @1
+	spec := AggregatorSpec{
+		GroupCols: []uint32{0},
+		Aggregations: []AggregatorSpec_Aggregation{
+			{
+				Func:   AggregatorSpec_ANY_NOT_NULL,
+				ColIdx: []uint32{0},
+			},
+			{
+				Func:      AggregatorSpec_PERCENTILE_DISC,
+				ColIdx:    []uint32{1},
+				Arguments: []Expression{{Expr: "0.5:::FLOAT8"}},
+			},
+			{
+				Func:                  AggregatorSpec_MODE,
+				ColIdx:                []uint32{1},
+				WithinGroupDescending: true,
+			},
+		},
+	}
+	input := sqlbase.EncDatumRows{
+		{v[0], v[4]},
+		{v[1], v[2]},
+		{v[0], v[2]},
+		{v[1], null},
+		{v[0], v[5]},
+		{v[1], v[1]},
+		{v[0], v[1]},
+		{v[0], v[3]},
+		{v[1], v[1]},
+	}
+	expected := sqlbase.EncDatumRows{
+		{v[0], v[3], v[5]},
+		{v[1], v[1], v[1]},
+	}
+
+	// Test with the default memory limit and with a limit that makes the
+	// sorted inputs immediately switch to disk.
+	for _, memLimit := range []int64{0, 1} {
+		t.Run(fmt.Sprintf("MemLimit=%d", memLimit), func(t *testing.T) {
+			ctx := context.Background()
+			st := cluster.MakeTestingClusterSettings()
+			tempEngine, err := engine.NewTempEngine(base.DefaultTestTempStorageConfig(st), base.DefaultTestStoreSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tempEngine.Close()
+
+			evalCtx := tree.MakeTestingEvalContext(st)
+			defer evalCtx.Stop(ctx)
+			diskMonitor := mon.MakeMonitor(
+				"test-disk",
+				mon.DiskResource,
+				nil, /* curCount */
+				nil, /* maxHist */
+				-1,  /* increment: use default block size */
+				math.MaxInt64,
+				st,
+			)
+			diskMonitor.Start(ctx, nil /* pool */, mon.MakeStandaloneBudget(math.MaxInt64))
+			defer diskMonitor.Stop(ctx)
+			flowCtx := FlowCtx{
+				Settings:    st,
+				EvalCtx:     &evalCtx,
+				TempStorage: tempEngine,
+				diskMonitor: &diskMonitor,
+			}
+			flowCtx.testingKnobs.MemoryLimitBytes = memLimit
+
+			in := NewRowBuffer(twoIntCols, input, RowBufferArgs{})
+			out := NewRowBuffer(threeIntCols, nil /* rows */, RowBufferArgs{})
+			ag, err := newAggregator(&flowCtx, 0 /* processorID */, &spec, in, &PostProcessSpec{}, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ag.Run(ctx, nil /* wg */)
+
+			var rets []string
+			for {
+				row := out.NextNoMeta(t)
+				if row == nil {
+					break
+				}
+				rets = append(rets, row.String(threeIntCols))
+			}
+			sort.Strings(rets)
+			retStr := strings.Join(rets, "")
+			expStr := strings.Join([]string{
+				expected[0].String(threeIntCols), expected[1].String(threeIntCols),
+			}, "")
+			if expStr != retStr {
+				t.Errorf("invalid results; expected:\n   %s\ngot:\n   %s", expStr, retStr)
+			}
+		})
+	}
+}
+
 func BenchmarkAggregation(b *testing.B) {
 	const numCols = 1
 	const numRows = 1000
@@ -112,6 +112,9 @@ func (a *AggregatorSpec) summary() (string, []string) {
 			buf.WriteString("DISTINCT ")
 		}
 		buf.WriteString(colListStr(agg.ColIdx))
+		if agg.WithinGroupDescending {
+			buf.WriteString(" DESC")
+		}
 		buf.WriteByte(')')
 		if agg.FilterColIdx != nil {
 			fmt.Fprintf(&buf, " FILTER @%d", *agg.FilterColIdx+1)
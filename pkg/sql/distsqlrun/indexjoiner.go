@@ -108,6 +108,7 @@ func newIndexJoiner(
 		ij.out.neededColumns(),
 		false, /* isCheck */
 		&ij.alloc,
+		ij.evalCtx,
 		spec.Visibility,
 	); err != nil {
 		return nil, err
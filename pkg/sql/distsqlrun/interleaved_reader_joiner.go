@@ -21,6 +21,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/scrub"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -358,7 +359,7 @@ func newInterleavedReaderJoiner(
 	}
 
 	if err := irj.initRowFetcher(
-		spec.Tables, spec.Reverse, &irj.alloc,
+		spec.Tables, spec.Reverse, &irj.alloc, flowCtx.NewEvalCtx(),
 	); err != nil {
 		return nil, err
 	}
@@ -391,7 +392,10 @@ func newInterleavedReaderJoiner(
 }
 
 func (irj *interleavedReaderJoiner) initRowFetcher(
-	tables []InterleavedReaderJoinerSpec_Table, reverseScan bool, alloc *sqlbase.DatumAlloc,
+	tables []InterleavedReaderJoinerSpec_Table,
+	reverseScan bool,
+	alloc *sqlbase.DatumAlloc,
+	evalCtx *tree.EvalContext,
 ) error {
 	args := make([]sqlbase.RowFetcherTableArgs, len(tables))
 
@@ -417,7 +421,7 @@ func (irj *interleavedReaderJoiner) initRowFetcher(
 	}
 
 	return irj.fetcher.Init(reverseScan, true /* returnRangeInfo */, true /* isCheck */, alloc,
-		args...)
+		evalCtx, args...)
 }
 
 func (irj *interleavedReaderJoiner) generateTrailingMeta(ctx context.Context) []ProducerMetadata {
@@ -213,7 +213,7 @@ func newJoinReader(
 		jr.primaryFetcher = &sqlbase.RowFetcher{}
 		_, _, err = initRowFetcher(
 			jr.primaryFetcher, &jr.desc, 0 /* indexIdx */, jr.colIdxMap, false, /* reverse */
-			jr.neededRightCols(), false /* isCheck */, &jr.alloc,
+			jr.neededRightCols(), false /* isCheck */, &jr.alloc, jr.evalCtx,
 			ScanVisibility_PUBLIC,
 		)
 		if err != nil {
@@ -232,7 +232,7 @@ func newJoinReader(
 	}
 	_, _, err = initRowFetcher(
 		&jr.fetcher, &jr.desc, int(spec.IndexIdx), jr.colIdxMap, false, /* reverse */
-		neededIndexColumns, false /* isCheck */, &jr.alloc,
+		neededIndexColumns, false /* isCheck */, &jr.alloc, jr.evalCtx,
 		ScanVisibility_PUBLIC,
 	)
 	if err != nil {
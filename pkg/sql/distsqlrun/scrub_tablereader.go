@@ -125,7 +125,7 @@ func newScrubTableReader(
 
 	if _, _, err := initRowFetcher(
 		&tr.fetcher, &tr.tableDesc, int(spec.IndexIdx), tr.tableDesc.ColumnIdxMap(), spec.Reverse,
-		neededColumns, true /* isCheck */, &tr.alloc,
+		neededColumns, true /* isCheck */, &tr.alloc, tr.evalCtx,
 		ScanVisibility_PUBLIC,
 	); err != nil {
 		return nil, err
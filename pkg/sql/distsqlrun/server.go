@@ -676,6 +676,11 @@ func (ep *dummyEvalPlanner) EvalSubquery(expr *tree.Subquery) (tree.Datum, error
 	return nil, errEvalPlanner
 }
 
+// Implements the tree.EvalPlanner interface.
+func (ep *dummyEvalPlanner) SendNotification(ctx context.Context, channel, payload string) error {
+	return errEvalPlanner
+}
+
 var errSequenceOperators = errors.New("cannot backfill such sequence operation")
 
 // Implements the tree.SequenceOperators interface by returning errors.
@@ -32,6 +32,8 @@ type dropDatabaseNode struct {
 	n      *tree.DropDatabase
 	dbDesc *sqlbase.DatabaseDescriptor
 	td     []toDelete
+	types  []*sqlbase.TypeDescriptor
+	funcs  []*sqlbase.FunctionDescriptor
 }
 
 // DropDatabase drops a database.
@@ -70,7 +72,26 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	if len(tbNames) > 0 {
+	types, err := p.getTypeDescsInDatabase(ctx, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs, err := p.getFunctionDescsInDatabase(ctx, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+	// The functions cannot be dropped along with the database if views of
+	// other databases call them.
+	for _, fn := range funcs {
+		if err := p.checkFunctionNotInUse(
+			ctx, fn, true /* triggers */, dbDesc.ID, tree.DropRestrict,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tbNames) > 0 || len(types) > 0 || len(funcs) > 0 {
 		switch n.DropBehavior {
 		case tree.DropRestrict:
 			return nil, pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
@@ -112,7 +133,7 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td}, nil
+	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td, types: types, funcs: funcs}, nil
 }
 
 func (n *dropDatabaseNode) startExec(params runParams) error {
@@ -139,6 +160,22 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 		tbNameStrings = append(tbNameStrings, toDel.tn.FQString())
 	}
 
+	// The types are deleted after the tables that use them.
+	for _, typ := range n.types {
+		if err := p.deleteTypeDesc(ctx, typ); err != nil {
+			return err
+		}
+		tn := tree.MakeTableName(tree.Name(n.dbDesc.Name), tree.Name(typ.Name))
+		tbNameStrings = append(tbNameStrings, tn.FQString())
+	}
+	for _, fn := range n.funcs {
+		if err := p.deleteFunctionDesc(ctx, fn); err != nil {
+			return err
+		}
+		tn := tree.MakeTableName(tree.Name(n.dbDesc.Name), tree.Name(fn.Name))
+		tbNameStrings = append(tbNameStrings, tn.FQString())
+	}
+
 	_ /* zoneKey */, nameKey, descKey := getKeysForDatabaseDescriptor(n.dbDesc)
 	zoneKeyPrefix := config.MakeZoneKeyPrefix(uint32(n.dbDesc.ID))
 
@@ -193,6 +230,12 @@ func (p *planner) filterCascadedTables(ctx context.Context, tables []toDelete) (
 		if err := p.accumulateDependentTables(ctx, cascadedTables, desc); err != nil {
 			return nil, err
 		}
+		// Sequences owned by a column are dropped along with its table.
+		for i := range desc.Columns {
+			for _, seqID := range desc.Columns[i].OwnsSequenceIds {
+				cascadedTables[seqID] = true
+			}
+		}
 	}
 	filteredTableList := make([]toDelete, 0, len(tables))
 	for _, toDel := range tables {
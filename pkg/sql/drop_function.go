@@ -0,0 +1,229 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropFunctionNode struct {
+	n  *tree.DropFunction
+	td []*toDeleteFunction
+}
+
+type toDeleteFunction struct {
+	tn   *tree.TableName
+	desc *sqlbase.FunctionDescriptor
+	// overloads are the indexes of the overloads to delete. When all the
+	// overloads are deleted, the descriptor is deleted as well.
+	overloads map[int]bool
+}
+
+// DropFunction drops user-defined functions, or some of their overloads.
+// Privileges: DROP on function.
+//   notes: postgres requires ownership of the function.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	byID := make(map[sqlbase.ID]*toDeleteFunction)
+	var td []*toDeleteFunction
+	for i := range n.Functions {
+		fn := &n.Functions[i]
+		tn, err := fn.Name.Normalize()
+		if err != nil {
+			return nil, err
+		}
+		desc, err := p.getFunctionDesc(ctx, tn, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if desc == nil {
+			continue
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.DROP); err != nil {
+			return nil, err
+		}
+
+		overloadIdx := 0
+		if fn.HasArgs {
+			argTypes := make([]types.T, len(fn.Args))
+			for i := range fn.Args {
+				if err := p.resolveColumnType(ctx, fn.Args[i].Type, desc.ParentID); err != nil {
+					return nil, err
+				}
+				argTypes[i] = coltypes.CastTargetToDatumType(fn.Args[i].Type)
+			}
+			overloadIdx = desc.FindOverload(argTypes)
+			if overloadIdx < 0 {
+				if n.IfExists {
+					continue
+				}
+				return nil, pgerror.NewErrorf(pgerror.CodeUndefinedFunctionError,
+					"function %s does not exist", tree.AsString(fn))
+			}
+		} else if len(desc.Overloads) > 1 {
+			return nil, pgerror.NewErrorf(pgerror.CodeAmbiguousFunctionError,
+				"function name %q is not unique", desc.Name).SetHintf(
+				"Specify the argument list to select the function unambiguously.")
+		}
+
+		if err := p.checkFunctionNotInUse(
+			ctx, desc, desc.Overloads[overloadIdx].ReturnsTrigger, sqlbase.InvalidID, n.DropBehavior,
+		); err != nil {
+			return nil, err
+		}
+
+		toDel, ok := byID[desc.ID]
+		if !ok {
+			toDel = &toDeleteFunction{tn: tn, desc: desc, overloads: make(map[int]bool)}
+			byID[desc.ID] = toDel
+			td = append(td, toDel)
+		}
+		toDel.overloads[overloadIdx] = true
+	}
+	return &dropFunctionNode{n: n, td: td}, nil
+}
+
+// checkFunctionNotInUse returns an error if a view still calls the
+// function or, if triggers is set, if a trigger of a table still calls
+// it. The views and tables of the database with ID skipParentID, which
+// are dropped along with the function, are ignored. The views that were
+// dropped after calling the function remain in its references until then,
+// so dropped and missing descriptors are ignored as well.
+func (p *planner) checkFunctionNotInUse(
+	ctx context.Context,
+	desc *sqlbase.FunctionDescriptor,
+	triggers bool,
+	skipParentID sqlbase.ID,
+	behavior tree.DropBehavior,
+) error {
+	for _, id := range desc.ReferencingDescriptorIDs {
+		table, err := sqlbase.GetTableDescFromID(ctx, p.txn, id)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if table.Dropped() || table.ParentID == skipParentID {
+			continue
+		}
+		var detail string
+		if table.IsView() {
+			detail = fmt.Sprintf("view %q depends on function %q", table.Name, desc.Name)
+		} else if triggers {
+			for _, t := range table.Triggers {
+				if t.FunctionID == desc.ID {
+					detail = fmt.Sprintf("trigger %q on table %q depends on function %q",
+						t.Name, table.Name, desc.Name)
+					break
+				}
+			}
+		}
+		if detail == "" {
+			continue
+		}
+		if behavior == tree.DropCascade {
+			return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"DROP FUNCTION ... CASCADE is not supported")
+		}
+		return pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+			"cannot drop function %q because other objects depend on it", desc.Name).SetDetailf("%s", detail)
+	}
+	return nil
+}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	for _, toDel := range n.td {
+		desc := toDel.desc
+		if len(toDel.overloads) == len(desc.Overloads) {
+			if err := params.p.deleteFunctionDesc(params.ctx, desc); err != nil {
+				return err
+			}
+		} else {
+			overloads := desc.Overloads[:0:0]
+			for i := range desc.Overloads {
+				if !toDel.overloads[i] {
+					overloads = append(overloads, desc.Overloads[i])
+				}
+			}
+			desc.Overloads = overloads
+			if err := params.p.writeFunctionDesc(params.ctx, desc); err != nil {
+				return err
+			}
+		}
+
+		if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			params.ctx,
+			params.p.txn,
+			EventLogDropFunction,
+			int32(desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				FunctionName string
+				Statement    string
+				User         string
+			}{toDel.tn.FQString(), n.n.String(), params.SessionData().User},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropFunctionNode) Close(context.Context)        {}
+
+// deleteFunctionDesc deletes the name and the descriptor of a function.
+func (p *planner) deleteFunctionDesc(ctx context.Context, desc *sqlbase.FunctionDescriptor) error {
+	b := &client.Batch{}
+	nameKey := tableKey{parentID: desc.ParentID, name: desc.Name}.Key()
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Del %s", nameKey)
+		log.VEventf(ctx, 2, "Del %s", descKey)
+	}
+	b.Del(nameKey)
+	b.Del(descKey)
+	p.Tables().addUncommittedFunction(desc)
+	return p.txn.Run(ctx, b)
+}
+
+// getFunctionDescsInDatabase returns the descriptors of the functions of
+// the database with the given ID.
+func (p *planner) getFunctionDescsInDatabase(
+	ctx context.Context, dbID sqlbase.ID,
+) ([]*sqlbase.FunctionDescriptor, error) {
+	descs, err := GetAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return nil, err
+	}
+	var res []*sqlbase.FunctionDescriptor
+	for _, desc := range descs {
+		if fn, ok := desc.(*sqlbase.FunctionDescriptor); ok && fn.ParentID == dbID {
+			res = append(res, fn)
+		}
+	}
+	return res, nil
+}
@@ -66,7 +66,7 @@ func (n *dropIndexNode) startExec(params runParams) error {
 		// the mutation list and new version number created by the first
 		// drop need to be visible to the second drop.
 		tableDesc, err := params.p.ResolveMutableTableDescriptor(
-			ctx, index.tn, true /*required*/, requireTableDesc)
+			ctx, index.tn, true /*required*/, requireTableOrMaterializedViewDesc)
 		if err != nil {
 			// Somehow the descriptor we had during newPlan() is not there
 			// any more.
@@ -202,6 +202,7 @@ func (p *planner) dropIndexByName(
 	if !found {
 		return fmt.Errorf("index %q in the middle of being added, try again later", idxName)
 	}
+	dropIndexExprColumns(tableDesc, &idx)
 
 	if err := tableDesc.Validate(ctx, p.txn, p.EvalContext().Settings); err != nil {
 		return err
@@ -233,3 +234,27 @@ func (p *planner) dropIndexByName(
 			droppedViews},
 	)
 }
+
+// dropIndexExprColumns drops the hidden columns that store the expressions
+// of the given index, unless they are used by another index.
+func dropIndexExprColumns(tableDesc *sqlbase.TableDescriptor, idx *sqlbase.IndexDescriptor) {
+	for _, colID := range idx.ColumnIDs {
+		inUse := false
+		for _, other := range tableDesc.AllNonDropIndexes() {
+			if other.ContainsColumnID(colID) {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			continue
+		}
+		for i := range tableDesc.Columns {
+			if col := tableDesc.Columns[i]; col.ID == colID && col.IsIndexExpr() {
+				tableDesc.AddColumnMutation(col, sqlbase.DescriptorMutation_DROP)
+				tableDesc.Columns = append(tableDesc.Columns[:i], tableDesc.Columns[i+1:]...)
+				break
+			}
+		}
+	}
+}
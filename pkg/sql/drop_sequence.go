@@ -64,6 +64,9 @@ func (n *dropSequenceNode) startExec(params runParams) error {
 	ctx := params.ctx
 	for _, toDel := range n.td {
 		droppedDesc := toDel.desc
+		if err := params.p.removeSequenceOwnership(ctx, droppedDesc); err != nil {
+			return err
+		}
 		err := params.p.dropSequenceImpl(ctx, droppedDesc, n.n.DropBehavior)
 		if err != nil {
 			return err
@@ -92,6 +92,14 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 				}
 			}
 		}
+		for i := range droppedDesc.Columns {
+			if err := p.canDropOwnedSequences(
+				ctx, &droppedDesc.Columns[i], "table", droppedDesc.Name,
+				func(ref sqlbase.TableDescriptor_Reference) bool { return dropping[ref.ID] },
+			); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if len(td) == 0 {
@@ -284,6 +292,13 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Drop the sequences owned by the columns of this table.
+	for i := range tableDesc.Columns {
+		if err := p.dropOwnedSequences(ctx, &tableDesc.Columns[i]); err != nil {
+			return droppedViews, err
+		}
+	}
+
 	// Drop all views that depend on this table, assuming that we wouldn't have
 	// made it to this point if `cascade` wasn't enabled.
 	for _, ref := range tableDesc.DependedOnBy {
@@ -329,7 +344,7 @@ func (p *planner) initiateDropTable(
 	//
 	// TODO(bram): If interleaved and ON DELETE CASCADE, we will be
 	// able to use this faster mechanism.
-	if tableDesc.IsTable() && !tableDesc.IsInterleaved() &&
+	if (tableDesc.IsTable() || tableDesc.IsMaterializedView()) && !tableDesc.IsInterleaved() &&
 		p.ExecCfg().Settings.Version.IsActive(cluster.VersionClearRange) {
 		// Get the zone config applying to this table in order to
 		// ensure there is a GC TTL.
@@ -347,7 +362,7 @@ func (p *planner) initiateDropTable(
 	if drainName {
 		// Queue up name for draining.
 		nameDetails := sqlbase.TableDescriptor_NameInfo{
-			ParentID: tableDesc.ParentID,
+			ParentID: tableDesc.GetNamespaceParentID(),
 			Name:     tableDesc.Name}
 		tableDesc.DrainingNames = append(tableDesc.DrainingNames, nameDetails)
 	}
@@ -371,6 +386,21 @@ func (p *planner) initiateDropTable(
 		}
 	}
 
+	// The types used by the table can be dropped once the table is.
+	if err := p.updateTypeBackReferences(
+		ctx, tableDesc.ID, tableDesc.EnumTypeIDs(), nil, /* newTypeIDs */
+	); err != nil {
+		return err
+	}
+
+	// The functions called by the triggers of the table can be dropped once
+	// the table is.
+	if err := p.updateFunctionBackReferences(
+		ctx, tableDesc.ID, tableDesc.TriggerFunctionIDs(), nil, /* newFunctionIDs */
+	); err != nil {
+		return err
+	}
+
 	// Initiate an immediate schema change. When dropping a table
 	// in a session, the data and the descriptor are not deleted.
 	// Instead, that is taken care of asynchronously by the schema
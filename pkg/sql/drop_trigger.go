@@ -0,0 +1,91 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableName *tree.TableName
+	tableDesc *sqlbase.TableDescriptor
+	idx       int
+}
+
+// DropTrigger drops a trigger of a table.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	tn, err := n.Table.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	idx := tableDesc.FindTriggerByName(string(n.Name))
+	if idx < 0 {
+		if n.IfExists {
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+			"trigger %q for table %q does not exist", n.Name, tableDesc.Name)
+	}
+	return &dropTriggerNode{n: n, tableName: tn, tableDesc: tableDesc, idx: idx}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	oldFunctionIDs := n.tableDesc.TriggerFunctionIDs()
+	triggerName := n.tableDesc.Triggers[n.idx].Name
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers[:n.idx], n.tableDesc.Triggers[n.idx+1:]...)
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+	if err := params.p.updateFunctionBackReferences(
+		params.ctx, n.tableDesc.ID, oldFunctionIDs, n.tableDesc.TriggerFunctionIDs(),
+	); err != nil {
+		return err
+	}
+
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogDropTrigger,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName   string
+			TriggerName string
+			Statement   string
+			User        string
+		}{n.tableName.FQString(), triggerName, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
@@ -0,0 +1,152 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropTypeNode struct {
+	n  *tree.DropType
+	td []toDeleteType
+}
+
+type toDeleteType struct {
+	tn   *tree.TableName
+	desc *sqlbase.TypeDescriptor
+}
+
+// DropType drops enum types.
+// Privileges: DROP on type.
+//   notes: postgres requires ownership of the type.
+func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	td := make([]toDeleteType, 0, len(n.Names))
+	for i := range n.Names {
+		tn, err := n.Names[i].Normalize()
+		if err != nil {
+			return nil, err
+		}
+		desc, err := p.getTypeDesc(ctx, tn, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if desc == nil {
+			continue
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.DROP); err != nil {
+			return nil, err
+		}
+		if err := p.checkTypeNotInUse(ctx, desc, n.DropBehavior); err != nil {
+			return nil, err
+		}
+		td = append(td, toDeleteType{tn: tn, desc: desc})
+	}
+	return &dropTypeNode{n: n, td: td}, nil
+}
+
+// checkTypeNotInUse returns an error if a column of a table still uses
+// the type.
+func (p *planner) checkTypeNotInUse(
+	ctx context.Context, desc *sqlbase.TypeDescriptor, behavior tree.DropBehavior,
+) error {
+	for _, id := range desc.ReferencingDescriptorIDs {
+		table, err := sqlbase.GetTableDescFromID(ctx, p.txn, id)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if table.Dropped() || len(table.EnumColumnTypes(desc.ID)) == 0 {
+			continue
+		}
+		if behavior == tree.DropCascade {
+			return pgerror.UnimplementedWithIssueError(24873,
+				"DROP TYPE ... CASCADE is not supported")
+		}
+		return pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+			"cannot drop type %q because other objects depend on it", desc.Name).SetDetailf(
+			"table %q depends on type %q", table.Name, desc.Name)
+	}
+	return nil
+}
+
+func (n *dropTypeNode) startExec(params runParams) error {
+	for _, toDel := range n.td {
+		if err := params.p.deleteTypeDesc(params.ctx, toDel.desc); err != nil {
+			return err
+		}
+
+		if err := MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+			params.ctx,
+			params.p.txn,
+			EventLogDropType,
+			int32(toDel.desc.ID),
+			int32(params.extendedEvalCtx.NodeID),
+			struct {
+				TypeName  string
+				Statement string
+				User      string
+			}{toDel.tn.FQString(), n.n.String(), params.SessionData().User},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTypeNode) Close(context.Context)        {}
+
+// deleteTypeDesc deletes the name and the descriptor of a type. Types
+// have no data, so unlike tables they are deleted right away.
+func (p *planner) deleteTypeDesc(ctx context.Context, desc *sqlbase.TypeDescriptor) error {
+	b := &client.Batch{}
+	nameKey := tableKey{parentID: desc.ParentID, name: desc.Name}.Key()
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+		log.VEventf(ctx, 2, "Del %s", nameKey)
+		log.VEventf(ctx, 2, "Del %s", descKey)
+	}
+	b.Del(nameKey)
+	b.Del(descKey)
+	return p.txn.Run(ctx, b)
+}
+
+// getTypeDescsInDatabase returns the descriptors of the types of the
+// database with the given ID.
+func (p *planner) getTypeDescsInDatabase(
+	ctx context.Context, dbID sqlbase.ID,
+) ([]*sqlbase.TypeDescriptor, error) {
+	descs, err := GetAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return nil, err
+	}
+	var res []*sqlbase.TypeDescriptor
+	for _, desc := range descs {
+		if typ, ok := desc.(*sqlbase.TypeDescriptor); ok && typ.ParentID == dbID {
+			res = append(res, typ)
+		}
+	}
+	return res, nil
+}
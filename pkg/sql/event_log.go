@@ -44,6 +44,10 @@ const (
 	EventLogTruncateTable EventLogType = "truncate_table"
 	// EventLogAlterTable is recorded when a table is altered.
 	EventLogAlterTable EventLogType = "alter_table"
+	// EventLogCommentOnTable is recorded when a table is commented.
+	EventLogCommentOnTable EventLogType = "comment_on_table"
+	// EventLogCommentOnColumn is recorded when a column is commented.
+	EventLogCommentOnColumn EventLogType = "comment_on_column"
 
 	// EventLogCreateIndex is recorded when an index is created.
 	EventLogCreateIndex EventLogType = "create_index"
@@ -51,11 +55,16 @@ const (
 	EventLogDropIndex EventLogType = "drop_index"
 	// EventLogAlterIndex is recorded when an index is altered.
 	EventLogAlterIndex EventLogType = "alter_index"
+	// EventLogCommentOnIndex is recorded when an index is commented.
+	EventLogCommentOnIndex EventLogType = "comment_on_index"
 
 	// EventLogCreateView is recorded when a view is created.
 	EventLogCreateView EventLogType = "create_view"
 	// EventLogDropView is recorded when a view is dropped.
 	EventLogDropView EventLogType = "drop_view"
+	// EventLogRefreshMaterializedView is recorded when the refresh of a
+	// materialized view is started.
+	EventLogRefreshMaterializedView EventLogType = "refresh_materialized_view"
 
 	// EventLogCreateSequence is recorded when a sequence is created.
 	EventLogCreateSequence EventLogType = "create_sequence"
@@ -64,6 +73,23 @@ const (
 	// EventLogAlterSequence is recorded when a sequence is altered.
 	EventLogAlterSequence EventLogType = "alter_sequence"
 
+	// EventLogCreateType is recorded when a type is created.
+	EventLogCreateType EventLogType = "create_type"
+	// EventLogDropType is recorded when a type is dropped.
+	EventLogDropType EventLogType = "drop_type"
+	// EventLogAlterType is recorded when a type is altered.
+	EventLogAlterType EventLogType = "alter_type"
+
+	// EventLogCreateFunction is recorded when a function is created.
+	EventLogCreateFunction EventLogType = "create_function"
+	// EventLogDropFunction is recorded when a function is dropped.
+	EventLogDropFunction EventLogType = "drop_function"
+
+	// EventLogCreateTrigger is recorded when a trigger is created.
+	EventLogCreateTrigger EventLogType = "create_trigger"
+	// EventLogDropTrigger is recorded when a trigger is dropped.
+	EventLogDropTrigger EventLogType = "drop_trigger"
+
 	// EventLogReverseSchemaChange is recorded when an in-progress schema change
 	// encounters a problem and is reversed.
 	EventLogReverseSchemaChange EventLogType = "reverse_schema_change"
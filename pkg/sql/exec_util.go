@@ -299,6 +299,9 @@ type ExecutorConfig struct {
 	ExecLogger       *log.SecondaryLogger
 	AuditLogger      *log.SecondaryLogger
 	InternalExecutor *InternalExecutor
+	// NotificationRegistry delivers the notifications sent with NOTIFY to the
+	// sessions of this node.
+	NotificationRegistry *NotificationRegistry
 
 	TestingKnobs              *ExecutorTestingKnobs
 	SchemaChangerTestingKnobs *SchemaChangerTestingKnobs
@@ -612,6 +615,7 @@ func checkResultType(typ types.T) error {
 	case types.String:
 	case types.Date:
 	case types.Time:
+	case types.TimeTZ:
 	case types.Timestamp:
 	case types.TimestampTZ:
 	case types.Interval:
@@ -704,10 +708,11 @@ func (p *planner) isAsOf(stmt tree.Statement, max hlc.Timestamp) (*hlc.Timestamp
 	return &ts, err
 }
 
-// isSavepoint returns true if stmt is a SAVEPOINT statement.
-func isSavepoint(stmt Statement) bool {
-	_, isSavepoint := stmt.AST.(*tree.Savepoint)
-	return isSavepoint
+// isRestartSavepoint returns true if stmt is a SAVEPOINT cockroach_restart
+// statement.
+func isRestartSavepoint(stmt Statement) bool {
+	s, isSavepoint := stmt.AST.(*tree.Savepoint)
+	return isSavepoint && tree.IsRestartSavepoint(s.Name)
 }
 
 // isSetTransaction returns true if stmt is a "SET TRANSACTION ..." statement.
@@ -802,6 +807,15 @@ func (r *SessionRegistry) deregister(id ClusterWideID) {
 	r.Unlock()
 }
 
+// isRegistered returns whether the session with the given ID is
+// registered.
+func (r *SessionRegistry) isRegistered(id ClusterWideID) bool {
+	r.Lock()
+	defer r.Unlock()
+	_, ok := r.store[id]
+	return ok
+}
+
 type registrySession interface {
 	user() string
 	cancelQuery(queryID ClusterWideID) bool
@@ -1619,7 +1633,8 @@ func (m *sessionDataMutator) SetSafeUpdates(val bool) {
 }
 
 func (m *sessionDataMutator) SetSearchPath(val sessiondata.SearchPath) {
-	m.data.SearchPath = val
+	// The temporary schema of the session stays in the search path.
+	m.data.SearchPath = val.WithTemporarySchemaName(m.data.SearchPath.GetTemporarySchemaName())
 }
 
 func (m *sessionDataMutator) SetLocation(loc *time.Location) {
@@ -111,6 +111,11 @@ func doExpandPlan(
 	case *createTableNode:
 		n.sourcePlan, err = doExpandPlan(ctx, p, noParams, n.sourcePlan)
 
+	case *createViewNode:
+		if n.sourcePlan != nil {
+			n.sourcePlan, err = doExpandPlan(ctx, p, noParams, n.sourcePlan)
+		}
+
 	case *updateNode:
 		n.source, err = doExpandPlan(ctx, p, noParams, n.source)
 
@@ -178,6 +183,20 @@ func doExpandPlan(
 		}
 		n.left, err = doExpandPlan(ctx, p, params, n.left)
 
+	case *recursiveCTENode:
+		n.initial, err = doExpandPlan(ctx, p, noParams, n.initial)
+		if err != nil {
+			return plan, err
+		}
+		n.recursive, err = doExpandPlan(ctx, p, noParams, n.recursive)
+
+	case *applyJoinNode:
+		n.input, err = doExpandPlan(ctx, p, noParams, n.input)
+		if err != nil {
+			return plan, err
+		}
+		n.right, err = doExpandPlan(ctx, p, noParams, n.right)
+
 	case *filterNode:
 		plan, err = expandFilterNode(ctx, p, params, n)
 
@@ -331,24 +350,37 @@ func doExpandPlan(
 
 	case *valuesNode:
 	case *alterIndexNode:
+	case *commentOnColumnNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *refreshMaterializedViewNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *scrubNode:
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *CreateUserNode:
-	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
+	case *listenNode:
+	case *notifyNode:
 	case *zeroNode:
 	case *unaryNode:
+	case *scanBufferNode:
 	case *hookFnNode:
 		for i := range n.subplans {
 			n.subplans[i], err = doExpandPlan(ctx, p, noParams, n.subplans[i])
@@ -648,6 +680,11 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *createTableNode:
 		n.sourcePlan = p.simplifyOrderings(n.sourcePlan, nil)
 
+	case *createViewNode:
+		if n.sourcePlan != nil {
+			n.sourcePlan = p.simplifyOrderings(n.sourcePlan, nil)
+		}
+
 	case *updateNode:
 		n.source = p.simplifyOrderings(n.source, nil)
 
@@ -703,6 +740,14 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 		n.right = p.simplifyOrderings(n.right, nil)
 		n.left = p.simplifyOrderings(n.left, nil)
 
+	case *recursiveCTENode:
+		n.initial = p.simplifyOrderings(n.initial, nil)
+		n.recursive = p.simplifyOrderings(n.recursive, nil)
+
+	case *applyJoinNode:
+		n.input = p.simplifyOrderings(n.input, nil)
+		n.right = p.simplifyOrderings(n.right, nil)
+
 	case *filterNode:
 		n.source.plan = p.simplifyOrderings(n.source.plan, usefulOrdering)
 		n.computePhysicalProps(p.EvalContext())
@@ -832,24 +877,37 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 
 	case *valuesNode:
 	case *alterIndexNode:
+	case *commentOnColumnNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *refreshMaterializedViewNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *scrubNode:
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *CreateUserNode:
-	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
+	case *listenNode:
+	case *notifyNode:
 	case *zeroNode:
 	case *unaryNode:
+	case *scanBufferNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
 	case *setVarNode:
@@ -127,6 +127,12 @@ func (p *planner) groupBy(
 	for i, expr := range n.GroupBy {
 		expr = tree.StripParens(expr)
 
+		if gs, ok := expr.(*tree.GroupingSet); ok {
+			// Grouping sets are only supported by the optimizer.
+			return nil, nil, pgerror.Unimplemented("grouping sets",
+				"%s is only supported by the cost-based optimizer", gs.Type)
+		}
+
 		// Check whether the GROUP BY clause refers to a rendered column
 		// (specified in the original query) by index, e.g. `SELECT a, SUM(b)
 		// FROM y GROUP BY 1`.
@@ -587,7 +593,7 @@ var _ tree.Visitor = &extractAggregatesVisitor{}
 // an IndexedVar that refers to the index of the function.
 func (v *extractAggregatesVisitor) addAggregation(f *aggregateFuncHolder) *tree.IndexedVar {
 	for i, g := range v.groupNode.funcs {
-		if aggregateFuncsEqual(f, g) {
+		if aggregateFuncsEqual(v.planner.EvalContext(), f, g) {
 			return v.ivarHelper.IndexedVarWithType(i, f.resultType)
 		}
 	}
@@ -633,7 +639,10 @@ func (v *extractAggregatesVisitor) VisitPre(expr tree.Expr) (recurse bool, newEx
 	case *tree.FuncExpr:
 		if agg := t.GetAggregateConstructor(); agg != nil {
 			var f *aggregateFuncHolder
-			if len(t.Exprs) == 0 {
+			// For ordered-set aggregates, the WITHIN GROUP column is the
+			// aggregated argument and all the direct arguments are consts.
+			args := t.AggregateArgs()
+			if len(args) == 0 {
 				// COUNT_ROWS has no arguments.
 				f = v.groupNode.newAggregateFuncHolder(
 					t.Func.String(),
@@ -646,25 +655,25 @@ func (v *extractAggregatesVisitor) VisitPre(expr tree.Expr) (recurse bool, newEx
 			} else {
 				// Only the first argument can be an expression, all the following ones
 				// must be consts. So before we proceed, they must be checked.
-				arguments := make(tree.Datums, len(t.Exprs)-1)
-				if len(t.Exprs) > 1 {
+				arguments := make(tree.Datums, len(args)-1)
+				if len(args) > 1 {
 					evalContext := v.planner.EvalContext()
-					for i := 1; i < len(t.Exprs); i++ {
-						if !tree.IsConst(evalContext, t.Exprs[i]) {
+					for i := 1; i < len(args); i++ {
+						if !tree.IsConst(evalContext, args[i]) {
 							v.err = pgerror.UnimplementedWithIssueError(28417, "aggregate functions with multiple non-constant expressions are not supported")
 							return false, expr
 						}
 						var err error
-						arguments[i-1], err = t.Exprs[i].(tree.TypedExpr).Eval(evalContext)
+						arguments[i-1], err = args[i].(tree.TypedExpr).Eval(evalContext)
 						if err != nil {
 							v.err = pgerror.NewErrorf(pgerror.CodeInternalError,
-								"programming error: can't evaluate %s - %v", t.Exprs[i].String(), err)
+								"programming error: can't evaluate %s - %v", args[i].String(), err)
 							return false, expr
 						}
 					}
 				}
 
-				argExpr := t.Exprs[0].(tree.TypedExpr)
+				argExpr := args[0].(tree.TypedExpr)
 
 				// TODO(knz): it's really a shame that we need to recurse
 				// through the sub-tree to determine whether the arguments
@@ -701,6 +710,10 @@ func (v *extractAggregatesVisitor) VisitPre(expr tree.Expr) (recurse bool, newEx
 				f.setDistinct()
 			}
 
+			if t.IsOrderedSetAggregate() && t.OrderBy[0].Direction == tree.Descending {
+				f.setWithinGroupDescending()
+			}
+
 			if t.Filter != nil {
 				filterExpr := t.Filter.(tree.TypedExpr)
 
@@ -773,6 +786,10 @@ type aggregateFuncHolder struct {
 	// aggregator.
 	arguments tree.Datums
 
+	// withinGroupDescending is set for ordered-set aggregates whose WITHIN
+	// GROUP ordering is descending.
+	withinGroupDescending bool
+
 	run aggregateFuncRun
 }
 
@@ -835,9 +852,33 @@ func (a *aggregateFuncHolder) isDistinct() bool {
 	return a.run.seen != nil
 }
 
-func aggregateFuncsEqual(a, b *aggregateFuncHolder) bool {
-	return a.funcName == b.funcName && a.resultType == b.resultType &&
-		a.argRenderIdx == b.argRenderIdx && a.filterRenderIdx == b.filterRenderIdx
+// setWithinGroupDescending causes a to aggregate the values of an ordered-set
+// aggregate in descending order.
+func (a *aggregateFuncHolder) setWithinGroupDescending() {
+	a.withinGroupDescending = true
+	create := a.create
+	a.create = func(evalCtx *tree.EvalContext, arguments tree.Datums) tree.AggregateFunc {
+		impl := create(evalCtx, arguments)
+		impl.(tree.OrderedSetAggregateFunc).SetDescending()
+		return impl
+	}
+}
+
+func aggregateFuncsEqual(evalCtx *tree.EvalContext, a, b *aggregateFuncHolder) bool {
+	if a.funcName != b.funcName || a.resultType != b.resultType ||
+		a.argRenderIdx != b.argRenderIdx || a.filterRenderIdx != b.filterRenderIdx ||
+		a.withinGroupDescending != b.withinGroupDescending ||
+		len(a.arguments) != len(b.arguments) {
+		return false
+	}
+	// The arguments must be compared as well, e.g. for the different
+	// fractions in percentile_disc(0.5) and percentile_disc(0.95).
+	for i := range a.arguments {
+		if a.arguments[i].Compare(evalCtx, b.arguments[i]) != 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func (a *aggregateFuncHolder) close(ctx context.Context) {
@@ -152,6 +152,8 @@ func (p *planner) makeIndexJoin(
 	}
 	table.initOrdering(0 /* exactPrefix */, p.EvalContext())
 	table.disableBatchLimit()
+	table.lockingStrength = origScan.lockingStrength
+	table.lockingWaitPolicy = origScan.lockingWaitPolicy
 
 	primaryKeyColumns, colIDtoRowIndex := processIndexJoinColumns(table, indexScan)
 
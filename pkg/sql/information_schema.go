@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -69,6 +70,11 @@ var (
 	// yesOrNoDatum.
 	yesString = tree.NewDString("YES")
 	noString  = tree.NewDString("NO")
+
+	// alwaysString and neverString are the values of is_generated in
+	// information_schema.columns.
+	alwaysString = tree.NewDString("ALWAYS")
+	neverString  = tree.NewDString("NEVER")
 )
 
 func yesOrNoDatum(b bool) tree.Datum {
@@ -78,6 +84,15 @@ func yesOrNoDatum(b bool) tree.Datum {
 	return noString
 }
 
+// isGeneratedDatum returns whether the column is a computed column, stored
+// or virtual, as reported by information_schema.columns.is_generated.
+func isGeneratedDatum(col *sqlbase.ColumnDescriptor) tree.Datum {
+	if col.IsComputed() {
+		return alwaysString
+	}
+	return neverString
+}
+
 func dNameOrNull(s string) tree.Datum {
 	if s == "" {
 		return tree.DNull
@@ -254,6 +269,7 @@ CREATE TABLE information_schema.columns (
 	CHARACTER_SET_CATALOG    STRING,
 	CHARACTER_SET_SCHEMA     STRING,
 	CHARACTER_SET_NAME       STRING,
+	IS_GENERATED             STRING NOT NULL,
 	GENERATION_EXPRESSION    STRING,          -- MySQL/CockroachDB extension.
 	IS_HIDDEN                STRING NOT NULL, -- CockroachDB extension for SHOW COLUMNS / dump.
 	CRDB_SQL_TYPE            STRING NOT NULL  -- CockroachDB extension for SHOW COLUMNS / dump.
@@ -285,6 +301,7 @@ CREATE TABLE information_schema.columns (
 					tree.DNull,                                                  // character_set_catalog
 					tree.DNull,                                                  // character_set_schema
 					tree.DNull,                                                  // character_set_name
+					isGeneratedDatum(column),                                    // is_generated
 					dStringPtrOrEmpty(column.ComputeExpr),                       // generation_expression
 					yesOrNoDatum(column.Hidden),                                 // is_hidden
 					tree.NewDString(column.Type.SQLString()),                    // crdb_sql_type
@@ -530,10 +547,6 @@ var (
 	matchOptionPartial = tree.NewDString("PARTIAL")
 	matchOptionNone    = tree.NewDString("NONE")
 
-	// Avoid unused warning for constants.
-	_ = matchOptionPartial
-	_ = matchOptionNone
-
 	refConstraintRuleNoAction   = tree.NewDString("NO ACTION")
 	refConstraintRuleRestrict   = tree.NewDString("RESTRICT")
 	refConstraintRuleSetNull    = tree.NewDString("SET NULL")
@@ -557,6 +570,21 @@ func dStringForFKAction(action sqlbase.ForeignKeyReference_Action) tree.Datum {
 	panic(errors.Errorf("unexpected ForeignKeyReference_Action: %v", action))
 }
 
+func dStringForFKMatch(match sqlbase.ForeignKeyReference_Match) tree.Datum {
+	switch match {
+	case sqlbase.ForeignKeyReference_UNSPECIFIED:
+		// These foreign keys only skip the rows with all NULL values.
+		return matchOptionFull
+	case sqlbase.ForeignKeyReference_SIMPLE:
+		return matchOptionNone
+	case sqlbase.ForeignKeyReference_FULL:
+		return matchOptionFull
+	case sqlbase.ForeignKeyReference_PARTIAL:
+		return matchOptionPartial
+	}
+	panic(errors.Errorf("unexpected ForeignKeyReference_Match: %v", match))
+}
+
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-referential-constraints.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/referential-constraints-table.html
 var informationSchemaReferentialConstraintsTable = virtualSchemaTable{
@@ -606,7 +634,7 @@ CREATE TABLE information_schema.referential_constraints (
 					dbNameStr,                       // unique_constraint_catalog
 					scNameStr,                       // unique_constraint_schema
 					tree.NewDString(refIndex.Name),  // unique_constraint_name
-					matchOptionFull,                 // match_option
+					dStringForFKMatch(fk.Match),     // match_option
 					dStringForFKAction(fk.OnUpdate), // update_rule
 					dStringForFKAction(fk.OnDelete), // delete_rule
 					tbNameStr,                       // table_name
@@ -832,12 +860,19 @@ CREATE TABLE information_schema.sequences (
 				if !table.IsSequence() {
 					return nil
 				}
+				typeName, precision := "integer", tree.DInt(64)
+				switch table.SequenceOpts.AsIntegerType {
+				case coltypes.Int2.TypeName():
+					typeName, precision = "smallint", 16
+				case coltypes.Int4.TypeName():
+					precision = 32
+				}
 				return addRow(
 					tree.NewDString(db.GetName()),    // catalog
 					tree.NewDString(scName),          // schema
 					tree.NewDString(table.GetName()), // name
-					tree.NewDString("integer"),       // type
-					tree.NewDInt(64),                 // numeric precision
+					tree.NewDString(typeName),        // type
+					tree.NewDInt(precision),          // numeric precision
 					tree.NewDInt(2),                  // numeric precision radix
 					tree.NewDInt(0),                  // numeric scale
 					tree.NewDString(strconv.FormatInt(table.SequenceOpts.Start, 10)),     // start value
@@ -1080,9 +1115,10 @@ func populateTablePrivileges(
 }
 
 var (
-	tableTypeSystemView = tree.NewDString("SYSTEM VIEW")
-	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
-	tableTypeView       = tree.NewDString("VIEW")
+	tableTypeSystemView       = tree.NewDString("SYSTEM VIEW")
+	tableTypeBaseTable        = tree.NewDString("BASE TABLE")
+	tableTypeView             = tree.NewDString("VIEW")
+	tableTypeMaterializedView = tree.NewDString("MATERIALIZED VIEW")
 )
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-tables.html
@@ -1108,6 +1144,9 @@ CREATE TABLE information_schema.tables (
 				if isVirtualDescriptor(table) {
 					tableType = tableTypeSystemView
 					insertable = noString
+				} else if table.IsMaterializedView() {
+					tableType = tableTypeMaterializedView
+					insertable = noString
 				} else if table.IsView() {
 					tableType = tableTypeView
 					insertable = noString
@@ -1146,7 +1185,7 @@ CREATE TABLE information_schema.views (
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual schemas have no views */
 			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
-				if !table.IsView() {
+				if !table.IsView() || table.IsMaterializedView() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
@@ -1182,6 +1221,10 @@ func forEachSchemaName(
 	for _, schema := range p.getVirtualTabler().getEntries() {
 		scNames = append(scNames, schema.desc.Name)
 	}
+	// Handle the temporary schema of the session.
+	if tc := p.Tables(); tc.temporarySchemaIDs[db.ID] != 0 {
+		scNames = append(scNames, tc.temporarySchemaName)
+	}
 	sort.Strings(scNames)
 	for _, sc := range scNames {
 		if err := fn(sc); err != nil {
@@ -1223,6 +1266,72 @@ func forEachDatabaseDesc(
 	return nil
 }
 
+// forEachTypeDesc retrieves all the user-defined type descriptors
+// visible in the given database context and iterates through them. For
+// each type, the function will call fn with its respective database and
+// type descriptor.
+func forEachTypeDesc(
+	ctx context.Context,
+	p *planner,
+	dbContext *DatabaseDescriptor,
+	fn func(*DatabaseDescriptor, *sqlbase.TypeDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbContext)
+
+	for _, desc := range descs {
+		typ, ok := desc.(*sqlbase.TypeDescriptor)
+		if !ok {
+			continue
+		}
+		dbDesc, parentExists := lCtx.dbDescs[typ.ParentID]
+		if !parentExists || (dbContext != nil && dbContext.ID != dbDesc.ID) ||
+			!userCanSeeDatabase(ctx, p, dbDesc) {
+			continue
+		}
+		if err := fn(dbDesc, typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachFunctionDesc retrieves all the user-defined function descriptors
+// visible in the given database context and iterates through them. For
+// each function, the function will call fn with its respective database
+// and function descriptor.
+func forEachFunctionDesc(
+	ctx context.Context,
+	p *planner,
+	dbContext *DatabaseDescriptor,
+	fn func(*DatabaseDescriptor, *sqlbase.FunctionDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbContext)
+
+	for _, desc := range descs {
+		function, ok := desc.(*sqlbase.FunctionDescriptor)
+		if !ok {
+			continue
+		}
+		dbDesc, parentExists := lCtx.dbDescs[function.ParentID]
+		if !parentExists || (dbContext != nil && dbContext.ID != dbDesc.ID) ||
+			!userCanSeeDatabase(ctx, p, dbDesc) {
+			continue
+		}
+		if err := fn(dbDesc, function); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // forEachTableDesc retrieves all table descriptors from the current
 // database and all system databases and iterates through them. For
 // each table, the function will call fn with its respective database
@@ -1362,7 +1471,16 @@ func forEachTableDescWithTableLookupInternal(
 		if table.Dropped() || !userCanSeeTable(ctx, p, table, allowAdding) || !parentExists {
 			continue
 		}
-		if err := fn(dbDesc, tree.PublicSchema, table, lCtx); err != nil {
+		scName := tree.PublicSchema
+		if table.IsTemporary() {
+			// The temporary tables of the other sessions are invisible.
+			tc := p.Tables()
+			if tc.temporarySchemaIDs[table.ParentID] != table.TemporarySchemaID {
+				continue
+			}
+			scName = tc.temporarySchemaName
+		}
+		if err := fn(dbDesc, scName, table, lCtx); err != nil {
 			return err
 		}
 	}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
@@ -94,6 +95,21 @@ func (p *planner) Insert(
 		}
 	}
 
+	// Determine which triggers fire on the insertion.
+	var triggers *rowTriggers
+	if n.OnConflict != nil {
+		if desc.HasTriggers(sqlbase.TriggerDescriptor_INSERT) ||
+			desc.HasTriggers(sqlbase.TriggerDescriptor_UPDATE) {
+			return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"UPSERT or INSERT...ON CONFLICT is not supported on table %q with triggers", desc.Name)
+		}
+	} else {
+		triggers, err = p.makeRowTriggers(ctx, tn, desc, sqlbase.TriggerDescriptor_INSERT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Determine what are the foreign key tables that are involved in the update.
 	var fkCheckType sqlbase.FKCheck
 	if n.OnConflict == nil || n.OnConflict.DoNothing {
@@ -277,6 +293,7 @@ func (p *planner) Insert(
 			run: insertRun{
 				ti:           tableInserter{ri: ri},
 				checkHelper:  fkTables[desc.ID].CheckHelper,
+				triggers:     triggers,
 				rowsNeeded:   rowsNeeded,
 				computedCols: computedCols,
 				computeExprs: computeExprs,
@@ -306,6 +323,9 @@ type insertRun struct {
 	checkHelper *sqlbase.CheckHelper
 	rowsNeeded  bool
 
+	// triggers are the triggers that fire on the inserted rows, if any.
+	triggers *rowTriggers
+
 	// insertCols are the columns being inserted into.
 	insertCols []sqlbase.ColumnDescriptor
 
@@ -508,12 +528,35 @@ func (n *insertNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		}
 	}
 
+	// Run the BEFORE triggers, if any.
+	colIDtoRowIndex := n.run.ti.ri.InsertColIDtoRowIndex
+	if n.run.triggers != nil {
+		if err := n.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_BEFORE, nil /* oldRow */, rowVals, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// Queue the insert in the KV batch.
 	_, err = n.run.ti.row(params.ctx, rowVals, n.run.traceKV)
 	if err != nil {
 		return err
 	}
 
+	// Run the AFTER triggers, if any. The row is written first so that the
+	// triggers can observe it.
+	if n.run.triggers != nil {
+		if err := n.run.ti.flushAndStartNewBatch(params.ctx); err != nil {
+			return err
+		}
+		if err := n.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_AFTER, nil /* oldRow */, rowVals, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if n.run.rows != nil {
 		for i, val := range rowVals {
@@ -0,0 +1,114 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// listenNode represents a LISTEN or UNLISTEN statement.
+type listenNode struct {
+	op listenOp
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/static/sql-listen.html for details.
+// Privileges: None.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if err := p.checkCanListen("LISTEN"); err != nil {
+		return nil, err
+	}
+	return &listenNode{op: listenOp{channel: string(n.Channel)}}, nil
+}
+
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/static/sql-unlisten.html for details.
+// Privileges: None.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	if err := p.checkCanListen("UNLISTEN"); err != nil {
+		return nil, err
+	}
+	return &listenNode{op: listenOp{channel: string(n.Channel), unlisten: true, all: n.All}}, nil
+}
+
+// checkCanListen returns an error if the session of the planner cannot
+// receive notifications, which is the case of the internal sessions.
+func (p *planner) checkCanListen(stmt string) error {
+	if p.extendedEvalCtx.Listens == nil {
+		return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"%s is not supported in this context", stmt)
+	}
+	return nil
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	lc := params.extendedEvalCtx.Listens
+	lc.ops = append(lc.ops, n.op)
+	return nil
+}
+
+func (*listenNode) Next(runParams) (bool, error) { return false, nil }
+func (*listenNode) Values() tree.Datums          { return tree.Datums{} }
+func (*listenNode) Close(context.Context)        {}
+
+// notifyNode represents a NOTIFY statement.
+type notifyNode struct {
+	n *tree.Notify
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/static/sql-notify.html for details.
+// Privileges: None.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	return &notifyNode{n: n}, nil
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.SendNotification(params.ctx, string(n.n.Channel), n.n.Payload)
+}
+
+func (*notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (*notifyNode) Values() tree.Datums          { return tree.Datums{} }
+func (*notifyNode) Close(context.Context)        {}
+
+// SendNotification is part of the tree.EvalPlanner interface. The
+// notification is written to system.notifications in the transaction of the
+// planner, so it is only delivered if the transaction commits. Its creation
+// time is the time of the statement rather than the timestamp of the
+// transaction, which keeps it close to the commit timestamp of the row.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if p.EvalContext().TxnReadOnly {
+		return readOnlyError("pg_notify()")
+	}
+	if channel == "" {
+		return pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"channel name cannot be empty")
+	}
+	if len(payload) >= maxNotificationPayloadLength {
+		return pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+			"payload string too long")
+	}
+	_, err := p.ExecCfg().InternalExecutor.Exec(
+		ctx,
+		"notify",
+		p.txn,
+		`INSERT INTO system.notifications ("createdAt", channel, payload, "nodeID") VALUES ($1, $2, $3, $4)`,
+		p.ExecCfg().Clock.Now().GoTime(), channel, payload, p.ExecCfg().NodeID.Get(),
+	)
+	return err
+}
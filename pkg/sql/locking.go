@@ -0,0 +1,147 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// lockingSpec tracks the application of the locking clause of a SELECT to the
+// tables in its FROM clause. The locking clause only applies to the tables
+// referenced directly in the FROM clause; in particular, it does not apply to
+// subqueries.
+//
+// The rows are locked by the scans of the tables, which lay down intents on
+// the keys they return. This means that all lock strengths acquire exclusive
+// locks, and that rows filtered out after the scan are locked as well.
+type lockingSpec struct {
+	clause tree.LockingClause
+	// alias is the name of the table being planned in the FROM clause, if it
+	// is aliased.
+	alias tree.Name
+	// matched records the names of the targets of the clause that were found
+	// in the FROM clause.
+	matched map[tree.Name]struct{}
+}
+
+// newLockingSpec validates the locking clause of the given SELECT clause and
+// returns the lockingSpec to use for planning its FROM clause.
+func (p *planner) newLockingSpec(
+	parsed *tree.SelectClause, clause tree.LockingClause,
+) (*lockingSpec, error) {
+	if len(clause) == 0 {
+		return nil, nil
+	}
+	strength := clause[0].Strength
+	if p.EvalContext().TxnReadOnly {
+		return nil, readOnlyError("SELECT " + strength.String())
+	}
+	if p.semaCtx.AsOfTimestamp != nil {
+		return nil, errLockingNotAllowed(strength, "AS OF SYSTEM TIME")
+	}
+	if parsed.Distinct || parsed.DistinctOn != nil {
+		return nil, errLockingNotAllowed(strength, "DISTINCT clause")
+	}
+	if len(parsed.GroupBy) > 0 {
+		return nil, errLockingNotAllowed(strength, "GROUP BY clause")
+	}
+	if parsed.Having != nil {
+		return nil, errLockingNotAllowed(strength, "HAVING clause")
+	}
+	for _, item := range clause {
+		for i := range item.Targets {
+			tn, err := item.Targets[i].Normalize()
+			if err != nil {
+				return nil, err
+			}
+			if tn.ExplicitSchema || tn.ExplicitCatalog {
+				return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
+					"%s must specify unqualified relation names", item.Strength)
+			}
+		}
+	}
+	return &lockingSpec{clause: clause, matched: make(map[tree.Name]struct{})}, nil
+}
+
+func errLockingNotAllowed(strength tree.LockingStrength, what string) error {
+	return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+		"%s is not allowed with %s", strength, what)
+}
+
+// forTable returns the locking strength and wait policy that apply to the
+// table with the given name, unless it is aliased.
+func (l *lockingSpec) forTable(
+	name tree.Name,
+) (tree.LockingStrength, tree.LockingWaitPolicy) {
+	if l.alias != "" {
+		name = l.alias
+	}
+	var strength tree.LockingStrength
+	var waitPolicy tree.LockingWaitPolicy
+	for _, item := range l.clause {
+		applies := len(item.Targets) == 0
+		for i := range item.Targets {
+			if item.Targets[i].TableName().TableName == name {
+				l.matched[name] = struct{}{}
+				applies = true
+			}
+		}
+		if applies {
+			strength = strength.Max(item.Strength)
+			waitPolicy = waitPolicy.Max(item.WaitPolicy)
+		}
+	}
+	return strength, waitPolicy
+}
+
+// checkTargets returns an error if a target of the locking clause was not
+// found in the FROM clause.
+func (l *lockingSpec) checkTargets() error {
+	for _, item := range l.clause {
+		for i := range item.Targets {
+			name := item.Targets[i].TableName().TableName
+			if _, ok := l.matched[name]; !ok {
+				return pgerror.NewErrorf(pgerror.CodeUndefinedTableError,
+					"relation %q in %s clause not found in FROM clause", string(name), item.Strength)
+			}
+		}
+	}
+	return nil
+}
+
+// maybeLockScan configures the given data source, if it is a table scan, to
+// lock the rows it returns according to the locking clause being planned.
+func (p *planner) maybeLockScan(
+	ctx context.Context, ds planDataSource, desc *sqlbase.TableDescriptor, name tree.Name,
+) error {
+	if p.curLocking == nil {
+		return nil
+	}
+	scan, ok := ds.plan.(*scanNode)
+	if !ok {
+		return nil
+	}
+	scan.lockingStrength, scan.lockingWaitPolicy = p.curLocking.forTable(name)
+	if scan.lockingStrength == tree.ForNone {
+		return nil
+	}
+	// Like in Postgres, locking rows requires the UPDATE privilege.
+	return p.CheckPrivilege(ctx, desc, privilege.UPDATE)
+}
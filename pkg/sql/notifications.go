@@ -0,0 +1,431 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// This file contains the support for LISTEN and NOTIFY.
+//
+// NOTIFY inserts a row in system.notifications using the transaction of
+// the statement, so the notification becomes visible when, and only if,
+// the transaction commits. Every node runs a NotificationRegistry which
+// periodically scans the table for the rows committed since its previous
+// scan and hands them to the sessions of the node that LISTEN on their
+// channel. A session writes its notifications to the client as
+// NotificationResponse messages as soon as it is not inside a transaction,
+// including when it is idle.
+//
+// The rows are keyed by the time of the NOTIFY statement, which says
+// nothing about when its transaction commits, so every scan reads the
+// whole table. The rows whose MVCC timestamp, the commit timestamp of
+// their transaction, is not above the timestamp of the previous scan were
+// delivered by that scan.
+//
+// The registry only scans the table when some session of the node listens
+// on a channel. The rows are removed from the table once they are older
+// than sql.notifications.retention, which keeps the scans small; a
+// notification whose transaction commits after that is not delivered. The
+// table is polled rather than watched with a rangefeed because rangefeeds
+// are not enabled by default.
+
+var notificationsPollInterval = settings.RegisterNonNegativeDurationSetting(
+	"sql.notifications.poll_interval",
+	"how often each node checks for new notifications sent with NOTIFY",
+	100*time.Millisecond,
+)
+
+var notificationsRetention = settings.RegisterNonNegativeDurationSetting(
+	"sql.notifications.retention",
+	"how long the notifications sent with NOTIFY are kept before they are removed",
+	time.Minute,
+)
+
+// maxNotificationPayloadLength is the maximum length of the payload of a
+// notification, as in Postgres.
+const maxNotificationPayloadLength = 8000
+
+// maxPendingNotifications is the maximum number of notifications that can
+// wait to be delivered to a session. Further notifications are dropped.
+const maxPendingNotifications = 10000
+
+// Notification is a notification sent with NOTIFY or pg_notify().
+type Notification struct {
+	Channel string
+	Payload string
+	// NodeID is the ID of the node of the session that sent the notification.
+	// It is reported to the client in place of the process ID of the sender.
+	NodeID int32
+}
+
+// NotificationRegistry delivers the notifications sent in the cluster to
+// the sessions of this node that listen on their channel.
+type NotificationRegistry struct {
+	ambientCtx log.AmbientContext
+	settings   *cluster.Settings
+	db         *client.DB
+
+	// rf decodes the rows of system.notifications. It is only used by the
+	// polling goroutine.
+	rf    sqlbase.RowFetcher
+	alloc sqlbase.DatumAlloc
+
+	mu struct {
+		syncutil.Mutex
+		// listeners maps the channels to the sessions listening on them.
+		listeners map[string]map[*notificationListener]struct{}
+	}
+}
+
+// NewNotificationRegistry returns a NotificationRegistry.
+func NewNotificationRegistry(
+	ambientCtx log.AmbientContext, st *cluster.Settings, db *client.DB,
+) *NotificationRegistry {
+	r := &NotificationRegistry{ambientCtx: ambientCtx, settings: st, db: db}
+	r.mu.listeners = make(map[string]map[*notificationListener]struct{})
+	return r
+}
+
+// Start starts a goroutine that delivers the notifications every
+// sql.notifications.poll_interval and removes the expired ones.
+func (r *NotificationRegistry) Start(stopper *stop.Stopper) {
+	ctx := r.ambientCtx.AnnotateCtx(context.Background())
+	if err := r.initRowFetcher(); err != nil {
+		log.Fatalf(ctx, "unable to decode %s: %v", sqlbase.NotificationsTable.Name, err)
+	}
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		highWater := r.db.Clock().Now()
+		lastGC := timeutil.Now()
+		for {
+			select {
+			case <-time.After(notificationsPollInterval.Get(&r.settings.SV)):
+				var err error
+				if highWater, err = r.poll(ctx, highWater); err != nil {
+					log.Warningf(ctx, "failed to read notifications: %v", err)
+				}
+				retention := notificationsRetention.Get(&r.settings.SV)
+				if timeutil.Since(lastGC) >= retention {
+					if err := r.removeExpired(ctx, retention); err != nil {
+						log.Warningf(ctx, "failed to remove expired notifications: %v", err)
+					}
+					lastGC = timeutil.Now()
+				}
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+func (r *NotificationRegistry) initRowFetcher() error {
+	desc := &sqlbase.NotificationsTable
+	colIdxMap := make(map[sqlbase.ColumnID]int, len(desc.Columns))
+	var valNeededForCol util.FastIntSet
+	for i, col := range desc.Columns {
+		colIdxMap[col.ID] = i
+		valNeededForCol.Add(i)
+	}
+	return r.rf.Init(
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &r.alloc,
+		nil, /* evalCtx */
+		sqlbase.RowFetcherTableArgs{
+			Spans:           desc.AllIndexSpans(),
+			Desc:            desc,
+			Index:           &desc.PrimaryIndex,
+			ColIdxMap:       colIdxMap,
+			Cols:            desc.Columns,
+			ValNeededForCol: valNeededForCol,
+		},
+	)
+}
+
+// poll delivers the notifications committed after highWater and returns the
+// timestamp up to which the notifications have been delivered.
+func (r *NotificationRegistry) poll(
+	ctx context.Context, highWater hlc.Timestamp,
+) (hlc.Timestamp, error) {
+	if !r.hasListeners() {
+		// Nobody listens on this node: the notifications sent until now are
+		// not for us.
+		return r.db.Clock().Now(), nil
+	}
+
+	// The scan pushes the transactions that are writing notifications above
+	// the timestamp of the scan, so the rows that it doesn't see are
+	// committed above that timestamp. The scan uses a high priority so that
+	// it doesn't wait for these transactions.
+	span := sqlbase.NotificationsTable.PrimaryIndexSpan()
+	var rows []client.KeyValue
+	var readTS hlc.Timestamp
+	if err := r.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		if err := txn.SetUserPriority(roachpb.MaxUserPriority); err != nil {
+			return err
+		}
+		var err error
+		rows, err = txn.Scan(ctx, span.Key, span.EndKey, 0 /* maxRows */)
+		readTS = txn.OrigTimestamp()
+		return err
+	}); err != nil {
+		return highWater, err
+	}
+
+	// The rows committed at or before highWater have already been delivered.
+	var kvs sqlbase.SpanKVFetcher
+	for _, row := range rows {
+		if highWater.Less(row.Value.Timestamp) {
+			kvs.KVs = append(kvs.KVs, roachpb.KeyValue{Key: row.Key, Value: *row.Value})
+		}
+	}
+	if len(kvs.KVs) == 0 {
+		return readTS, nil
+	}
+	if err := r.rf.StartScanFrom(ctx, &kvs); err != nil {
+		return highWater, err
+	}
+	var notifications []Notification
+	for {
+		datums, _, _, err := r.rf.NextRowDecoded(ctx)
+		if err != nil {
+			return highWater, err
+		}
+		if datums == nil {
+			break
+		}
+		// The columns are createdAt, id, channel, payload and nodeID.
+		notifications = append(notifications, Notification{
+			Channel: string(tree.MustBeDString(datums[2])),
+			Payload: string(tree.MustBeDString(datums[3])),
+			NodeID:  int32(tree.MustBeDInt(datums[4])),
+		})
+	}
+	r.dispatch(ctx, notifications)
+	return readTS, nil
+}
+
+// removeExpired removes the notifications older than retention. The rows
+// are ordered by creation time, so they form a prefix of the table.
+func (r *NotificationRegistry) removeExpired(ctx context.Context, retention time.Duration) error {
+	start := roachpb.Key(sqlbase.MakeIndexKeyPrefix(
+		&sqlbase.NotificationsTable, sqlbase.NotificationsTable.PrimaryIndex.ID))
+	cutoff := tree.MakeDTimestamp(timeutil.Now().Add(-retention), time.Microsecond)
+	end, err := sqlbase.EncodeTableKey(append(roachpb.Key(nil), start...), cutoff, encoding.Ascending)
+	if err != nil {
+		return err
+	}
+	return r.db.DelRange(ctx, start, roachpb.Key(end))
+}
+
+// hasListeners returns true if some session of the node listens on a
+// channel.
+func (r *NotificationRegistry) hasListeners() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.mu.listeners) > 0
+}
+
+// dispatch hands the notifications to the sessions listening on their
+// channel.
+func (r *NotificationRegistry) dispatch(ctx context.Context, notifications []Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range notifications {
+		for l := range r.mu.listeners[n.Channel] {
+			l.enqueue(ctx, n)
+		}
+	}
+}
+
+func (r *NotificationRegistry) register(channel string, l *notificationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*notificationListener]struct{})
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+func (r *NotificationRegistry) deregister(channel string, l *notificationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.mu.listeners[channel], l)
+	if len(r.mu.listeners[channel]) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// notificationListener receives the notifications for the channels a
+// session listens on, and asks the session to deliver them to its client.
+type notificationListener struct {
+	registry *NotificationRegistry
+	// stmtBuf is the command buffer of the session, in which
+	// DeliverNotifications commands are pushed.
+	stmtBuf *StmtBuf
+
+	// channels is the set of channels the session listens on. It is only
+	// accessed by the session's goroutine.
+	channels map[string]struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// pending are the notifications that have not been delivered yet.
+		pending []Notification
+		// signaled is set while a DeliverNotifications command pushed for the
+		// pending notifications has not been executed.
+		signaled bool
+	}
+}
+
+func newNotificationListener(
+	registry *NotificationRegistry, stmtBuf *StmtBuf,
+) *notificationListener {
+	return &notificationListener{
+		registry: registry,
+		stmtBuf:  stmtBuf,
+		channels: make(map[string]struct{}),
+	}
+}
+
+// listen starts listening on channel.
+func (l *notificationListener) listen(channel string) {
+	if _, ok := l.channels[channel]; ok {
+		return
+	}
+	l.channels[channel] = struct{}{}
+	l.registry.register(channel, l)
+}
+
+// unlisten stops listening on channel.
+func (l *notificationListener) unlisten(channel string) {
+	if _, ok := l.channels[channel]; !ok {
+		return
+	}
+	delete(l.channels, channel)
+	l.registry.deregister(channel, l)
+}
+
+// unlistenAll stops listening on all the channels.
+func (l *notificationListener) unlistenAll() {
+	for channel := range l.channels {
+		l.unlisten(channel)
+	}
+}
+
+// enqueue adds a notification to the pending notifications and asks the
+// session to deliver it.
+func (l *notificationListener) enqueue(ctx context.Context, n Notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.pending) >= maxPendingNotifications {
+		log.Warningf(ctx, "too many pending notifications; dropping notification on channel %q", n.Channel)
+		return
+	}
+	l.mu.pending = append(l.mu.pending, n)
+	l.signalLocked(ctx)
+}
+
+// signal asks the session to deliver the pending notifications, if any.
+func (l *notificationListener) signal(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// A previous DeliverNotifications command may have been skipped along
+	// with the rest of a batch that encountered an error.
+	l.mu.signaled = false
+	l.signalLocked(ctx)
+}
+
+func (l *notificationListener) signalLocked(ctx context.Context) {
+	if l.mu.signaled || len(l.mu.pending) == 0 {
+		return
+	}
+	// An error means that the session is terminating.
+	if err := l.stmtBuf.Push(ctx, DeliverNotifications{}); err == nil {
+		l.mu.signaled = true
+	}
+}
+
+// clearSignal notes that the DeliverNotifications command has been executed
+// without delivering the pending notifications.
+func (l *notificationListener) clearSignal() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.signaled = false
+}
+
+// takePending returns the pending notifications and forgets them.
+func (l *notificationListener) takePending() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	l.mu.signaled = false
+	return pending
+}
+
+// listenOp is a LISTEN or UNLISTEN statement waiting for its transaction to
+// commit.
+type listenOp struct {
+	channel string
+	// unlisten is set for UNLISTEN.
+	unlisten bool
+	// all is set for UNLISTEN *.
+	all bool
+}
+
+// listenCollection accumulates the LISTEN and UNLISTEN statements of a
+// transaction. As in Postgres, they take effect when the transaction
+// commits.
+type listenCollection struct {
+	ops []listenOp
+}
+
+func (lc *listenCollection) reset() {
+	lc.ops = nil
+}
+
+// apply applies the statements of a committed transaction to the listener
+// of the session.
+func (lc *listenCollection) apply(l *notificationListener) {
+	for _, op := range lc.ops {
+		switch {
+		case op.all:
+			l.unlistenAll()
+		case op.unlisten:
+			l.unlisten(op.channel)
+		default:
+			l.listen(op.channel)
+		}
+	}
+	lc.reset()
+}
@@ -69,6 +69,15 @@ func (f *stubFactory) ConstructHashJoin(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructApplyJoin(
+	joinType sqlbase.JoinType,
+	left, right exec.Node,
+	planRightSideFn exec.ApplyJoinPlanRightSideFn,
+	onCond tree.TypedExpr,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructMergeJoin(
 	joinType sqlbase.JoinType,
 	left, right exec.Node,
@@ -107,6 +116,16 @@ func (f *stubFactory) ConstructSetOp(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, unionAll bool,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
+func (f *stubFactory) ConstructScanBuffer(ref exec.Node, label string) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructSort(
 	input exec.Node, ordering sqlbase.ColumnOrdering,
 ) (exec.Node, error) {
@@ -152,6 +152,14 @@ type Column interface {
 	// IsHidden returns true if the column is hidden (e.g., there is always a
 	// hidden column called rowid if there is no primary key on the table).
 	IsHidden() bool
+
+	// IsComputed returns true if the column is a computed column, in which
+	// case its value is derived from the other columns of the table.
+	IsComputed() bool
+
+	// ComputedExprStr returns the serialized expression that computes the
+	// value of the column, or the empty string if the column is not computed.
+	ComputedExprStr() string
 }
 
 // IndexColumn describes a single column that is part of an index definition.
@@ -249,6 +257,13 @@ type Index interface {
 	// Column returns the ith IndexColumn within the index definition, where
 	// i < ColumnCount.
 	Column(i int) IndexColumn
+
+	// Predicate returns the boolean expression of a partial index, as SQL
+	// text, along with true. A partial index only contains the rows that
+	// satisfy its predicate, so it can only be used by queries that are known
+	// to be restricted to these rows. Predicate returns false if the index is
+	// not partial.
+	Predicate() (string, bool)
 }
 
 // TableStatistic is an interface to a table statistic. Each statistic is
@@ -328,6 +343,10 @@ func formatCatalogIndex(idx Index, isPrimary bool, tp treeprinter.Node) {
 
 		child.Child(buf.String())
 	}
+
+	if pred, ok := idx.Predicate(); ok {
+		child.Childf("WHERE %s", pred)
+	}
 }
 
 func formatColumn(col Column, buf *bytes.Buffer) {
@@ -15,6 +15,7 @@
 package execbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -33,6 +34,16 @@ type Builder struct {
 	// expressions we built. Each entry is associated with a tree.Subquery
 	// expression node.
 	subqueries []exec.Subquery
+
+	// workTables maps the ID of the working table of each recursive CTE whose
+	// recursive term is being built to the node that provides its rows (see
+	// buildWorkTableScan).
+	workTables map[int]exec.Node
+
+	// outerVals maps the columns of the left side of each apply join whose right
+	// side is being built to their values in the current left row (see
+	// buildApplyJoin).
+	outerVals map[opt.ColumnID]tree.Datum
 }
 
 // New constructs an instance of the execution node builder using the
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -145,16 +146,27 @@ func (b *Builder) buildRelational(ev memo.ExprView) (execPlan, error) {
 	case opt.ZipOp:
 		ep, err = b.buildZip(ev)
 
+	case opt.RecursiveCTEOp:
+		ep, err = b.buildRecursiveCTE(ev)
+
+	case opt.WorkTableScanOp:
+		ep, err = b.buildWorkTableScan(ev)
+
 	default:
 		if ev.IsJoinNonApply() {
 			ep, err = b.buildHashJoin(ev)
 			break
 		}
 		if ev.IsJoinApply() {
-			if ev.Child(1).Operator() == opt.ZipOp {
+			if ev.Operator() == opt.InnerJoinApplyOp && ev.Child(1).Operator() == opt.ZipOp &&
+				ev.Child(2).Operator() == opt.TrueOp {
 				ep, err = b.buildProjectSet(ev)
 				break
 			}
+			if ev.Operator() == opt.InnerJoinApplyOp || ev.Operator() == opt.LeftJoinApplyOp {
+				ep, err = b.buildApplyJoin(ev)
+				break
+			}
 			return execPlan{}, b.decorrelationError()
 		}
 		return execPlan{}, errors.Errorf("unsupported relational op %s", ev.Operator())
@@ -284,6 +296,11 @@ func (b *Builder) buildScan(ev memo.ExprView) (execPlan, error) {
 		var err error
 		if idx.IsInverted() {
 			err = fmt.Errorf("index \"%s\" is inverted and cannot be used for this query", idx.IdxName())
+		} else if _, ok := idx.Predicate(); ok {
+			err = fmt.Errorf(
+				"index \"%s\" is a partial index that does not contain all the rows needed by this query",
+				idx.IdxName(),
+			)
 		} else {
 			// This should never happen.
 			err = fmt.Errorf("index \"%s\" cannot be used for this query", idx.IdxName())
@@ -439,6 +456,98 @@ func (b *Builder) buildMergeJoin(ev memo.ExprView) (execPlan, error) {
 	return ep, nil
 }
 
+// buildApplyJoin builds an inner or left apply join, i.e. a join in which the
+// right side refers to columns of the left side (e.g. a LATERAL subquery that
+// could not be decorrelated). The right side is built anew for every left row,
+// using a separate builder in which the references to the left columns are
+// replaced by the values of the left row.
+func (b *Builder) buildApplyJoin(ev memo.ExprView) (execPlan, error) {
+	var joinType sqlbase.JoinType
+	if ev.Operator() == opt.InnerJoinApplyOp {
+		joinType = sqlbase.InnerJoin
+	} else {
+		joinType = sqlbase.LeftOuterJoin
+	}
+
+	leftCols := colSetToList(ev.Child(0).Logical().Relational.OutputCols)
+	left, err := b.buildRelational(ev.Child(0))
+	if err != nil {
+		return execPlan{}, err
+	}
+	leftNode, err := b.ensureColumns(left, leftCols)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	right := ev.Child(1)
+	rightCols := colSetToList(right.Logical().Relational.OutputCols)
+	planRightSide := func(leftRow tree.Datums) (exec.Node, error) {
+		innerBld := New(b.factory, right, b.evalCtx)
+		innerBld.workTables = b.workTables
+		innerBld.outerVals = make(map[opt.ColumnID]tree.Datum, len(b.outerVals)+len(leftCols))
+		for col, val := range b.outerVals {
+			innerBld.outerVals[col] = val
+		}
+		for i, col := range leftCols {
+			innerBld.outerVals[col] = leftRow[i]
+		}
+
+		plan, err := innerBld.buildRelational(right)
+		if err != nil {
+			return nil, err
+		}
+		if len(innerBld.subqueries) > 0 {
+			// The subqueries of the right side would have to be run for every
+			// left row.
+			return nil, b.decorrelationError()
+		}
+		return innerBld.ensureColumns(plan, rightCols)
+	}
+
+	// Build the right side once with NULL values for the left columns, so that
+	// it can be described by EXPLAIN. This also makes sure that the right side
+	// can be built at all.
+	nullRow := make(tree.Datums, len(leftCols))
+	for i := range nullRow {
+		nullRow[i] = tree.DNull
+	}
+	rightNode, err := planRightSide(nullRow)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	var ep execPlan
+	for i, col := range leftCols {
+		ep.outputCols.Set(int(col), i)
+	}
+	for i, col := range rightCols {
+		ep.outputCols.Set(int(col), len(leftCols)+i)
+	}
+	ctx := buildScalarCtx{
+		ivh:     tree.MakeIndexedVarHelper(nil /* container */, ep.outputCols.Len()),
+		ivarMap: ep.outputCols,
+	}
+	onExpr, err := b.buildScalar(&ctx, ev.Child(2))
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	ep.root, err = b.factory.ConstructApplyJoin(joinType, leftNode, rightNode, planRightSide, onExpr)
+	if err != nil {
+		return execPlan{}, err
+	}
+	return ep, nil
+}
+
+// colSetToList returns the columns in the given set, in increasing order.
+func colSetToList(set opt.ColSet) opt.ColList {
+	res := make(opt.ColList, 0, set.Len())
+	for i, ok := set.Next(0); ok; i, ok = set.Next(i + 1) {
+		res = append(res, opt.ColumnID(i))
+	}
+	return res
+}
+
 // initJoinBuild builds the inputs to the join as well as the ON expression.
 func (b *Builder) initJoinBuild(
 	leftChild memo.ExprView,
@@ -986,6 +1095,67 @@ func (b *Builder) buildProjectSet(ev memo.ExprView) (execPlan, error) {
 	return ep, nil
 }
 
+func (b *Builder) buildRecursiveCTE(ev memo.ExprView) (execPlan, error) {
+	def := ev.Private().(*memo.RecursiveCTEDef)
+	initial, err := b.buildRelational(ev.Child(0))
+	if err != nil {
+		return execPlan{}, err
+	}
+	initialNode, err := b.ensureColumns(initial, def.InitialCols)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	// The recursive term is built anew for every iteration, using a separate
+	// builder in which the working table refers to the recursive CTE node.
+	recursive := ev.Child(1)
+	fn := func(bufferRef exec.Node) (exec.Node, error) {
+		innerBld := New(b.factory, recursive, b.evalCtx)
+		innerBld.workTables = make(map[int]exec.Node, len(b.workTables)+1)
+		for id, ref := range b.workTables {
+			innerBld.workTables[id] = ref
+		}
+		innerBld.workTables[def.WorkTableID] = bufferRef
+
+		plan, err := innerBld.buildRelational(recursive)
+		if err != nil {
+			return nil, err
+		}
+		if len(innerBld.subqueries) > 0 {
+			return nil, pgerror.Unimplemented("recursive cte",
+				"subqueries are not supported in the recursive term of a recursive CTE")
+		}
+		return innerBld.ensureColumns(plan, def.RecursiveCols)
+	}
+
+	node, err := b.factory.ConstructRecursiveCTE(initialNode, fn, def.Name, def.UnionAll)
+	if err != nil {
+		return execPlan{}, err
+	}
+	ep := execPlan{root: node}
+	for i, col := range def.OutCols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
+func (b *Builder) buildWorkTableScan(ev memo.ExprView) (execPlan, error) {
+	def := ev.Private().(*memo.WorkTableScanDef)
+	ref, ok := b.workTables[def.ID]
+	if !ok {
+		return execPlan{}, errors.Errorf("working table of recursive query %q not found", def.Name)
+	}
+	node, err := b.factory.ConstructScanBuffer(ref, def.Name)
+	if err != nil {
+		return execPlan{}, err
+	}
+	ep := execPlan{root: node}
+	for i, col := range def.Cols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
 // needProjection figures out what projection is needed on top of the input plan
 // to produce the given list of columns. If the input plan already produces
 // the columns (in the same order), returns needProj=false.
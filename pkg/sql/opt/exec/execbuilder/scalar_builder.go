@@ -131,6 +131,16 @@ func (b *Builder) indexedVar(
 ) tree.TypedExpr {
 	idx, ok := ctx.ivarMap.Get(int(colID))
 	if !ok {
+		if val, ok := b.outerVals[colID]; ok {
+			// This is a reference to the left side of an enclosing apply join.
+			if val == tree.DNull {
+				// Cast the NULL so that the expression has the type of the column.
+				if texpr, err := tree.ReType(val, md.ColumnType(colID)); err == nil {
+					return texpr
+				}
+			}
+			return val
+		}
 		panic(fmt.Sprintf("cannot map variable %d to an indexed var", colID))
 	}
 	return ctx.ivh.IndexedVarWithType(idx, md.ColumnType(colID))
@@ -243,7 +253,16 @@ func (b *Builder) buildFunction(ctx *buildScalarCtx, ev memo.ExprView) (tree.Typ
 		}
 	}
 	funcDef := ev.Private().(*memo.FuncOpDef)
-	funcRef := tree.WrapFunction(funcDef.Name)
+	var funcRef tree.ResolvableFunctionReference
+	if fd, ok := tree.FunDefs[funcDef.Name]; ok && &fd.FunctionProperties == funcDef.Properties {
+		funcRef = tree.WrapFunction(funcDef.Name)
+	} else {
+		// User-defined functions are not in the builtin namespace; their
+		// definition is rebuilt from the resolved overload.
+		funcRef = tree.ResolvableFunctionReference{FunctionReference: tree.NewFunctionDefinition(
+			funcDef.Name, funcDef.Properties, []tree.Overload{*funcDef.Overload},
+		)}
+	}
 	return tree.NewTypedFuncExpr(
 		funcRef,
 		0, /* aggQualifier */
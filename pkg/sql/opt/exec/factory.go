@@ -89,6 +89,22 @@ type Factory interface {
 	// using IndexedVars (first the left columns, then the right columns).
 	ConstructHashJoin(joinType sqlbase.JoinType, left, right Node, onCond tree.TypedExpr) (Node, error)
 
+	// ConstructApplyJoin returns a node that runs a join in which the right side
+	// refers to the columns of the left side. For every row of the left node,
+	// planRightSideFn is used to create a node for the right side, with the
+	// values of the left row substituted for the references to the left
+	// columns. The given right node is the right side built with NULL values in
+	// place of the left columns; it is only used to describe the query. The ON
+	// expression can refer to columns from both sides using IndexedVars (first
+	// the left columns, then the right columns). Only inner and left outer joins
+	// are supported.
+	ConstructApplyJoin(
+		joinType sqlbase.JoinType,
+		left, right Node,
+		planRightSideFn ApplyJoinPlanRightSideFn,
+		onCond tree.TypedExpr,
+	) (Node, error)
+
 	// ConstructMergeJoin returns a node that (under distsql) runs a merge join.
 	// The ON expression can refer to columns from both inputs using IndexedVars
 	// (first the left columns, then the right columns). In addition, the i-th
@@ -133,6 +149,20 @@ type Factory interface {
 	// nodes must have the same number of columns.
 	ConstructSetOp(typ tree.UnionType, all bool, left, right Node) (Node, error)
 
+	// ConstructRecursiveCTE returns a node that implements the UNION [ALL] of a
+	// recursive common table expression. The rows of the initial node are
+	// emitted first; then fn is used to create a node for every iteration of
+	// the recursive term, until an iteration produces no rows. The label is the
+	// name of the CTE.
+	ConstructRecursiveCTE(
+		initial Node, fn RecursiveCTEIterationFn, label string, unionAll bool,
+	) (Node, error)
+
+	// ConstructScanBuffer returns a node that reads the rows produced by the
+	// previous iteration of the given recursive CTE node (see
+	// RecursiveCTEIterationFn).
+	ConstructScanBuffer(ref Node, label string) (Node, error)
+
 	// ConstructSort returns a node that performs a resorting of the rows produced
 	// by the input node.
 	ConstructSort(input Node, ordering sqlbase.ColumnOrdering) (Node, error)
@@ -194,6 +224,16 @@ type Factory interface {
 	ConstructShowTrace(typ tree.ShowTraceType, compact bool) (Node, error)
 }
 
+// RecursiveCTEIterationFn creates a node for an iteration of the recursive
+// term of a recursive CTE. The given bufferRef is the recursive CTE node; it
+// can be passed to ConstructScanBuffer to read the rows produced by the
+// previous iteration.
+type RecursiveCTEIterationFn func(bufferRef Node) (Node, error)
+
+// ApplyJoinPlanRightSideFn creates a node for the right side of an apply join
+// (see ConstructApplyJoin), given a row of the left side.
+type ApplyJoinPlanRightSideFn func(leftRow tree.Datums) (Node, error)
+
 // OutputOrdering indicates the required output ordering on a Node that is being
 // created. It refers to the output columns of the node by ordinal.
 //
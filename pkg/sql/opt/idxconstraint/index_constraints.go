@@ -627,6 +627,13 @@ func (c *indexConstraintCtx) makeSpansForExpr(
 		}
 	}
 
+	// Support a boolean expression that computes the index column (e.g. an
+	// index on (@1 > 5)) as (expr = TRUE).
+	if ev.Operator() != opt.VariableOp && c.colType(offset) == types.Bool &&
+		c.isIndexColumn(ev, offset) {
+		return c.makeSpansForSingleColumnDatum(offset, opt.EqOp, tree.DBoolTrue, out)
+	}
+
 	if ev.ChildCount() < 2 {
 		c.unconstrained(offset, out)
 		return false
@@ -766,6 +773,10 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForExpr(
 			return true
 		}
 
+		if arr, ok := tree.UnwrapDatum(c.evalCtx, rightDatum).(*tree.DArray); ok {
+			return c.makeArrayContainsSpans(arr, out)
+		}
+
 		rd := rightDatum.(*tree.DJSON).JSON
 
 		switch rd.Type() {
@@ -827,6 +838,32 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForExpr(
 			return true
 		}
 
+	case opt.OverlapsOp:
+		// The operator is commutative, so the index column can be on either side.
+		col, other := ev.Child(0), ev.Child(1)
+		if !c.isIndexColumn(col, 0 /* index */) {
+			col, other = other, col
+		}
+
+		if !c.isIndexColumn(col, 0 /* index */) || !other.IsConstValue() {
+			c.unconstrained(0 /* offset */, out)
+			return false
+		}
+
+		otherDatum := memo.ExtractConstDatum(other)
+
+		if otherDatum == tree.DNull {
+			c.contradiction(0 /* offset */, out)
+			return true
+		}
+
+		arr, ok := tree.UnwrapDatum(c.evalCtx, otherDatum).(*tree.DArray)
+		if !ok {
+			c.unconstrained(0 /* offset */, out)
+			return false
+		}
+		return c.makeArrayOverlapsSpans(arr, out)
+
 	case opt.AndOp, opt.FiltersOp:
 		for i, n := 0, ev.ChildCount(); i < n; i++ {
 			tight := c.makeInvertedIndexSpansForExpr(ev.Child(i), out)
@@ -849,6 +886,69 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForExpr(
 	return false
 }
 
+// makeArrayContainsSpans generates the spans of an inverted index on an array
+// column for the condition that the column contains all the elements of the
+// given array. The index contains an entry for each distinct element of each
+// array, so the rows that contain any one of the elements are a superset of
+// the result; the spans are only tight if there is just one distinct element.
+func (c *indexConstraintCtx) makeArrayContainsSpans(
+	arr *tree.DArray, out *constraint.Constraint,
+) (tight bool) {
+	if len(arr.Array) == 0 {
+		// Every array contains the empty array.
+		c.unconstrained(0 /* offset */, out)
+		return false
+	}
+
+	first := arr.Array[0]
+	tight = true
+	for _, elem := range arr.Array {
+		if elem == tree.DNull {
+			// NULL elements are never contained in an array.
+			c.contradiction(0 /* offset */, out)
+			return true
+		}
+		if elem.Compare(c.evalCtx, first) != 0 {
+			tight = false
+		}
+	}
+
+	c.eqSpan(0 /* offset */, makeSingleElementArray(arr, first), out)
+	return tight
+}
+
+// makeArrayOverlapsSpans generates the spans of an inverted index on an array
+// column for the condition that the column has an element in common with the
+// given array: one span for each distinct element. Since a row that contains
+// several of the elements has an entry in several of the spans, the scan can
+// return the same row more than once.
+func (c *indexConstraintCtx) makeArrayOverlapsSpans(
+	arr *tree.DArray, out *constraint.Constraint,
+) (tight bool) {
+	c.contradiction(0 /* offset */, out)
+	for _, elem := range arr.Array {
+		if elem == tree.DNull {
+			// NULL elements never overlap.
+			continue
+		}
+		var elemConstraint constraint.Constraint
+		c.eqSpan(0 /* offset */, makeSingleElementArray(arr, elem), &elemConstraint)
+		out.UnionWith(c.evalCtx, &elemConstraint)
+	}
+	return true
+}
+
+// makeSingleElementArray returns an array of the same type as arr containing
+// just the given element. Such an array is encoded in an inverted index as the
+// key of the element.
+func makeSingleElementArray(arr *tree.DArray, elem tree.Datum) tree.Datum {
+	res := tree.NewDArray(arr.ParamTyp)
+	if err := res.Append(elem); err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // getMaxSimplifyPrefix finds the longest prefix (maxSimplifyPrefix) such that
 // every span has the same first maxSimplifyPrefix values for the start and end
 // key. For example, for:
@@ -1025,12 +1125,13 @@ func (ic *Instance) Init(
 	filter memo.ExprView,
 	columns []opt.OrderingColumn,
 	notNullCols opt.ColSet,
+	computedCols memo.ComputedCols,
 	isInverted bool,
 	evalCtx *tree.EvalContext,
 	factory *norm.Factory,
 ) {
 	ic.filter = filter
-	ic.indexConstraintCtx.init(columns, notNullCols, isInverted, evalCtx, factory)
+	ic.indexConstraintCtx.init(columns, notNullCols, computedCols, isInverted, evalCtx, factory)
 	if isInverted {
 		ic.tight = ic.makeInvertedIndexSpansForExpr(ic.filter, &ic.constraint)
 	} else {
@@ -1087,7 +1188,13 @@ type indexConstraintCtx struct {
 
 	notNullCols opt.ColSet
 
-	// isInverted indicates if the index is an inverted index (e.g. JSONB).
+	// computedCols maps the computed columns of the table to the groups of the
+	// expressions that compute them. An expression in the filter that is in one
+	// of these groups is treated like a reference to the corresponding column.
+	computedCols memo.ComputedCols
+
+	// isInverted indicates if the index is an inverted index (e.g. JSONB or
+	// ARRAY).
 	// An inverted index behaves differently than a normal index because a PK
 	// can appear in multiple index entries. For example, `a @> x AND a @> y` is
 	// not a contradiction because some PKs can appear in both regions of the
@@ -1105,6 +1212,7 @@ type indexConstraintCtx struct {
 func (c *indexConstraintCtx) init(
 	columns []opt.OrderingColumn,
 	notNullCols opt.ColSet,
+	computedCols memo.ComputedCols,
 	isInverted bool,
 	evalCtx *tree.EvalContext,
 	factory *norm.Factory,
@@ -1112,6 +1220,7 @@ func (c *indexConstraintCtx) init(
 	c.md = factory.Metadata()
 	c.columns = columns
 	c.notNullCols = notNullCols
+	c.computedCols = computedCols
 	c.isInverted = isInverted
 	c.evalCtx = evalCtx
 	c.factory = factory
@@ -1124,9 +1233,15 @@ func (c *indexConstraintCtx) init(
 }
 
 // isIndexColumn returns true if ev is a variable on the n indexed var that
-// corresponds to index column <offset>.
+// corresponds to index column <offset>, or if the index column is a computed
+// column and ev is the expression that computes it.
 func (c *indexConstraintCtx) isIndexColumn(ev memo.ExprView, offset int) bool {
-	return ev.Operator() == opt.VariableOp && ev.Private().(opt.ColumnID) == c.columns[offset].ID()
+	colID := c.columns[offset].ID()
+	if ev.Operator() == opt.VariableOp {
+		return ev.Private().(opt.ColumnID) == colID
+	}
+	group, ok := c.computedCols[colID]
+	return ok && ev.Group() == group
 }
 
 // isNullable returns true if the index column <offset> is nullable.
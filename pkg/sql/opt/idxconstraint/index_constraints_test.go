@@ -152,7 +152,7 @@ func TestIndexConstraints(t *testing.T) {
 				ev := f.Memo().Root()
 
 				var ic idxconstraint.Instance
-				ic.Init(ev, indexCols, notNullCols, invertedIndex, &evalCtx, &f)
+				ic.Init(ev, indexCols, notNullCols, nil /* computedCols */, invertedIndex, &evalCtx, &f)
 				result := ic.Constraint()
 				var buf bytes.Buffer
 				for i := 0; i < result.Spans.Count(); i++ {
@@ -266,7 +266,7 @@ func BenchmarkIndexConstraints(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				var ic idxconstraint.Instance
-				ic.Init(ev, indexCols, notNullCols, false /*isInverted */, &evalCtx, &f)
+				ic.Init(ev, indexCols, notNullCols, nil /* computedCols */, false /*isInverted */, &evalCtx, &f)
 				_ = ic.Constraint()
 				_ = ic.RemainingFilter()
 			}
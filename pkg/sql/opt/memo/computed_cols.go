@@ -0,0 +1,40 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package memo
+
+import "github.com/cockroachdb/cockroach/pkg/sql/opt"
+
+var computedColsAnnID = opt.NewTableAnnID()
+
+// ComputedCols maps the computed columns of a table to the memo groups of the
+// scalar expressions that compute their values. Since the memo interns
+// expressions, a filter that contains the same expression as a computed column
+// references the same group, which allows filters on the expression to be
+// used to constrain indexes on the computed column (e.g. the hidden columns
+// that back index expressions).
+type ComputedCols map[opt.ColumnID]GroupID
+
+// TableComputedCols returns the computed column expressions that were built
+// for the given table, or nil if there are none.
+func TableComputedCols(md *opt.Metadata, tabID opt.TableID) ComputedCols {
+	computed, _ := md.TableAnnotation(tabID, computedColsAnnID).(ComputedCols)
+	return computed
+}
+
+// SetTableComputedCols records the computed column expressions of the given
+// table in the metadata.
+func SetTableComputedCols(md *opt.Metadata, tabID opt.TableID, computed ComputedCols) {
+	md.SetTableAnnotation(tabID, computedColsAnnID, computed)
+}
@@ -131,7 +131,8 @@ func (ev ExprView) formatRelational(f *ExprFmtCtx, tp treeprinter.Node) {
 		formatPrivate(f, def, physProps)
 		f.Buffer.WriteByte(')')
 
-	case opt.ScanOp, opt.VirtualScanOp, opt.IndexJoinOp, opt.ShowTraceForSessionOp:
+	case opt.ScanOp, opt.VirtualScanOp, opt.IndexJoinOp, opt.ShowTraceForSessionOp,
+		opt.RecursiveCTEOp, opt.WorkTableScanOp:
 		fmt.Fprintf(f.Buffer, "%v", ev.op)
 		formatPrivate(f, ev.Private(), physProps)
 
@@ -172,6 +173,14 @@ func (ev ExprView) formatRelational(f *ExprFmtCtx, tp treeprinter.Node) {
 			colMap := ev.Private().(*SetOpColMap)
 			ev.formatColList(f, tp, "columns:", colMap.Out)
 
+		case opt.RecursiveCTEOp:
+			def := ev.Private().(*RecursiveCTEDef)
+			ev.formatColList(f, tp, "columns:", def.OutCols)
+
+		case opt.WorkTableScanOp:
+			def := ev.Private().(*WorkTableScanDef)
+			ev.formatColList(f, tp, "columns:", def.Cols)
+
 		default:
 			// Fall back to writing output columns in column id order, with
 			// best guess label.
@@ -204,6 +213,13 @@ func (ev ExprView) formatRelational(f *ExprFmtCtx, tp treeprinter.Node) {
 		ev.formatColList(f, tp, "left columns:", colMap.Left)
 		ev.formatColList(f, tp, "right columns:", colMap.Right)
 
+		// Special-case handling for recursive CTEs to show the initial and
+		// recursive input columns that correspond to the output columns.
+	case opt.RecursiveCTEOp:
+		def := ev.Private().(*RecursiveCTEDef)
+		ev.formatColList(f, tp, "initial columns:", def.InitialCols)
+		ev.formatColList(f, tp, "recursive columns:", def.RecursiveCols)
+
 	case opt.ScanOp:
 		def := ev.Private().(*ScanOpDef)
 		if def.Constraint != nil {
@@ -525,6 +541,15 @@ func formatPrivate(f *ExprFmtCtx, private interface{}, physProps *props.Physical
 		label := f.Memo.metadata.QualifiedColumnLabel(t, fullyQualify)
 		fmt.Fprintf(f.Buffer, " %s", label)
 
+	case *RecursiveCTEDef:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+		if t.UnionAll {
+			f.Buffer.WriteString(",all")
+		}
+
+	case *WorkTableScanDef:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *IndexJoinDef:
 		tab := f.Memo.metadata.Table(t.Table)
 		fmt.Fprintf(f.Buffer, " %s", tab.Name().TableName)
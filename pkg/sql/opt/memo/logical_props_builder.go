@@ -108,6 +108,12 @@ func (b *logicalPropsBuilder) buildRelationalProps(ev ExprView) props.Logical {
 	case opt.ZipOp:
 		logical = b.buildZipProps(ev)
 
+	case opt.RecursiveCTEOp:
+		logical = b.buildRecursiveCTEProps(ev)
+
+	case opt.WorkTableScanOp:
+		logical = b.buildWorkTableScanProps(ev)
+
 	default:
 		panic(fmt.Sprintf("unrecognized relational expression type: %v", ev.op))
 	}
@@ -1058,6 +1064,92 @@ func (b *logicalPropsBuilder) buildZipProps(ev ExprView) props.Logical {
 	return logical
 }
 
+func (b *logicalPropsBuilder) buildRecursiveCTEProps(ev ExprView) props.Logical {
+	logical := props.Logical{Relational: b.allocRelationalProps()}
+	relational := logical.Relational
+
+	initialProps := ev.childGroup(0).logical.Relational
+	recursiveProps := ev.childGroup(1).logical.Relational
+	def := ev.Private().(*RecursiveCTEDef)
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	relational.OutputCols = def.OutCols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// Columns have to be not-null in both inputs to be not-null in the result.
+	for i := range def.OutCols {
+		if initialProps.NotNullCols.Contains(int(def.InitialCols[i])) &&
+			recursiveProps.NotNullCols.Contains(int(def.RecursiveCols[i])) {
+			relational.NotNullCols.Add(int(def.OutCols[i]))
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns from either input are outer columns of the operator.
+	relational.OuterCols = initialProps.OuterCols.Union(recursiveProps.OuterCols)
+
+	// Functional Dependencies
+	// -----------------------
+	if !def.UnionAll {
+		// Duplicates are eliminated, so a strict key exists.
+		relational.FuncDeps.AddStrictKey(relational.OutputCols, relational.OutputCols)
+	}
+
+	// Cardinality
+	// -----------
+	// The recursion can produce any number of rows, but at least one row is
+	// returned if the initial input returns at least one row.
+	relational.Cardinality = props.AnyCardinality
+	if !initialProps.Cardinality.CanBeZero() {
+		relational.Cardinality = relational.Cardinality.AtLeast(props.OneCardinality)
+	}
+
+	// Statistics
+	// ----------
+	b.sb.init(b.evalCtx, ev.Metadata())
+	b.sb.buildRecursiveCTE(ev, relational)
+
+	return logical
+}
+
+func (b *logicalPropsBuilder) buildWorkTableScanProps(ev ExprView) props.Logical {
+	logical := props.Logical{Relational: b.allocRelationalProps()}
+	relational := logical.Relational
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	relational.OutputCols = ev.Private().(*WorkTableScanDef).Cols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// All columns are assumed to be nullable.
+
+	// Outer Columns
+	// -------------
+	// WorkTableScan operator never has outer columns.
+
+	// Functional Dependencies
+	// -----------------------
+	// WorkTableScan operator has an empty FD set.
+
+	// Cardinality
+	// -----------
+	// Don't make any assumptions about cardinality of output.
+	relational.Cardinality = props.AnyCardinality
+
+	// Statistics
+	// ----------
+	b.sb.init(b.evalCtx, ev.Metadata())
+	b.sb.buildWorkTableScan(ev, relational)
+
+	return logical
+}
+
 func (b *logicalPropsBuilder) buildScalarProps(ev ExprView) props.Logical {
 	logical := props.Logical{Scalar: b.allocScalarProps()}
 	scalar := logical.Scalar
@@ -1180,6 +1272,11 @@ func makeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			// Skip inverted indexes for now.
 			continue
 		}
+		if _, ok := index.Predicate(); ok {
+			// A partial index only guarantees uniqueness over the rows that
+			// satisfy its predicate.
+			continue
+		}
 
 		// If index has a separate lax key, add a lax key FD. Otherwise, add a
 		// strict key. See the comment for opt.Index.LaxKeyColumnCount.
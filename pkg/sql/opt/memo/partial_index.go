@@ -0,0 +1,131 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package memo
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+var partialIndexPredicatesAnnID = opt.NewTableAnnID()
+
+// PartialIndexPredicates maps the ordinals of the partial indexes of a table
+// to the memo groups of their predicates.
+type PartialIndexPredicates map[int]GroupID
+
+// TablePartialIndexPredicates returns the partial index predicates that were
+// built for the given table, or nil if there are none.
+func TablePartialIndexPredicates(md *opt.Metadata, tabID opt.TableID) PartialIndexPredicates {
+	preds, _ := md.TableAnnotation(tabID, partialIndexPredicatesAnnID).(PartialIndexPredicates)
+	return preds
+}
+
+// SetTablePartialIndexPredicates records the partial index predicates of the
+// given table in the metadata.
+func SetTablePartialIndexPredicates(
+	md *opt.Metadata, tabID opt.TableID, preds PartialIndexPredicates,
+) {
+	md.SetTableAnnotation(tabID, partialIndexPredicatesAnnID, preds)
+}
+
+// FiltersImplyPredicate returns true if every row that satisfies the given
+// filter also satisfies the given partial index predicate, in which case the
+// partial index contains all the rows needed by the filter. The check is
+// conservative: each conjunct of the predicate must either be interned as one
+// of the conjuncts of the filter, or be a tight constraint that contains the
+// constraints of the filter on the same columns.
+func FiltersImplyPredicate(
+	mem *Memo, filter, pred GroupID, evalCtx *tree.EvalContext,
+) bool {
+	filterConjuncts := collectConjuncts(MakeNormExprView(mem, filter), nil)
+	predConjuncts := collectConjuncts(MakeNormExprView(mem, pred), nil)
+
+	cb := constraintsBuilder{md: mem.Metadata(), evalCtx: evalCtx}
+	filterConstraints := unconstrained
+	for i := range filterConjuncts {
+		c, _ := cb.buildConstraints(filterConjuncts[i])
+		filterConstraints = filterConstraints.Intersect(evalCtx, c)
+	}
+	if filterConstraints == contradiction {
+		// No rows satisfy the filter.
+		return true
+	}
+
+	for _, p := range predConjuncts {
+		if conjunctsContain(filterConjuncts, p.Group()) {
+			continue
+		}
+		predConstraints, tight := cb.buildConstraints(p)
+		if !tight || predConstraints.IsUnconstrained() {
+			return false
+		}
+		if !constraintsImply(evalCtx, filterConstraints, predConstraints) {
+			return false
+		}
+	}
+	return true
+}
+
+// collectConjuncts appends the conjuncts of the given boolean expression to
+// the given list.
+func collectConjuncts(ev ExprView, conjuncts []ExprView) []ExprView {
+	switch ev.Operator() {
+	case opt.TrueOp:
+		return conjuncts
+
+	case opt.FiltersOp, opt.AndOp:
+		for i, n := 0, ev.ChildCount(); i < n; i++ {
+			conjuncts = collectConjuncts(ev.Child(i), conjuncts)
+		}
+		return conjuncts
+	}
+	return append(conjuncts, ev)
+}
+
+func conjunctsContain(conjuncts []ExprView, group GroupID) bool {
+	for i := range conjuncts {
+		if conjuncts[i].Group() == group {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintsImply returns true if, for each constraint in pred, filter has a
+// constraint on the same columns whose spans are all contained in it.
+func constraintsImply(evalCtx *tree.EvalContext, filter, pred *constraint.Set) bool {
+	for i := 0; i < pred.Length(); i++ {
+		p := pred.Constraint(i)
+		found := false
+		for j := 0; j < filter.Length() && !found; j++ {
+			f := filter.Constraint(j)
+			if !f.Columns.Equals(&p.Columns) {
+				continue
+			}
+			found = true
+			for k := 0; k < f.Spans.Count(); k++ {
+				if !p.ContainsSpan(evalCtx, f.Spans.Get(k)) {
+					found = false
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
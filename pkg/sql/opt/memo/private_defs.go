@@ -460,6 +460,43 @@ func (m *MergeOnDef) CanProvideOrdering(required *props.OrderingChoice) bool {
 	}
 }
 
+// RecursiveCTEDef defines the value of the Def private field of the
+// RecursiveCTE operator.
+type RecursiveCTEDef struct {
+	// Name is the name of the common table expression. It is used for display.
+	Name string
+
+	// WorkTableID identifies the WorkTableScan operators that read the working
+	// table of this RecursiveCTE.
+	WorkTableID int
+
+	// InitialCols and RecursiveCols are the columns produced by the Initial
+	// and Recursive inputs, respectively. They match OutCols positionally.
+	InitialCols   opt.ColList
+	RecursiveCols opt.ColList
+
+	// OutCols are the columns produced by the RecursiveCTE operator.
+	OutCols opt.ColList
+
+	// UnionAll is true if the UNION ALL form is used; otherwise, duplicate
+	// rows are discarded.
+	UnionAll bool
+}
+
+// WorkTableScanDef defines the value of the Def private field of the
+// WorkTableScan operator.
+type WorkTableScanDef struct {
+	// Name is the name of the common table expression. It is used for display.
+	Name string
+
+	// ID identifies the RecursiveCTE operator whose working table is read.
+	ID int
+
+	// Cols are the columns produced by the operator. They match the columns of
+	// the working table positionally.
+	Cols opt.ColList
+}
+
 // SubqueryDef contains information related to a subquery (Subquery, Any,
 // Exists).
 type SubqueryDef struct {
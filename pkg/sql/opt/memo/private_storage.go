@@ -453,6 +453,52 @@ func (ps *privateStorage) internRowNumberDef(def *RowNumberDef) PrivateID {
 	return ps.addValue(privateKey{iface: typ, str: ps.keyBuf.String()}, def)
 }
 
+// internRecursiveCTEDef adds the given value to storage and returns an id that
+// can later be used to retrieve the value by calling the lookup method. If the
+// value has been previously added to storage, then internRecursiveCTEDef
+// always returns the same private id that was returned from the previous call.
+func (ps *privateStorage) internRecursiveCTEDef(def *RecursiveCTEDef) PrivateID {
+	// The below code is carefully constructed to not allocate in the case where
+	// the value is already in the map. Be careful when modifying.
+	ps.keyBuf.Reset()
+	ps.keyBuf.writeUvarint(uint64(def.WorkTableID))
+	if def.UnionAll {
+		ps.keyBuf.WriteByte(1)
+	} else {
+		ps.keyBuf.WriteByte(0)
+	}
+	// The column lists are always the same length, so no separators are
+	// needed.
+	ps.keyBuf.writeColList(def.InitialCols)
+	ps.keyBuf.writeColList(def.RecursiveCols)
+	ps.keyBuf.writeColList(def.OutCols)
+	ps.keyBuf.WriteString(def.Name)
+	typ := (*RecursiveCTEDef)(nil)
+	if id, ok := ps.privatesMap[privateKey{iface: typ, str: ps.keyBuf.String()}]; ok {
+		return id
+	}
+	return ps.addValue(privateKey{iface: typ, str: ps.keyBuf.String()}, def)
+}
+
+// internWorkTableScanDef adds the given value to storage and returns an id
+// that can later be used to retrieve the value by calling the lookup method.
+// If the value has been previously added to storage, then
+// internWorkTableScanDef always returns the same private id that was returned
+// from the previous call.
+func (ps *privateStorage) internWorkTableScanDef(def *WorkTableScanDef) PrivateID {
+	// The below code is carefully constructed to not allocate in the case where
+	// the value is already in the map. Be careful when modifying.
+	ps.keyBuf.Reset()
+	ps.keyBuf.writeUvarint(uint64(def.ID))
+	ps.keyBuf.writeColList(def.Cols)
+	ps.keyBuf.WriteString(def.Name)
+	typ := (*WorkTableScanDef)(nil)
+	if id, ok := ps.privatesMap[privateKey{iface: typ, str: ps.keyBuf.String()}]; ok {
+		return id
+	}
+	return ps.addValue(privateKey{iface: typ, str: ps.keyBuf.String()}, def)
+}
+
 func (ps *privateStorage) internSubqueryDef(def *SubqueryDef) PrivateID {
 	ps.keyBuf.Reset()
 	ps.keyBuf.writeUvarint(uint64(uintptr(unsafe.Pointer(def.OriginalExpr))))
@@ -497,6 +543,10 @@ func (ps *privateStorage) internDatum(datum tree.Datum) PrivateID {
 	// using the reflect.Type of the value.
 	ps.keyBuf.Reset()
 	datum.Format(&ps.datumCtx)
+	if enum, ok := datum.(*tree.DEnum); ok {
+		// Values of different enum types can have the same label.
+		ps.keyBuf.writeUvarint(uint64(enum.EnumTyp.TypeID))
+	}
 	typ := reflect.TypeOf(datum)
 	id, ok := ps.privatesMap[privateKey{iface: typ, str: ps.keyBuf.String()}]
 	if ok {
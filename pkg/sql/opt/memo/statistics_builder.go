@@ -299,7 +299,7 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, ev ExprView) *props.Colu
 	case opt.ZipOp:
 		return sb.colStatZip(colSet, ev)
 
-	case opt.ExplainOp, opt.ShowTraceForSessionOp:
+	case opt.ExplainOp, opt.ShowTraceForSessionOp, opt.RecursiveCTEOp, opt.WorkTableScanOp:
 		relProps := ev.Logical().Relational
 		return sb.colStatLeaf(colSet, &relProps.Stats, &relProps.FuncDeps)
 	}
@@ -1195,6 +1195,43 @@ func (sb *statisticsBuilder) colStatZip(colSet opt.ColSet, ev ExprView) *props.C
 	return colStat
 }
 
+// +--------------+
+// | RecursiveCTE |
+// +--------------+
+
+func (sb *statisticsBuilder) buildRecursiveCTE(ev ExprView, relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+
+	// The number of iterations is unknown, so assume that the recursive input
+	// contributes as many rows as an unknown table.
+	initialStats := &ev.childGroup(0).logical.Relational.Stats
+	s.RowCount = initialStats.RowCount + unknownRowCount
+
+	sb.finalizeFromCardinality(relProps)
+}
+
+// +---------------+
+// | WorkTableScan |
+// +---------------+
+
+func (sb *statisticsBuilder) buildWorkTableScan(ev ExprView, relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+
+	// The size of the working table varies with every iteration; don't make
+	// any assumptions about it.
+	s.RowCount = unknownRowCount
+
+	sb.finalizeFromCardinality(relProps)
+}
+
 /////////////////////////////////////////////////
 // General helper functions for building stats //
 /////////////////////////////////////////////////
@@ -96,6 +96,10 @@ type Builder struct {
 	// subquery contains a pointer to the subquery which is currently being built
 	// (if any).
 	subquery *subquery
+
+	// numWorkTables is the number of recursive CTEs built so far. It is used
+	// to assign a unique ID to the working table of each recursive CTE.
+	numWorkTables int
 }
 
 // New creates a new Builder structure initialized with the given
@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
 )
 
 // groupby information stored in scopes.
@@ -81,6 +82,17 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets contains the grouping sets of a GROUP BY clause with
+	// ROLLUP, CUBE or GROUPING SETS. Each set contains the ordinals (in
+	// groupings) of the grouping columns that are part of the set. It is nil
+	// for a regular GROUP BY clause. See buildGroupingSets for more details.
+	groupingSets []util.FastIntSet
+
+	// groupingSetCol is the grouping column which holds the ordinal of the
+	// grouping set of each row. It is only set if there are multiple grouping
+	// sets.
+	groupingSetCol opt.ColumnID
 }
 
 // aggregateInfo stores information about an aggregation function call.
@@ -286,12 +298,18 @@ func (b *Builder) buildAggregation(
 		groupingColSet.Add(int(groupingCols[i].id))
 	}
 
-	aggOutScope.group = b.constructGroupBy(
-		aggInScope.group,
-		groupingColSet,
-		aggCols,
-		aggInScope.ordering,
-	)
+	if fromScope.groupby.hasEmptyGroupingSet() {
+		aggOutScope.group = b.constructGroupingSetsUnion(
+			fromScope, aggInScope, groupingColSet, aggCols,
+		)
+	} else {
+		aggOutScope.group = b.constructGroupBy(
+			aggInScope.group,
+			groupingColSet,
+			aggCols,
+			aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != 0 {
@@ -348,14 +366,19 @@ func (b *Builder) buildGroupingList(
 	}
 
 	inScope.startBuildingGroupingCols()
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, inScope, outScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, inScope, outScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, inScope, outScope)
+		}
 	}
 	inScope.endBuildingGroupingCols()
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
-// expression.
+// expression. It returns the ordinals (in inScope.groupby.groupings) of the
+// grouping columns for the expression.
 //
 // groupBy  The given GROUP BY expression.
 // selects  The select expressions are needed in case the GROUP BY expression
@@ -364,7 +387,7 @@ func (b *Builder) buildGroupingList(
 // See Builder.buildStmt for a description of the remaining input values.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, inScope, outScope *scope,
-) {
+) (ordinals util.FastIntSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 
@@ -391,14 +414,23 @@ func (b *Builder) buildGrouping(
 
 	// Finally, build each of the GROUP BY columns.
 	for _, e := range exprs {
+		// A repeated GROUP BY expression maps to the existing grouping column.
+		exprStr := symbolicExprStr(e)
+		if col, ok := inScope.groupby.groupStrs[exprStr]; ok {
+			ordinals.Add(inScope.groupingOrdinal(outScope, col.id))
+			continue
+		}
+
 		// Save a representation of the GROUP BY expression for validation of the
 		// SELECT and HAVING expressions. This enables queries such as:
 		//   SELECT x+y FROM t GROUP BY x+y
 		col := b.addColumn(outScope, label, e.ResolvedType(), e)
 		b.buildScalar(e, inScope, outScope, col, nil)
-		inScope.groupby.groupStrs[symbolicExprStr(e)] = col
+		inScope.groupby.groupStrs[exprStr] = col
+		ordinals.Add(len(inScope.groupby.groupings))
 		inScope.groupby.groupings = append(inScope.groupby.groupings, col.group)
 	}
+	return ordinals
 }
 
 // buildAggregateFunction is called when we are building a function which is an
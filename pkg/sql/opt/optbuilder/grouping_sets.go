@@ -0,0 +1,466 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+)
+
+const (
+	// maxCubeElements is the maximum number of elements in a CUBE clause (which
+	// expands to 2^n grouping sets).
+	maxCubeElements = 12
+
+	// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+	// clause can expand to.
+	maxGroupingSets = 4096
+
+	// maxGroupingArgs is the maximum number of arguments to GROUPING(); the
+	// result is a bit mask which must fit in an INT.
+	maxGroupingArgs = 63
+
+	// groupingSetColName is the name of the hidden column which holds the
+	// ordinal of the grouping set of each row.
+	groupingSetColName = "grouping_set"
+)
+
+// hasGroupingSets returns true if the given GROUP BY clause contains a
+// ROLLUP, CUBE or GROUPING SETS element.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := tree.StripParens(e).(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds the grouping columns of a GROUP BY clause which
+// contains ROLLUP, CUBE or GROUPING SETS elements, and computes the list of
+// grouping sets it expands to. For example:
+//
+//   GROUP BY a, ROLLUP (b, c)
+//
+// expands to the grouping sets (a, b, c), (a, b) and (a).
+//
+// Each distinct grouping expression becomes a single grouping column. If there
+// are multiple grouping sets, the query is evaluated as a single GroupBy
+// operator over the input cross joined with the list of grouping set
+// ordinals; the ordinal becomes an additional grouping column, and each
+// grouping column is replaced with NULL in the rows of the sets that don't
+// contain it. For the example above, this looks like:
+//
+//   SELECT ... FROM
+//     (SELECT a,
+//             CASE WHEN grouping_set IN (0, 1) THEN b END AS b,
+//             CASE WHEN grouping_set IN (0) THEN c END AS c,
+//             grouping_set
+//      FROM t, (VALUES (0), (1), (2)) AS v(grouping_set))
+//   GROUP BY a, b, c, grouping_set
+//
+// The empty grouping set must produce a row even if the input is empty, so it
+// is handled separately by constructGroupingSetsUnion.
+//
+// See Builder.buildStmt for a description of the remaining input values.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, inScope, outScope *scope,
+) {
+	// The grouping sets of the clause are the cartesian product of the grouping
+	// sets of each element.
+	sets := []util.FastIntSet{{}}
+	for _, e := range groupBy {
+		elemSets := b.buildGroupingSetsElem(e, selects, inScope, outScope)
+		product := make([]util.FastIntSet, 0, len(sets)*len(elemSets))
+		for _, s := range sets {
+			for _, es := range elemSets {
+				product = append(product, s.Union(es))
+			}
+		}
+		sets = product
+		if len(sets) > maxGroupingSets {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeStatementTooComplexError,
+				"too many grouping sets present (maximum %d)", maxGroupingSets)})
+		}
+	}
+	inScope.groupby.groupingSets = sets
+	if len(sets) == 1 {
+		// A single grouping set is equivalent to a regular GROUP BY.
+		return
+	}
+
+	// Cross join the input with the ordinals of the grouping sets. The empty
+	// grouping sets are handled by constructGroupingSetsUnion, which only needs
+	// the first of them to be present.
+	var ords []int
+	emptySeen := false
+	for i := range sets {
+		if sets[i].Empty() {
+			if emptySeen {
+				continue
+			}
+			emptySeen = true
+		}
+		ords = append(ords, i)
+	}
+	values, setCol := b.constructGroupingSetValues(ords)
+	inScope.group = b.factory.ConstructInnerJoin(inScope.group, values, b.factory.ConstructTrue())
+
+	groupingsLen := len(inScope.groupby.groupings)
+	outScope.cols = append(outScope.cols, scopeColumn{
+		name:   groupingSetColName,
+		typ:    types.Int,
+		id:     setCol,
+		hidden: true,
+	})
+	inScope.groupby.groupings = append(inScope.groupby.groupings, 0)
+	inScope.groupby.groupingSetCol = setCol
+
+	// Replace each grouping column with NULL in the rows of the sets which don't
+	// contain it. Columns which are part of all the non-empty sets don't need to
+	// be replaced, since the empty sets are aggregated separately.
+	setVar := b.factory.ConstructVariable(b.factory.InternColumnID(setCol))
+	groupingCols := outScope.getGroupingCols(groupingsLen + 1)[:groupingsLen]
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		var contained []memo.GroupID
+		masked := false
+		for j := range sets {
+			if sets[j].Contains(i) {
+				contained = append(contained, b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(j))))
+			} else if !sets[j].Empty() {
+				masked = true
+			}
+		}
+		if !masked {
+			continue
+		}
+
+		expr := col.group
+		if expr == 0 {
+			expr = b.factory.ConstructVariable(b.factory.InternColumnID(col.id))
+		}
+		typs := make([]types.T, len(contained))
+		for j := range typs {
+			typs[j] = types.Int
+		}
+		cond := b.factory.ConstructIn(setVar, b.factory.ConstructTuple(
+			b.factory.InternList(contained), b.factory.InternType(types.TTuple{Types: typs}),
+		))
+		whens := []memo.GroupID{
+			b.factory.ConstructWhen(cond, expr),
+			b.factory.ConstructNull(b.factory.InternType(col.typ)),
+		}
+		oldID := col.id
+		b.populateSynthesizedColumn(col, b.factory.ConstructCase(
+			b.factory.ConstructTrue(), b.factory.InternList(whens),
+		))
+
+		// The GROUP BY expressions must now refer to the new column.
+		for str, c := range inScope.groupby.groupStrs {
+			if c.id == oldID {
+				inScope.groupby.groupStrs[str] = col
+			}
+		}
+	}
+}
+
+// buildGroupingSetsElem builds the grouping columns of an element of a GROUP
+// BY clause and returns the grouping sets it expands to.
+func (b *Builder) buildGroupingSetsElem(
+	e tree.Expr, selects tree.SelectExprs, inScope, outScope *scope,
+) []util.FastIntSet {
+	gs, ok := tree.StripParens(e).(*tree.GroupingSet)
+	if !ok {
+		return []util.FastIntSet{b.buildGrouping(e, selects, inScope, outScope)}
+	}
+
+	switch gs.Type {
+	case tree.RollupGrouping:
+		// ROLLUP (a, b, c) expands to (a, b, c), (a, b), (a) and ().
+		elems := b.buildGroupingSetsExprs(gs.Exprs, selects, inScope, outScope)
+		sets := make([]util.FastIntSet, len(elems)+1)
+		for i := range sets {
+			for j := 0; j < len(elems)-i; j++ {
+				sets[i].UnionWith(elems[j])
+			}
+		}
+		return sets
+
+	case tree.CubeGrouping:
+		// CUBE (a, b) expands to (a, b), (a), (b) and ().
+		if len(gs.Exprs) > maxCubeElements {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeProgramLimitExceededError,
+				"CUBE is limited to %d elements", maxCubeElements)})
+		}
+		elems := b.buildGroupingSetsExprs(gs.Exprs, selects, inScope, outScope)
+		sets := make([]util.FastIntSet, 1<<uint(len(elems)))
+		for i := range sets {
+			// Iterate from the full set to the empty set; element 0 corresponds to
+			// the most significant bit.
+			mask := len(sets) - 1 - i
+			for j := range elems {
+				if mask&(1<<uint(len(elems)-1-j)) != 0 {
+					sets[i].UnionWith(elems[j])
+				}
+			}
+		}
+		return sets
+
+	case tree.SetsGrouping:
+		var sets []util.FastIntSet
+		for _, expr := range gs.Exprs {
+			sets = append(sets, b.buildGroupingSetsElem(expr, selects, inScope, outScope)...)
+			if len(sets) > maxGroupingSets {
+				panic(builderError{pgerror.NewErrorf(pgerror.CodeStatementTooComplexError,
+					"too many grouping sets present (maximum %d)", maxGroupingSets)})
+			}
+		}
+		return sets
+
+	default:
+		panic(fmt.Errorf("unknown grouping set type: %v", gs.Type))
+	}
+}
+
+// buildGroupingSetsExprs builds the grouping columns of the elements of a
+// ROLLUP or CUBE clause, and returns the set of grouping column ordinals for
+// each of them. An element can be a tuple of expressions, in which case it is
+// treated as a single unit.
+func (b *Builder) buildGroupingSetsExprs(
+	exprs tree.Exprs, selects tree.SelectExprs, inScope, outScope *scope,
+) []util.FastIntSet {
+	elems := make([]util.FastIntSet, len(exprs))
+	for i, e := range exprs {
+		elems[i] = b.buildGrouping(e, selects, inScope, outScope)
+	}
+	return elems
+}
+
+// constructGroupingSetValues constructs a Values operator with a single INT
+// column that contains the given grouping set ordinals. It returns the
+// operator and the ID of its column.
+func (b *Builder) constructGroupingSetValues(ords []int) (memo.GroupID, opt.ColumnID) {
+	col := b.factory.Metadata().AddColumn(groupingSetColName, types.Int)
+	rows := make([]memo.GroupID, len(ords))
+	typ := b.factory.InternType(types.TTuple{Types: []types.T{types.Int}})
+	for i, ord := range ords {
+		elem := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(ord)))
+		rows[i] = b.factory.ConstructTuple(b.factory.InternList([]memo.GroupID{elem}), typ)
+	}
+	values := b.factory.ConstructValues(
+		b.factory.InternList(rows), b.factory.InternColList(opt.ColList{col}),
+	)
+	return values, col
+}
+
+// hasEmptyGroupingSet returns true if there are multiple grouping sets and at
+// least one of them is empty.
+func (g *groupby) hasEmptyGroupingSet() bool {
+	if len(g.groupingSets) < 2 {
+		return false
+	}
+	for i := range g.groupingSets {
+		if g.groupingSets[i].Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// groupingOrdinal returns the ordinal (in s.groupby.groupings) of the grouping
+// column with the given ID, which has already been added to outScope.
+func (s *scope) groupingOrdinal(outScope *scope, id opt.ColumnID) int {
+	cols := outScope.getGroupingCols(len(s.groupby.groupings))
+	for i := range cols {
+		if cols[i].id == id {
+			return i
+		}
+	}
+	panic(fmt.Errorf("grouping column %d not found", id))
+}
+
+// constructGroupingSetsUnion constructs the aggregation for a GROUP BY clause
+// with multiple grouping sets, at least one of which is empty. The non-empty
+// sets are computed by a GroupBy operator, as described in buildGroupingSets.
+// The empty sets are computed by a ScalarGroupBy operator over the rows of the
+// first empty set, so that they produce a row even if the input is empty; the
+// result is then cross joined with the ordinals of all the empty sets, and NULL
+// is projected for each grouping column:
+//
+//   SELECT a, b, count(*), grouping_set FROM ... WHERE grouping_set <> 2
+//   GROUP BY a, b, grouping_set
+//   UNION ALL
+//   SELECT NULL, NULL, count, grouping_set
+//   FROM (SELECT count(*) FROM ... WHERE grouping_set = 2),
+//        (VALUES (2)) AS v(grouping_set)
+//
+// The output columns of the union are the grouping columns and the aggregate
+// columns of the GroupBy operator.
+func (b *Builder) constructGroupingSetsUnion(
+	fromScope, aggInScope *scope, groupingColSet opt.ColSet, aggCols []scopeColumn,
+) memo.GroupID {
+	g := &fromScope.groupby
+	emptyOrd := -1
+	var emptyOrds []int
+	for i := range g.groupingSets {
+		if g.groupingSets[i].Empty() {
+			if emptyOrd == -1 {
+				emptyOrd = i
+			}
+			emptyOrds = append(emptyOrds, i)
+		}
+	}
+	setVar := b.factory.ConstructVariable(b.factory.InternColumnID(g.groupingSetCol))
+	emptyConst := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(emptyOrd)))
+	filter := func(cond memo.GroupID) memo.GroupID {
+		return b.factory.ConstructSelect(
+			aggInScope.group,
+			b.factory.ConstructFilters(b.factory.InternList([]memo.GroupID{cond})),
+		)
+	}
+
+	// Build the aggregation of the non-empty sets.
+	left := b.constructGroupBy(
+		filter(b.factory.ConstructNe(setVar, emptyConst)),
+		groupingColSet,
+		aggCols,
+		aggInScope.ordering,
+	)
+
+	// Build the aggregation of the empty sets, using new columns for the
+	// aggregates.
+	var leftCols, rightCols opt.ColList
+	var rightAggCols []scopeColumn
+	var seen opt.ColSet
+	for i := range aggCols {
+		if seen.Contains(int(aggCols[i].id)) {
+			continue
+		}
+		seen.Add(int(aggCols[i].id))
+		col := aggCols[i]
+		col.id = b.factory.Metadata().AddColumn(string(col.name), col.typ)
+		rightAggCols = append(rightAggCols, col)
+		leftCols = append(leftCols, aggCols[i].id)
+		rightCols = append(rightCols, col.id)
+	}
+	right := b.constructGroupBy(
+		filter(b.factory.ConstructEq(setVar, emptyConst)),
+		opt.ColSet{},
+		rightAggCols,
+		aggInScope.ordering,
+	)
+	values, setCol := b.constructGroupingSetValues(emptyOrds)
+	right = b.factory.ConstructInnerJoin(right, values, b.factory.ConstructTrue())
+
+	// Project NULL for each of the grouping columns.
+	projCols := make([]scopeColumn, len(rightAggCols), len(rightAggCols)+len(g.groupings))
+	for i := range rightAggCols {
+		projCols[i] = rightAggCols[i]
+		projCols[i].group = 0
+	}
+	groupingCols := aggInScope.getGroupingCols(len(g.groupings))
+	for i := range groupingCols {
+		col := groupingCols[i]
+		col.group = 0
+		if col.id == g.groupingSetCol {
+			col.id = setCol
+		} else {
+			col.id = b.factory.Metadata().AddColumn(string(col.name), col.typ)
+			col.group = b.factory.ConstructNull(b.factory.InternType(col.typ))
+		}
+		projCols = append(projCols, col)
+		leftCols = append(leftCols, groupingCols[i].id)
+		rightCols = append(rightCols, col.id)
+	}
+	right = b.constructProject(right, projCols)
+
+	private := b.factory.InternSetOpColMap(&memo.SetOpColMap{
+		Left: leftCols, Right: rightCols, Out: leftCols,
+	})
+	return b.factory.ConstructUnionAll(left, right, private)
+}
+
+// buildGroupingFunc builds a call to the GROUPING() function, which returns a
+// bit mask indicating which of its arguments are not part of the grouping set
+// of the current row. The rightmost argument corresponds to the least
+// significant bit. For example, in:
+//
+//   SELECT a, b, grouping(a, b) FROM t GROUP BY ROLLUP (a, b)
+//
+// the result is 0 for the rows of the set (a, b), 1 for the rows of the set
+// (a) and 3 for the row of the empty set.
+//
+// See Builder.buildStmt for a description of the remaining input and return
+// values.
+func (b *Builder) buildGroupingFunc(
+	f *tree.FuncExpr, inScope, outScope *scope, outCol *scopeColumn, colRefs *opt.ColSet,
+) (out memo.GroupID) {
+	if !inScope.inGroupingContext() || inScope.groupby.inAgg ||
+		inScope.groupby.buildingGroupingCols {
+		panic(builderError{pgerror.NewErrorf(pgerror.CodeGroupingError,
+			"arguments to GROUPING must be grouping expressions of the associated query level")})
+	}
+	if len(f.Exprs) > maxGroupingArgs {
+		panic(builderError{pgerror.NewErrorf(pgerror.CodeProgramLimitExceededError,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1)})
+	}
+
+	g := &inScope.groupby
+	aggOutScope := g.aggOutScope
+	ords := make([]int, len(f.Exprs))
+	for i, e := range f.Exprs {
+		texpr := tree.StripParens(e).(tree.TypedExpr)
+		col, ok := g.groupStrs[symbolicExprStr(texpr)]
+		if !ok {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeGroupingError,
+				"arguments to GROUPING must be grouping expressions of the associated query level")})
+		}
+		ords[i] = inScope.groupingOrdinal(aggOutScope, col.id)
+	}
+
+	// Compute the result for each grouping set.
+	sets := g.groupingSets
+	if len(sets) < 2 {
+		// All the grouping columns are part of the only grouping set.
+		out = b.factory.ConstructConstVal(tree.NewDInt(0))
+		return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	}
+	whens := make([]memo.GroupID, 0, len(sets)+1)
+	for i := range sets {
+		var mask int64
+		for j, ord := range ords {
+			if !sets[i].Contains(ord) {
+				mask |= 1 << uint(len(ords)-1-j)
+			}
+		}
+		whens = append(whens, b.factory.ConstructWhen(
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i))),
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(mask))),
+		))
+	}
+	whens = append(whens, b.factory.ConstructNull(b.factory.InternType(types.Int)))
+
+	setCol := aggOutScope.getColumn(g.groupingSetCol)
+	input := b.finishBuildScalarRef(setCol, aggOutScope, nil, nil, colRefs)
+	out = b.factory.ConstructCase(input, b.factory.InternList(whens))
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
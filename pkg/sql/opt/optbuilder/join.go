@@ -34,19 +34,35 @@ import (
 // return values.
 func (b *Builder) buildJoin(join *tree.JoinTableExpr, inScope *scope) (outScope *scope) {
 	leftScope := b.buildDataSource(join.Left, nil /* indexFlags */, inScope)
-	rightScope := b.buildDataSource(join.Right, nil /* indexFlags */, inScope)
+
+	var rightScope *scope
+	isLateral := isLateralTable(join.Right)
+	if isLateral {
+		rightScope = b.buildLateralDataSource(join.Right, leftScope, inScope)
+	} else {
+		rightScope = b.buildDataSource(join.Right, nil /* indexFlags */, inScope)
+	}
 
 	// Check that the same table name is not used on both sides.
 	b.validateJoinTableNames(leftScope, rightScope)
 
 	joinType := sqlbase.JoinTypeFromAstString(join.Join)
+	if isLateral && joinType != sqlbase.InnerJoin && joinType != sqlbase.LeftOuterJoin {
+		// The right side of a RIGHT or FULL join is not evaluated for each row of
+		// the left side, so it cannot refer to its columns.
+		if b.outerCols(rightScope.group).Intersects(leftScope.colSet()) {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeInvalidColumnReferenceError,
+				"the combining JOIN type must be INNER or LEFT for a LATERAL reference")})
+		}
+		isLateral = false
+	}
 
 	switch cond := join.Cond.(type) {
 	case tree.NaturalJoinCond, *tree.UsingJoinCond:
 		outScope = inScope.push()
 
 		var jb usingJoinBuilder
-		jb.init(b, joinType, leftScope, rightScope, outScope)
+		jb.init(b, joinType, isLateral, leftScope, rightScope, outScope)
 
 		switch t := cond.(type) {
 		case tree.NaturalJoinCond:
@@ -75,7 +91,9 @@ func (b *Builder) buildJoin(join *tree.JoinTableExpr, inScope *scope) (outScope
 			filter = b.factory.ConstructTrue()
 		}
 
-		outScope.group = b.constructJoin(joinType, leftScope.group, rightScope.group, filter)
+		outScope.group = b.constructJoin(
+			joinType, isLateral, leftScope.group, rightScope.group, filter,
+		)
 		return outScope
 
 	default:
@@ -83,6 +101,44 @@ func (b *Builder) buildJoin(join *tree.JoinTableExpr, inScope *scope) (outScope
 	}
 }
 
+// buildLateralDataSource builds a LATERAL data source, which can refer to the
+// columns of the data sources that precede it in the FROM clause. Those
+// columns are contained in leftScope.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildLateralDataSource(
+	texpr tree.TableExpr, leftScope, inScope *scope,
+) (outScope *scope) {
+	// Build the data source in a scope that contains the columns of the left
+	// side, so that they can be referenced as outer columns. The scope is a copy
+	// of leftScope, so that the lateral data source does not affect it.
+	lateralScope := inScope.push()
+	lateralScope.appendColumnsFromScope(leftScope)
+
+	outScope = b.buildDataSource(texpr, nil /* indexFlags */, lateralScope)
+
+	if lateralScope.groupby.aggInScope != nil {
+		// An aggregate function in the lateral data source refers only to the
+		// columns of the left side.
+		panic(builderError{pgerror.NewErrorf(pgerror.CodeGroupingError,
+			"aggregate functions are not allowed in FROM clause of their own query level")})
+	}
+	return outScope
+}
+
+// isLateralTable returns true if the given table expression is a LATERAL data
+// source.
+func isLateralTable(texpr tree.TableExpr) bool {
+	t, ok := texpr.(*tree.AliasedTableExpr)
+	return ok && t.Lateral
+}
+
+// outerCols returns the outer columns of the given relational expression.
+func (b *Builder) outerCols(group memo.GroupID) opt.ColSet {
+	return memo.MakeNormExprView(b.factory.Memo(), group).Logical().Relational.OuterCols
+}
+
 // validateJoinTableNames checks that table names are not repeated between the
 // left and right sides of a join. leftTables contains a pre-built map of the
 // tables from the left side of the join, and rightScope contains the
@@ -138,9 +194,23 @@ func (b *Builder) findJoinColsToValidate(scope *scope) util.FastIntSet {
 	return ords
 }
 
+// constructJoin constructs a join of the given type. If isLateral is true, the
+// right input can refer to columns of the left input, and an apply join is
+// constructed (only inner and left joins are allowed in this case).
 func (b *Builder) constructJoin(
-	joinType sqlbase.JoinType, left, right, filter memo.GroupID,
+	joinType sqlbase.JoinType, isLateral bool, left, right, filter memo.GroupID,
 ) memo.GroupID {
+	if isLateral {
+		switch joinType {
+		case sqlbase.InnerJoin:
+			return b.factory.ConstructInnerJoinApply(left, right, filter)
+		case sqlbase.LeftOuterJoin:
+			return b.factory.ConstructLeftJoinApply(left, right, filter)
+		default:
+			panic(fmt.Errorf("unsupported LATERAL JOIN type %d", joinType))
+		}
+	}
+
 	switch joinType {
 	case sqlbase.InnerJoin:
 		return b.factory.ConstructInnerJoin(left, right, filter)
@@ -224,6 +294,10 @@ type usingJoinBuilder struct {
 	rightScope *scope
 	outScope   *scope
 
+	// isLateral is true if the right input can refer to columns of the left
+	// input (see Builder.constructJoin).
+	isLateral bool
+
 	// hideCols contains the ids of join columns which are hidden in the result
 	// expression.
 	hideCols opt.ColSet
@@ -238,11 +312,12 @@ type usingJoinBuilder struct {
 }
 
 func (jb *usingJoinBuilder) init(
-	b *Builder, joinType sqlbase.JoinType, leftScope, rightScope, outScope *scope,
+	b *Builder, joinType sqlbase.JoinType, isLateral bool, leftScope, rightScope, outScope *scope,
 ) {
 	jb.b = b
 	jb.lb = norm.MakeListBuilder(b.factory.CustomFuncs())
 	jb.joinType = joinType
+	jb.isLateral = isLateral
 	jb.leftScope = leftScope
 	jb.rightScope = rightScope
 	jb.outScope = outScope
@@ -310,6 +385,7 @@ func (jb *usingJoinBuilder) finishBuild() {
 
 	jb.outScope.group = jb.b.constructJoin(
 		jb.joinType,
+		jb.isLateral,
 		jb.leftScope.group,
 		jb.rightScope.group,
 		jb.b.factory.ConstructFilters(jb.lb.BuildList()),
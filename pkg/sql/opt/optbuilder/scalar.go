@@ -69,6 +69,7 @@ var comparisonOpMap = [tree.NumComparisonOperators]binaryFactoryFunc{
 	tree.JSONExists:     (*norm.Factory).ConstructJsonExists,
 	tree.JSONAllExists:  (*norm.Factory).ConstructJsonAllExists,
 	tree.JSONSomeExists: (*norm.Factory).ConstructJsonSomeExists,
+	tree.Overlaps:       (*norm.Factory).ConstructOverlaps,
 }
 
 // Map from tree.BinaryOperator to Factory constructor function.
@@ -494,6 +495,9 @@ func (b *Builder) buildFunction(
 		}
 		panic(unimplementedf("window functions are not supported"))
 	}
+	if f.IsOrderedSetAggregate() {
+		panic(unimplementedf("ordered-set aggregates are not supported"))
+	}
 
 	def, err := f.Func.Resolve(b.semaCtx.SearchPath)
 	if err != nil {
@@ -511,6 +515,15 @@ func (b *Builder) buildFunction(
 		panic("aggregate function should have been replaced")
 	}
 
+	if def.Name == "grouping" {
+		return b.buildGroupingFunc(f, inScope, outScope, outCol, colRefs)
+	}
+
+	if body := b.inlineFunctionBody(f); body != nil {
+		out = b.buildScalar(body, inScope, nil, nil, colRefs)
+		return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	}
+
 	argList := make([]memo.GroupID, len(f.Exprs))
 	for i, pexpr := range f.Exprs {
 		argList[i] = b.buildScalar(pexpr.(tree.TypedExpr), inScope, nil, nil, colRefs)
@@ -529,6 +542,55 @@ func (b *Builder) buildFunction(
 	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
 }
 
+// inlineFunctionBody returns the body of the user-defined function called by
+// f, in which the arguments of the call replace the parameters of the
+// function, or nil if the function cannot be inlined. A function can be
+// inlined if it is not volatile and if its arguments are evaluated exactly as
+// many times as in the call, unless they are constants, placeholders or
+// column references.
+func (b *Builder) inlineFunctionBody(f *tree.FuncExpr) tree.TypedExpr {
+	o := f.ResolvedOverload()
+	if o == nil || o.InlineBody == nil {
+		return nil
+	}
+	params := o.Types.Types()
+	args := make([]tree.TypedExpr, len(f.Exprs))
+	for i := range f.Exprs {
+		args[i] = f.Exprs[i].(tree.TypedExpr)
+		if typ := args[i].ResolvedType(); typ == types.Unknown || !typ.Equivalent(params[i]) {
+			return nil
+		}
+	}
+
+	refs := make([]int, len(args))
+	_, _ = tree.SimpleVisit(o.InlineBody, func(expr tree.Expr) (error, bool, tree.Expr) {
+		if v, ok := expr.(*tree.IndexedVar); ok {
+			refs[v.Idx]++
+		}
+		return nil, true, expr
+	})
+	for i, arg := range args {
+		if refs[i] != 1 {
+			switch arg.(type) {
+			case tree.Datum, *tree.Placeholder, *scopeColumn:
+			default:
+				return nil
+			}
+		}
+	}
+
+	body, err := tree.SimpleVisit(o.InlineBody, func(expr tree.Expr) (error, bool, tree.Expr) {
+		if v, ok := expr.(*tree.IndexedVar); ok {
+			return nil, false, args[v.Idx]
+		}
+		return nil, true, expr
+	})
+	if err != nil {
+		panic(builderError{err})
+	}
+	return body.(tree.TypedExpr)
+}
+
 // buildRangeCond builds a RANGE clause as a simpler expression. Examples:
 // x BETWEEN a AND b                ->  x >= a AND x <= b
 // x NOT BETWEEN a AND b            ->  NOT (x >= a AND x <= b)
@@ -72,6 +72,10 @@ type scope struct {
 	// context is the current context in the SQL query (e.g., "SELECT" or
 	// "HAVING"). It is used for error messages.
 	context string
+
+	// ctes contains the common table expressions defined by a WITH clause and
+	// visible in this scope and its descendants. See resolveCTE.
+	ctes map[tree.Name]*cteSource
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -735,9 +739,17 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		if t.WindowDef != nil {
 			panic(unimplementedf("window functions are not supported"))
 		}
+		if t.IsOrderedSetAggregate() {
+			panic(unimplementedf("ordered-set aggregates are not supported"))
+		}
 
 		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
 		if err != nil {
+			if tree.MaybeUserDefinedFunction(err) {
+				// User-defined functions are resolved during type checking,
+				// and are neither generators nor aggregates.
+				break
+			}
 			panic(builderError{err})
 		}
 
@@ -66,6 +66,13 @@ func (b *Builder) buildDataSource(
 			panic(builderError{err})
 		}
 
+		if !tn.ExplicitSchema {
+			// If the name was not prefixed, it may refer to a CTE.
+			if cte := inScope.resolveCTE(tn.TableName); cte != nil {
+				return b.buildCTEReference(cte, tn, inScope)
+			}
+		}
+
 		ds := b.resolveDataSource(tn)
 		switch t := ds.(type) {
 		case opt.Table:
@@ -317,10 +324,116 @@ func (b *Builder) buildScan(
 		}
 
 		outScope.group = b.factory.ConstructScan(b.factory.InternScanOpDef(&def))
+		b.buildComputedCols(tab, tabID, tn)
+		b.buildPartialIndexPredicates(tab, tabID, tn)
 	}
 	return outScope
 }
 
+// buildComputedCols builds memo groups for the expressions of the computed
+// columns of the given table and records them in the table metadata, so that
+// filters on those expressions can be used to constrain indexes on the
+// computed columns. Expressions that cannot be built are skipped.
+func (b *Builder) buildComputedCols(tab opt.Table, tabID opt.TableID, tn *tree.TableName) {
+	var computed memo.ComputedCols
+	var tabScope *scope
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if !col.IsComputed() {
+			continue
+		}
+
+		// The computed expression can reference any column of the table, so
+		// build it in a scope that contains all of them.
+		if tabScope == nil {
+			tabScope = b.buildTableScope(tab, tabID, tn)
+		}
+
+		expr, err := parser.ParseExpr(col.ComputedExprStr())
+		if err != nil {
+			continue
+		}
+		if group, ok := b.tryBuildComputedExpr(expr, col.DatumType(), tabScope); ok {
+			if computed == nil {
+				computed = make(memo.ComputedCols)
+			}
+			computed[tabID.ColumnID(i)] = group
+		}
+	}
+	if computed != nil {
+		memo.SetTableComputedCols(b.factory.Metadata(), tabID, computed)
+	}
+}
+
+// buildPartialIndexPredicates builds memo groups for the predicates of the
+// partial indexes of the given table and records them in the table metadata,
+// so that the optimizer can determine which partial indexes contain all the
+// rows needed by a query. Predicates that cannot be built are skipped, which
+// prevents the optimizer from using their indexes.
+func (b *Builder) buildPartialIndexPredicates(
+	tab opt.Table, tabID opt.TableID, tn *tree.TableName,
+) {
+	var preds memo.PartialIndexPredicates
+	var tabScope *scope
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		predStr, ok := tab.Index(i).Predicate()
+		if !ok {
+			continue
+		}
+		if tabScope == nil {
+			tabScope = b.buildTableScope(tab, tabID, tn)
+		}
+
+		expr, err := parser.ParseExpr(predStr)
+		if err != nil {
+			continue
+		}
+		if group, ok := b.tryBuildComputedExpr(expr, types.Bool, tabScope); ok {
+			if preds == nil {
+				preds = make(memo.PartialIndexPredicates)
+			}
+			preds[i] = group
+		}
+	}
+	if preds != nil {
+		memo.SetTablePartialIndexPredicates(b.factory.Metadata(), tabID, preds)
+	}
+}
+
+// buildTableScope returns a scope that contains all the columns of the given
+// table, in which expressions stored in the table schema can be built.
+func (b *Builder) buildTableScope(tab opt.Table, tabID opt.TableID, tn *tree.TableName) *scope {
+	tabScope := b.allocScope()
+	tabScope.cols = make([]scopeColumn, tab.ColumnCount())
+	for j := range tabScope.cols {
+		tabScope.cols[j] = scopeColumn{
+			id:    tabID.ColumnID(j),
+			name:  tab.Column(j).ColName(),
+			table: *tn,
+			typ:   tab.Column(j).DatumType(),
+		}
+	}
+	return tabScope
+}
+
+// tryBuildComputedExpr builds a memo group for the given computed column
+// expression. It returns ok=false if the expression is not supported by the
+// optimizer.
+func (b *Builder) tryBuildComputedExpr(
+	expr tree.Expr, typ types.T, tabScope *scope,
+) (group memo.GroupID, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isBldErr := r.(builderError); !isBldErr {
+				panic(r)
+			}
+			group, ok = 0, false
+		}
+	}()
+	texpr := tabScope.resolveType(expr, typ)
+	return b.buildScalar(texpr, tabScope, nil, nil, nil), true
+}
+
 // buildWithOrdinality builds a group which appends an increasing integer column to
 // the output. colName optionally denotes the name this column is given, or can
 // be blank for none.
@@ -351,16 +464,25 @@ func (b *Builder) buildWithOrdinality(colName string, inScope *scope) (outScope
 // return values.
 func (b *Builder) buildSelect(stmt *tree.Select, inScope *scope) (outScope *scope) {
 	if stmt.With != nil {
-		panic(unimplementedf("with clause not supported"))
+		inScope = b.buildCTEs(stmt.With, inScope)
 	}
 
 	wrapped := stmt.Select
 	orderBy := stmt.OrderBy
 	limit := stmt.Limit
+	if len(stmt.Locking) > 0 {
+		panic(unimplementedf("locking clauses are not supported"))
+	}
 
 	for s, ok := wrapped.(*tree.ParenSelect); ok; s, ok = wrapped.(*tree.ParenSelect) {
 		stmt = s.Select
 		wrapped = stmt.Select
+		if len(stmt.Locking) > 0 {
+			panic(unimplementedf("locking clauses are not supported"))
+		}
+		if stmt.With != nil {
+			inScope = b.buildCTEs(stmt.With, inScope)
+		}
 		if stmt.OrderBy != nil {
 			if orderBy != nil {
 				panic(builderError{pgerror.NewErrorf(
@@ -532,6 +654,36 @@ func (b *Builder) buildFrom(from *tree.From, where *tree.Where, inScope *scope)
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildFromTables(tables tree.TableExprs, inScope *scope) (outScope *scope) {
+	// A LATERAL table can refer to the tables that precede it, so those must be
+	// built first and joined to it using an apply join. The tables that follow
+	// it are joined as usual.
+	for i := len(tables) - 1; i > 0; i-- {
+		if !isLateralTable(tables[i]) {
+			continue
+		}
+		leftScope := b.buildFromTables(tables[:i], inScope)
+		lateralScope := b.buildLateralDataSource(tables[i], leftScope, inScope)
+		b.validateJoinTableNames(leftScope, lateralScope)
+
+		outScope = inScope.push()
+		outScope.appendColumnsFromScope(leftScope)
+		outScope.appendColumnsFromScope(lateralScope)
+		outScope.group = b.factory.ConstructInnerJoinApply(
+			leftScope.group, lateralScope.group, b.factory.ConstructTrue(),
+		)
+		if i == len(tables)-1 {
+			return outScope
+		}
+
+		tableScope := b.buildFromTables(tables[i+1:], inScope)
+		b.validateJoinTableNames(outScope, tableScope)
+		outScope.appendColumnsFromScope(tableScope)
+		outScope.group = b.factory.ConstructInnerJoin(
+			outScope.group, tableScope.group, b.factory.ConstructTrue(),
+		)
+		return outScope
+	}
+
 	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, inScope)
 
 	// Recursively build table join.
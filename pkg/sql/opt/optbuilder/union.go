@@ -32,7 +32,17 @@ import (
 func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope *scope) {
 	leftScope := b.buildSelect(clause.Left, inScope)
 	rightScope := b.buildSelect(clause.Right, inScope)
+	return b.buildSetOp(clause.Type, clause.All, leftScope, rightScope, inScope)
+}
 
+// buildSetOp builds a set operation (UNION, INTERSECT or EXCEPT, with or
+// without ALL) that combines the already-built left and right inputs.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildSetOp(
+	typ tree.UnionType, all bool, leftScope, rightScope, inScope *scope,
+) (outScope *scope) {
 	// Remove any hidden columns, as they are not included in the Union.
 	leftScope.removeHiddenCols()
 	rightScope.removeHiddenCols()
@@ -42,7 +52,7 @@ func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope
 		panic(builderError{pgerror.NewErrorf(
 			pgerror.CodeSyntaxError,
 			"each %v query must have the same number of columns: %d vs %d",
-			clause.Type, len(leftScope.cols), len(rightScope.cols),
+			typ, len(leftScope.cols), len(rightScope.cols),
 		)})
 	}
 
@@ -63,7 +73,7 @@ func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope
 	//   SELECT NULL UNION SELECT 1
 	// The type of NULL is unknown, and the type of 1 is int. We need to
 	// synthesize a new column so the output column will have the correct type.
-	newColsNeeded := clause.Type == tree.UnionOp
+	newColsNeeded := typ == tree.UnionOp
 	if newColsNeeded {
 		// Create a new scope to hold the new synthesized columns.
 		outScope = outScope.push()
@@ -79,11 +89,11 @@ func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope
 		// http://www.postgresql.org/docs/9.5/static/typeconv-union-case.html.
 		if !(l.typ.Equivalent(r.typ) || l.typ == types.Unknown || r.typ == types.Unknown) {
 			panic(builderError{pgerror.NewErrorf(pgerror.CodeDatatypeMismatchError,
-				"%v types %s and %s cannot be matched", clause.Type, l.typ, r.typ)})
+				"%v types %s and %s cannot be matched", typ, l.typ, r.typ)})
 		}
 		if l.hidden != r.hidden {
 			// This should never happen.
-			panic(fmt.Errorf("%v types cannot be matched", clause.Type))
+			panic(fmt.Errorf("%v types cannot be matched", typ))
 		}
 
 		if newColsNeeded {
@@ -111,8 +121,8 @@ func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope
 	setOpColMap := memo.SetOpColMap{Left: leftCols, Right: rightCols, Out: newCols}
 	private := b.factory.InternSetOpColMap(&setOpColMap)
 
-	if clause.All {
-		switch clause.Type {
+	if all {
+		switch typ {
 		case tree.UnionOp:
 			outScope.group = b.factory.ConstructUnionAll(leftScope.group, rightScope.group, private)
 		case tree.IntersectOp:
@@ -121,7 +131,7 @@ func (b *Builder) buildUnion(clause *tree.UnionClause, inScope *scope) (outScope
 			outScope.group = b.factory.ConstructExceptAll(leftScope.group, rightScope.group, private)
 		}
 	} else {
-		switch clause.Type {
+		switch typ {
 		case tree.UnionOp:
 			outScope.group = b.factory.ConstructUnion(leftScope.group, rightScope.group, private)
 		case tree.IntersectOp:
@@ -0,0 +1,240 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+)
+
+// cteSource holds the information about a common table expression that is
+// needed to build references to it.
+type cteSource struct {
+	// name holds the name of the CTE and the renaming of its columns, if
+	// present.
+	name tree.AliasClause
+
+	// cols are the columns produced by the CTE.
+	cols []scopeColumn
+
+	// group is the memo group of the CTE query. It is not set for the
+	// self-reference of a recursive CTE.
+	group memo.GroupID
+
+	// used is set once the CTE has been referenced. Multiple references to the
+	// same CTE are not supported.
+	used bool
+
+	// workTableID, if non-zero, indicates that this entry stands for the
+	// self-reference in the recursive term of a recursive CTE. References are
+	// built as a WorkTableScan operator with this ID.
+	workTableID int
+
+	// invalidRefErr, if set, is the error raised when the CTE is referenced.
+	// It is used to reject references that are invalid in the current context,
+	// e.g. a recursive reference within the non-recursive term of a recursive
+	// CTE.
+	invalidRefErr error
+}
+
+// resolveCTE looks up the CTE with the given name in this scope and its
+// ancestors, returning nil if there is none.
+func (s *scope) resolveCTE(name tree.Name) *cteSource {
+	for ; s != nil; s = s.parent {
+		if cte, ok := s.ctes[name]; ok {
+			return cte
+		}
+	}
+	return nil
+}
+
+// colName returns the name of the i-th column of the CTE, taking into account
+// the renaming of its columns, if present.
+func (cte *cteSource) colName(i int) string {
+	if i < len(cte.name.Cols) {
+		return string(cte.name.Cols[i])
+	}
+	return string(cte.cols[i].name)
+}
+
+// buildCTEs builds the common table expressions defined by the given WITH
+// clause. It returns a new scope in which the CTEs are visible; the query
+// that follows the WITH clause must be built using this scope.
+func (b *Builder) buildCTEs(with *tree.With, inScope *scope) (outScope *scope) {
+	outScope = inScope.push()
+	outScope.ctes = make(map[tree.Name]*cteSource, len(with.CTEList))
+	for _, cte := range with.CTEList {
+		if _, ok := outScope.ctes[cte.Name.Alias]; ok {
+			panic(builderError{pgerror.NewErrorf(
+				pgerror.CodeDuplicateAliasError,
+				"WITH query name %s specified more than once", cte.Name.Alias,
+			)})
+		}
+
+		var cteScope *scope
+		if with.Recursive {
+			cteScope = b.buildRecursiveCTE(cte, outScope)
+		} else {
+			cteScope = b.buildStmt(cte.Stmt, outScope)
+		}
+
+		// Hidden columns are not accessible outside the CTE.
+		cteScope.removeHiddenCols()
+
+		outScope.ctes[cte.Name.Alias] = &cteSource{
+			name:  cte.Name,
+			cols:  cteScope.cols,
+			group: cteScope.group,
+		}
+	}
+	return outScope
+}
+
+// buildRecursiveCTE builds a common table expression defined in a WITH
+// RECURSIVE clause. A recursive CTE has the form:
+//
+//   <non-recursive term> UNION [ALL] <recursive term>
+//
+// where only the recursive term can refer to the CTE itself. If the recursive
+// term does not refer to the CTE, the result is a regular union; otherwise it
+// is a RecursiveCTE operator, with the self-reference built as a WorkTableScan
+// operator.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildRecursiveCTE(cte *tree.CTE, inScope *scope) (outScope *scope) {
+	name := cte.Name.Alias
+	union := cte.RecursiveUnion()
+	if union == nil {
+		// This is not of the recursive form; it can be built as a regular CTE
+		// as long as it does not refer to itself.
+		inScope.ctes[name] = &cteSource{invalidRefErr: pgerror.NewErrorf(
+			pgerror.CodeInvalidRecursionError,
+			"recursive query %q does not have the form non-recursive-term UNION [ALL] recursive-term",
+			tree.ErrString(&name),
+		)}
+		defer delete(inScope.ctes, name)
+		return b.buildStmt(cte.Stmt, inScope)
+	}
+
+	inScope.ctes[name] = &cteSource{invalidRefErr: pgerror.NewErrorf(
+		pgerror.CodeInvalidRecursionError,
+		"recursive reference to query %q must not appear within its non-recursive term",
+		tree.ErrString(&name),
+	)}
+	initialScope := b.buildSelect(union.Left, inScope)
+	delete(inScope.ctes, name)
+	initialScope.removeHiddenCols()
+
+	// Build the recursive term in a scope where the CTE refers to the working
+	// table, which has the columns of the non-recursive term.
+	b.numWorkTables++
+	workTable := &cteSource{
+		name:        cte.Name,
+		cols:        initialScope.cols,
+		workTableID: b.numWorkTables,
+	}
+	recursiveInScope := inScope.push()
+	recursiveInScope.ctes = map[tree.Name]*cteSource{name: workTable}
+	recursiveScope := b.buildSelect(union.Right, recursiveInScope)
+	recursiveScope.removeHiddenCols()
+
+	if !workTable.used {
+		// The CTE is not actually recursive.
+		return b.buildSetOp(tree.UnionOp, union.All, initialScope, recursiveScope, inScope)
+	}
+
+	if len(initialScope.cols) != len(recursiveScope.cols) {
+		panic(builderError{pgerror.NewErrorf(
+			pgerror.CodeSyntaxError,
+			"each UNION query must have the same number of columns: %d vs %d",
+			len(initialScope.cols), len(recursiveScope.cols),
+		)})
+	}
+
+	outScope = inScope.push()
+	outScope.cols = make([]scopeColumn, 0, len(initialScope.cols))
+	for i := range initialScope.cols {
+		l := &initialScope.cols[i]
+		r := &recursiveScope.cols[i]
+		// The types of the working table are determined by the non-recursive
+		// term, so the recursive term must produce values of the same types.
+		if !(l.typ.Equivalent(r.typ) || r.typ == types.Unknown) {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeDatatypeMismatchError,
+				"recursive query %q column %d has type %s in non-recursive term but type %s overall",
+				tree.ErrString(&name), i+1, l.typ, r.typ,
+			)})
+		}
+		b.synthesizeColumn(outScope, workTable.colName(i), l.typ, nil, 0 /* group */)
+	}
+
+	def := memo.RecursiveCTEDef{
+		Name:          string(name),
+		WorkTableID:   workTable.workTableID,
+		InitialCols:   colsToColList(initialScope.cols),
+		RecursiveCols: colsToColList(recursiveScope.cols),
+		OutCols:       colsToColList(outScope.cols),
+		UnionAll:      union.All,
+	}
+	outScope.group = b.factory.ConstructRecursiveCTE(
+		initialScope.group, recursiveScope.group, b.factory.InternRecursiveCTEDef(&def),
+	)
+	return outScope
+}
+
+// buildCTEReference builds a reference to the given CTE, which was resolved
+// from the given table name.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildCTEReference(
+	cte *cteSource, tn *tree.TableName, inScope *scope,
+) (outScope *scope) {
+	if cte.invalidRefErr != nil {
+		panic(builderError{cte.invalidRefErr})
+	}
+	if cte.used {
+		if cte.workTableID != 0 {
+			panic(builderError{pgerror.NewErrorf(pgerror.CodeInvalidRecursionError,
+				"recursive reference to query %q must not appear more than once", tree.ErrString(tn),
+			)})
+		}
+		panic(unimplementedf("unsupported multiple use of CTE clause %q", tree.ErrString(tn)))
+	}
+	cte.used = true
+
+	outScope = inScope.push()
+	if cte.workTableID != 0 {
+		// Synthesize new columns for the rows of the working table.
+		outScope.cols = make([]scopeColumn, 0, len(cte.cols))
+		for i := range cte.cols {
+			b.synthesizeColumn(outScope, cte.colName(i), cte.cols[i].typ, nil, 0 /* group */)
+		}
+		def := memo.WorkTableScanDef{
+			Name: string(cte.name.Alias),
+			ID:   cte.workTableID,
+			Cols: colsToColList(outScope.cols),
+		}
+		outScope.group = b.factory.ConstructWorkTableScan(b.factory.InternWorkTableScanDef(&def))
+	} else {
+		outScope.appendColumns(cte.cols)
+		outScope.group = cte.group
+	}
+
+	b.renameSource(cte.name, outScope)
+	return outScope
+}
@@ -72,6 +72,10 @@ func mapPrivateType(typ string) string {
 		return "*memo.LookupJoinDef"
 	case "RowNumberDef":
 		return "*memo.RowNumberDef"
+	case "RecursiveCTEDef":
+		return "*memo.RecursiveCTEDef"
+	case "WorkTableScanDef":
+		return "*memo.WorkTableScanDef"
 	case "SetOpColMap":
 		return "*memo.SetOpColMap"
 	case "ExplainOpDef":
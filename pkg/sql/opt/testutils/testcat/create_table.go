@@ -74,6 +74,18 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		}
 	}
 
+	// Add hidden computed columns for index expressions. They are added before
+	// the primary index so that they are stored in it, like any other column.
+	for _, def := range stmt.Defs {
+		switch def := def.(type) {
+		case *tree.UniqueConstraintTableDef:
+			tab.addIndexExprColumns(&def.IndexTableDef)
+
+		case *tree.IndexTableDef:
+			tab.addIndexExprColumns(def)
+		}
+	}
+
 	// Add the primary index (if there is one defined).
 	for _, def := range stmt.Defs {
 		switch def := def.(type) {
@@ -227,19 +239,117 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	nullable := !def.PrimaryKey && def.Nullable.Nullability != tree.NotNull
 	typ := coltypes.CastTargetToDatumType(def.Type)
 	col := &Column{Name: string(def.Name), Type: typ, Nullable: nullable}
+	if def.IsComputed() {
+		col.ComputedExpr = tree.Serialize(def.Computed.Expr)
+	}
 	tt.Columns = append(tt.Columns, col)
 }
 
+// addIndexExprColumns adds a hidden computed column for each expression in the
+// given index definition, unless the table already has a column that computes
+// the same expression.
+func (tt *Table) addIndexExprColumns(def *tree.IndexTableDef) {
+	for _, colDef := range def.Columns {
+		if colDef.Expr == nil || tt.findIndexExprColumn(colDef.Expr) != -1 {
+			continue
+		}
+
+		// Replace the column references with ordinal references so that the
+		// expression can be type checked.
+		expr, err := tree.SimpleVisit(colDef.Expr, func(e tree.Expr) (error, bool, tree.Expr) {
+			if name, ok := e.(*tree.UnresolvedName); ok {
+				return nil, false, tree.NewOrdinalReference(tt.FindOrdinal(name.Parts[0]))
+			}
+			return nil, true, e
+		})
+		if err != nil {
+			panic(err)
+		}
+		semaCtx := tree.MakeSemaContext(false /* privileged */)
+		semaCtx.IVarContainer = &indexExprContainer{tt: tt}
+		texpr, err := tree.TypeCheck(expr, &semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+
+		name := "crdb_internal_idx_expr"
+		for i := 1; tt.findColumn(name) != -1; i++ {
+			name = fmt.Sprintf("crdb_internal_idx_expr_%d", i)
+		}
+		tt.Columns = append(tt.Columns, &Column{
+			Name:         name,
+			Type:         texpr.ResolvedType(),
+			Nullable:     true,
+			Hidden:       true,
+			ComputedExpr: tree.Serialize(colDef.Expr),
+		})
+	}
+}
+
+// findIndexExprColumn returns the ordinal of the hidden computed column that
+// computes the given index expression, or -1 if there is no such column.
+func (tt *Table) findIndexExprColumn(expr tree.Expr) int {
+	exprStr := tree.Serialize(expr)
+	for i, col := range tt.Columns {
+		if col.Hidden && col.ComputedExpr == exprStr {
+			return i
+		}
+	}
+	return -1
+}
+
+// findColumn returns the ordinal of the column with the given name, or -1 if
+// there is no such column.
+func (tt *Table) findColumn(name string) int {
+	for i, col := range tt.Columns {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexExprContainer implements tree.IndexedVarContainer in order to type
+// check index expressions.
+type indexExprContainer struct {
+	tt *Table
+}
+
+// IndexedVarEval is part of the tree.IndexedVarContainer interface.
+func (c *indexExprContainer) IndexedVarEval(idx int, ctx *tree.EvalContext) (tree.Datum, error) {
+	panic("unsupported")
+}
+
+// IndexedVarResolvedType is part of the tree.IndexedVarContainer interface.
+func (c *indexExprContainer) IndexedVarResolvedType(idx int) types.T {
+	return c.tt.Columns[idx].Type
+}
+
+// IndexedVarNodeFormatter is part of the tree.IndexedVarContainer interface.
+func (c *indexExprContainer) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	n := tree.Name(c.tt.Columns[idx].Name)
+	return &n
+}
+
 func (tt *Table) addIndex(def *tree.IndexTableDef, typ indexType) {
 	idx := &Index{
 		Name:     tt.makeIndexName(def.Name, typ),
 		Inverted: def.Inverted,
 	}
+	if def.Predicate != nil {
+		idx.PredicateExpr = tree.Serialize(def.Predicate)
+	}
 
 	// Add explicit columns and mark primary key columns as not null.
 	notNullIndex := true
 	for _, colDef := range def.Columns {
-		col := idx.addColumn(tt, string(colDef.Column), colDef.Direction, keyCol)
+		var col *Column
+		if colDef.Expr != nil {
+			ord := tt.findIndexExprColumn(colDef.Expr)
+			col = idx.addColumnByOrdinal(tt, ord, colDef.Direction, keyCol)
+		} else {
+			col = idx.addColumn(tt, string(colDef.Column), colDef.Direction, keyCol)
+		}
 
 		if typ == primaryIndex {
 			col.Nullable = false
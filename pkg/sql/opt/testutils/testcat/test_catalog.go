@@ -388,6 +388,10 @@ type Index struct {
 
 	// Inverted is true when this index is an inverted index.
 	Inverted bool
+
+	// PredicateExpr is the predicate of a partial index, or the empty string
+	// if the index is not partial.
+	PredicateExpr string
 }
 
 // IdxName is part of the opt.Index interface.
@@ -425,12 +429,18 @@ func (ti *Index) Column(i int) opt.IndexColumn {
 	return ti.Columns[i]
 }
 
+// Predicate is part of the opt.Index interface.
+func (ti *Index) Predicate() (string, bool) {
+	return ti.PredicateExpr, ti.PredicateExpr != ""
+}
+
 // Column implements the opt.Column interface for testing purposes.
 type Column struct {
-	Hidden   bool
-	Nullable bool
-	Name     string
-	Type     types.T
+	Hidden       bool
+	Nullable     bool
+	Name         string
+	Type         types.T
+	ComputedExpr string
 }
 
 var _ opt.Column = &Column{}
@@ -455,6 +465,16 @@ func (tc *Column) IsHidden() bool {
 	return tc.Hidden
 }
 
+// IsComputed is part of the opt.Column interface.
+func (tc *Column) IsComputed() bool {
+	return tc.ComputedExpr != ""
+}
+
+// ComputedExprStr is part of the opt.Column interface.
+func (tc *Column) ComputedExprStr() string {
+	return tc.ComputedExpr
+}
+
 // TableStat implements the opt.TableStatistic interface for testing purposes.
 type TableStat struct {
 	js stats.JSONStatistic
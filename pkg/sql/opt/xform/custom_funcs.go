@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 )
 
@@ -146,7 +147,10 @@ func (c *CustomFuncs) GenerateIndexScans(def memo.PrivateID) []memo.Expr {
 //
 //      (Select (Scan $scanDef) $filter)
 //
-//  - a filter that cannot be converted to a constraint generates nothing
+//  - a filter that cannot be converted to a constraint generates nothing,
+//    unless the index is a partial index whose predicate is implied by the
+//    filter, in which case an unconstrained Scan operator wrapped in a Select
+//    operator having the entire filter is generated
 //
 // And for a secondary index that does not cover the needed columns:
 //
@@ -190,15 +194,21 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 	var sb indexScanBuilder
 	sb.init(c, scanOpDef.Table)
 
-	// Iterate over all indexes.
+	// Iterate over all indexes, including the partial indexes whose predicates
+	// are implied by the filter.
 	var iter scanIndexIter
-	iter.init(c.e.mem, scanOpDef)
+	iter.initWithFilter(c.e.mem, scanOpDef, filter, c.e.evalCtx)
 	for iter.next() {
 		// Check whether the filter can constrain the index.
 		constraint, remaining, ok := c.tryConstrainIndex(
 			filter, scanOpDef.Table, iter.indexOrdinal, false /* isInverted */)
 		if !ok {
-			continue
+			// A partial index can be scanned in its entirety, since it only
+			// contains rows that might satisfy the filter.
+			if !iter.isPartial() {
+				continue
+			}
+			constraint, remaining = nil, filter
 		}
 
 		// Construct new constrained ScanOpDef.
@@ -248,9 +258,13 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 func (c *CustomFuncs) HasInvertedIndexes(def memo.PrivateID) bool {
 	// Don't bother matching unless there's an inverted index.
 	scanOpDef := c.e.mem.LookupPrivate(def).(*memo.ScanOpDef)
-	var iter scanIndexIter
-	iter.init(c.e.mem, scanOpDef)
-	return iter.nextInverted()
+	tab := c.e.mem.Metadata().Table(scanOpDef.Table)
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		if tab.Index(i).IsInverted() {
+			return true
+		}
+	}
+	return false
 }
 
 // GenerateInvertedIndexScans enumerates all inverted indexes on the Scan
@@ -272,7 +286,7 @@ func (c *CustomFuncs) GenerateInvertedIndexScans(
 
 	// Iterate over all inverted indexes.
 	var iter scanIndexIter
-	iter.init(c.e.mem, scanOpDef)
+	iter.initWithFilter(c.e.mem, scanOpDef, filter, c.e.evalCtx)
 	for iter.nextInverted() {
 		// Check whether the filter can constrain the index.
 		constraint, remaining, ok := c.tryConstrainIndex(
@@ -286,8 +300,8 @@ func (c *CustomFuncs) GenerateInvertedIndexScans(
 		newDef.Index = iter.indexOrdinal
 		newDef.Constraint = constraint
 
-		// Though the index is marked as containing the JSONB column being
-		// indexed, it doesn't actually, and it's only valid to extract the
+		// Though the index is marked as containing the JSONB or ARRAY column
+		// being indexed, it doesn't actually, and it's only valid to extract the
 		// primary key columns from it.
 		newDef.Cols = sb.primaryKeyCols()
 
@@ -298,6 +312,13 @@ func (c *CustomFuncs) GenerateInvertedIndexScans(
 		// correct columns, but it's difficult to tell at this point.
 		sb.setScan(c.e.mem.InternScanOpDef(&newDef))
 
+		// An array has an entry in the index for each of its elements, so a row
+		// can be found in more than one span of an array index (e.g. for the &&
+		// operator). Remove the duplicate rows before looking them up.
+		if c.isArrayIndex(scanOpDef.Table, iter.index) && constraint.Spans.Count() > 1 {
+			sb.addDistinct(newDef.Cols)
+		}
+
 		// If remaining filter exists, split it into one part that can be pushed
 		// below the IndexJoin, and one part that needs to stay above.
 		remaining = sb.addSelectAfterSplit(remaining, newDef.Cols)
@@ -310,6 +331,14 @@ func (c *CustomFuncs) GenerateInvertedIndexScans(
 	return c.e.exprs
 }
 
+// isArrayIndex returns true if the given inverted index indexes an ARRAY
+// column.
+func (c *CustomFuncs) isArrayIndex(tabID opt.TableID, index opt.Index) bool {
+	colID := tabID.ColumnID(index.Column(0).Ordinal)
+	_, ok := c.e.mem.Metadata().ColumnType(colID).(types.TArray)
+	return ok
+}
+
 // tryConstrainIndex tries to derive a constraint for the given index from the
 // specified filter. If a constraint is derived, it is returned along with any
 // filter remaining after extracting the constraint. If no constraint can be
@@ -343,7 +372,8 @@ func (c *CustomFuncs) tryConstrainIndex(
 	// Generate index constraints.
 	var ic idxconstraint.Instance
 	ev := memo.MakeNormExprView(c.e.mem, filter)
-	ic.Init(ev, columns, notNullCols, isInverted, c.e.evalCtx, c.e.f)
+	computedCols := memo.TableComputedCols(md, tabID)
+	ic.Init(ev, columns, notNullCols, computedCols, isInverted, c.e.evalCtx, c.e.f)
 	constraint = ic.Constraint()
 	if constraint.IsUnconstrained() {
 		return nil, 0, false
@@ -378,6 +408,17 @@ func (c *CustomFuncs) canMaybeConstrainIndex(
 	// generate a constraint.
 	firstIndexCol := tabID.ColumnID(index.Column(0).Ordinal)
 	filterProps := c.LookupLogical(filter).Scalar
+
+	// If the first index column is a computed column, the filter can constrain
+	// the index by referencing the expression that computes the column (see
+	// idxconstraint). In that case, the filter must involve at least one of the
+	// columns referenced by that expression.
+	if group, ok := memo.TableComputedCols(md, tabID)[firstIndexCol]; ok {
+		exprCols := c.LookupLogical(group).Scalar.OuterCols
+		return filterProps.OuterCols.Contains(int(firstIndexCol)) ||
+			filterProps.OuterCols.Intersects(exprCols)
+	}
+
 	if !filterProps.OuterCols.Contains(int(firstIndexCol)) {
 		return false
 	}
@@ -865,6 +906,8 @@ func (c *CustomFuncs) MakeOrderingChoiceFromColumn(
 //     doSomething(iter.indexOrdinal)
 //   }
 //
+// Partial indexes are skipped, unless the iterator is initialized with a
+// filter that implies their predicates (see initWithFilter).
 type scanIndexIter struct {
 	mem          *memo.Memo
 	scanOpDef    *memo.ScanOpDef
@@ -872,6 +915,11 @@ type scanIndexIter struct {
 	indexOrdinal int
 	index        opt.Index
 	cols         opt.ColSet
+
+	// filter, if non-zero, is the group of the filter applied to the rows of
+	// the scan. evalCtx is only set along with it.
+	filter  memo.GroupID
+	evalCtx *tree.EvalContext
 }
 
 func (it *scanIndexIter) init(mem *memo.Memo, scanOpDef *memo.ScanOpDef) {
@@ -880,11 +928,24 @@ func (it *scanIndexIter) init(mem *memo.Memo, scanOpDef *memo.ScanOpDef) {
 	it.tab = mem.Metadata().Table(scanOpDef.Table)
 	it.indexOrdinal = -1
 	it.index = nil
+	it.filter = 0
+	it.evalCtx = nil
+}
+
+// initWithFilter is like init, except that the iteration also includes the
+// partial indexes that contain all the rows that satisfy the given filter.
+func (it *scanIndexIter) initWithFilter(
+	mem *memo.Memo, scanOpDef *memo.ScanOpDef, filter memo.GroupID, evalCtx *tree.EvalContext,
+) {
+	it.init(mem, scanOpDef)
+	it.filter = filter
+	it.evalCtx = evalCtx
 }
 
 // next advances iteration to the next index of the Scan operator's table. This
 // is the primary index if it's the first time next is called, or a secondary
-// index thereafter. Inverted index are skipped. If the ForceIndex flag is set,
+// index thereafter. Inverted index are skipped, as are partial indexes whose
+// predicates are not implied by the iterator's filter. If the ForceIndex flag is set,
 // then all indexes except the forced index are skipped. When there are no more
 // indexes to enumerate, next returns false. The current index is accessible via
 // the iterator's "index" field.
@@ -903,6 +964,9 @@ func (it *scanIndexIter) next() bool {
 			// If we are forcing a specific index, ignore the others.
 			continue
 		}
+		if it.isPartial() && !it.filterImpliesPredicate() {
+			continue
+		}
 		it.cols = opt.ColSet{}
 		return true
 	}
@@ -928,11 +992,36 @@ func (it *scanIndexIter) nextInverted() bool {
 			// If we are forcing a specific index, ignore the others.
 			continue
 		}
+		if it.isPartial() && !it.filterImpliesPredicate() {
+			continue
+		}
 		it.cols = opt.ColSet{}
 		return true
 	}
 }
 
+// isPartial returns true if the current index is a partial index.
+func (it *scanIndexIter) isPartial() bool {
+	_, ok := it.index.Predicate()
+	return ok
+}
+
+// filterImpliesPredicate returns true if the iterator's filter implies the
+// predicate of the current partial index, meaning that the index contains all
+// the rows needed by the scan.
+func (it *scanIndexIter) filterImpliesPredicate() bool {
+	if it.filter == 0 {
+		return false
+	}
+	preds := memo.TablePartialIndexPredicates(it.mem.Metadata(), it.scanOpDef.Table)
+	pred, ok := preds[it.indexOrdinal]
+	if !ok {
+		// The predicate could not be built by the optimizer.
+		return false
+	}
+	return memo.FiltersImplyPredicate(it.mem, it.filter, pred, it.evalCtx)
+}
+
 // indexCols returns the set of columns contained in the current index.
 func (it *scanIndexIter) indexCols() opt.ColSet {
 	if it.cols.Empty() {
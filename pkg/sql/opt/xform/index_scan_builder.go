@@ -45,6 +45,7 @@ type indexScanBuilder struct {
 	tabID        opt.TableID
 	pkCols       opt.ColSet
 	scanDef      memo.PrivateID
+	distinctDef  memo.PrivateID
 	innerFilter  memo.GroupID
 	outerFilter  memo.GroupID
 	indexJoinDef memo.PrivateID
@@ -73,11 +74,22 @@ func (b *indexScanBuilder) primaryKeyCols() opt.ColSet {
 // any expressions added during previous invocations of the builder.
 func (b *indexScanBuilder) setScan(def memo.PrivateID) {
 	b.scanDef = def
+	b.distinctDef = 0
 	b.innerFilter = 0
 	b.outerFilter = 0
 	b.indexJoinDef = 0
 }
 
+// addDistinct wraps the scan with a DistinctOn expression that removes the rows
+// having the same values for the given columns. This is needed when the spans
+// of an inverted index scan can contain entries for the same row.
+func (b *indexScanBuilder) addDistinct(cols opt.ColSet) {
+	if b.innerFilter != 0 || b.indexJoinDef != 0 {
+		panic("cannot call addDistinct after a select or index join is added")
+	}
+	b.distinctDef = b.mem.InternGroupByDef(&memo.GroupByDef{GroupingCols: cols})
+}
+
 // addSelect wraps the input expression with a Select expression having the
 // given filter.
 func (b *indexScanBuilder) addSelect(filter memo.GroupID) {
@@ -148,12 +160,22 @@ func (b *indexScanBuilder) addIndexJoin(cols opt.ColSet) {
 // expressions that were specified by previous calls to various add methods.
 func (b *indexScanBuilder) build() memo.Expr {
 	// 1. Only scan.
-	if b.innerFilter == 0 && b.indexJoinDef == 0 {
+	if b.distinctDef == 0 && b.innerFilter == 0 && b.indexJoinDef == 0 {
 		return memo.Expr(memo.MakeScanExpr(b.scanDef))
 	}
 
-	// 2. Wrap scan in inner filter if it was added.
+	// 2. Wrap scan in distinct if it was added.
 	input := b.f.ConstructScan(b.scanDef)
+	if b.distinctDef != 0 {
+		aggs := b.f.ConstructAggregations(memo.EmptyList, b.f.InternColList(nil))
+		if b.innerFilter == 0 && b.indexJoinDef == 0 {
+			return memo.Expr(memo.MakeDistinctOnExpr(input, aggs, b.distinctDef))
+		}
+
+		input = b.f.ConstructDistinctOn(input, aggs, b.distinctDef)
+	}
+
+	// 3. Wrap input in inner filter if it was added.
 	if b.innerFilter != 0 {
 		if b.indexJoinDef == 0 {
 			return memo.Expr(memo.MakeSelectExpr(input, b.innerFilter))
@@ -162,7 +184,7 @@ func (b *indexScanBuilder) build() memo.Expr {
 		input = b.f.ConstructSelect(input, b.innerFilter)
 	}
 
-	// 3. Wrap input in index join if it was added.
+	// 4. Wrap input in index join if it was added.
 	if b.indexJoinDef != 0 {
 		if b.outerFilter == 0 {
 			return memo.Expr(memo.MakeIndexJoinExpr(input, b.indexJoinDef))
@@ -171,10 +193,10 @@ func (b *indexScanBuilder) build() memo.Expr {
 		input = b.f.ConstructIndexJoin(input, b.indexJoinDef)
 	}
 
-	// 4. Wrap input in outer filter (which must exist at this point).
+	// 5. Wrap input in outer filter (which must exist at this point).
 	if b.outerFilter == 0 {
-		// indexJoinDef == 0: outerFilter == 0 handled by #1 and #2 above.
-		// indexJoinDef != 0: outerFilter == 0 handled by #3 above.
+		// indexJoinDef == 0: outerFilter == 0 handled by #1, #2 and #3 above.
+		// indexJoinDef != 0: outerFilter == 0 handled by #4 above.
 		panic("outer filter cannot be 0 at this point")
 	}
 	return memo.Expr(memo.MakeSelectExpr(input, b.outerFilter))
@@ -70,6 +70,11 @@ func interestingOrderingsForScan(ev memo.ExprView) opt.OrderingSet {
 		if index.IsInverted() {
 			continue
 		}
+		if _, ok := index.Predicate(); ok && i != def.Index {
+			// A partial index can only be scanned when the query's filter
+			// implies its predicate.
+			continue
+		}
 		numIndexCols := index.KeyColumnCount()
 		var o opt.Ordering
 		for j := 0; j < numIndexCols; j++ {
@@ -111,7 +111,7 @@ func (oc *optCatalog) newDataSource(
 	// Create wrapper for the data source now.
 	var ds opt.DataSource
 	switch {
-	case desc.IsTable():
+	case desc.IsTable(), desc.IsMaterializedView():
 		ds = newOptTable(oc, desc, name)
 	case desc.IsView():
 		ds = newOptView(oc, desc, name)
@@ -513,6 +513,11 @@ func (oi *optIndex) Column(i int) opt.IndexColumn {
 	return opt.IndexColumn{Column: oi.tab.Column(ord), Ordinal: ord}
 }
 
+// Predicate is part of the opt.Index interface.
+func (oi *optIndex) Predicate() (string, bool) {
+	return oi.desc.Predicate, oi.desc.IsPartial()
+}
+
 type optTableStat struct {
 	createdAt      time.Time
 	columnOrdinals []int
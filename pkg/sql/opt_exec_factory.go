@@ -272,6 +272,44 @@ func (ef *execFactory) ConstructHashJoin(
 	return p.makeJoinNode(leftSrc, rightSrc, pred), nil
 }
 
+// ConstructApplyJoin is part of the exec.Factory interface.
+func (ef *execFactory) ConstructApplyJoin(
+	joinType sqlbase.JoinType,
+	left, right exec.Node,
+	planRightSideFn exec.ApplyJoinPlanRightSideFn,
+	onCond tree.TypedExpr,
+) (exec.Node, error) {
+	if joinType != sqlbase.InnerJoin && joinType != sqlbase.LeftOuterJoin {
+		return nil, errors.Errorf("unsupported apply join type %s", joinType)
+	}
+	leftSrc := asDataSource(left)
+	rightSrc := asDataSource(right)
+	pred, err := makePredicate(joinType, leftSrc.info, rightSrc.info, nil /* usingColumns */)
+	if err != nil {
+		return nil, err
+	}
+	pred.onCond = pred.iVarHelper.Rebind(
+		onCond, false /* alsoReset */, false, /* normalizeToNonNil */
+	)
+	if pred.onCond == tree.DBoolTrue {
+		pred.onCond = nil
+	}
+	return &applyJoinNode{
+		joinType: joinType,
+		input:    leftSrc.plan,
+		right:    rightSrc.plan,
+		planRightSideFn: func(_ runParams, leftRow tree.Datums) (planNode, error) {
+			plan, err := planRightSideFn(leftRow)
+			if err != nil {
+				return nil, err
+			}
+			return plan.(planNode), nil
+		},
+		pred:    pred,
+		columns: pred.info.SourceColumns,
+	}, nil
+}
+
 // ConstructMergeJoin is part of the exec.Factory interface.
 func (ef *execFactory) ConstructMergeJoin(
 	joinType sqlbase.JoinType,
@@ -452,6 +490,38 @@ func (ef *execFactory) ConstructSetOp(
 	return ef.planner.newUnionNode(typ, all, left.(planNode), right.(planNode))
 }
 
+// ConstructRecursiveCTE is part of the exec.Factory interface.
+func (ef *execFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, unionAll bool,
+) (exec.Node, error) {
+	n := &recursiveCTENode{
+		initial:  initial.(planNode),
+		label:    label,
+		unionAll: unionAll,
+		columns:  planColumns(initial.(planNode)),
+	}
+	// Build the plan of the recursive term once, so that it can be described
+	// by EXPLAIN.
+	recursive, err := fn(n)
+	if err != nil {
+		return nil, err
+	}
+	n.recursive = recursive.(planNode)
+	n.genIterationFn = func(_ runParams, buffer *recursiveCTENode) (planNode, error) {
+		plan, err := fn(buffer)
+		if err != nil {
+			return nil, err
+		}
+		return plan.(planNode), nil
+	}
+	return n, nil
+}
+
+// ConstructScanBuffer is part of the exec.Factory interface.
+func (ef *execFactory) ConstructScanBuffer(ref exec.Node, label string) (exec.Node, error) {
+	return &scanBufferNode{buffer: ref.(*recursiveCTENode), label: label}, nil
+}
+
 // ConstructSort is part of the exec.Factory interface.
 func (ef *execFactory) ConstructSort(
 	input exec.Node, ordering sqlbase.ColumnOrdering,
@@ -259,6 +259,24 @@ func (p *planner) propagateFilters(
 			return plan, extraFilter, err
 		}
 
+	case *recursiveCTENode:
+		// Filters cannot be pushed into a recursive CTE: every row produced
+		// feeds the next iteration, whether or not it passes the filter.
+		if n.initial, err = p.triggerFilterPropagation(ctx, n.initial); err != nil {
+			return plan, extraFilter, err
+		}
+		if n.recursive, err = p.triggerFilterPropagation(ctx, n.recursive); err != nil {
+			return plan, extraFilter, err
+		}
+
+	case *applyJoinNode:
+		if n.input, err = p.triggerFilterPropagation(ctx, n.input); err != nil {
+			return plan, extraFilter, err
+		}
+		if n.right, err = p.triggerFilterPropagation(ctx, n.right); err != nil {
+			return plan, extraFilter, err
+		}
+
 	case *createTableNode:
 		if n.n.As() {
 			if n.sourcePlan, err = p.triggerFilterPropagation(ctx, n.sourcePlan); err != nil {
@@ -266,6 +284,13 @@ func (p *planner) propagateFilters(
 			}
 		}
 
+	case *createViewNode:
+		if n.sourcePlan != nil {
+			if n.sourcePlan, err = p.triggerFilterPropagation(ctx, n.sourcePlan); err != nil {
+				return plan, extraFilter, err
+			}
+		}
+
 	case *deleteNode:
 		if n.source, err = p.triggerFilterPropagation(ctx, n.source); err != nil {
 			return plan, extraFilter, err
@@ -357,24 +382,37 @@ func (p *planner) propagateFilters(
 		}
 
 	case *alterIndexNode:
+	case *commentOnColumnNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *refreshMaterializedViewNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *scrubNode:
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *CreateUserNode:
-	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
+	case *listenNode:
+	case *notifyNode:
 	case *hookFnNode:
 	case *valuesNode:
+	case *scanBufferNode:
 	case *sequenceSelectNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
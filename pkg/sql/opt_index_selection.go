@@ -139,16 +139,40 @@ func (p *planner) selectIndex(
 		for i := range s.resultColumns {
 			md.AddColumn(s.resultColumns[i].Name, s.resultColumns[i].Typ)
 		}
+		computedCols, err := p.buildComputedCols(ctx, &optimizer, s.desc)
+		if err != nil {
+			return nil, err
+		}
 		bld := optbuilder.NewScalar(ctx, &p.semaCtx, p.EvalContext(), optimizer.Factory())
 		bld.AllowUnsupportedExpr = true
-		err := bld.Build(s.filter)
+		err = bld.Build(s.filter)
 		if err != nil {
 			return nil, err
 		}
 		filterExpr := optimizer.Memo().Root()
+
+		// Remove any partial indexes that don't contain all the rows that
+		// satisfy the filter.
+		for i := 0; i < len(candidates); {
+			if candidates[i].index.IsPartial() {
+				implied, err := p.filterImpliesPredicate(
+					ctx, &optimizer, filterExpr.Group(), s.desc, candidates[i].index,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if !implied {
+					candidates[i] = candidates[len(candidates)-1]
+					candidates = candidates[:len(candidates)-1]
+					continue
+				}
+			}
+			i++
+		}
+
 		for _, c := range candidates {
 			if err := c.makeIndexConstraints(
-				&optimizer, filterExpr, p.EvalContext(),
+				&optimizer, filterExpr, computedCols, p.EvalContext(),
 			); err != nil {
 				return nil, err
 			}
@@ -158,13 +182,36 @@ func (p *planner) selectIndex(
 				return newZeroNode(s.resultColumns), nil
 			}
 		}
+	} else {
+		// Without a filter, partial indexes never contain all the rows needed.
+		for i := 0; i < len(candidates); {
+			if candidates[i].index.IsPartial() {
+				candidates[i] = candidates[len(candidates)-1]
+				candidates = candidates[:len(candidates)-1]
+			} else {
+				i++
+			}
+		}
+	}
+
+	if len(candidates) == 0 && s.specifiedIndex != nil && s.specifiedIndex.IsPartial() {
+		// The primary index is never partial, so the only way this can happen is
+		// if we had a specified index.
+		return nil, fmt.Errorf(
+			"index \"%s\" is a partial index that does not contain all the rows needed by this query",
+			s.specifiedIndex.Name,
+		)
 	}
 
 	// Remove any inverted indexes that don't generate any spans, a full-scan of
-	// an inverted index is always invalid.
+	// an inverted index is always invalid. Also remove the inverted indexes on
+	// arrays that generate more than one span, since the same row can be found
+	// in several of them and the index join would return it more than once.
 	for i := 0; i < len(candidates); {
 		c := candidates[i].ic.Constraint()
-		if candidates[i].index.Type == sqlbase.IndexDescriptor_INVERTED && (c == nil || c.IsUnconstrained()) {
+		if candidates[i].index.Type == sqlbase.IndexDescriptor_INVERTED &&
+			(c == nil || c.IsUnconstrained() ||
+				(c.Spans.Count() > 1 && candidates[i].isArrayIndex())) {
 			candidates[i] = candidates[len(candidates)-1]
 			candidates = candidates[:len(candidates)-1]
 		} else {
@@ -364,6 +411,16 @@ func (v *indexInfo) analyzeOrdering(
 	}
 }
 
+// isArrayIndex returns true if the index is an inverted index on an ARRAY
+// column.
+func (v *indexInfo) isArrayIndex() bool {
+	if v.index.Type != sqlbase.IndexDescriptor_INVERTED {
+		return false
+	}
+	col, err := v.desc.FindColumnByID(v.index.ColumnIDs[0])
+	return err == nil && col.Type.SemanticType == sqlbase.ColumnType_ARRAY
+}
+
 // isCoveringIndex returns true if all of the columns needed from the scanNode are contained within
 // the index. This allows a scan of only the index to be performed without requiring subsequent
 // lookup of the full row.
@@ -408,11 +465,76 @@ func (v indexInfoByCost) Sort() {
 	sort.Sort(v)
 }
 
+// buildComputedCols builds memo groups for the expressions of the computed
+// columns of the given table, so that filters on those expressions can be used
+// to constrain indexes on the computed columns (see idxconstraint). The
+// metadata columns must correspond to the columns of the table. Expressions
+// that cannot be built are skipped.
+func (p *planner) buildComputedCols(
+	ctx context.Context, optimizer *xform.Optimizer, desc *sqlbase.TableDescriptor,
+) (memo.ComputedCols, error) {
+	exprs, err := sqlbase.MakeComputedExprs(
+		desc.Columns, desc, tree.NewUnqualifiedTableName(tree.Name(desc.Name)),
+		&p.txCtx, p.EvalContext(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var computedCols memo.ComputedCols
+	for i, expr := range exprs {
+		if !desc.Columns[i].IsComputed() {
+			continue
+		}
+		bld := optbuilder.NewScalar(ctx, &p.semaCtx, p.EvalContext(), optimizer.Factory())
+		bld.AllowUnsupportedExpr = true
+		if err := bld.Build(expr); err != nil {
+			// The expression is not supported by the optimizer; filters on it
+			// simply won't constrain indexes on the column.
+			continue
+		}
+		if computedCols == nil {
+			computedCols = make(memo.ComputedCols)
+		}
+		computedCols[opt.ColumnID(i+1)] = optimizer.Memo().RootGroup()
+	}
+	return computedCols, nil
+}
+
+// filterImpliesPredicate returns true if the given filter implies the predicate
+// of the given partial index, meaning that the index contains all the rows that
+// satisfy the filter. The metadata columns must correspond to the columns of
+// the table.
+func (p *planner) filterImpliesPredicate(
+	ctx context.Context,
+	optimizer *xform.Optimizer,
+	filter memo.GroupID,
+	desc *sqlbase.TableDescriptor,
+	index *sqlbase.IndexDescriptor,
+) (bool, error) {
+	pred, err := sqlbase.MakePartialIndexPredicate(desc, index)
+	if err != nil {
+		return false, err
+	}
+	bld := optbuilder.NewScalar(ctx, &p.semaCtx, p.EvalContext(), optimizer.Factory())
+	bld.AllowUnsupportedExpr = true
+	if err := bld.Build(pred); err != nil {
+		// The predicate is not supported by the optimizer, so we can't prove
+		// that it is implied by the filter.
+		return false, nil
+	}
+	return memo.FiltersImplyPredicate(
+		optimizer.Memo(), filter, optimizer.Memo().RootGroup(), p.EvalContext(),
+	), nil
+}
+
 // makeIndexConstraints uses the opt code to generate index
 // constraints. Initializes v.ic, as well as v.exactPrefix and v.cost (with a
 // baseline cost for the index).
 func (v *indexInfo) makeIndexConstraints(
-	optimizer *xform.Optimizer, filter memo.ExprView, evalCtx *tree.EvalContext,
+	optimizer *xform.Optimizer,
+	filter memo.ExprView,
+	computedCols memo.ComputedCols,
+	evalCtx *tree.EvalContext,
 ) error {
 	numIndexCols := len(v.index.ColumnIDs)
 
@@ -462,7 +584,7 @@ func (v *indexInfo) makeIndexConstraints(
 			notNullCols.Add(idx + 1)
 		}
 	}
-	v.ic.Init(filter, columns, notNullCols, isInverted, evalCtx, optimizer.Factory())
+	v.ic.Init(filter, columns, notNullCols, computedCols, isInverted, evalCtx, optimizer.Factory())
 	idxConstraint := v.ic.Constraint()
 	if idxConstraint.IsUnconstrained() {
 		// The index isn't being restricted at all, bump the cost significantly to
@@ -105,7 +105,7 @@ func makeSpans(
 		t.Fatal(err)
 	}
 	filterExpr := o.Memo().Root()
-	err = c.makeIndexConstraints(&o, filterExpr, p.EvalContext())
+	err = c.makeIndexConstraints(&o, filterExpr, nil /* computedCols */, p.EvalContext())
 	if err != nil {
 		t.Fatal(err)
 	}
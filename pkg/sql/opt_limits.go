@@ -112,6 +112,14 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 			p.applyLimit(n.left, numRows, true)
 		}
 
+	case *recursiveCTENode:
+		p.setUnlimited(n.initial)
+		p.setUnlimited(n.recursive)
+
+	case *applyJoinNode:
+		p.setUnlimited(n.input)
+		p.setUnlimited(n.right)
+
 	case *distinctNode:
 		p.applyLimit(n.plan, numRows, true)
 
@@ -173,6 +181,10 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 		if n.sourcePlan != nil {
 			p.applyLimit(n.sourcePlan, numRows, soft)
 		}
+	case *createViewNode:
+		if n.sourcePlan != nil {
+			p.applyLimit(n.sourcePlan, numRows, soft)
+		}
 	case *explainDistSQLNode:
 		// EXPLAIN ANALYZE is special: it handles its own limit propagation, since
 		// it fully executes during startExec.
@@ -203,24 +215,37 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 
 	case *valuesNode:
 	case *alterIndexNode:
+	case *commentOnColumnNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *refreshMaterializedViewNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *scrubNode:
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *CreateUserNode:
-	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
+	case *listenNode:
+	case *notifyNode:
 	case *zeroNode:
 	case *unaryNode:
+	case *scanBufferNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
 	case *setVarNode:
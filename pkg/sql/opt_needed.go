@@ -31,6 +31,11 @@ func setNeededColumns(plan planNode, needed []bool) {
 			setNeededColumns(n.sourcePlan, allColumns(n.sourcePlan))
 		}
 
+	case *createViewNode:
+		if n.sourcePlan != nil {
+			setNeededColumns(n.sourcePlan, allColumns(n.sourcePlan))
+		}
+
 	case *explainDistSQLNode:
 		setNeededColumns(n.plan, allColumns(n.plan))
 
@@ -65,6 +70,18 @@ func setNeededColumns(plan planNode, needed []bool) {
 		setNeededColumns(n.index, n.primaryKeyColumns)
 		markOmitted(n.resultColumns, needed)
 
+	case *recursiveCTENode:
+		// The rows of every iteration are fed back to the recursive term, and
+		// may need to be deduplicated, so all the columns are needed.
+		setNeededColumns(n.initial, allColumns(n.initial))
+		setNeededColumns(n.recursive, allColumns(n.recursive))
+
+	case *applyJoinNode:
+		// The left row is substituted into the plans of the right side, so all
+		// the left columns are needed.
+		setNeededColumns(n.input, allColumns(n.input))
+		setNeededColumns(n.right, allColumns(n.right))
+
 	case *unionNode:
 		if !n.emitAll {
 			// For UNION (as opposed to UNION ALL) we have to check for
@@ -249,24 +266,37 @@ func setNeededColumns(plan planNode, needed []bool) {
 		setNeededColumns(n.rows, allColumns(n.rows))
 
 	case *alterIndexNode:
+	case *commentOnColumnNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *refreshMaterializedViewNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *scrubNode:
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *CreateUserNode:
-	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
+	case *listenNode:
+	case *notifyNode:
 	case *zeroNode:
 	case *unaryNode:
+	case *scanBufferNode:
 	case *hookFnNode:
 	case *sequenceSelectNode:
 	case *setVarNode:
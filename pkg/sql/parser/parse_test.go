@@ -80,6 +80,12 @@ func TestParse(t *testing.T) {
 		{`CREATE INDEX ON a (b) INTERLEAVE IN PARENT c (d)`},
 		{`CREATE INDEX ON a (b) INTERLEAVE IN PARENT c.d (e)`},
 		{`CREATE INDEX ON a (b ASC, c DESC)`},
+		{`CREATE INDEX ON a (lower(b))`},
+		{`CREATE INDEX ON a (lower(b) DESC, c)`},
+		{`CREATE INDEX ON a ((b + c) ASC)`},
+		{`CREATE UNIQUE INDEX ON a ((b->>'c'))`},
+		{`CREATE TABLE a (b STRING, INDEX (lower(b)))`},
+		{`CREATE TABLE a (b STRING, UNIQUE (lower(b)))`},
 		{`CREATE UNIQUE INDEX a ON b (c)`},
 		{`CREATE UNIQUE INDEX a ON b (c) STORING (d)`},
 		{`CREATE UNIQUE INDEX a ON b (c) INTERLEAVE IN PARENT d (e, f)`},
@@ -89,9 +95,16 @@ func TestParse(t *testing.T) {
 		{`CREATE INVERTED INDEX a ON b.c (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) STORING (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) INTERLEAVE IN PARENT d (e)`},
+		{`CREATE INDEX a ON b (c) WHERE d > 0`},
+		{`CREATE UNIQUE INDEX IF NOT EXISTS a ON b (c) STORING (d) WHERE (e IS NOT NULL) AND f`},
+		{`CREATE INVERTED INDEX a ON b (c) WHERE d`},
+		{`CREATE TABLE a (b INT, INDEX (b) WHERE b > 0)`},
+		{`CREATE TABLE a (b INT, UNIQUE INDEX c (b) WHERE b > 0)`},
 
 		{`CREATE TABLE a ()`},
 		{`CREATE TABLE a (b INT)`},
+		{`CREATE TEMPORARY TABLE a (b INT)`},
+		{`CREATE TEMPORARY TABLE IF NOT EXISTS a (b INT)`},
 		{`CREATE TABLE a (b INT, c INT)`},
 		{`CREATE TABLE a (b CHAR)`},
 		{`CREATE TABLE a (b CHAR(3))`},
@@ -145,6 +158,10 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT, c STRING, FOREIGN KEY (b, c) REFERENCES other)`},
 		{`CREATE TABLE a (b INT, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y))`},
 		{`CREATE TABLE a (b INT, c STRING, CONSTRAINT s FOREIGN KEY (b, c) REFERENCES other (x, y))`},
+		{`CREATE TABLE a (b INT, c STRING, FOREIGN KEY (b, c) REFERENCES other MATCH SIMPLE)`},
+		{`CREATE TABLE a (b INT, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL)`},
+		{`CREATE TABLE a (b INT, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL ON DELETE CASCADE)`},
+		{`CREATE TABLE a (b INT REFERENCES other (x) MATCH FULL ON UPDATE SET NULL)`},
 		{`CREATE TABLE a (b INT, c STRING, INDEX (b, c))`},
 		{`CREATE TABLE a (b INT, c STRING, INDEX d (b, c))`},
 		{`CREATE TABLE a (b INT, c STRING, CONSTRAINT d UNIQUE (b, c))`},
@@ -184,6 +201,8 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a.b (b INT)`},
 		{`CREATE TABLE IF NOT EXISTS a (b INT)`},
 		{`CREATE TABLE a (b INT AS (a + b) STORED)`},
+		{`CREATE TABLE a (b INT AS (a + b) VIRTUAL)`},
+		{`CREATE TABLE a (b INT AS (a + b) VIRTUAL, INDEX (b))`},
 		{`CREATE TABLE view (view INT)`},
 
 		{`CREATE TABLE a (b INT CONSTRAINT c PRIMARY KEY)`},
@@ -220,6 +239,7 @@ func TestParse(t *testing.T) {
 
 		{`CREATE TABLE a AS SELECT * FROM b`},
 		{`CREATE TABLE IF NOT EXISTS a AS SELECT * FROM b`},
+		{`CREATE TEMPORARY TABLE a AS SELECT * FROM b`},
 		{`CREATE TABLE a AS SELECT * FROM b ORDER BY c`},
 		{`CREATE TABLE IF NOT EXISTS a AS SELECT * FROM b ORDER BY c`},
 		{`CREATE TABLE a AS SELECT * FROM b LIMIT 3`},
@@ -244,6 +264,8 @@ func TestParse(t *testing.T) {
 		{`CREATE VIEW a AS VALUES (1, 'one'), (2, 'two')`},
 		{`CREATE VIEW a (x, y) AS VALUES (1, 'one'), (2, 'two')`},
 		{`CREATE VIEW a AS TABLE b`},
+		{`CREATE MATERIALIZED VIEW a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW a (x, y) AS SELECT c, d FROM b`},
 
 		{`CREATE SEQUENCE a`},
 		{`CREATE SEQUENCE IF NOT EXISTS a`},
@@ -263,11 +285,32 @@ func TestParse(t *testing.T) {
 		{`CREATE SEQUENCE a INCREMENT 5 NO MAXVALUE MINVALUE 1 START 3`},
 		{`CREATE SEQUENCE a INCREMENT 5 NO CYCLE NO MAXVALUE MINVALUE 1 START 3 CACHE 1`},
 		{`CREATE SEQUENCE a VIRTUAL`},
+		{`CREATE SEQUENCE a AS INT4`},
+		{`CREATE SEQUENCE a AS INT2 MAXVALUE 100`},
+		{`CREATE SEQUENCE a OWNED BY b.c`},
+		{`CREATE SEQUENCE a OWNED BY db.b.c`},
+		{`CREATE SEQUENCE a OWNED BY NONE`},
 
 		{`CREATE STATISTICS a ON col1 FROM t`},
 		{`CREATE STATISTICS a ON col1, col2 FROM t`},
 		{`CREATE STATISTICS a ON col1 FROM d.t`},
 
+		{`CREATE TYPE a AS ENUM ('x', 'y')`},
+		{`CREATE TYPE a AS ENUM ()`},
+		{`CREATE TYPE db.a AS ENUM ('x')`},
+
+		{`CREATE FUNCTION f() RETURNS INT LANGUAGE sql AS 'SELECT 1'`},
+		{`CREATE FUNCTION f(a INT, STRING) RETURNS STRING LANGUAGE sql IMMUTABLE AS 'SELECT $2 || a::STRING'`},
+		{`CREATE FUNCTION db.f(x FLOAT8) RETURNS FLOAT8 AS 'SELECT x * 2.0' STABLE LANGUAGE sql`},
+		{`CREATE OR REPLACE FUNCTION f(a INT) RETURNS INT LANGUAGE sql VOLATILE AS 'SELECT a'`},
+		{`CREATE FUNCTION audit() RETURNS TRIGGER LANGUAGE sql AS 'INSERT INTO log VALUES (NEW.k)'`},
+		{`CREATE OR REPLACE FUNCTION db.audit() RETURNS TRIGGER LANGUAGE sql AS 'DELETE FROM log'`},
+		{`CREATE TRIGGER t BEFORE INSERT ON kv FOR EACH ROW EXECUTE FUNCTION audit()`},
+		{`CREATE TRIGGER t AFTER INSERT OR UPDATE OR DELETE ON db.kv FOR EACH ROW EXECUTE FUNCTION db.audit()`},
+		{`CREATE TABLE a (b mood, c "Mood")`},
+		{`SELECT CAST(b AS mood)`},
+		{`SELECT ANNOTATE_TYPE('x', mood)`},
+
 		{`DELETE FROM a`},
 		{`DELETE FROM a.b`},
 		{`DELETE FROM a WHERE a = b`},
@@ -279,8 +322,11 @@ func TestParse(t *testing.T) {
 		{`DELETE FROM a WHERE a = b RETURNING a + b`},
 		{`DELETE FROM a WHERE a = b RETURNING NOTHING`},
 		{`DELETE FROM a WHERE a = b ORDER BY c LIMIT d RETURNING e`},
+		{`DELETE FROM a USING b WHERE a.x = b.x`},
+		{`DELETE FROM a AS c USING b, d JOIN e ON d.x = e.x WHERE c.x = b.x RETURNING c.y`},
 
 		{`DISCARD ALL`},
+		{`DISCARD TEMPORARY`},
 
 		{`DROP DATABASE a`},
 		{`DROP DATABASE IF EXISTS a`},
@@ -314,6 +360,9 @@ func TestParse(t *testing.T) {
 		{`DROP VIEW IF EXISTS a, b RESTRICT`},
 		{`DROP VIEW a.b CASCADE`},
 		{`DROP VIEW a, b CASCADE`},
+		{`DROP MATERIALIZED VIEW a`},
+		{`DROP MATERIALIZED VIEW IF EXISTS a, b RESTRICT`},
+		{`DROP MATERIALIZED VIEW a.b CASCADE`},
 		{`DROP SEQUENCE a`},
 		{`DROP SEQUENCE a.b`},
 		{`DROP SEQUENCE a, b`},
@@ -323,11 +372,30 @@ func TestParse(t *testing.T) {
 		{`DROP SEQUENCE a.b CASCADE`},
 		{`DROP SEQUENCE a, b CASCADE`},
 
+		{`DROP TYPE a`},
+		{`DROP TYPE IF EXISTS a, b RESTRICT`},
+		{`DROP TYPE db.a CASCADE`},
+
+		{`DROP FUNCTION f`},
+		{`DROP FUNCTION f()`},
+		{`DROP FUNCTION IF EXISTS f(INT, STRING), db.g RESTRICT`},
+		{`DROP FUNCTION f(a INT) CASCADE`},
+		{`DROP TRIGGER t ON kv`},
+		{`DROP TRIGGER IF EXISTS t ON db.kv CASCADE`},
+
 		{`CANCEL JOBS SELECT a`},
 		{`CANCEL QUERIES SELECT a`},
 		{`CANCEL SESSIONS SELECT a`},
 		{`CANCEL QUERIES IF EXISTS SELECT a`},
 		{`CANCEL SESSIONS IF EXISTS SELECT a`},
+
+		{`COMMENT ON TABLE foo IS 'a'`},
+		{`COMMENT ON TABLE foo IS NULL`},
+		{`COMMENT ON TABLE db.foo IS 'b'`},
+		{`COMMENT ON COLUMN foo.a IS 'a'`},
+		{`COMMENT ON COLUMN db.foo.a IS NULL`},
+		{`COMMENT ON INDEX foo@idx IS 'a'`},
+		{`COMMENT ON INDEX idx IS NULL`},
 		{`RESUME JOBS SELECT a`},
 		{`PAUSE JOBS SELECT a`},
 
@@ -352,8 +420,12 @@ func TestParse(t *testing.T) {
 		{`SHOW TABLES`},
 		{`SHOW TABLES FROM a`},
 		{`SHOW TABLES FROM a.b`},
+		{`SHOW TABLES WITH COMMENT`},
+		{`SHOW TABLES FROM a WITH COMMENT`},
+		{`SHOW TABLES FROM a.b WITH COMMENT`},
 		{`SHOW COLUMNS FROM a`},
 		{`SHOW COLUMNS FROM a.b.c`},
+		{`SHOW COLUMNS FROM a WITH COMMENT`},
 		{`SHOW INDEXES FROM a`},
 		{`SHOW INDEXES FROM a.b.c`},
 		{`SHOW CONSTRAINTS FROM a`},
@@ -450,6 +522,14 @@ func TestParse(t *testing.T) {
 		{`DEALLOCATE a`},
 		{`DEALLOCATE ALL`},
 
+		{`LISTEN a`},
+		{`LISTEN "my channel"`},
+		{`UNLISTEN a`},
+		{`UNLISTEN *`},
+		{`NOTIFY a`},
+		{`NOTIFY a, 'payload'`},
+		{`NOTIFY a, e'\'quoted\''`},
+
 		// Tables are the default, but can also be specified with
 		// GRANT x ON TABLE y. However, the stringer does not output TABLE.
 		{`GRANT SELECT ON TABLE foo TO root`},
@@ -514,6 +594,10 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING 1, 2`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING a + b`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_pkey DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_b_key DO UPDATE SET a = 1 WHERE b > 2`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a, b) WHERE b > 2 DO UPDATE SET a = excluded.a WHERE a.b < 3`},
 
 		{`SELECT 1 + 1`},
 		{`SELECT -1`},
@@ -552,6 +636,7 @@ func TestParse(t *testing.T) {
 		{`SELECT a ? b`},
 		{`SELECT a ?| b`},
 		{`SELECT a ?& b`},
+		{`SELECT a && b`},
 		{`SELECT a->'x'`},
 		{`SELECT a#>'{x}'`},
 		{`SELECT a#>>'{x}'`},
@@ -600,6 +685,7 @@ func TestParse(t *testing.T) {
 		{`SELECT BYTES 'foo', 'foo'::BYTES`},
 		{`SELECT DATE 'foo', 'foo'::DATE`},
 		{`SELECT TIME 'foo', 'foo'::TIME`},
+		{`SELECT TIMETZ 'foo', 'foo'::TIMETZ`},
 		{`SELECT TIMESTAMP 'foo', 'foo'::TIMESTAMP`},
 		{`SELECT TIMESTAMPTZ 'foo', 'foo'::TIMESTAMPTZ`},
 		{`SELECT JSONB 'foo', 'foo'::JSONB`},
@@ -656,6 +742,11 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM (SELECT 1 FROM t) AS bar (bar1, bar2, bar3)`},
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY`},
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY AS bar`},
+		{`SELECT * FROM ab, LATERAL (SELECT * FROM kv)`},
+		{`SELECT * FROM ab, LATERAL (SELECT * FROM kv WHERE k = a) AS x`},
+		{`SELECT * FROM ab, LATERAL (SELECT * FROM kv) WITH ORDINALITY AS x (k, v, o)`},
+		{`SELECT * FROM ab, LATERAL ROWS FROM (foo(a))`},
+		{`SELECT * FROM ab LEFT JOIN LATERAL (SELECT * FROM kv WHERE k = a) AS x ON true`},
 		{`SELECT a FROM ROWS FROM (a(x), b(y), c(z))`},
 		{`SELECT a FROM t1, t2`},
 		{`SELECT a FROM t AS t1`},
@@ -744,6 +835,11 @@ func TestParse(t *testing.T) {
 
 		{`SELECT 1 FROM t GROUP BY a`},
 		{`SELECT 1 FROM t GROUP BY a, b`},
+		{`SELECT 1 FROM t GROUP BY ROLLUP(a, b)`},
+		{`SELECT 1 FROM t GROUP BY CUBE(a, (b, c))`},
+		{`SELECT 1 FROM t GROUP BY a, GROUPING SETS ((a, b), (a), ())`},
+		{`SELECT 1 FROM t GROUP BY GROUPING SETS (a, ROLLUP(b, c), CUBE(d), GROUPING SETS (e))`},
+		{`SELECT grouping(a, b) FROM t GROUP BY ROLLUP(a, b)`},
 
 		{`SELECT a FROM t HAVING a = b`},
 
@@ -831,6 +927,9 @@ func TestParse(t *testing.T) {
 
 		{`SELECT avg(1) FILTER (WHERE a > b)`},
 		{`SELECT avg(1) FILTER (WHERE a > b) OVER (ORDER BY c)`},
+		{`SELECT percentile_disc(0.5) WITHIN GROUP (ORDER BY a)`},
+		{`SELECT percentile_cont(ARRAY[0.5, 0.95]) WITHIN GROUP (ORDER BY a DESC)`},
+		{`SELECT mode() WITHIN GROUP (ORDER BY a) FILTER (WHERE a > b)`},
 
 		{`SELECT a FROM t UNION SELECT 1 FROM t`},
 		{`SELECT a FROM t UNION SELECT 1 FROM t UNION SELECT 1 FROM t`},
@@ -851,6 +950,10 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM t1 FULL JOIN t2 USING (a)`},
 		{`SELECT * FROM (t1 WITH ORDINALITY AS o1 CROSS JOIN t2 WITH ORDINALITY AS o2) WITH ORDINALITY AS o3`},
 
+		{`WITH a AS (SELECT 1) SELECT * FROM a`},
+		{`WITH RECURSIVE a AS (SELECT 1 UNION ALL SELECT * FROM a) SELECT * FROM a`},
+		{`WITH RECURSIVE a (x) AS (SELECT 1 UNION SELECT x + 1 FROM a WHERE x < 10) SELECT x FROM a`},
+
 		{`SELECT a FROM t1 AS OF SYSTEM TIME '2016-01-01'`},
 		{`SELECT a FROM t1, t2 AS OF SYSTEM TIME '2016-01-01'`},
 		{`SELECT a FROM t1 AS OF SYSTEM TIME -('a' || 'b')::INTERVAL`},
@@ -858,6 +961,17 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM t LIMIT a`},
 		{`SELECT a FROM t OFFSET b`},
 		{`SELECT a FROM t LIMIT a OFFSET b`},
+		{`SELECT a FROM t FOR UPDATE`},
+		{`SELECT a FROM t FOR NO KEY UPDATE`},
+		{`SELECT a FROM t FOR SHARE`},
+		{`SELECT a FROM t FOR KEY SHARE`},
+		{`SELECT a FROM t FOR UPDATE OF t`},
+		{`SELECT a FROM t, u FOR UPDATE OF t, db.public.u NOWAIT`},
+		{`SELECT a FROM t FOR SHARE SKIP LOCKED`},
+		{`SELECT a FROM t FOR UPDATE OF t FOR SHARE OF u NOWAIT`},
+		{`SELECT a FROM t ORDER BY a LIMIT 1 FOR UPDATE`},
+		{`WITH a AS (SELECT 1) SELECT * FROM t FOR UPDATE SKIP LOCKED`},
+		{`SELECT * FROM (SELECT a FROM t FOR UPDATE) AS s`},
 		{`SELECT DISTINCT * FROM t`},
 		{`SELECT DISTINCT a, b FROM t`},
 		{`SELECT DISTINCT ON (a, b) c FROM t`},
@@ -922,6 +1036,8 @@ func TestParse(t *testing.T) {
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING a, a + b`},
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING NOTHING`},
 		{`UPDATE a SET b = 3 WHERE a = b ORDER BY c LIMIT d RETURNING e`},
+		{`UPDATE a SET b = c.d FROM c WHERE a.b = c.b`},
+		{`UPDATE a AS e SET b = c.d FROM c, d JOIN f ON d.x = f.x WHERE e.b = c.b RETURNING e.b`},
 
 		{`UPDATE t AS "0" SET k = ''`},                 // "0" lost its quotes
 		{`SELECT * FROM "0" JOIN "0" USING (id, "0")`}, // last "0" lost its quotes.
@@ -936,6 +1052,8 @@ func TestParse(t *testing.T) {
 		{`ALTER INDEX IF EXISTS a@primary RENAME TO like`},
 		{`ALTER TABLE a RENAME COLUMN c1 TO c2`},
 		{`ALTER TABLE IF EXISTS a RENAME COLUMN c1 TO c2`},
+		{`ALTER TABLE a RENAME CONSTRAINT c1 TO c2`},
+		{`ALTER TABLE IF EXISTS a RENAME CONSTRAINT c1 TO c2`},
 
 		{`ALTER TABLE a ADD COLUMN b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
 		{`ALTER TABLE a ADD COLUMN IF NOT EXISTS b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
@@ -968,6 +1086,7 @@ func TestParse(t *testing.T) {
 		{`ALTER TABLE a ALTER COLUMN b SET DEFAULT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP DEFAULT`},
 		{`ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP STORED`},
 
 		{`ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT`},
@@ -1054,6 +1173,15 @@ func TestParse(t *testing.T) {
 		{`ALTER SEQUENCE a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a NO CYCLE CACHE 1`},
+		{`ALTER SEQUENCE a AS INT8`},
+		{`ALTER SEQUENCE a OWNED BY b.c`},
+		{`ALTER SEQUENCE a OWNED BY NONE`},
+
+		{`ALTER TYPE a ADD VALUE 'z'`},
+		{`ALTER TYPE db.a ADD VALUE IF NOT EXISTS 'z'`},
+
+		{`REFRESH MATERIALIZED VIEW a`},
+		{`REFRESH MATERIALIZED VIEW CONCURRENTLY a.b`},
 
 		{`EXPERIMENTAL SCRUB DATABASE x`},
 		{`EXPERIMENTAL SCRUB DATABASE x AS OF SYSTEM TIME 1`},
@@ -1134,17 +1262,35 @@ func TestParse2(t *testing.T) {
 	}{
 		{`CREATE DATABASE a WITH ENCODING = 'foo'`,
 			`CREATE DATABASE a ENCODING = 'foo'`},
+		{`CREATE FUNCTION f(a INT) RETURNS INT LANGUAGE 'sql' AS 'SELECT a'`,
+			`CREATE FUNCTION f(a INT) RETURNS INT LANGUAGE sql AS 'SELECT a'`},
+		{`CREATE FUNCTION f(a INT) RETURNS INT LANGUAGE SQL AS 'SELECT a'`,
+			`CREATE FUNCTION f(a INT) RETURNS INT LANGUAGE sql AS 'SELECT a'`},
+		{`CREATE TRIGGER t AFTER DELETE ON kv FOR EACH ROW EXECUTE PROCEDURE audit()`,
+			`CREATE TRIGGER t AFTER DELETE ON kv FOR EACH ROW EXECUTE FUNCTION audit()`},
 		{`CREATE DATABASE a TEMPLATE = template0`,
 			`CREATE DATABASE a TEMPLATE = 'template0'`},
 		{`CREATE DATABASE a TEMPLATE = invalid`,
 			`CREATE DATABASE a TEMPLATE = 'invalid'`},
 		{`CREATE TABLE a (b INT, UNIQUE INDEX foo (b))`,
 			`CREATE TABLE a (b INT, CONSTRAINT foo UNIQUE (b))`},
+		{`CREATE TEMP TABLE a (b INT)`,
+			`CREATE TEMPORARY TABLE a (b INT)`},
+		{`CREATE LOCAL TEMP TABLE a (b INT)`,
+			`CREATE TEMPORARY TABLE a (b INT)`},
+		{`CREATE LOCAL TEMPORARY TABLE a AS SELECT * FROM b`,
+			`CREATE TEMPORARY TABLE a AS SELECT * FROM b`},
+		{`DISCARD TEMP`,
+			`DISCARD TEMPORARY`},
 		{`CREATE TABLE a (b INT, UNIQUE INDEX foo (b) INTERLEAVE IN PARENT c (d))`,
 			`CREATE TABLE a (b INT, CONSTRAINT foo UNIQUE (b) INTERLEAVE IN PARENT c (d))`},
 		{`CREATE TABLE a (UNIQUE INDEX (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`,
 			`CREATE TABLE a (UNIQUE (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`},
 		{`CREATE INDEX ON a (b) COVERING (c)`, `CREATE INDEX ON a (b) STORING (c)`},
+		{`SELECT 1 FROM t GROUP BY rollup (a)`, `SELECT 1 FROM t GROUP BY ROLLUP(a)`},
+		{`SELECT GROUPING(a) FROM t GROUP BY cube (a)`,
+			`SELECT grouping(a) FROM t GROUP BY CUBE(a)`},
+		{`CREATE INDEX ON a ((lower(b)))`, `CREATE INDEX ON a (lower(b))`},
 
 		{`CREATE INDEX a ON b USING GIN (c)`,
 			`CREATE INVERTED INDEX a ON b (c)`},
@@ -1167,6 +1313,8 @@ func TestParse2(t *testing.T) {
 			`CREATE TABLE a (b JSONB)`},
 		{`CREATE TABLE a (b TIMESTAMP WITH TIME ZONE)`,
 			`CREATE TABLE a (b TIMESTAMPTZ)`},
+		{`CREATE TABLE a (b TIME WITH TIME ZONE)`,
+			`CREATE TABLE a (b TIMETZ)`},
 		{`CREATE TABLE a (b BYTES, c BYTEA, d BLOB)`,
 			`CREATE TABLE a (b BYTES, c BYTES, d BYTES)`},
 		{`CREATE TABLE a (b CHAR(1), c CHARACTER(1), d CHARACTER(3))`,
@@ -1181,6 +1329,8 @@ func TestParse2(t *testing.T) {
 		{`SELECT CAST(1 AS "timestamp")`, `SELECT CAST(1 AS TIMESTAMP)`},
 		{`SELECT CAST(1 AS _int8)`, `SELECT CAST(1 AS INT[])`},
 		{`SELECT CAST(1 AS "_int8")`, `SELECT CAST(1 AS INT[])`},
+		{`SELECT 'f'::"blah"`, `SELECT 'f'::blah`},
+		{`SELECT foo'bar'`, `SELECT foo 'bar'`},
 
 		{`SELECT 'a' FROM t@{FORCE_INDEX=bar}`, `SELECT 'a' FROM t@bar`},
 
@@ -1216,7 +1366,6 @@ func TestParse2(t *testing.T) {
 
 		{`SELECT b <<= c`, `SELECT inet_contained_by_or_equals(b, c)`},
 		{`SELECT b >>= c`, `SELECT inet_contains_or_equals(b, c)`},
-		{`SELECT b && c`, `SELECT inet_contains_or_contained_by(b, c)`},
 
 		{`SELECT NUMERIC 'foo'`, `SELECT DECIMAL 'foo'`},
 		{`SELECT REAL 'foo'`, `SELECT FLOAT4 'foo'`},
@@ -1272,6 +1421,8 @@ func TestParse2(t *testing.T) {
 			`SELECT current_timestamp()`},
 		{`SELECT CURRENT_DATE`,
 			`SELECT current_date()`},
+		{`SELECT CURRENT_TIME`,
+			`SELECT current_time()`},
 		{`SELECT POSITION(a IN b)`,
 			`SELECT strpos(b, a)`},
 		{`SELECT TRIM(BOTH a FROM b)`,
@@ -1295,6 +1446,15 @@ func TestParse2(t *testing.T) {
 			`SELECT a FROM t1 OFFSET a`},
 		{`SELECT a FROM t1 OFFSET a ROWS`,
 			`SELECT a FROM t1 OFFSET a`},
+		// The locking clause may come before or after LIMIT, but is always output
+		// last.
+		{`SELECT a FROM t FOR UPDATE LIMIT 1`,
+			`SELECT a FROM t LIMIT 1 FOR UPDATE`},
+		{`SELECT a FROM t ORDER BY a FOR SHARE NOWAIT OFFSET 2`,
+			`SELECT a FROM t ORDER BY a OFFSET 2 FOR SHARE NOWAIT`},
+		// FOR READ ONLY is the same as no locking clause.
+		{`SELECT a FROM t FOR READ ONLY`,
+			`SELECT a FROM t`},
 		// We allow OFFSET before LIMIT, but always output LIMIT first.
 		{`SELECT a FROM t OFFSET a LIMIT b`,
 			`SELECT a FROM t LIMIT b OFFSET a`},
@@ -1413,6 +1573,13 @@ func TestParse2(t *testing.T) {
 		// Special extract syntax
 		{`SELECT EXTRACT(second from now())`,
 			`SELECT extract('second', now())`},
+		// Special AT TIME ZONE syntax
+		{`SELECT a AT TIME ZONE 'UTC'`,
+			`SELECT timezone('UTC', a)`},
+		{`SELECT a AT TIME ZONE b AT TIME ZONE c`,
+			`SELECT timezone(c, timezone(b, a))`},
+		{`SELECT a + b AT TIME ZONE 'UTC'`,
+			`SELECT a + timezone('UTC', b)`},
 		// Special trim syntax
 		{`SELECT TRIM('xy' from 'xyxtrimyyx')`,
 			`SELECT btrim('xyxtrimyyx', 'xy')`},
@@ -1447,6 +1614,10 @@ func TestParse2(t *testing.T) {
 			`SELECT a FROM ROWS FROM (generate_series(1, 32)) AS s (x)`},
 		{`SELECT a FROM generate_series(1, 32) WITH ORDINALITY AS s (x)`,
 			`SELECT a FROM ROWS FROM (generate_series(1, 32)) WITH ORDINALITY AS s (x)`},
+		{`SELECT * FROM ab, LATERAL foo(a)`,
+			`SELECT * FROM ab, LATERAL ROWS FROM (foo(a))`},
+		{`SELECT * FROM ab, LATERAL foo(a) WITH ORDINALITY AS x`,
+			`SELECT * FROM ab, LATERAL ROWS FROM (foo(a)) WITH ORDINALITY AS x`},
 
 		// Tuples
 		{`SELECT 1 IN (b)`, `SELECT 1 IN (b,)`},
@@ -1728,6 +1899,7 @@ func TestParse2(t *testing.T) {
 		{`ALTER TABLE a ADD b INT FAMILY fam_a`, `ALTER TABLE a ADD COLUMN b INT FAMILY fam_a`},
 		{`ALTER TABLE a DROP b`, `ALTER TABLE a DROP COLUMN b`},
 		{`ALTER TABLE a ALTER b DROP NOT NULL`, `ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER b SET NOT NULL`, `ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER b TYPE INT`, `ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT`},
 	}
 	for _, d := range testData {
@@ -1928,11 +2100,6 @@ HINT: try \h SELECT`},
 SELECT 1e-
        ^
 HINT: try \h SELECT`},
-		{"SELECT foo''",
-			`type does not exist at or near ""
-SELECT foo''
-          ^
-`},
 		{
 			`SELECT 0x FROM t`,
 			`invalid hexadecimal numeric literal
@@ -2009,20 +2176,6 @@ HINT: try \h INSERT`,
 ALTER TABLE t RENAME COLUMN x TO family
                                  ^
 HINT: try \h ALTER TABLE`,
-		},
-		{
-			`SELECT CAST(1.2+2.3 AS notatype)`,
-			`type does not exist at or near "notatype"
-SELECT CAST(1.2+2.3 AS notatype)
-                       ^
-`,
-		},
-		{
-			`SELECT ANNOTATE_TYPE(1.2+2.3, notatype)`,
-			`type does not exist at or near "notatype"
-SELECT ANNOTATE_TYPE(1.2+2.3, notatype)
-                              ^
-`,
 		},
 		{
 			`CREATE USER foo WITH PASSWORD`,
@@ -2088,10 +2241,24 @@ SELECT 1 + ANY ARRAY[1, 2, 3]
 `,
 		},
 		{
-			`SELECT 'f'::"blah"`,
-			`type does not exist at or near "blah"
-SELECT 'f'::"blah"
-            ^
+			`SELECT percentile_disc(DISTINCT 0.5) WITHIN GROUP (ORDER BY a)`,
+			`cannot use DISTINCT with WITHIN GROUP at or near "EOF"
+SELECT percentile_disc(DISTINCT 0.5) WITHIN GROUP (ORDER BY a)
+                                                              ^
+`,
+		},
+		{
+			`COMMENT ON COLUMN a IS 'b'`,
+			`column name must be qualified: a at or near "b"
+COMMENT ON COLUMN a IS 'b'
+                       ^
+`,
+		},
+		{
+			`CREATE SEQUENCE a OWNED BY b`,
+			`invalid OWNED BY option: specify OWNED BY table.column or OWNED BY NONE at or near "EOF"
+CREATE SEQUENCE a OWNED BY b
+                            ^
 `,
 		},
 		{
@@ -2121,20 +2288,6 @@ HINT: See: https://github.com/cockroachdb/cockroach/issues/8318`,
 UPDATE foo SET a.b = 1
                  ^
 HINT: See: https://github.com/cockroachdb/cockroach/issues/8318`,
-		},
-		{
-			`SELECT * FROM ab, LATERAL (SELECT * FROM kv)`,
-			`unimplemented at or near "EOF"
-SELECT * FROM ab, LATERAL (SELECT * FROM kv)
-                                            ^
-HINT: See: https://github.com/cockroachdb/cockroach/issues/24560`,
-		},
-		{
-			`SELECT * FROM ab, LATERAL foo(a)`,
-			`unimplemented at or near "EOF"
-SELECT * FROM ab, LATERAL foo(a)
-                                ^
-HINT: See: https://github.com/cockroachdb/cockroach/issues/24560`,
 		},
 		// Ensure that the support for ON ROLE <namelist> doesn't leak
 		// where it should not be recognized.
@@ -2165,6 +2318,20 @@ HINT: try \h BACKUP`,
 RESTORE ROLE foo, bar FROM 'baz'
              ^
 HINT: try \h RESTORE`,
+		},
+		{
+			`ALTER TYPE a ADD VALUE 'z' BEFORE 'x'`,
+			`unimplemented at or near "x"
+ALTER TYPE a ADD VALUE 'z' BEFORE 'x'
+                                  ^
+HINT: See: https://github.com/cockroachdb/cockroach/issues/24873`,
+		},
+		{
+			`CREATE TABLE a (b INT REFERENCES c (d) MATCH PARTIAL)`,
+			`unimplemented at or near "partial"
+CREATE TABLE a (b INT REFERENCES c (d) MATCH PARTIAL)
+                                             ^
+`,
 		},
 		{
 			`SELECT max(a ORDER BY b) FROM ab`,
@@ -31,6 +31,8 @@ import (
 	"bytes"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
@@ -76,6 +78,7 @@ var pgCatalog = virtualSchema{
 		pgCatalogIndexesTable,
 		pgCatalogInheritsTable,
 		pgCatalogLanguageTable,
+		pgCatalogMatViewsTable,
 		pgCatalogNamespaceTable,
 		pgCatalogOperatorTable,
 		pgCatalogProcTable,
@@ -270,6 +273,7 @@ var (
 	relKindIndex    = tree.NewDString("i")
 	relKindView     = tree.NewDString("v")
 	relKindSequence = tree.NewDString("S")
+	relKindMatView  = tree.NewDString("m")
 
 	relPersistencePermanent = tree.NewDString("p")
 )
@@ -313,7 +317,9 @@ CREATE TABLE pg_catalog.pg_class (
 			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
 				// The only difference between tables, views and sequences is the relkind column.
 				relKind := relKindTable
-				if table.IsView() {
+				if table.IsMaterializedView() {
+					relKind = relKindMatView
+				} else if table.IsView() {
 					relKind = relKindView
 				} else if table.IsSequence() {
 					relKind = relKindSequence
@@ -459,12 +465,20 @@ var (
 	fkMatchTypeFull    = tree.NewDString("f")
 	fkMatchTypePartial = tree.NewDString("p")
 	fkMatchTypeSimple  = tree.NewDString("s")
-
-	// Avoid unused warning for constants.
-	_ = fkMatchTypeFull
-	_ = fkMatchTypePartial
 )
 
+func fkMatchTypeForMatch(match sqlbase.ForeignKeyReference_Match) tree.Datum {
+	switch match {
+	case sqlbase.ForeignKeyReference_UNSPECIFIED, sqlbase.ForeignKeyReference_SIMPLE:
+		return fkMatchTypeSimple
+	case sqlbase.ForeignKeyReference_FULL:
+		return fkMatchTypeFull
+	case sqlbase.ForeignKeyReference_PARTIAL:
+		return fkMatchTypePartial
+	}
+	panic(errors.Errorf("unexpected ForeignKeyReference_Match: %v", match))
+}
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-constraint.html.
 var pgCatalogConstraintTable = virtualSchemaTable{
 	schema: `
@@ -553,7 +567,7 @@ CREATE TABLE pg_catalog.pg_constraint (
 					confrelid = h.TableOid(referencedDB, tree.PublicSchema, con.ReferencedTable)
 					confupdtype = fkActionNone
 					confdeltype = fkActionNone
-					confmatchtype = fkMatchTypeSimple
+					confmatchtype = fkMatchTypeForMatch(con.FK.Match)
 					if conkey, err = colIDArrayToDatum(con.Index.ColumnIDs); err != nil {
 						return err
 					}
@@ -801,9 +815,60 @@ CREATE TABLE pg_catalog.pg_description (
 	description STRING
 );
 `,
-	populate: func(_ context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Comments on database objects are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		vt := p.getVirtualTabler()
+		pgClassDesc, err := vt.getVirtualTableDesc(&pgClassTableName)
+		if err != nil {
+			return errors.New("could not find pg_catalog.pg_class")
+		}
+
+		// Only comments on tables, columns and indexes are supported; they are
+		// stored in the table descriptors.
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables have no comments */
+			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
+				pgClassTableOid := h.TableOid(db, pgCatalogName, pgClassDesc)
+				tableOid := h.TableOid(db, scName, table)
+				if table.Comment != "" {
+					if err := addRow(
+						tableOid,                       // objoid
+						pgClassTableOid,                // classoid
+						zeroVal,                        // objsubid
+						tree.NewDString(table.Comment), // description
+					); err != nil {
+						return err
+					}
+				}
+
+				// The column number must match pg_attribute.attnum.
+				colNum := 0
+				if err := forEachColumnInTable(table, func(column *sqlbase.ColumnDescriptor) error {
+					colNum++
+					if column.Comment == "" {
+						return nil
+					}
+					return addRow(
+						tableOid,                        // objoid
+						pgClassTableOid,                 // classoid
+						tree.NewDInt(tree.DInt(colNum)), // objsubid
+						tree.NewDString(column.Comment), // description
+					)
+				}); err != nil {
+					return err
+				}
+
+				return forEachIndexInTable(table, func(index *sqlbase.IndexDescriptor) error {
+					if index.Comment == "" {
+						return nil
+					}
+					return addRow(
+						h.IndexOid(db, scName, table, index), // objoid
+						pgClassTableOid,                      // classoid
+						zeroVal,                              // objsubid
+						tree.NewDString(index.Comment),       // description
+					)
+				})
+			})
 	},
 }
 
@@ -832,9 +897,22 @@ CREATE TABLE pg_catalog.pg_enum (
   enumlabel STRING
 );
 `,
-	populate: func(_ context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Enum types are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTypeDesc(ctx, p, dbContext, func(db *DatabaseDescriptor, typ *sqlbase.TypeDescriptor) error {
+			typOid := tree.NewDOid(tree.DInt(typ.EnumType().Oid()))
+			for i, member := range typ.EnumMembers {
+				if err := addRow(
+					h.EnumLabelOid(typ, member.Label), // oid
+					typOid,                            // enumtypid
+					tree.NewDFloat(tree.DFloat(i+1)),  // enumsortorder
+					tree.NewDString(member.Label),     // enumlabel
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
 }
 
@@ -992,6 +1070,10 @@ CREATE TABLE pg_catalog.pg_index (
 					if err != nil {
 						return err
 					}
+					indpred := tree.DNull
+					if index.IsPartial() {
+						indpred = tree.NewDString(index.Predicate)
+					}
 					return addRow(
 						h.IndexOid(db, scName, table, index), // indexrelid
 						tableOid, // indrelid
@@ -1011,7 +1093,7 @@ CREATE TABLE pg_catalog.pg_index (
 						indclass,                                 // indclass
 						indoption,                                // indoption
 						tree.DNull,                               // indexprs
-						tree.DNull,                               // indpred
+						indpred,                                  // indpred
 					)
 				})
 			})
@@ -1068,25 +1150,24 @@ func indexDefFromDescriptor(
 	index *sqlbase.IndexDescriptor,
 	tableLookup tableLookupFn,
 ) (string, error) {
+	elems, err := table.IndexElems(index)
+	if err != nil {
+		return "", err
+	}
+	for i := range elems {
+		if elems[i].Direction == tree.DefaultDirection {
+			elems[i].Direction = tree.Ascending
+		}
+	}
 	indexDef := tree.CreateIndex{
 		Name: tree.Name(index.Name),
 		Table: tree.NormalizableTableName{
 			TableNameReference: tree.NewTableName(tree.Name(db.Name), tree.Name(table.Name)),
 		},
 		Unique:  index.Unique,
-		Columns: make(tree.IndexElemList, len(index.ColumnNames)),
+		Columns: elems,
 		Storing: make(tree.NameList, len(index.StoreColumnNames)),
 	}
-	for i, name := range index.ColumnNames {
-		elem := tree.IndexElem{
-			Column:    tree.Name(name),
-			Direction: tree.Ascending,
-		}
-		if index.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
-			elem.Direction = tree.Descending
-		}
-		indexDef.Columns[i] = elem
-	}
 	for i, name := range index.StoreColumnNames {
 		indexDef.Storing[i] = tree.Name(name)
 	}
@@ -1117,6 +1198,13 @@ func indexDefFromDescriptor(
 		}
 		indexDef.Interleave = intlDef
 	}
+	if index.IsPartial() {
+		pred, err := parser.ParseExpr(index.Predicate)
+		if err != nil {
+			return "", err
+		}
+		indexDef.Predicate = pred
+	}
 	return indexDef.String(), nil
 }
 
@@ -1156,6 +1244,39 @@ CREATE TABLE pg_catalog.pg_language (
 	},
 }
 
+// See: https://www.postgresql.org/docs/10/static/view-pg-matviews.html.
+var pgCatalogMatViewsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_matviews (
+	schemaname NAME,
+	matviewname NAME,
+	matviewowner NAME,
+	tablespace NAME,
+	hasindexes BOOL,
+	ispopulated BOOL,
+	definition TEXT
+);
+`,
+	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /*virtual schemas do not have views*/
+			func(db *sqlbase.DatabaseDescriptor, scName string, desc *sqlbase.TableDescriptor) error {
+				if !desc.IsMaterializedView() {
+					return nil
+				}
+				return addRow(
+					tree.NewDName(scName),    // schemaname
+					tree.NewDName(desc.Name), // matviewname
+					tree.DNull,               // matviewowner
+					tree.DNull,               // tablespace
+					tree.MakeDBool(tree.DBool(len(desc.Indexes) > 0)), // hasindexes
+					// A materialized view is populated when it is created.
+					tree.DBoolTrue,                  // ispopulated
+					tree.NewDString(desc.ViewQuery), // definition
+				)
+			})
+	},
+}
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-namespace.html.
 var pgCatalogNamespaceTable = virtualSchemaTable{
 	schema: `
@@ -1305,6 +1426,12 @@ var (
 	_ = proArgModeTable
 )
 
+var (
+	proVolatileImmutable = tree.NewDString("i")
+	proVolatileStable    = tree.NewDString("s")
+	proVolatileVolatile  = tree.NewDString("v")
+)
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-proc.html.
 var pgCatalogProcTable = virtualSchemaTable{
 	schema: `
@@ -1343,7 +1470,7 @@ CREATE TABLE pg_catalog.pg_proc (
 `,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
-		return forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
+		if err := forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
 			nspOid := h.NamespaceOid(db, pgCatalogName)
 			for _, name := range builtins.AllBuiltinNames {
 				// parser.Builtins contains duplicate uppercase and lowercase keys.
@@ -1459,6 +1586,80 @@ CREATE TABLE pg_catalog.pg_proc (
 				}
 			}
 			return nil
+		}); err != nil {
+			return err
+		}
+
+		// User-defined functions.
+		return forEachFunctionDesc(ctx, p, dbContext, func(db *DatabaseDescriptor, fn *sqlbase.FunctionDescriptor) error {
+			nspOid := h.NamespaceOid(db, tree.PublicSchema)
+			for i := range fn.Overloads {
+				o := &fn.Overloads[i]
+				dArgTypes := tree.NewDArray(types.Oid)
+				dArgNames := tree.NewDArray(types.String)
+				hasArgNames := false
+				for _, arg := range o.Args {
+					if err := dArgTypes.Append(tree.NewDOid(tree.DInt(arg.Type.ToDatumType().Oid()))); err != nil {
+						return err
+					}
+					if err := dArgNames.Append(tree.NewDString(arg.Name)); err != nil {
+						return err
+					}
+					hasArgNames = hasArgNames || arg.Name != ""
+				}
+				var argNames tree.Datum = tree.DNull
+				if hasArgNames {
+					argNames = dArgNames
+				}
+				retType := tree.NewDOid(tree.DInt(o.ReturnType.ToDatumType().Oid()))
+				if o.ReturnsTrigger {
+					retType = tree.NewDOid(tree.DInt(oid.T_trigger))
+				}
+				var volatility tree.Datum
+				switch o.Volatility {
+				case sqlbase.FunctionDescriptor_Overload_IMMUTABLE:
+					volatility = proVolatileImmutable
+				case sqlbase.FunctionDescriptor_Overload_STABLE:
+					volatility = proVolatileStable
+				default:
+					volatility = proVolatileVolatile
+				}
+				if err := addRow(
+					h.UserDefinedFunctionOid(fn, o),         // oid
+					tree.NewDName(fn.Name),                  // proname
+					nspOid,                                  // pronamespace
+					tree.DNull,                              // proowner
+					oidZero,                                 // prolang
+					tree.DNull,                              // procost
+					tree.DNull,                              // prorows
+					oidZero,                                 // provariadic
+					tree.DNull,                              // protransform
+					tree.DBoolFalse,                         // proisagg
+					tree.DBoolFalse,                         // proiswindow
+					tree.DBoolFalse,                         // prosecdef
+					tree.DBoolFalse,                         // proleakproof
+					tree.DBoolFalse,                         // proisstrict
+					tree.DBoolFalse,                         // proretset
+					volatility,                              // provolatile
+					tree.DNull,                              // proparallel
+					tree.NewDInt(tree.DInt(len(o.Args))),    // pronargs
+					tree.NewDInt(tree.DInt(0)),              // pronargdefaults
+					retType,                                 // prorettype
+					tree.NewDOidVectorFromDArray(dArgTypes), // proargtypes
+					tree.DNull,                              // proallargtypes
+					tree.DNull,                              // proargmodes
+					argNames,                                // proargnames
+					tree.DNull,                              // proargdefaults
+					tree.DNull,                              // protrftypes
+					tree.NewDString(o.Body),                 // prosrc
+					tree.DNull,                              // probin
+					tree.DNull,                              // proconfig
+					tree.DNull,                              // proacl
+				); err != nil {
+					return err
+				}
+			}
+			return nil
 		})
 	},
 }
@@ -1575,14 +1776,25 @@ CREATE TABLE pg_catalog.pg_sequence (
 					return nil
 				}
 				opts := table.SequenceOpts
+				typOid := oid.T_int8
+				switch opts.AsIntegerType {
+				case coltypes.Int2.TypeName():
+					typOid = oid.T_int2
+				case coltypes.Int4.TypeName():
+					typOid = oid.T_int4
+				}
+				cacheSize := opts.CacheSize
+				if cacheSize < 1 {
+					cacheSize = 1
+				}
 				return addRow(
 					h.TableOid(db, scName, table),           // seqrelid
-					tree.NewDOid(tree.DInt(oid.T_int8)),     // seqtypid
+					tree.NewDOid(tree.DInt(typOid)),         // seqtypid
 					tree.NewDInt(tree.DInt(opts.Start)),     // seqstart
 					tree.NewDInt(tree.DInt(opts.Increment)), // seqincrement
 					tree.NewDInt(tree.DInt(opts.MaxValue)),  // seqmax
 					tree.NewDInt(tree.DInt(opts.MinValue)),  // seqmin
-					tree.NewDInt(1),                         // seqcache
+					tree.NewDInt(tree.DInt(cacheSize)),      // seqcache
 					tree.DBoolFalse,                         // seqcycle
 				)
 			})
@@ -1734,11 +1946,82 @@ CREATE TABLE pg_catalog.pg_trigger (
 );
 `,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Triggers are unsupported.
-		return nil
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have triggers */
+			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
+				tableOid := h.TableOid(db, scName, table)
+				for i := range table.Triggers {
+					t := &table.Triggers[i]
+					funcOid := oidZero
+					fn := &sqlbase.FunctionDescriptor{}
+					if err := getDescriptorByID(ctx, p.txn, t.FunctionID, fn); err != nil {
+						return err
+					}
+					if idx := fn.FindOverload(nil /* argTypes */); idx >= 0 {
+						funcOid = h.UserDefinedFunctionOid(fn, &fn.Overloads[idx])
+					}
+					attrs, err := makeZeroedIntVector(0)
+					if err != nil {
+						return err
+					}
+					if err := addRow(
+						h.TriggerOid(db, scName, table, t), // oid
+						tableOid,                           // tgrelid
+						tree.NewDName(t.Name),              // tgname
+						funcOid,                            // tgfoid
+						tree.NewDInt(triggerType(t)),       // tgtype
+						trgEnabledOrigin,                   // tgenabled
+						tree.DBoolFalse,                    // tgisinternal
+						oidZero,                            // tgconstrrelid
+						oidZero,                            // tgconstrindid
+						oidZero,                            // tgconstraint
+						tree.DBoolFalse,                    // tgdeferrable
+						tree.DBoolFalse,                    // tginitdeferred
+						tree.NewDInt(0),                    // tgnargs
+						attrs,                              // tgattr
+						tree.NewDBytes(""),                 // tgargs
+						tree.DNull,                         // tgqual
+						tree.DNull,                         // tgoldtable
+						tree.DNull,                         // tgnewtable
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
 }
 
+var trgEnabledOrigin = tree.NewDString("O")
+
+// See https://github.com/postgres/postgres/blob/master/src/include/catalog/pg_trigger.h.
+const (
+	trgTypeRow    = 1 << 0
+	trgTypeBefore = 1 << 1
+	trgTypeInsert = 1 << 2
+	trgTypeDelete = 1 << 3
+	trgTypeUpdate = 1 << 4
+)
+
+// triggerType returns the tgtype bitmask of a trigger.
+func triggerType(t *sqlbase.TriggerDescriptor) tree.DInt {
+	typ := trgTypeRow
+	if t.Timing == sqlbase.TriggerDescriptor_BEFORE {
+		typ |= trgTypeBefore
+	}
+	for _, e := range t.Events {
+		switch e {
+		case sqlbase.TriggerDescriptor_INSERT:
+			typ |= trgTypeInsert
+		case sqlbase.TriggerDescriptor_DELETE:
+			typ |= trgTypeDelete
+		case sqlbase.TriggerDescriptor_UPDATE:
+			typ |= trgTypeUpdate
+		}
+	}
+	return tree.DInt(typ)
+}
+
 var (
 	typTypeBase      = tree.NewDString("b")
 	typTypeComposite = tree.NewDString("c")
@@ -1750,7 +2033,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typTypeComposite
 	_ = typTypeDomain
-	_ = typTypeEnum
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -1773,13 +2055,15 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryComposite
-	_ = typCategoryEnum
 	_ = typCategoryGeometric
 	_ = typCategoryRange
 	_ = typCategoryBitString
 	_ = typCategoryUnknown
 
 	typDelim = tree.NewDString(",")
+
+	// As in postgres, enum values have the size of an OID.
+	enumTypLen = tree.NewDInt(4)
 )
 
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html.
@@ -1821,7 +2105,7 @@ CREATE TABLE pg_catalog.pg_type (
 `,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
-		return forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
+		if err := forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
 			nspOid := h.NamespaceOid(db, pgCatalogName)
 
 			for o, typ := range types.OidToType {
@@ -1895,6 +2179,48 @@ CREATE TABLE pg_catalog.pg_type (
 				}
 			}
 			return nil
+		}); err != nil {
+			return err
+		}
+
+		// User-defined enum types.
+		return forEachTypeDesc(ctx, p, dbContext, func(db *DatabaseDescriptor, typ *sqlbase.TypeDescriptor) error {
+			return addRow(
+				tree.NewDOid(tree.DInt(typ.EnumType().Oid())), // oid
+				tree.NewDName(typ.Name),                       // typname
+				h.NamespaceOid(db, tree.PublicSchema),         // typnamespace
+				tree.DNull,                                    // typowner
+				enumTypLen,                                    // typlen
+				tree.DBoolTrue,                                // typbyval
+				typTypeEnum,                                   // typtype
+				typCategoryEnum,                               // typcategory
+				tree.DBoolFalse,                               // typispreferred
+				tree.DBoolTrue,                                // typisdefined
+				typDelim,                                      // typdelim
+				oidZero,                                       // typrelid
+				oidZero,                                       // typelem
+				oidZero,                                       // typarray
+
+				// regproc references
+				h.RegProc("enum_in"),   // typinput
+				h.RegProc("enum_out"),  // typoutput
+				h.RegProc("enum_recv"), // typreceive
+				h.RegProc("enum_send"), // typsend
+				oidZero,                // typmodin
+				oidZero,                // typmodout
+				oidZero,                // typanalyze
+
+				tree.DNull,      // typalign
+				tree.DNull,      // typstorage
+				tree.DBoolFalse, // typnotnull
+				oidZero,         // typbasetype
+				negOneVal,       // typtypmod
+				zeroVal,         // typndims
+				oidZero,         // typcollation
+				tree.DNull,      // typdefaultbin
+				tree.DNull,      // typdefault
+				tree.DNull,      // typacl
+			)
 		})
 	},
 }
@@ -2057,6 +2383,7 @@ var datumToTypeCategory = map[reflect.Type]*tree.DString{
 	reflect.TypeOf(types.Bytes):       typCategoryUserDefined,
 	reflect.TypeOf(types.Date):        typCategoryDateTime,
 	reflect.TypeOf(types.Time):        typCategoryDateTime,
+	reflect.TypeOf(types.TimeTZ):      typCategoryDateTime,
 	reflect.TypeOf(types.Float):       typCategoryNumeric,
 	reflect.TypeOf(types.Int):         typCategoryNumeric,
 	reflect.TypeOf(types.Interval):    typCategoryTimespan,
@@ -2096,7 +2423,7 @@ CREATE TABLE pg_catalog.pg_views (
 		// because it does not distinguish views in separate databases.
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /*virtual schemas do not have views*/
 			func(db *sqlbase.DatabaseDescriptor, scName string, desc *sqlbase.TableDescriptor) error {
-				if !desc.IsView() {
+				if !desc.IsView() || desc.IsMaterializedView() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
@@ -2191,6 +2518,8 @@ const (
 	userTypeTag
 	collationTypeTag
 	operatorTypeTag
+	enumLabelTypeTag
+	triggerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -2352,6 +2681,29 @@ func (h oidHasher) BuiltinOid(name string, builtin *tree.Overload) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) UserDefinedFunctionOid(
+	fn *sqlbase.FunctionDescriptor, o *sqlbase.FunctionDescriptor_Overload,
+) *tree.DOid {
+	h.writeTypeTag(functionTypeTag)
+	h.writeUInt32(uint32(fn.ID))
+	h.writeStr(o.Signature())
+	return h.getOid()
+}
+
+func (h oidHasher) TriggerOid(
+	db *sqlbase.DatabaseDescriptor,
+	scName string,
+	table *sqlbase.TableDescriptor,
+	trigger *sqlbase.TriggerDescriptor,
+) *tree.DOid {
+	h.writeTypeTag(triggerTypeTag)
+	h.writeDB(db)
+	h.writeSchema(scName)
+	h.writeTable(table)
+	h.writeStr(trigger.Name)
+	return h.getOid()
+}
+
 func (h oidHasher) RegProc(name string) tree.Datum {
 	_, overloads := builtins.GetBuiltinProperties(name)
 	if len(overloads) == 0 {
@@ -2372,6 +2724,13 @@ func (h oidHasher) CollationOid(collation string) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) EnumLabelOid(typ *sqlbase.TypeDescriptor, label string) *tree.DOid {
+	h.writeTypeTag(enumLabelTypeTag)
+	h.writeUInt32(uint32(typ.ID))
+	h.writeStr(label)
+	return h.getOid()
+}
+
 func (h oidHasher) OperatorOid(name string, leftType, rightType, returnType *tree.DOid) *tree.DOid {
 	h.writeTypeTag(operatorTypeTag)
 	h.writeStr(name)
@@ -164,6 +164,17 @@ func TestBinaryTime(t *testing.T) {
 	})
 }
 
+func TestBinaryTimeTZ(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	testBinaryDatumType(t, "timetz", func(val string) tree.Datum {
+		d, err := tree.ParseDTimeTZ(val, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	})
+}
+
 func TestBinaryUuid(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	testBinaryDatumType(t, "uuid", func(val string) tree.Datum {
@@ -249,6 +249,14 @@ func (r *commandResult) SetPortalOutput(
 	_ /* err */ = r.conn.writeRowDescription(ctx, cols, formatCodes, &r.conn.writerState.buf)
 }
 
+// BufferNotification is part of the NotificationResult interface.
+func (r *commandResult) BufferNotification(n sql.Notification) {
+	r.conn.writerState.fi.registerCmd(r.pos)
+	r.conn.bufferNotification(n)
+	// The notifications are flushed to the client when the result is closed.
+	r.typ = flush
+}
+
 // IncrementRowsAffected is part of the CommandResult interface.
 func (r *commandResult) IncrementRowsAffected(n int) {
 	r.rowsAffected += n
@@ -0,0 +1,139 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package pgwire_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestNotify checks that the notifications sent with NOTIFY on a node are
+// delivered to the clients listening on another node.
+func TestNotify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tc := serverutils.StartTestCluster(t, 2, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(context.TODO())
+
+	sender := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	senderPid := int(tc.Server(0).NodeID())
+
+	pgURL, cleanup := sqlutils.PGUrl(
+		t, tc.Server(1).ServingAddr(), "TestNotify", url.User(security.RootUser))
+	defer cleanup()
+
+	// The listener is idle between the notifications.
+	listener := pq.NewListener(pgURL.String(), time.Second, time.Second, nil /* eventCallback */)
+	defer listener.Close()
+	if err := listener.Listen("c"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectNotification := func(t *testing.T, ch <-chan *pq.Notification, payload string) {
+		t.Helper()
+		for {
+			select {
+			case n := <-ch:
+				if n == nil {
+					// The listener reconnected.
+					continue
+				}
+				if n.Channel != "c" || n.Extra != payload || n.BePid != senderPid {
+					t.Fatalf("expected notification %q from node %d on channel c, got %q from node %d on channel %s",
+						payload, senderPid, n.Extra, n.BePid, n.Channel)
+				}
+				return
+			case <-time.After(testutils.DefaultSucceedsSoonDuration):
+				t.Fatalf("notification %q was not received", payload)
+			}
+		}
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		sender.Exec(t, `NOTIFY c, 'implicit'`)
+		expectNotification(t, listener.Notify, "implicit")
+
+		sender.Exec(t, `BEGIN; NOTIFY c, 'explicit'; COMMIT`)
+		expectNotification(t, listener.Notify, "explicit")
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		sender.Exec(t, `BEGIN; NOTIFY c, 'rolled back'; ROLLBACK`)
+		sender.Exec(t, `BEGIN; SELECT pg_notify('c', 'rolled back'); ROLLBACK`)
+
+		// The notifications are delivered in order, so the rolled back ones
+		// would be received first.
+		sender.Exec(t, `NOTIFY c, 'committed'`)
+		expectNotification(t, listener.Notify, "committed")
+	})
+
+	t.Run("late commit", func(t *testing.T) {
+		// The transaction commits after the listening node has scanned the
+		// table several times past the time of the NOTIFY statement.
+		tx, err := sender.DB.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.Exec(`NOTIFY c, 'late'`); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(tc.Server(0).Clock().MaxOffset() + time.Second)
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		expectNotification(t, listener.Notify, "late")
+	})
+
+	t.Run("open transaction", func(t *testing.T) {
+		notifications := make(chan *pq.Notification, 10)
+		conn, err := pq.NewListenerConn(pgURL.String(), notifications)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if _, err := conn.Listen("c"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := conn.ExecSimpleQuery(`BEGIN`); err != nil {
+			t.Fatal(err)
+		}
+
+		// Once the idle listener has received the notification, the node has
+		// handed it to both sessions.
+		sender.Exec(t, `NOTIFY c, 'during transaction'`)
+		expectNotification(t, listener.Notify, "during transaction")
+		select {
+		case n := <-notifications:
+			t.Fatalf("notification %q delivered inside a transaction", n.Extra)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		if _, err := conn.ExecSimpleQuery(`COMMIT`); err != nil {
+			t.Fatal(err)
+		}
+		expectNotification(t, notifications, "during transaction")
+	})
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 )
 
@@ -266,6 +267,12 @@ func DecodeOidDatum(id oid.Oid, code FormatCode, b []byte) (tree.Datum, error) {
 				return nil, errors.Errorf("could not parse string %q as time", b)
 			}
 			return d, nil
+		case oid.T_timetz:
+			d, err := tree.ParseDTimeTZ(string(b), time.UTC)
+			if err != nil {
+				return nil, errors.Errorf("could not parse string %q as timetz", b)
+			}
+			return d, nil
 
 		case oid.T_interval:
 			d, err := tree.ParseDInterval(string(b))
@@ -478,6 +485,14 @@ func DecodeOidDatum(id oid.Oid, code FormatCode, b []byte) (tree.Datum, error) {
 			}
 			i := int64(binary.BigEndian.Uint64(b))
 			return tree.MakeDTime(timeofday.TimeOfDay(i)), nil
+		case oid.T_timetz:
+			if len(b) < 12 {
+				return nil, errors.Errorf("timetz requires 12 bytes for binary format")
+			}
+			i := int64(binary.BigEndian.Uint64(b))
+			// The zone is received in seconds west of UTC.
+			zone := int32(binary.BigEndian.Uint32(b[8:]))
+			return tree.NewDTimeTZ(timetz.MakeTimeTZ(timeofday.TimeOfDay(i), -zone)), nil
 		case oid.T_interval:
 			if len(b) < 16 {
 				return nil, errors.Errorf("interval requires 16 bytes for binary format")
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
@@ -120,6 +121,9 @@ func (b *writeBuffer) writeTextDatum(
 	case *tree.DCollatedString:
 		b.writeLengthPrefixedString(v.Contents)
 
+	case *tree.DEnum:
+		b.writeLengthPrefixedString(v.Label())
+
 	case *tree.DDate:
 		t := timeutil.Unix(int64(*v)*secondsInDay, 0)
 		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
@@ -133,6 +137,12 @@ func (b *writeBuffer) writeTextDatum(
 		b.putInt32(int32(len(s)))
 		b.write(s)
 
+	case *tree.DTimeTZ:
+		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
+		s := formatTimeTZ(v.TimeTZ, b.putbuf[4:4])
+		b.putInt32(int32(len(s)))
+		b.write(s)
+
 	case *tree.DTimestamp:
 		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
 		s := formatTs(v.Time, nil, b.putbuf[4:4])
@@ -340,6 +350,9 @@ func (b *writeBuffer) writeBinaryDatum(
 	case *tree.DCollatedString:
 		b.writeLengthPrefixedString(v.Contents)
 
+	case *tree.DEnum:
+		b.writeLengthPrefixedString(v.Label())
+
 	case *tree.DTimestamp:
 		b.putInt32(8)
 		b.putInt64(timeToPgBinary(v.Time, nil))
@@ -356,6 +369,12 @@ func (b *writeBuffer) writeBinaryDatum(
 		b.putInt32(8)
 		b.putInt64(int64(*v))
 
+	case *tree.DTimeTZ:
+		// The zone is sent in seconds west of UTC.
+		b.putInt32(12)
+		b.putInt64(int64(v.TimeOfDay))
+		b.putInt32(-v.OffsetSecs)
+
 	case *tree.DInterval:
 		b.putInt32(16)
 		b.putInt64(v.Nanos / int64(time.Microsecond/time.Nanosecond))
@@ -409,6 +428,13 @@ func formatTime(t timeofday.TimeOfDay, tmp []byte) []byte {
 	return t.ToTime().AppendFormat(tmp, pgTimeFormat)
 }
 
+// formatTimeTZ formats t into a format lib/pq understands, appending to the
+// provided tmp buffer and reallocating if needed. The function will then
+// return the resulting buffer.
+func formatTimeTZ(t timetz.TimeTZ, tmp []byte) []byte {
+	return append(formatTime(t.TimeOfDay, tmp), timetz.FormatOffset(t.OffsetSecs)...)
+}
+
 // formatTs formats t with an optional offset into a format lib/pq understands,
 // appending to the provided tmp buffer and reallocating if needed. The function
 // will then return the resulting buffer. formatTs is mostly cribbed from
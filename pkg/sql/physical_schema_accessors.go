@@ -89,8 +89,25 @@ func (a UncachedPhysicalAccessor) GetObjectNames(
 		return nil, err
 	}
 
-	var tableNames tree.TableNames
+	// Types and functions share the namespace with tables. Fetch the
+	// descriptors to filter them out.
+	b := flags.txn.NewBatch()
 	for _, row := range sr {
+		b.Get(sqlbase.MakeDescMetadataKey(sqlbase.ID(row.ValueInt())))
+	}
+	if err := flags.txn.Run(flags.ctx, b); err != nil {
+		return nil, err
+	}
+
+	var tableNames tree.TableNames
+	for i, row := range sr {
+		var desc sqlbase.Descriptor
+		if err := b.Results[i].Rows[0].ValueProto(&desc); err != nil {
+			return nil, err
+		}
+		if desc.GetType() != nil || desc.GetFunction() != nil {
+			continue
+		}
 		_, tableName, err := encoding.DecodeUnsafeStringAscending(
 			bytes.TrimPrefix(row.Key, prefix), nil)
 		if err != nil {
@@ -191,6 +208,46 @@ func (a *CachedPhysicalAccessor) GetDatabaseDesc(
 	return a.SchemaAccessor.GetDatabaseDesc(name, flags)
 }
 
+// IsValidSchema implements the SchemaAccessor interface.
+func (a *CachedPhysicalAccessor) IsValidSchema(dbDesc *DatabaseDescriptor, scName string) bool {
+	if a.tc.isTemporarySchema(scName) {
+		// The temporary schema of the session only exists in the
+		// databases where the session has created temporary tables.
+		return a.tc.temporarySchemaIDs[dbDesc.ID] != 0
+	}
+	return a.SchemaAccessor.IsValidSchema(dbDesc, scName)
+}
+
+// GetObjectNames implements the SchemaAccessor interface.
+func (a *CachedPhysicalAccessor) GetObjectNames(
+	dbDesc *DatabaseDescriptor, scName string, flags DatabaseListFlags,
+) (TableNames, error) {
+	schemaID := a.tc.temporarySchemaIDs[dbDesc.ID]
+	if !a.tc.isTemporarySchema(scName) || schemaID == 0 {
+		return a.SchemaAccessor.GetObjectNames(dbDesc, scName, flags)
+	}
+
+	// Only tables can be temporary, there is no need to filter out types.
+	prefix := sqlbase.MakeNameMetadataKey(schemaID, "")
+	sr, err := flags.txn.Scan(flags.ctx, prefix, prefix.PrefixEnd(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var tableNames tree.TableNames
+	for _, row := range sr {
+		_, tableName, err := encoding.DecodeUnsafeStringAscending(
+			bytes.TrimPrefix(row.Key, prefix), nil)
+		if err != nil {
+			return nil, err
+		}
+		tn := tree.MakeTableNameWithSchema(tree.Name(dbDesc.Name), tree.Name(scName), tree.Name(tableName))
+		tn.ExplicitCatalog = flags.explicitPrefix
+		tn.ExplicitSchema = flags.explicitPrefix
+		tableNames = append(tableNames, tn)
+	}
+	return tableNames, nil
+}
+
 // GetObjectDesc implements the SchemaAccessor interface.
 func (a *CachedPhysicalAccessor) GetObjectDesc(
 	name *ObjectName, flags ObjectLookupFlags,
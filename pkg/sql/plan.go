@@ -161,9 +161,17 @@ type planNodeFastPath interface {
 var _ planNode = &alterIndexNode{}
 var _ planNode = &alterSequenceNode{}
 var _ planNode = &alterTableNode{}
+var _ planNode = &alterTypeNode{}
+var _ planNode = &applyJoinNode{}
+var _ planNode = &commentOnColumnNode{}
+var _ planNode = &commentOnIndexNode{}
+var _ planNode = &commentOnTableNode{}
 var _ planNode = &createDatabaseNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createSequenceNode{}
+var _ planNode = &createTypeNode{}
+var _ planNode = &createFunctionNode{}
+var _ planNode = &createTriggerNode{}
 var _ planNode = &createStatsNode{}
 var _ planNode = &createTableNode{}
 var _ planNode = &CreateUserNode{}
@@ -175,6 +183,9 @@ var _ planNode = &dropDatabaseNode{}
 var _ planNode = &dropIndexNode{}
 var _ planNode = &dropSequenceNode{}
 var _ planNode = &dropTableNode{}
+var _ planNode = &dropTypeNode{}
+var _ planNode = &dropFunctionNode{}
+var _ planNode = &dropTriggerNode{}
 var _ planNode = &DropUserNode{}
 var _ planNode = &dropViewNode{}
 var _ planNode = &explainDistSQLNode{}
@@ -186,11 +197,16 @@ var _ planNode = &indexJoinNode{}
 var _ planNode = &insertNode{}
 var _ planNode = &joinNode{}
 var _ planNode = &limitNode{}
+var _ planNode = &listenNode{}
+var _ planNode = &notifyNode{}
 var _ planNode = &ordinalityNode{}
 var _ planNode = &projectSetNode{}
+var _ planNode = &recursiveCTENode{}
+var _ planNode = &refreshMaterializedViewNode{}
 var _ planNode = &relocateNode{}
 var _ planNode = &renderNode{}
 var _ planNode = &rowCountNode{}
+var _ planNode = &scanBufferNode{}
 var _ planNode = &scanNode{}
 var _ planNode = &scatterNode{}
 var _ planNode = &serializeNode{}
@@ -262,6 +278,10 @@ type planTop struct {
 	// TODO(knz): Remove this in favor of a better encapsulated mechanism.
 	deps planDependencies
 
+	// funcDeps, if non-nil, collects the user-defined functions used by this
+	// query. Like deps, this is used by CREATE VIEW.
+	funcDeps map[sqlbase.ID]struct{}
+
 	// cteNameEnvironment collects the mapping from common table expression alias
 	// to the planNodes that represent their source.
 	cteNameEnvironment cteNameEnvironment
@@ -625,6 +645,14 @@ func startExec(params runParams, plan planNode) error {
 			case *showTraceNode:
 				// showTrace needs to override the params struct, and does so in its startExec() method.
 				return false, nil
+			case *recursiveCTENode:
+				// recursiveCTE starts its initial query in its startExec() method, and
+				// plans and starts the recursive query separately for every iteration.
+				return false, nil
+			case *applyJoinNode:
+				// applyJoin starts its left side in its startExec() method, and plans
+				// and starts the right side separately for every left row.
+				return false, nil
 			case *createStatsNode:
 				return false, errors.Errorf("statistics can only be created via DistSQL")
 			}
@@ -758,12 +786,20 @@ func (p *planner) newPlan(
 		return p.AlterTable(ctx, n)
 	case *tree.AlterSequence:
 		return p.AlterSequence(ctx, n)
+	case *tree.AlterType:
+		return p.AlterType(ctx, n)
 	case *tree.AlterUserSetPassword:
 		return p.AlterUserSetPassword(ctx, n)
 	case *tree.CancelQueries:
 		return p.CancelQueries(ctx, n)
 	case *tree.CancelSessions:
 		return p.CancelSessions(ctx, n)
+	case *tree.CommentOnColumn:
+		return p.CommentOnColumn(ctx, n)
+	case *tree.CommentOnIndex:
+		return p.CommentOnIndex(ctx, n)
+	case *tree.CommentOnTable:
+		return p.CommentOnTable(ctx, n)
 	case *tree.ControlJobs:
 		return p.ControlJobs(ctx, n)
 	case *tree.Scrub:
@@ -780,6 +816,12 @@ func (p *planner) newPlan(
 		return p.CreateView(ctx, n)
 	case *tree.CreateSequence:
 		return p.CreateSequence(ctx, n)
+	case *tree.CreateType:
+		return p.CreateType(ctx, n)
+	case *tree.CreateFunction:
+		return p.CreateFunction(ctx, n)
+	case *tree.CreateTrigger:
+		return p.CreateTrigger(ctx, n)
 	case *tree.CreateStats:
 		return p.CreateStatistics(ctx, n)
 	case *tree.Deallocate:
@@ -798,6 +840,12 @@ func (p *planner) newPlan(
 		return p.DropView(ctx, n)
 	case *tree.DropSequence:
 		return p.DropSequence(ctx, n)
+	case *tree.DropType:
+		return p.DropType(ctx, n)
+	case *tree.DropFunction:
+		return p.DropFunction(ctx, n)
+	case *tree.DropTrigger:
+		return p.DropTrigger(ctx, n)
 	case *tree.DropUser:
 		return p.DropUser(ctx, n)
 	case *tree.Execute:
@@ -808,8 +856,14 @@ func (p *planner) newPlan(
 		return p.Grant(ctx, n)
 	case *tree.Insert:
 		return p.Insert(ctx, n, desiredTypes)
+	case *tree.Listen:
+		return p.Listen(ctx, n)
+	case *tree.Notify:
+		return p.Notify(ctx, n)
 	case *tree.ParenSelect:
 		return p.newPlan(ctx, n.Select, desiredTypes)
+	case *tree.RefreshMaterializedView:
+		return p.RefreshMaterializedView(ctx, n)
 	case *tree.Relocate:
 		return p.Relocate(ctx, n)
 	case *tree.RenameColumn:
@@ -828,7 +882,7 @@ func (p *planner) newPlan(
 		return p.Select(ctx, n, desiredTypes)
 	case *tree.SelectClause:
 		return p.SelectClause(ctx, n, nil /* orderBy */, nil /* limit */, nil, /* with */
-			desiredTypes, publicColumns)
+			nil /* locking */, desiredTypes, publicColumns)
 	case *tree.SetClusterSetting:
 		return p.SetClusterSetting(ctx, n)
 	case *tree.SetZoneConfig:
@@ -896,6 +950,8 @@ func (p *planner) newPlan(
 		return p.Truncate(ctx, n)
 	case *tree.UnionClause:
 		return p.Union(ctx, n, desiredTypes)
+	case *tree.Unlisten:
+		return p.Unlisten(ctx, n)
 	case *tree.Update:
 		return p.Update(ctx, n, desiredTypes)
 	case *tree.ValuesClause:
@@ -959,7 +1015,7 @@ func (p *planner) doPrepare(ctx context.Context, stmt tree.Statement) (planNode,
 		return p.Select(ctx, n, nil)
 	case *tree.SelectClause:
 		return p.SelectClause(ctx, n, nil /* orderBy */, nil /* limit */, nil, /* with */
-			nil /* desiredTypes */, publicColumns)
+			nil /* locking */, nil /* desiredTypes */, publicColumns)
 	case *tree.SetClusterSetting:
 		return p.SetClusterSetting(ctx, n)
 	case *tree.SetVar:
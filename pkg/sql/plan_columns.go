@@ -67,6 +67,12 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return n.columns
 	case *unionNode:
 		return n.columns
+	case *recursiveCTENode:
+		return n.columns
+	case *applyJoinNode:
+		return n.columns
+	case *scanBufferNode:
+		return n.buffer.columns
 	case *valuesNode:
 		return n.columns
 	case *explainPlanNode:
@@ -35,7 +35,8 @@ func collectSpans(params runParams, plan planNode) (reads, writes roachpb.Spans,
 	case
 		*valuesNode,
 		*zeroNode,
-		*unaryNode:
+		*unaryNode,
+		*scanBufferNode:
 		return nil, nil, nil
 
 	case *scanNode:
@@ -94,6 +95,10 @@ func collectSpans(params runParams, plan planNode) (reads, writes roachpb.Spans,
 		return concatSpans(params, n.left.plan, n.right.plan)
 	case *unionNode:
 		return concatSpans(params, n.left, n.right)
+	case *recursiveCTENode:
+		return concatSpans(params, n.initial, n.recursive)
+	case *applyJoinNode:
+		return concatSpans(params, n.input, n.right)
 	}
 
 	panic(fmt.Sprintf("don't know how to collect spans for node %T", plan))
@@ -70,6 +70,10 @@ type extendedEvalContext struct {
 
 	SchemaChangers *schemaChangerCollection
 
+	// Listens accumulates the LISTEN and UNLISTEN statements of the
+	// transaction. It is nil if the session cannot receive notifications.
+	Listens *listenCollection
+
 	schemaAccessors *schemaInterface
 }
 
@@ -137,6 +141,10 @@ type planner struct {
 	// want to do 1PC transactions have to implement the autoCommitNode interface.
 	autoCommit bool
 
+	// curLocking is the locking clause (FOR UPDATE, etc) of the SELECT whose
+	// FROM clause is currently being planned, if any.
+	curLocking *lockingSpec
+
 	// cancelChecker is used by planNodes to check for cancellation of the associated
 	// query.
 	cancelChecker *sqlbase.CancelChecker
@@ -242,6 +250,8 @@ func newInternalPlanner(
 	p.semaCtx = tree.MakeSemaContext(sd.User == security.RootUser /* privileged */)
 	p.semaCtx.Location = &sd.DataConversion.Location
 	p.semaCtx.SearchPath = sd.SearchPath
+	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 
 	plannerMon := mon.MakeUnlimitedMonitor(ctx,
 		"internal-planner",
@@ -0,0 +1,282 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+)
+
+// recursiveCTEMaxIterations is the upper bound on the number of iterations
+// that the recursive term of a WITH RECURSIVE query may perform. It protects
+// the server against runaway recursion, e.g. a recursive term without a
+// terminating condition.
+var recursiveCTEMaxIterations = settings.RegisterNonNegativeIntSetting(
+	"sql.recursive_cte.max_iterations",
+	"maximum number of iterations of the recursive term of a WITH RECURSIVE query",
+	100000,
+)
+
+// recursiveCTEIterationFn is used by recursiveCTENode to produce a new plan
+// for the recursive term at every iteration. The resulting plan reads the
+// rows produced by the previous iteration through a scanBufferNode that
+// refers to the given recursiveCTENode.
+type recursiveCTEIterationFn func(params runParams, buffer *recursiveCTENode) (planNode, error)
+
+// recursiveCTENode implements the logic for a recursive CTE:
+//  1. Evaluate the initial query; emit the results and also save them in
+//     a "working" table.
+//  2. So long as the working table is not empty:
+//     - evaluate the recursive query, substituting the current contents of
+//       the working table for the recursive self-reference;
+//     - emit all resulting rows, and save them as the next iteration's
+//       working table.
+//
+// For the UNION (non-ALL) form, rows that were already emitted are discarded
+// before they are emitted or added to the next working table.
+//
+// The rows of the working table are kept in memory and accounted for
+// against the session memory monitor.
+type recursiveCTENode struct {
+	initial planNode
+
+	// recursive is the plan of the recursive term built at planning time. It
+	// is only used to describe the query (e.g. by EXPLAIN) and is never
+	// executed: each iteration runs a fresh plan produced by genIterationFn.
+	recursive planNode
+
+	genIterationFn recursiveCTEIterationFn
+
+	// label is the name of the CTE, used for display.
+	label string
+
+	// unionAll is set for the UNION ALL form; otherwise duplicate rows are
+	// eliminated.
+	unionAll bool
+
+	columns sqlbase.ResultColumns
+
+	run recursiveCTERun
+}
+
+// recursiveCTERun contains the run-time state of recursiveCTENode during
+// local execution.
+type recursiveCTERun struct {
+	// workingRows contains the rows produced by the previous iteration; they
+	// are read by the scanBufferNode of the current iteration.
+	workingRows *sqlbase.RowContainer
+	// nextRows accumulates the rows produced by the current iteration.
+	nextRows *sqlbase.RowContainer
+
+	// initialDone is set once all the rows of the initial query were read.
+	initialDone bool
+	// iterationPlan is the plan of the current iteration, if any.
+	iterationPlan planNode
+	// iterations counts the iterations performed so far.
+	iterations int64
+
+	// seen contains the encoding of every row emitted so far; it is only used
+	// for the UNION form. seenAcc accounts for its memory usage.
+	seen    map[string]struct{}
+	seenAcc mon.BoundAccount
+	// scratch is a preallocated buffer for encoding the current row.
+	scratch []byte
+
+	currentRow tree.Datums
+}
+
+func (n *recursiveCTENode) startExec(params runParams) error {
+	// The children of this node are not started by startExec() on the
+	// enclosing plan; the initial query is started here and the plans for the
+	// recursive term are started as needed by Next().
+	if err := startExec(params, n.initial); err != nil {
+		return err
+	}
+	colTypes := sqlbase.ColTypeInfoFromResCols(n.columns)
+	n.run.workingRows = sqlbase.NewRowContainer(
+		params.EvalContext().Mon.MakeBoundAccount(), colTypes, 0, /* rowCapacity */
+	)
+	n.run.nextRows = sqlbase.NewRowContainer(
+		params.EvalContext().Mon.MakeBoundAccount(), colTypes, 0, /* rowCapacity */
+	)
+	if !n.unionAll {
+		n.run.seen = make(map[string]struct{})
+		n.run.seenAcc = params.EvalContext().Mon.MakeBoundAccount()
+	}
+	return nil
+}
+
+func (n *recursiveCTENode) Next(params runParams) (bool, error) {
+	for {
+		if err := params.p.cancelChecker.Check(); err != nil {
+			return false, err
+		}
+
+		var source planNode
+		if !n.run.initialDone {
+			source = n.initial
+		} else {
+			if n.run.iterationPlan == nil {
+				if err := n.startIteration(params); err != nil {
+					return false, err
+				}
+				if n.run.iterationPlan == nil {
+					// The previous iteration didn't produce any rows; we're done.
+					return false, nil
+				}
+			}
+			source = n.run.iterationPlan
+		}
+
+		next, err := source.Next(params)
+		if err != nil {
+			return false, err
+		}
+		if !next {
+			if !n.run.initialDone {
+				n.run.initialDone = true
+			} else {
+				n.run.iterationPlan.Close(params.ctx)
+				n.run.iterationPlan = nil
+			}
+			continue
+		}
+
+		row := source.Values()
+		if !n.unionAll {
+			isNew, err := n.markSeen(params.ctx, row)
+			if err != nil {
+				return false, err
+			}
+			if !isNew {
+				continue
+			}
+		}
+		n.run.currentRow, err = n.run.nextRows.AddRow(params.ctx, row)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// startIteration makes the rows produced by the last iteration available to
+// the recursive term, and starts a new plan for the recursive term. If the
+// last iteration produced no rows, iterationPlan is left unset.
+func (n *recursiveCTENode) startIteration(params runParams) error {
+	if n.run.nextRows.Len() == 0 {
+		return nil
+	}
+	n.run.workingRows, n.run.nextRows = n.run.nextRows, n.run.workingRows
+	n.run.nextRows.Clear(params.ctx)
+
+	n.run.iterations++
+	limit := recursiveCTEMaxIterations.Get(&params.EvalContext().Settings.SV)
+	if n.run.iterations > limit {
+		return pgerror.NewErrorf(pgerror.CodeProgramLimitExceededError,
+			"recursive query %q exceeded the maximum number of iterations (%d); "+
+				"check that its recursive term terminates or adjust the %s cluster setting",
+			n.label, limit, "sql.recursive_cte.max_iterations")
+	}
+
+	plan, err := n.genIterationFn(params, n)
+	if err != nil {
+		return err
+	}
+	if err := startPlan(params, plan); err != nil {
+		plan.Close(params.ctx)
+		return err
+	}
+	n.run.iterationPlan = plan
+	return nil
+}
+
+// markSeen records the given row as emitted and returns false if it was
+// already emitted before.
+func (n *recursiveCTENode) markSeen(ctx context.Context, row tree.Datums) (bool, error) {
+	var err error
+	n.run.scratch, err = sqlbase.EncodeDatumsKeyAscending(n.run.scratch[:0], row)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := n.run.seen[string(n.run.scratch)]; ok {
+		return false, nil
+	}
+	if err := n.run.seenAcc.Grow(ctx, int64(len(n.run.scratch))); err != nil {
+		return false, err
+	}
+	n.run.seen[string(n.run.scratch)] = struct{}{}
+	return true, nil
+}
+
+func (n *recursiveCTENode) Values() tree.Datums {
+	return n.run.currentRow
+}
+
+func (n *recursiveCTENode) Close(ctx context.Context) {
+	n.initial.Close(ctx)
+	if n.recursive != nil {
+		n.recursive.Close(ctx)
+	}
+	if n.run.iterationPlan != nil {
+		n.run.iterationPlan.Close(ctx)
+		n.run.iterationPlan = nil
+	}
+	if n.run.workingRows != nil {
+		n.run.workingRows.Close(ctx)
+		n.run.workingRows = nil
+	}
+	if n.run.nextRows != nil {
+		n.run.nextRows.Close(ctx)
+		n.run.nextRows = nil
+	}
+	if n.run.seen != nil {
+		n.run.seenAcc.Close(ctx)
+		n.run.seen = nil
+	}
+}
+
+// scanBufferNode reads the rows produced by the previous iteration of a
+// recursiveCTENode. It stands for the self-reference in the recursive term of
+// a recursive CTE.
+type scanBufferNode struct {
+	buffer *recursiveCTENode
+
+	// label is the name of the CTE, used for display.
+	label string
+
+	curRowIdx int
+}
+
+func (n *scanBufferNode) startExec(params runParams) error {
+	n.curRowIdx = -1
+	return nil
+}
+
+func (n *scanBufferNode) Next(params runParams) (bool, error) {
+	n.curRowIdx++
+	return n.curRowIdx < n.buffer.run.workingRows.Len(), nil
+}
+
+func (n *scanBufferNode) Values() tree.Datums {
+	return n.buffer.run.workingRows.At(n.curRowIdx)
+}
+
+func (n *scanBufferNode) Close(ctx context.Context) {}
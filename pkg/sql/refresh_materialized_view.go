@@ -0,0 +1,377 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+)
+
+type refreshMaterializedViewNode struct {
+	n    *tree.RefreshMaterializedView
+	desc *sqlbase.TableDescriptor
+}
+
+// RefreshMaterializedView recomputes the contents of a materialized view.
+// Privileges: CREATE on view.
+//
+// The refresh runs as a schema change job, which evaluates the query of the
+// view into new indexes and then swaps them with the indexes of the view
+// atomically. Readers see the old contents of the view until the swap, with
+// or without CONCURRENTLY. Like in Postgres, CONCURRENTLY requires a unique
+// index on the view.
+func (p *planner) RefreshMaterializedView(
+	ctx context.Context, n *tree.RefreshMaterializedView,
+) (planNode, error) {
+	tn, err := n.Name.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireMaterializedViewDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, desc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	if n.Concurrently && !hasUniqueSecondaryIndex(desc) {
+		return nil, pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+			"cannot refresh materialized view %q concurrently", tree.ErrString(tn)).SetHintf(
+			"Create a unique index with no WHERE clause on one or more columns of the materialized view.")
+	}
+
+	// The refresh evaluates the query of the view in a separate transaction,
+	// which cannot see a view that has not been committed yet.
+	if p.Tables().isCreatedTable(desc.ID) {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"cannot refresh materialized view %q in the transaction that created it",
+			tree.ErrString(tn))
+	}
+
+	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+}
+
+func hasUniqueSecondaryIndex(desc *sqlbase.TableDescriptor) bool {
+	for i := range desc.Indexes {
+		if desc.Indexes[i].Unique {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *refreshMaterializedViewNode) startExec(params runParams) error {
+	n.desc.AddMaterializedViewRefreshMutation()
+	mutationID, err := params.p.createSchemaChangeJob(params.ctx, n.desc,
+		tree.AsStringWithFlags(n.n, tree.FmtAlwaysQualifyTableNames))
+	if err != nil {
+		return err
+	}
+	if err := params.p.writeSchemaChange(params.ctx, n.desc, mutationID); err != nil {
+		return err
+	}
+
+	// Record the refresh in the event log. This is an auditable log event and
+	// is recorded in the same transaction as the table descriptor update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogRefreshMaterializedView,
+		int32(n.desc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			ViewName   string
+			Statement  string
+			User       string
+			MutationID uint32
+		}{
+			n.n.Name.TableName().FQString(), n.n.String(),
+			params.SessionData().User, uint32(mutationID),
+		},
+	)
+}
+
+func (*refreshMaterializedViewNode) Next(runParams) (bool, error) { return false, nil }
+func (*refreshMaterializedViewNode) Values() tree.Datums          { return tree.Datums{} }
+func (*refreshMaterializedViewNode) Close(context.Context)        {}
+
+// populateMaterializedView writes the rows returned by next, which returns
+// nil after the last row, into the indexes of the given materialized view.
+// The rows hold the values of the visible columns of the view; the value of
+// the hidden primary key column is generated.
+func populateMaterializedView(
+	ctx context.Context,
+	txn *client.Txn,
+	desc *sqlbase.TableDescriptor,
+	nodeID roachpb.NodeID,
+	next func() (tree.Datums, error),
+	traceKV bool,
+) error {
+	var alloc sqlbase.DatumAlloc
+	ri, err := sqlbase.MakeRowInserter(txn, desc, nil, desc.Columns, sqlbase.SkipFKs, &alloc)
+	if err != nil {
+		return err
+	}
+	ti := tableInserter{ri: ri}
+	if err := ti.init(txn, nil /* evalCtx */); err != nil {
+		return err
+	}
+	defer ti.close(ctx)
+
+	// At this point, one more column has been added by ensurePrimaryKey() to
+	// the columns of the view.
+	rowBuffer := make(tree.Datums, len(desc.Columns))
+	pkColIdx := len(desc.Columns) - 1
+	for {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+		copy(rowBuffer, row)
+		rowBuffer[pkColIdx] = tree.NewDInt(builtins.GenerateUniqueInt(nodeID))
+		if _, err := ti.row(ctx, rowBuffer, traceKV); err != nil {
+			return err
+		}
+		if ti.curBatchSize() >= maxInsertBatchSize {
+			if err := ti.flushAndStartNewBatch(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = ti.finalize(ctx, noAutoCommit, traceKV)
+	return err
+}
+
+// planRowSource returns a function which returns the rows of the given
+// started plan, and nil after the last row.
+func planRowSource(params runParams, plan planNode) func() (tree.Datums, error) {
+	return func() (tree.Datums, error) {
+		if err := params.p.cancelChecker.Check(); err != nil {
+			return nil, err
+		}
+		if next, err := plan.Next(params); !next {
+			return nil, err
+		}
+		return plan.Values(), nil
+	}
+}
+
+// refreshMaterializedView runs the refresh of a materialized view described
+// by the given mutation. While the mutation has the ADD direction, the query
+// of the view is evaluated into the new indexes of the refresh, which then
+// replace the indexes of the view. The data of the indexes which are no
+// longer used by the view is deleted afterwards, which also cleans up after
+// a refresh that has been rolled back.
+func (sc *SchemaChanger) refreshMaterializedView(
+	ctx context.Context,
+	lease *sqlbase.TableDescriptor_SchemaChangeLease,
+	tableDesc *sqlbase.TableDescriptor,
+	m sqlbase.DescriptorMutation,
+	mutationIdx int,
+) error {
+	r := m.GetMaterializedViewRefresh()
+	if len(r.NewIndexIDs) == 0 {
+		// The refresh was rolled back before it started.
+		return nil
+	}
+
+	if m.Direction == sqlbase.DescriptorMutation_ADD && tableDesc.PrimaryIndex.ID != r.NewIndexIDs[0] {
+		if err := sc.populateMaterializedViewRefresh(ctx, lease, r); err != nil {
+			return err
+		}
+
+		desc, err := sc.leaseMgr.Publish(ctx, sc.tableID, func(desc *sqlbase.TableDescriptor) error {
+			for i, mutation := range desc.Mutations {
+				if mutation.MutationID != sc.mutationID {
+					break
+				}
+				if mutation.GetMaterializedViewRefresh() != nil {
+					desc.MakeMutationComplete(mutation)
+					// The replaced indexes remain to be deleted.
+					desc.Mutations[i].Direction = sqlbase.DescriptorMutation_DROP
+					return nil
+				}
+			}
+			return errDidntUpdateDescriptor
+		}, nil)
+		if err != nil {
+			return err
+		}
+		tableDesc = desc.GetTable()
+		if err := sc.waitToUpdateLeases(ctx, sc.tableID); err != nil {
+			return err
+		}
+	}
+
+	inUse := make(map[sqlbase.IndexID]struct{}, len(tableDesc.Indexes)+1)
+	inUse[tableDesc.PrimaryIndex.ID] = struct{}{}
+	for i := range tableDesc.Indexes {
+		inUse[tableDesc.Indexes[i].ID] = struct{}{}
+	}
+	var unused []sqlbase.IndexDescriptor
+	for _, ids := range [][]sqlbase.IndexID{r.IndexIDs, r.NewIndexIDs} {
+		for _, id := range ids {
+			if _, ok := inUse[id]; !ok {
+				unused = append(unused, sqlbase.IndexDescriptor{ID: id})
+			}
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	log.VEventf(ctx, 2, "deleting %d indexes replaced by refresh of %q", len(unused), tableDesc.Name)
+	return sc.truncateIndexes(ctx, lease, tableDesc.Version, unused, mutationIdx)
+}
+
+// materializedViewRefreshChunkSize is the maximum number of rows of a
+// materialized view written per transaction during a refresh.
+const materializedViewRefreshChunkSize = 1000
+
+// populateMaterializedViewRefresh evaluates the query of a materialized view
+// into the new indexes of the given refresh. The rows are streamed from the
+// plan of the query, which runs in a single read-only transaction, and are
+// written in chunks by separate transactions: the new indexes are invisible
+// until they are swapped with the indexes of the view, so a partially
+// populated refresh can't be observed. If the read transaction is retried,
+// the new indexes are populated again from scratch.
+func (sc *SchemaChanger) populateMaterializedViewRefresh(
+	ctx context.Context,
+	lease *sqlbase.TableDescriptor_SchemaChangeLease,
+	r *sqlbase.MaterializedViewRefresh,
+) error {
+	if err := sc.ExtendLease(ctx, lease); err != nil {
+		return err
+	}
+	chunkSize := int(sc.getChunkSize(materializedViewRefreshChunkSize))
+	return sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, sc.tableID)
+		if err != nil {
+			return err
+		}
+		target, err := tableDesc.MaterializedViewRefreshTarget(r)
+		if err != nil {
+			return err
+		}
+
+		// Delete anything written by an earlier attempt of the refresh.
+		if err := sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			b := txn.NewBatch()
+			for _, id := range r.NewIndexIDs {
+				span := tableDesc.IndexSpan(id)
+				b.DelRange(span.Key, span.EndKey, false /* returnKeys */)
+			}
+			return txn.Run(ctx, b)
+		}); err != nil {
+			return err
+		}
+
+		stmt, err := parser.ParseOne(tableDesc.ViewQuery)
+		if err != nil {
+			return err
+		}
+		p, cleanup := newInternalPlanner(
+			"refresh-materialized-view", txn, security.RootUser, &MemoryMetrics{}, sc.execCfg)
+		defer cleanup()
+
+		// The memory used by the query and by the buffered rows is accounted
+		// for by a monitor bounded by the SQL memory budget of the node.
+		refreshMon := mon.MakeMonitor("refresh-materialized-view",
+			mon.MemoryResource,
+			nil, /* curCount */
+			nil, /* maxHist */
+			-1,  /* increment: use default block size */
+			noteworthyMemoryUsageBytes,
+			sc.settings)
+		refreshMon.Start(ctx, sc.execCfg.DistSQLSrv.ParentMemoryMonitor, mon.BoundAccount{})
+		defer refreshMon.Stop(ctx)
+		p.extendedEvalCtx.Mon = &refreshMon
+		planAcc := refreshMon.MakeBoundAccount()
+		p.extendedEvalCtx.ActiveMemAcc = &planAcc
+		defer planAcc.Close(ctx)
+
+		if err := p.makePlan(ctx, Statement{AST: stmt}); err != nil {
+			return err
+		}
+		defer p.curPlan.close(ctx)
+		params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+		if err := p.curPlan.start(params); err != nil {
+			return err
+		}
+		next := planRowSource(params, p.curPlan.plan)
+
+		// The rows of each chunk are buffered, so that the write transaction
+		// can be retried.
+		rows := sqlbase.NewRowContainer(
+			refreshMon.MakeBoundAccount(),
+			sqlbase.ColTypeInfoFromResCols(planColumns(p.curPlan.plan)),
+			chunkSize,
+		)
+		defer rows.Close(ctx)
+		for done := false; !done; {
+			rows.Clear(ctx)
+			for rows.Len() < chunkSize {
+				row, err := next()
+				if err != nil {
+					return err
+				}
+				if row == nil {
+					done = true
+					break
+				}
+				if _, err := rows.AddRow(ctx, row); err != nil {
+					return err
+				}
+			}
+			if rows.Len() == 0 {
+				break
+			}
+
+			if err := sc.ExtendLease(ctx, lease); err != nil {
+				return err
+			}
+			if err := sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+				i := 0
+				return populateMaterializedView(ctx, txn, target, sc.nodeID,
+					func() (tree.Datums, error) {
+						if i == rows.Len() {
+							return nil, nil
+						}
+						i++
+						return rows.At(i - 1), nil
+					}, false /* traceKV */)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestRefreshMaterializedViewChunks checks that a refresh whose rows are
+// written in several transactions populates the view completely.
+func TestRefreshMaterializedViewChunks(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const chunkSize = 10
+	const numGroups = 2*chunkSize + 1
+
+	params, _ := tests.CreateTestServerParams()
+	params.Knobs = base.TestingKnobs{
+		SQLSchemaChanger: &sql.SchemaChangerTestingKnobs{BackfillChunkSize: chunkSize},
+	}
+	s, sqlDBRaw, kvDB := serverutils.StartServer(t, params)
+	sqlDB := sqlutils.MakeSQLRunner(sqlDBRaw)
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB.Exec(t, `CREATE DATABASE t`)
+	sqlDB.Exec(t, `CREATE TABLE t.kv (k INT PRIMARY KEY, v INT)`)
+	sqlDB.Exec(t, `INSERT INTO t.kv SELECT i, i % $1 FROM generate_series(1, $2) AS g(i)`,
+		numGroups, 10*numGroups)
+	sqlDB.Exec(t, `CREATE MATERIALIZED VIEW t.totals AS SELECT v, sum(k) AS total FROM t.kv GROUP BY v`)
+	sqlDB.Exec(t, `CREATE UNIQUE INDEX totals_v ON t.totals (v)`)
+
+	sqlDB.Exec(t, `UPDATE t.kv SET k = k + 1000000`)
+	sqlDB.Exec(t, `REFRESH MATERIALIZED VIEW t.totals`)
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*), sum(total) FROM t.totals`,
+		sqlDB.QueryStr(t, `SELECT count(DISTINCT v), sum(k) FROM t.kv`),
+	)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM t.totals@totals_v WHERE v >= 0`,
+		[][]string{{strconv.Itoa(numGroups)}},
+	)
+
+	// The indexes of the view don't hold any row of an earlier refresh.
+	tableDesc := sqlbase.GetTableDescriptor(kvDB, "t", "totals")
+	tests.CheckKeyCount(t, kvDB, tableDesc.IndexSpan(tableDesc.PrimaryIndex.ID), numGroups)
+}
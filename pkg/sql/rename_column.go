@@ -132,6 +132,28 @@ func (p *planner) RenameColumn(ctx context.Context, n *tree.RenameColumn) (planN
 		}
 	}
 
+	// Rename the column in the predicates of partial indexes.
+	renameInPredicate := func(index *sqlbase.IndexDescriptor) error {
+		if !index.IsPartial() {
+			return nil
+		}
+		var err error
+		index.Predicate, err = renameIn(index.Predicate)
+		return err
+	}
+	for i := range tableDesc.Indexes {
+		if err := renameInPredicate(&tableDesc.Indexes[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range tableDesc.Mutations {
+		if index := m.GetIndex(); index != nil {
+			if err := renameInPredicate(index); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Rename the column in the indexes.
 	tableDesc.RenameColumnDescriptor(col, string(n.NewName))
 
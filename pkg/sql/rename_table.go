@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -74,16 +75,25 @@ func (p *planner) RenameTable(ctx context.Context, n *tree.RenameTable) (planNod
 			ctx, tableDesc.TypeName(), oldTn.String(), tableDesc.ParentID, tableDesc.DependedOnBy[0].ID)
 	}
 
-	prevDbDesc, err := p.ResolveUncachedDatabase(ctx, oldTn)
-	if err != nil {
-		return nil, err
-	}
-
-	// Check if target database exists.
-	// We also look at uncached descriptors here.
-	targetDbDesc, err := p.ResolveUncachedDatabase(ctx, newTn)
-	if err != nil {
-		return nil, err
+	var targetDbDesc *DatabaseDescriptor
+	prevParentID := tableDesc.GetNamespaceParentID()
+	if tableDesc.IsTemporary() {
+		// A temporary table stays in the temporary schema of its database.
+		targetDbDesc, err = p.resolveTemporaryTableTarget(ctx, newTn)
+		if err != nil {
+			return nil, err
+		}
+		if targetDbDesc.ID != tableDesc.ParentID {
+			return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"cannot move temporary table %q to another database", tree.ErrString(oldTn))
+		}
+	} else {
+		// Check if target database exists.
+		// We also look at uncached descriptors here.
+		targetDbDesc, err = p.ResolveUncachedDatabase(ctx, newTn)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if err := p.CheckPrivilege(ctx, targetDbDesc, privilege.CREATE); err != nil {
@@ -102,7 +112,7 @@ func (p *planner) RenameTable(ctx context.Context, n *tree.RenameTable) (planNod
 	tableDesc.ParentID = targetDbDesc.ID
 
 	descKey := sqlbase.MakeDescMetadataKey(tableDesc.GetID())
-	newTbKey := tableKey{targetDbDesc.ID, newTn.Table()}.Key()
+	newTbKey := tableKey{tableDesc.GetNamespaceParentID(), newTn.Table()}.Key()
 
 	if err := tableDesc.Validate(ctx, p.txn, p.EvalContext().Settings); err != nil {
 		return nil, err
@@ -112,7 +122,7 @@ func (p *planner) RenameTable(ctx context.Context, n *tree.RenameTable) (planNod
 	descDesc := sqlbase.WrapDescriptor(tableDesc)
 
 	renameDetails := sqlbase.TableDescriptor_NameInfo{
-		ParentID: prevDbDesc.ID,
+		ParentID: prevParentID,
 		Name:     oldTn.Table()}
 	tableDesc.DrainingNames = append(tableDesc.DrainingNames, renameDetails)
 	if err := p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
@@ -98,6 +98,7 @@ func (p *planner) Select(
 	limit := n.Limit
 	orderBy := n.OrderBy
 	with := n.With
+	locking := n.Locking
 
 	for s, ok := wrapped.(*tree.ParenSelect); ok; s, ok = wrapped.(*tree.ParenSelect) {
 		wrapped = s.Select.Select
@@ -117,13 +118,14 @@ func (p *planner) Select(
 			}
 			limit = s.Select.Limit
 		}
+		locking = append(locking, s.Select.Locking...)
 	}
 
 	switch s := wrapped.(type) {
 	case *tree.SelectClause:
 		// Select can potentially optimize index selection if it's being ordered,
 		// so we allow it to do its own sorting.
-		return p.SelectClause(ctx, s, orderBy, limit, with, desiredTypes, publicColumns)
+		return p.SelectClause(ctx, s, orderBy, limit, with, locking, desiredTypes, publicColumns)
 
 	// TODO(dan): Union can also do optimizations when it has an ORDER BY, but
 	// currently expects the ordering to be done externally, so we let it fall
@@ -133,6 +135,13 @@ func (p *planner) Select(
 	// TODO(jordan): this limitation also applies to CTEs, which do not yet
 	// propagate into VALUES and UNION clauses
 	default:
+		if len(locking) > 0 {
+			what := "UNION/INTERSECT/EXCEPT"
+			if _, ok := s.(*tree.ValuesClause); ok {
+				what = "VALUES"
+			}
+			return nil, errLockingNotAllowed(locking[0].Strength, what)
+		}
 		plan, err := p.newPlan(ctx, s, desiredTypes)
 		if err != nil {
 			return nil, err
@@ -168,7 +177,7 @@ func (p *planner) Select(
 // LIMIT, or parenthesis in the parsed SELECT. See `sql/tree.Select` and
 // `sql/tree.SelectStatement`.
 //
-// Privileges: SELECT on table
+// Privileges: SELECT on table, UPDATE on the tables locked by a locking clause
 //   Notes: postgres requires SELECT. Also requires UPDATE on "FOR UPDATE".
 //          mysql requires SELECT.
 func (p *planner) SelectClause(
@@ -177,6 +186,7 @@ func (p *planner) SelectClause(
 	orderBy tree.OrderBy,
 	limit *tree.Limit,
 	with *tree.With,
+	locking tree.LockingClause,
 	desiredTypes []types.T,
 	scanVisibility scanVisibility,
 ) (planNode, error) {
@@ -196,9 +206,24 @@ func (p *planner) SelectClause(
 		defer resetter(p)
 	}
 
-	if err := p.initFrom(ctx, r, parsed, scanVisibility); err != nil {
+	spec, err := p.newLockingSpec(parsed, locking)
+	if err != nil {
+		return nil, err
+	}
+	// The locking clause applies to the tables in this FROM clause only, not
+	// to the ones in subqueries.
+	savedLocking := p.curLocking
+	p.curLocking = spec
+	err = p.initFrom(ctx, r, parsed, scanVisibility)
+	p.curLocking = savedLocking
+	if err != nil {
 		return nil, err
 	}
+	if spec != nil {
+		if err := spec.checkTargets(); err != nil {
+			return nil, err
+		}
+	}
 
 	// We need to process the WHERE clause before initTargets below because
 	// it must not see any column generated by SRFs.
@@ -285,6 +310,14 @@ func (p *planner) SelectClause(
 	if err != nil {
 		return nil, err
 	}
+	if len(locking) > 0 {
+		if window != nil {
+			return nil, errLockingNotAllowed(locking[0].Strength, "window functions")
+		}
+		if group != nil {
+			return nil, errLockingNotAllowed(locking[0].Strength, "aggregate functions")
+		}
+	}
 
 	if group != nil && group.requiresIsDistinctFromNullFilter() {
 		if where == nil {
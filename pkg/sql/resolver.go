@@ -113,6 +113,10 @@ func ResolveExistingObject(
 		goodType = desc.IsTable() || desc.IsView()
 	case requireSequenceDesc:
 		goodType = desc.IsSequence()
+	case requireMaterializedViewDesc:
+		goodType = desc.IsMaterializedView()
+	case requireTableOrMaterializedViewDesc:
+		goodType = desc.IsTable() || desc.IsMaterializedView()
 	}
 	if !goodType {
 		return nil, sqlbase.NewWrongObjectTypeError(tn, requiredTypeNames[requiredType])
@@ -206,13 +210,17 @@ const (
 	requireViewDesc
 	requireTableOrViewDesc
 	requireSequenceDesc
+	requireMaterializedViewDesc
+	requireTableOrMaterializedViewDesc
 )
 
 var requiredTypeNames = [...]string{
-	requireTableDesc:       "table",
-	requireViewDesc:        "view",
-	requireTableOrViewDesc: "table or view",
-	requireSequenceDesc:    "sequence",
+	requireTableDesc:                   "table",
+	requireViewDesc:                    "view",
+	requireTableOrViewDesc:             "table or view",
+	requireSequenceDesc:                "sequence",
+	requireMaterializedViewDesc:        "materialized view",
+	requireTableOrMaterializedViewDesc: "table or materialized view",
 }
 
 // LookupSchema implements the tree.TableNameTargetResolver interface.
@@ -308,7 +316,15 @@ func getDescriptorsFromTargetList(
 func (p *planner) getQualifiedTableName(
 	ctx context.Context, desc *sqlbase.TableDescriptor,
 ) (string, error) {
-	dbDesc, err := sqlbase.GetDatabaseDescFromID(ctx, p.txn, desc.ParentID)
+	return getQualifiedTableName(ctx, p.txn, desc)
+}
+
+// getQualifiedTableName is like planner.getQualifiedTableName, for callers
+// that don't have a planner.
+func getQualifiedTableName(
+	ctx context.Context, txn *client.Txn, desc *sqlbase.TableDescriptor,
+) (string, error) {
+	dbDesc, err := sqlbase.GetDatabaseDescFromID(ctx, txn, desc.ParentID)
 	if err != nil {
 		return "", err
 	}
@@ -316,7 +332,7 @@ func (p *planner) getQualifiedTableName(
 	return tbName.String(), nil
 }
 
-// findTableContainingIndex returns the descriptor of a table
+// findTableContainingIndex returns the descriptor of a table or materialized view
 // containing the index of the given name.
 // This is used by expandMutableIndexName().
 //
@@ -350,7 +366,7 @@ func findTableContainingIndex(
 		if err != nil {
 			return nil, nil, err
 		}
-		if tableDesc == nil || !tableDesc.IsTable() {
+		if tableDesc == nil || !(tableDesc.IsTable() || tableDesc.IsMaterializedView()) {
 			continue
 		}
 
@@ -403,7 +419,7 @@ func expandIndexName(
 
 	if !index.SearchTable {
 		// The index and its table prefix must exist already. Resolve the table.
-		desc, err = ResolveExistingObject(ctx, sc, tn, requireTable, requireTableDesc)
+		desc, err = ResolveExistingObject(ctx, sc, tn, requireTable, requireTableOrMaterializedViewDesc)
 		if err != nil {
 			return nil, nil, err
 		}
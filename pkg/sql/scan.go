@@ -98,6 +98,11 @@ type scanNode struct {
 
 	disableBatchLimits bool
 
+	// lockingStrength and lockingWaitPolicy are set if the rows returned by
+	// the scan are locked by a SELECT ... FOR UPDATE/SHARE clause.
+	lockingStrength   tree.LockingStrength
+	lockingWaitPolicy tree.LockingWaitPolicy
+
 	run scanRun
 
 	// This struct must be allocated on the heap and its location stay
@@ -202,8 +207,12 @@ func (n *scanNode) startExec(params runParams) error {
 		Cols:             n.cols,
 		ValNeededForCol:  n.valNeededForCol.Copy(),
 	}
-	return n.run.fetcher.Init(n.reverse, false, /* returnRangeInfo */
-		false /* isCheck */, &params.p.alloc, tableArgs)
+	if err := n.run.fetcher.Init(n.reverse, false, /* returnRangeInfo */
+		false /* isCheck */, &params.p.alloc, params.EvalContext(), tableArgs); err != nil {
+		return err
+	}
+	n.run.fetcher.SetLocking(n.lockingStrength, n.lockingWaitPolicy)
+	return nil
 }
 
 func (n *scanNode) Close(context.Context) {
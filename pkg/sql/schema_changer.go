@@ -439,8 +439,10 @@ func (sc *SchemaChanger) maybeAddDrop(
 
 		// This can happen if a change other than the drop originally
 		// scheduled the changer for this table. If that's the case,
-		// we still need to wait for the deadline to expire.
-		if table.DropTime != 0 {
+		// we still need to wait for the deadline to expire. The data of
+		// temporary tables cannot be read through AS OF SYSTEM TIME
+		// queries once their session is gone, so it is cleared right away.
+		if table.DropTime != 0 && !table.IsTemporary() {
 			var timeRemaining time.Duration
 			if err := sc.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
 				timeRemaining = 0
@@ -539,6 +541,31 @@ func (sc *SchemaChanger) drainNames(
 	return err
 }
 
+// makeEnumMembersWritable makes the values that were added to the enum
+// types of the table's columns writable, once all the nodes have seen
+// the version of the table that contains them.
+func (sc *SchemaChanger) makeEnumMembersWritable(
+	ctx context.Context, lease *sqlbase.TableDescriptor_SchemaChangeLease,
+) error {
+	if err := sc.ExtendLease(ctx, lease); err != nil {
+		return err
+	}
+
+	_, err := sc.leaseMgr.Publish(
+		ctx,
+		sc.tableID,
+		func(desc *sqlbase.TableDescriptor) error {
+			if !desc.HasReadOnlyEnumMembers() {
+				return errDidntUpdateDescriptor
+			}
+			desc.MakeEnumMembersWritable()
+			return nil
+		},
+		nil,
+	)
+	return err
+}
+
 // Execute the entire schema change in steps.
 // inSession is set to false when this is called from the asynchronous
 // schema change execution path.
@@ -592,6 +619,12 @@ func (sc *SchemaChanger) exec(
 		}
 	}
 
+	if tableDesc.HasReadOnlyEnumMembers() {
+		if err := sc.makeEnumMembersWritable(ctx, &lease); err != nil {
+			return err
+		}
+	}
+
 	if drop, err := sc.maybeAddDrop(ctx, inSession, &lease, tableDesc, evalCtx); err != nil {
 		return err
 	} else if drop {
@@ -723,6 +756,11 @@ func (sc *SchemaChanger) RunStateMachineBeforeBackfill(ctx context.Context) erro
 					// DELETE_AND_WRITE_ONLY state to fill in the missing elements of the
 					// index (INSERT and UPDATE that happened in the interim).
 					desc.Mutations[i].State = sqlbase.DescriptorMutation_DELETE_AND_WRITE_ONLY
+					if r := mutation.GetMaterializedViewRefresh(); r != nil {
+						// The indexes of the view cannot be added while the refresh
+						// runs, so the indexes it replaces are known from now on.
+						desc.StartMaterializedViewRefresh(r)
+					}
 					modified = true
 
 				case sqlbase.DescriptorMutation_DELETE_AND_WRITE_ONLY:
@@ -1430,7 +1468,9 @@ func (s *SchemaChangeManager) Start(stopper *stop.Stopper) {
 									kv.Key, table.ID, table.Version)
 							}
 
-							if table.DropTime > 0 {
+							// See maybeAddDrop for why temporary tables
+							// are not kept until the GC TTL.
+							if table.DropTime > 0 && !table.IsTemporary() {
 								schemaChanger.dropTime = table.DropTime
 								zoneCfg, _, err := ZoneConfigHook(cfg, uint32(table.ID), nil)
 								if err != nil {
@@ -19,9 +19,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"unsafe"
 
 	"github.com/cockroachdb/apd"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
@@ -73,6 +75,25 @@ func aggPropsNullableArgs() tree.FunctionProperties {
 	return f
 }
 
+func orderedSetAggProps() tree.FunctionProperties {
+	f := aggProps()
+	f.OrderedSetAggregate = true
+	return f
+}
+
+// orderedSetTypes are the types that can be aggregated by the ordered-set
+// aggregates. JSON is excluded because it has no key encoding, which is
+// needed to sort the values when they are spilled to disk.
+var orderedSetTypes = func() []types.T {
+	r := make([]types.T, 0, len(types.AnyNonArray))
+	for _, t := range types.AnyNonArray {
+		if t != types.JSON {
+			r = append(r, t)
+		}
+	}
+	return r
+}()
+
 // aggregates are a special class of builtin functions that are wrapped
 // at execution in a bucketing layer to combine (aggregate) the result
 // of the function being run over many rows.
@@ -178,6 +199,17 @@ var aggregates = map[string]builtinDefinition{
 				"Identifies the minimum selected value.")
 		}),
 
+	"mode": collectOverloads(orderedSetAggProps(), orderedSetTypes,
+		func(t types.T) tree.Overload {
+			return makeAggOverload([]types.T{t}, t, newModeAggregate,
+				"Identifies the most frequent selected value. Ties are broken by the "+
+					"WITHIN GROUP ordering.")
+		}),
+
+	"percentile_disc": makeBuiltin(orderedSetAggProps(), makePercentileDiscOverloads()...),
+
+	"percentile_cont": makeBuiltin(orderedSetAggProps(), makePercentileContOverloads()...),
+
 	"string_agg": makeBuiltin(aggPropsNullableArgs(),
 		makeAggOverload([]types.T{types.String, types.String}, types.String, newStringConcatAggregate,
 			"Concatenates all selected values using the provided delimiter."),
@@ -299,6 +331,43 @@ var aggregates = map[string]builtinDefinition{
 // AnyNotNull is the name of the aggregate returned by NewAnyNotNullAggregate.
 const AnyNotNull = "any_not_null"
 
+func makePercentileDiscOverloads() []tree.Overload {
+	overloads := make([]tree.Overload, 0, 2*len(orderedSetTypes))
+	for _, t := range orderedSetTypes {
+		overloads = append(overloads, makeAggOverload(
+			[]types.T{t, types.Float}, t, newPercentileDiscAggregate,
+			"Identifies the first selected value whose position in the WITHIN GROUP "+
+				"ordering is at or beyond the given fraction."))
+		if types.IsValidArrayElementType(t) {
+			overloads = append(overloads, makeAggOverload(
+				[]types.T{t, types.TArray{Typ: types.Float}}, types.TArray{Typ: t}, newPercentileDiscAggregate,
+				"Identifies, for each of the given fractions, the first selected value whose "+
+					"position in the WITHIN GROUP ordering is at or beyond the fraction."))
+		}
+	}
+	return overloads
+}
+
+func makePercentileContOverloads() []tree.Overload {
+	var overloads []tree.Overload
+	add := func(t, ret types.T, f func([]types.T, *tree.EvalContext, tree.Datums) tree.AggregateFunc) {
+		overloads = append(overloads,
+			makeAggOverload([]types.T{t, types.Float}, ret, f,
+				"Calculates the value at the given fraction of the WITHIN GROUP ordering, "+
+					"interpolating between adjacent selected values if needed."),
+			makeAggOverload([]types.T{t, types.TArray{Typ: types.Float}}, types.TArray{Typ: ret}, f,
+				"Calculates the values at each of the given fractions of the WITHIN GROUP ordering, "+
+					"interpolating between adjacent selected values if needed."),
+		)
+	}
+	add(types.Float, types.Float, newFloatPercentileContAggregate)
+	// Like in Postgres, INT and DECIMAL values are interpolated as FLOAT.
+	add(types.Int, types.Float, newNumericPercentileContAggregate)
+	add(types.Decimal, types.Float, newNumericPercentileContAggregate)
+	add(types.Interval, types.Interval, newIntervalPercentileContAggregate)
+	return overloads
+}
+
 func makePrivate(b builtinDefinition) builtinDefinition {
 	b.props.Private = true
 	return b
@@ -430,6 +499,7 @@ const sizeOfBoolOrAggregate = int64(unsafe.Sizeof(boolOrAggregate{}))
 const sizeOfBytesXorAggregate = int64(unsafe.Sizeof(bytesXorAggregate{}))
 const sizeOfIntXorAggregate = int64(unsafe.Sizeof(intXorAggregate{}))
 const sizeOfJSONAggregate = int64(unsafe.Sizeof(jsonAggregate{}))
+const sizeOfOrderedSetAggregate = int64(unsafe.Sizeof(orderedSetAggregate{}))
 
 // See NewAnyNotNullAggregate.
 type anyNotNullAggregate struct {
@@ -1937,3 +2007,297 @@ func (a *jsonAggregate) Close(ctx context.Context) {
 func (a *jsonAggregate) Size() int64 {
 	return sizeOfJSONAggregate
 }
+
+// orderedSetAggregate is the implementation shared by the ordered-set
+// aggregates. Values passed to Add are buffered and sorted once the result is
+// requested, unless the caller provides them in sorted order with
+// SetSortedInput.
+type orderedSetAggregate struct {
+	evalCtx    *tree.EvalContext
+	descending bool
+	values     tree.Datums
+	acc        mon.BoundAccount
+
+	// compute calculates the result of the aggregate from the count non-NULL
+	// values returned by next in WITHIN GROUP order.
+	compute func(count int, next func() (tree.Datum, error)) (tree.Datum, error)
+
+	// result is set when the result was computed by SetSortedInput.
+	result tree.Datum
+}
+
+var _ tree.OrderedSetAggregateFunc = &orderedSetAggregate{}
+
+func newOrderedSetAggregate(
+	evalCtx *tree.EvalContext,
+	compute func(count int, next func() (tree.Datum, error)) (tree.Datum, error),
+) *orderedSetAggregate {
+	return &orderedSetAggregate{
+		evalCtx: evalCtx,
+		acc:     evalCtx.Mon.MakeBoundAccount(),
+		compute: compute,
+	}
+}
+
+// SetDescending is part of the tree.OrderedSetAggregateFunc interface.
+func (a *orderedSetAggregate) SetDescending() {
+	a.descending = true
+}
+
+// SetSortedInput is part of the tree.OrderedSetAggregateFunc interface.
+func (a *orderedSetAggregate) SetSortedInput(count int, next func() (tree.Datum, error)) error {
+	result, err := a.compute(count, next)
+	if err != nil {
+		return err
+	}
+	a.result = result
+	return nil
+}
+
+// Add buffers the passed datum until the result is computed.
+func (a *orderedSetAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	if err := a.acc.Grow(ctx, int64(datum.Size())); err != nil {
+		return err
+	}
+	a.values = append(a.values, datum)
+	return nil
+}
+
+// Result sorts the buffered values and computes the result from them.
+func (a *orderedSetAggregate) Result() (tree.Datum, error) {
+	if a.result != nil {
+		return a.result, nil
+	}
+	sort.Slice(a.values, func(i, j int) bool {
+		if a.descending {
+			return a.values[i].Compare(a.evalCtx, a.values[j]) > 0
+		}
+		return a.values[i].Compare(a.evalCtx, a.values[j]) < 0
+	})
+	i := 0
+	return a.compute(len(a.values), func() (tree.Datum, error) {
+		d := a.values[i]
+		i++
+		return d, nil
+	})
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *orderedSetAggregate) Close(ctx context.Context) {
+	a.acc.Close(ctx)
+}
+
+// Size is part of the tree.AggregateFunc interface.
+func (a *orderedSetAggregate) Size() int64 {
+	return sizeOfOrderedSetAggregate
+}
+
+func newModeAggregate(_ []types.T, evalCtx *tree.EvalContext, _ tree.Datums) tree.AggregateFunc {
+	return newOrderedSetAggregate(evalCtx, func(
+		count int, next func() (tree.Datum, error),
+	) (tree.Datum, error) {
+		var mode, cur tree.Datum
+		var modeFreq, curFreq int
+		for i := 0; i < count; i++ {
+			d, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if cur != nil && d.Compare(evalCtx, cur) == 0 {
+				curFreq++
+			} else {
+				cur, curFreq = d, 1
+			}
+			// Only replace the mode if the current value is strictly more
+			// frequent, so that ties are won by the value that sorts first.
+			if curFreq > modeFreq {
+				mode, modeFreq = cur, curFreq
+			}
+		}
+		if mode == nil {
+			return tree.DNull, nil
+		}
+		return mode, nil
+	})
+}
+
+func newPercentileDiscAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return newOrderedSetAggregate(evalCtx, func(
+		count int, next func() (tree.Datum, error),
+	) (tree.Datum, error) {
+		return computePercentiles(params[0], arguments, count, next,
+			func(fraction float64) (int, int) {
+				// The first value whose position is at or beyond the fraction.
+				row := int(math.Ceil(fraction*float64(count))) - 1
+				if row < 0 {
+					row = 0
+				}
+				return row, row
+			},
+			func(lo, _ tree.Datum, _ float64) (tree.Datum, error) {
+				return lo, nil
+			},
+		)
+	})
+}
+
+func newFloatPercentileContAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return newPercentileContAggregate(params, evalCtx, arguments, interpolateFloats)
+}
+
+// newNumericPercentileContAggregate computes percentile_cont over INT or
+// DECIMAL values by casting them to FLOAT before interpolating.
+func newNumericPercentileContAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	agg := newPercentileContAggregate(
+		[]types.T{types.Float, params[1]}, evalCtx, arguments, interpolateFloats,
+	)
+	compute := agg.compute
+	agg.compute = func(count int, next func() (tree.Datum, error)) (tree.Datum, error) {
+		return compute(count, func() (tree.Datum, error) {
+			d, err := next()
+			if err != nil {
+				return nil, err
+			}
+			return tree.PerformCast(evalCtx, d, coltypes.Float)
+		})
+	}
+	return agg
+}
+
+func interpolateFloats(lo, hi tree.Datum, frac float64) (tree.Datum, error) {
+	l, h := float64(*lo.(*tree.DFloat)), float64(*hi.(*tree.DFloat))
+	return tree.NewDFloat(tree.DFloat(l + (h-l)*frac)), nil
+}
+
+func newIntervalPercentileContAggregate(
+	params []types.T, evalCtx *tree.EvalContext, arguments tree.Datums,
+) tree.AggregateFunc {
+	return newPercentileContAggregate(params, evalCtx, arguments,
+		func(lo, hi tree.Datum, frac float64) (tree.Datum, error) {
+			l, h := lo.(*tree.DInterval).Duration, hi.(*tree.DInterval).Duration
+			return &tree.DInterval{Duration: l.Add(h.Sub(l).MulFloat(frac))}, nil
+		},
+	)
+}
+
+func newPercentileContAggregate(
+	params []types.T,
+	evalCtx *tree.EvalContext,
+	arguments tree.Datums,
+	interpolate func(lo, hi tree.Datum, frac float64) (tree.Datum, error),
+) *orderedSetAggregate {
+	return newOrderedSetAggregate(evalCtx, func(
+		count int, next func() (tree.Datum, error),
+	) (tree.Datum, error) {
+		return computePercentiles(params[0], arguments, count, next,
+			func(fraction float64) (int, int) {
+				// The values surrounding the fraction, interpolated below.
+				pos := fraction * float64(count-1)
+				return int(math.Floor(pos)), int(math.Ceil(pos))
+			},
+			func(lo, hi tree.Datum, fraction float64) (tree.Datum, error) {
+				pos := fraction * float64(count-1)
+				if frac := pos - math.Floor(pos); frac != 0 {
+					return interpolate(lo, hi, frac)
+				}
+				return lo, nil
+			},
+		)
+	})
+}
+
+// computePercentiles computes the result of a percentile aggregate whose
+// direct argument is either a single fraction or an array of fractions.
+// rows returns the positions of the two values from which the percentile at
+// the given fraction is computed by result; the values are read from next in
+// a single pass.
+func computePercentiles(
+	typ types.T,
+	arguments tree.Datums,
+	count int,
+	next func() (tree.Datum, error),
+	rows func(fraction float64) (int, int),
+	result func(lo, hi tree.Datum, fraction float64) (tree.Datum, error),
+) (tree.Datum, error) {
+	if count == 0 || arguments[0] == tree.DNull {
+		return tree.DNull, nil
+	}
+
+	// Collect the fractions, leaving NULL elements of an array as nil.
+	var fractions []tree.Datum
+	arr, isArray := tree.AsDArray(arguments[0])
+	if isArray {
+		fractions = make([]tree.Datum, len(arr.Array))
+		for i, d := range arr.Array {
+			if d != tree.DNull {
+				fractions[i] = d
+			}
+		}
+	} else {
+		fractions = []tree.Datum{arguments[0]}
+	}
+
+	var positions []int
+	for _, d := range fractions {
+		if d == nil {
+			continue
+		}
+		f := float64(*d.(*tree.DFloat))
+		if f < 0 || f > 1 || math.IsNaN(f) {
+			return nil, pgerror.NewErrorf(pgerror.CodeNumericValueOutOfRangeError,
+				"percentile value %g is not between 0 and 1", f)
+		}
+		lo, hi := rows(f)
+		positions = append(positions, lo, hi)
+	}
+	sort.Ints(positions)
+
+	// Read the values at the required positions.
+	values := make(map[int]tree.Datum, len(positions))
+	pos := -1
+	var d tree.Datum
+	for _, p := range positions {
+		for pos < p {
+			var err error
+			if d, err = next(); err != nil {
+				return nil, err
+			}
+			pos++
+		}
+		values[p] = d
+	}
+
+	results := make(tree.Datums, len(fractions))
+	for i, frac := range fractions {
+		if frac == nil {
+			results[i] = tree.DNull
+			continue
+		}
+		f := float64(*frac.(*tree.DFloat))
+		lo, hi := rows(f)
+		var err error
+		if results[i], err = result(values[lo], values[hi], f); err != nil {
+			return nil, err
+		}
+	}
+	if !isArray {
+		return results[0], nil
+	}
+	resultArr := tree.NewDArray(typ)
+	for _, r := range results {
+		if err := resultArr.Append(r); err != nil {
+			return nil, err
+		}
+	}
+	return resultArr, nil
+}
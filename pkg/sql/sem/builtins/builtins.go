@@ -51,6 +51,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
@@ -1602,6 +1603,26 @@ CockroachDB supports the following flags:
 		},
 	),
 
+	// The result of grouping() is computed by the optimizer from the grouping
+	// set of each row, so the function itself is never evaluated.
+	"grouping": makeBuiltin(
+		tree.FunctionProperties{
+			NullableArgs: true,
+		},
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: types.Any},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
+				return nil, pgerror.Unimplemented("grouping",
+					"grouping() is only supported by the cost-based optimizer")
+			},
+			Info: "Returns a bit mask with a bit set for each argument that is not " +
+				"part of the grouping set of the current row. The first argument " +
+				"corresponds to the most significant bit. The arguments must be " +
+				"GROUP BY expressions.",
+		},
+	),
+
 	// Timestamp/Date functions.
 
 	"experimental_strftime": makeBuiltin(
@@ -1709,6 +1730,19 @@ CockroachDB supports the following flags:
 		},
 	),
 
+	"current_time": makeBuiltin(
+		tree.FunctionProperties{Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{},
+			ReturnType: tree.FixedReturnType(types.TimeTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				t := ctx.GetTxnTimestamp(time.Microsecond).Time.In(ctx.GetLocation())
+				return tree.NewDTimeTZ(timetz.MakeTimeTZFromTime(t)), nil
+			},
+			Info: "Returns the time of the current transaction." + txnTSContextDoc,
+		},
+	),
+
 	"now":                   txnTSImpl,
 	"current_timestamp":     txnTSImpl,
 	"transaction_timestamp": txnTSImpl,
@@ -1964,6 +1998,47 @@ may increase either contention or retry errors, or both.`,
 		},
 	),
 
+	// timezone implements the AT TIME ZONE operator, which the parser
+	// rewrites to timezone(zone, input).
+	// https://www.postgresql.org/docs/10/static/functions-datetime.html#FUNCTIONS-DATETIME-ZONECONVERT
+	"timezone": makeBuiltin(
+		tree.FunctionProperties{Category: categoryDateAndTime},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"input", types.Timestamp}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.EvalTimestampAtTimeZone(args[0], args[1].(*tree.DTimestamp))
+			},
+			Info: "Treats `input` as a timestamp in the time zone `timezone`, and converts\n" +
+				"it to a timestamp with time zone.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.String}, {"input", types.TimestampTZ}},
+			ReturnType: tree.FixedReturnType(types.Timestamp),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.EvalTimestampTZAtTimeZone(args[0], args[1].(*tree.DTimestampTZ))
+			},
+			Info: "Converts `input` to the local time in the time zone `timezone`.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.Interval}, {"input", types.Timestamp}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.EvalTimestampAtTimeZone(args[0], args[1].(*tree.DTimestamp))
+			},
+			Info: "Treats `input` as a timestamp at the UTC offset `timezone`, and converts\n" +
+				"it to a timestamp with time zone.",
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"timezone", types.Interval}, {"input", types.TimestampTZ}},
+			ReturnType: tree.FixedReturnType(types.Timestamp),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.EvalTimestampTZAtTimeZone(args[0], args[1].(*tree.DTimestampTZ))
+			},
+			Info: "Converts `input` to the local time at the UTC offset `timezone`.",
+		},
+	),
+
 	// Math functions
 	"abs": makeBuiltin(defProps(),
 		floatOverload1(func(x float64) (tree.Datum, error) {
@@ -4295,7 +4370,7 @@ func asJSONBuildObjectKey(d tree.Datum) (string, error) {
 		return string(*t), nil
 	case *tree.DCollatedString:
 		return t.Contents, nil
-	case *tree.DBool, *tree.DInt, *tree.DFloat, *tree.DDecimal, *tree.DTimestamp, *tree.DTimestampTZ, *tree.DDate, *tree.DUuid, *tree.DInterval, *tree.DBytes, *tree.DIPAddr, *tree.DOid, *tree.DTime:
+	case *tree.DBool, *tree.DInt, *tree.DFloat, *tree.DDecimal, *tree.DTimestamp, *tree.DTimestampTZ, *tree.DDate, *tree.DUuid, *tree.DInterval, *tree.DBytes, *tree.DIPAddr, *tree.DOid, *tree.DTime, *tree.DTimeTZ:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", pgerror.NewErrorf(pgerror.CodeInternalError, "unexpected type %T for key value", d)
@@ -65,6 +65,7 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.AnyArray.Oid():    {},
 	types.Date.Oid():        {},
 	types.Time.Oid():        {},
+	types.TimeTZ.Oid():      {},
 	types.Decimal.Oid():     {},
 	types.Interval.Oid():    {},
 	types.JSON.Oid():        {},
@@ -223,6 +224,22 @@ func makePGGetIndexDef(argTypes tree.ArgTypes) tree.Overload {
 	}
 }
 
+// getPgObjDesc runs the given query, which must return the description of a
+// database object from pg_catalog.pg_description, and returns the
+// description, or NULL if the object has no comment.
+func getPgObjDesc(
+	ctx *tree.EvalContext, opName string, query string, args ...interface{},
+) (tree.Datum, error) {
+	r, err := ctx.InternalExecutor.QueryRow(ctx.Ctx(), opName, ctx.Txn, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) == 0 {
+		return tree.DNull, nil
+	}
+	return r[0], nil
+}
+
 // Make a pg_get_viewdef function with the given arguments.
 func makePGGetViewDef(argTypes tree.ArgTypes) tree.Overload {
 	return tree.Overload{
@@ -709,33 +726,46 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
-	"col_description": makeBuiltin(defProps(),
+	"col_description": makeBuiltin(tree.FunctionProperties{DistsqlBlacklist: true},
 		tree.Overload{
 			Types:      tree.ArgTypes{{"table_oid", types.Oid}, {"column_number", types.Int}},
 			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getPgObjDesc(ctx, "col_description",
+					`SELECT description FROM pg_catalog.pg_description `+
+						`WHERE objoid=$1 AND objsubid=$2 AND objsubid != 0`, args[0], args[1])
 			},
-			Info: notUsableInfo,
+			Info: "Returns the comment for a table column, which is specified by the OID of " +
+				"its table and its column number. (obj_description cannot be used for table " +
+				"columns, since columns do not have OIDs of their own.)",
 		},
 	),
 
-	"obj_description": makeBuiltin(defProps(),
+	"obj_description": makeBuiltin(tree.FunctionProperties{DistsqlBlacklist: true},
 		tree.Overload{
 			Types:      tree.ArgTypes{{"object_oid", types.Oid}},
 			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getPgObjDesc(ctx, "obj_description",
+					`SELECT description FROM pg_catalog.pg_description `+
+						`WHERE objoid=$1 AND objsubid=0`, args[0])
 			},
-			Info: notUsableInfo,
+			Info: "Returns the comment for a database object specified by its OID alone. " +
+				"This is deprecated since there is no guarantee that OIDs are unique across " +
+				"different system catalogs; therefore, the wrong comment might be returned.",
 		},
 		tree.Overload{
 			Types:      tree.ArgTypes{{"object_oid", types.Oid}, {"catalog_name", types.String}},
 			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getPgObjDesc(ctx, "obj_description",
+					`SELECT d.description FROM pg_catalog.pg_description d `+
+						`JOIN pg_catalog.pg_class c ON d.classoid = c.oid `+
+						`WHERE d.objoid=$1 AND d.objsubid=0 AND c.relname=$2`, args[0], args[1])
 			},
-			Info: notUsableInfo,
+			Info: "Returns the comment for a database object specified by its OID and the name " +
+				"of the containing system catalog. For example, obj_description(123456, " +
+				"'pg_class') would retrieve the comment for the table with OID 123456.",
 		},
 	),
 
@@ -819,6 +849,39 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// pg_notify returns void in Postgres. Like pg_sleep, it returns true here
+	// since there is no void type.
+	// https://www.postgresql.org/docs/current/static/functions-info.html#FUNCTIONS-INFO-NOTIFY
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{
+			DistsqlBlacklist: true,
+			Impure:           true,
+			NullableArgs:     true,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"channel", types.String}, {"payload", types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.NewError(pgerror.CodeInvalidParameterValueError,
+						"channel name cannot be empty")
+				}
+				payload := ""
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				channel := string(tree.MustBeDString(args[0]))
+				if err := ctx.Planner.SendNotification(ctx.Ctx(), channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DBoolTrue, nil
+			},
+			Info: "Sends a notification event with the given payload to all sessions " +
+				"listening on channel. The notification is delivered when the current " +
+				"transaction commits.",
+		},
+	),
+
 	"pg_sleep": makeBuiltin(
 		tree.FunctionProperties{
 			// pg_sleep is marked as impure so it doesn't get executed during
@@ -41,3 +41,26 @@ type AggregateFunc interface {
 	// not account for additional memory used during accumulation.
 	Size() int64
 }
+
+// OrderedSetAggregateFunc is an AggregateFunc that computes its result from
+// the complete set of aggregated values in the order specified by WITHIN
+// GROUP, e.g. percentile_disc.
+//
+// Values can either be accumulated with Add, in which case the aggregate
+// buffers and sorts them itself, or be provided in their final order with
+// SetSortedInput. The latter allows the caller to sort the values (and
+// possibly spill them to disk) outside of the aggregate.
+type OrderedSetAggregateFunc interface {
+	AggregateFunc
+
+	// SetDescending indicates that the WITHIN GROUP ordering is descending.
+	// It must be called before any values are accumulated.
+	SetDescending()
+
+	// SetSortedInput computes the result from the count non-NULL values
+	// returned by successive calls to next, which are in WITHIN GROUP order.
+	// next must not be called more than count times; the aggregate may stop
+	// consuming values early once it has computed its result. Any values
+	// accumulated with Add are ignored.
+	SetSortedInput(count int, next func() (Datum, error)) error
+}
@@ -72,6 +72,10 @@ func ComputeColNameInternal(sp sessiondata.SearchPath, target Expr) (int, string
 	case *FuncExpr:
 		fd, err := e.Func.Resolve(sp)
 		if err != nil {
+			if n, ok := e.Func.FunctionReference.(*UnresolvedName); ok && MaybeUserDefinedFunction(err) {
+				// User-defined functions are resolved during type checking.
+				return 2, n.Parts[0], nil
+			}
 			return 0, "", err
 		}
 		return 2, fd.Name, nil
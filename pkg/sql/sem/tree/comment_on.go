@@ -0,0 +1,68 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lex"
+
+// CommentOnTable represents a COMMENT ON TABLE statement.
+type CommentOnTable struct {
+	Table NormalizableTableName
+	// Comment is nil when the comment is removed with IS NULL.
+	Comment *string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CommentOnTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMMENT ON TABLE ")
+	ctx.FormatNode(&node.Table)
+	formatComment(ctx, node.Comment)
+}
+
+// CommentOnColumn represents a COMMENT ON COLUMN statement.
+type CommentOnColumn struct {
+	*ColumnItem
+	// Comment is nil when the comment is removed with IS NULL.
+	Comment *string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CommentOnColumn) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMMENT ON COLUMN ")
+	ctx.FormatNode(node.ColumnItem)
+	formatComment(ctx, node.Comment)
+}
+
+// CommentOnIndex represents a COMMENT ON INDEX statement.
+type CommentOnIndex struct {
+	Index TableNameWithIndex
+	// Comment is nil when the comment is removed with IS NULL.
+	Comment *string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CommentOnIndex) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMMENT ON INDEX ")
+	ctx.FormatNode(&node.Index)
+	formatComment(ctx, node.Comment)
+}
+
+func formatComment(ctx *FmtCtx, comment *string) {
+	ctx.WriteString(" IS ")
+	if comment == nil {
+		ctx.WriteString("NULL")
+		return
+	}
+	lex.EncodeSQLStringWithFlags(ctx.Buffer, *comment, ctx.flags.EncodeFlags())
+}
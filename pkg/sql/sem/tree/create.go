@@ -70,15 +70,28 @@ func (node *CreateDatabase) Format(ctx *FmtCtx) {
 	}
 }
 
-// IndexElem represents a column with a direction in a CREATE INDEX statement.
+// IndexElem represents a column or an expression with a direction in a
+// CREATE INDEX statement. Exactly one of Column and Expr is set.
 type IndexElem struct {
 	Column    Name
+	Expr      Expr
 	Direction Direction
 }
 
 // Format implements the NodeFormatter interface.
 func (node *IndexElem) Format(ctx *FmtCtx) {
-	ctx.FormatNode(&node.Column)
+	if node.Expr != nil {
+		// As in postgres, function calls do not need to be parenthesized.
+		if _, ok := node.Expr.(*FuncExpr); ok {
+			ctx.FormatNode(node.Expr)
+		} else {
+			ctx.WriteByte('(')
+			ctx.FormatNode(node.Expr)
+			ctx.WriteByte(')')
+		}
+	} else {
+		ctx.FormatNode(&node.Column)
+	}
 	if node.Direction != DefaultDirection {
 		ctx.WriteByte(' ')
 		ctx.WriteString(node.Direction.String())
@@ -112,6 +125,9 @@ type CreateIndex struct {
 	Storing     NameList
 	Interleave  *InterleaveDef
 	PartitionBy *PartitionBy
+	// Predicate, if set, makes this a partial index which only contains the
+	// rows that satisfy it.
+	Predicate Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -147,6 +163,10 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // TableDef represents a column, index or constraint definition within a CREATE
@@ -211,10 +231,12 @@ type ColumnTableDef struct {
 		Col            Name
 		ConstraintName Name
 		Actions        ReferenceActions
+		Match          CompositeKeyMatchMethod
 	}
 	Computed struct {
 		Computed bool
 		Expr     Expr
+		Virtual  bool
 	}
 	Family struct {
 		Name        Name
@@ -316,9 +338,11 @@ func NewColumnTableDef(
 			d.References.Col = t.Col
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
+			d.References.Match = t.Match
 		case *ColumnComputedDef:
 			d.Computed.Computed = true
 			d.Computed.Expr = t.Expr
+			d.Computed.Virtual = t.Virtual
 		case *ColumnFamilyConstraint:
 			if d.HasColumnFamily() {
 				return nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
@@ -354,6 +378,11 @@ func (node *ColumnTableDef) IsComputed() bool {
 	return node.Computed.Computed
 }
 
+// IsVirtual returns if the ColumnTableDef is a virtual computed column.
+func (node *ColumnTableDef) IsVirtual() bool {
+	return node.Computed.Computed && node.Computed.Virtual
+}
+
 // HasColumnFamily returns if the ColumnTableDef has a column family.
 func (node *ColumnTableDef) HasColumnFamily() bool {
 	return node.Family.Name != "" || node.Family.Create
@@ -414,12 +443,20 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.FormatNode(&node.References.Col)
 			ctx.WriteByte(')')
 		}
+		if node.References.Match != MatchUnspecified {
+			ctx.WriteByte(' ')
+			ctx.WriteString(node.References.Match.String())
+		}
 		ctx.FormatNode(&node.References.Actions)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
 		ctx.FormatNode(node.Computed.Expr)
-		ctx.WriteString(") STORED")
+		if node.Computed.Virtual {
+			ctx.WriteString(") VIRTUAL")
+		} else {
+			ctx.WriteString(") STORED")
+		}
 	}
 	if node.HasColumnFamily() {
 		if node.Family.Create {
@@ -491,11 +528,15 @@ type ColumnFKConstraint struct {
 	Table   NormalizableTableName
 	Col     Name // empty-string means use PK
 	Actions ReferenceActions
+	Match   CompositeKeyMatchMethod
 }
 
 // ColumnComputedDef represents the description of a computed column.
 type ColumnComputedDef struct {
 	Expr Expr
+	// Virtual is set for columns that are not stored and are instead
+	// evaluated when read.
+	Virtual bool
 }
 
 // ColumnFamilyConstraint represents FAMILY on a column.
@@ -514,6 +555,9 @@ type IndexTableDef struct {
 	Interleave  *InterleaveDef
 	Inverted    bool
 	PartitionBy *PartitionBy
+	// Predicate, if set, makes this a partial index which only contains the
+	// rows that satisfy it.
+	Predicate Expr
 }
 
 // SetName implements the TableDef interface.
@@ -545,6 +589,10 @@ func (node *IndexTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // ConstraintTableDef represents a constraint definition within a CREATE TABLE
@@ -567,15 +615,25 @@ type UniqueConstraintTableDef struct {
 
 // Format implements the NodeFormatter interface.
 func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
-	if node.Name != "" {
-		ctx.WriteString("CONSTRAINT ")
-		ctx.FormatNode(&node.Name)
-		ctx.WriteByte(' ')
-	}
-	if node.PrimaryKey {
-		ctx.WriteString("PRIMARY KEY ")
+	if node.Predicate != nil && !node.PrimaryKey {
+		// Partial unique indexes can only be declared with the UNIQUE INDEX
+		// syntax.
+		ctx.WriteString("UNIQUE INDEX ")
+		if node.Name != "" {
+			ctx.FormatNode(&node.Name)
+			ctx.WriteByte(' ')
+		}
 	} else {
-		ctx.WriteString("UNIQUE ")
+		if node.Name != "" {
+			ctx.WriteString("CONSTRAINT ")
+			ctx.FormatNode(&node.Name)
+			ctx.WriteByte(' ')
+		}
+		if node.PrimaryKey {
+			ctx.WriteString("PRIMARY KEY ")
+		} else {
+			ctx.WriteString("UNIQUE ")
+		}
 	}
 	ctx.WriteByte('(')
 	ctx.FormatNode(&node.Columns)
@@ -591,6 +649,10 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // ReferenceAction is the method used to maintain referential integrity through
@@ -637,6 +699,38 @@ func (node *ReferenceActions) Format(ctx *FmtCtx) {
 	}
 }
 
+// CompositeKeyMatchMethod is the algorithm used to match the values of a
+// composite foreign key, some of which may be NULL, against the referenced
+// table.
+type CompositeKeyMatchMethod int
+
+// The values for CompositeKeyMatchMethod.
+const (
+	// MatchUnspecified is used when no MATCH clause is given. It only skips
+	// the check of the rows with only NULL values, and requires the rows
+	// mixing NULL and non-NULL values to match a referenced row with NULL
+	// values in the same columns.
+	MatchUnspecified CompositeKeyMatchMethod = iota
+	// MatchSimple skips the check of the rows with at least one NULL value.
+	MatchSimple
+	// MatchFull skips the check of the rows with only NULL values, and
+	// rejects the rows mixing NULL and non-NULL values.
+	MatchFull
+	// MatchPartial is not supported.
+	MatchPartial
+)
+
+var compositeKeyMatchMethodName = [...]string{
+	MatchUnspecified: "",
+	MatchSimple:      "MATCH SIMPLE",
+	MatchFull:        "MATCH FULL",
+	MatchPartial:     "MATCH PARTIAL",
+}
+
+func (c CompositeKeyMatchMethod) String() string {
+	return compositeKeyMatchMethodName[c]
+}
+
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
 	Name     Name
@@ -644,6 +738,7 @@ type ForeignKeyConstraintTableDef struct {
 	FromCols NameList
 	ToCols   NameList
 	Actions  ReferenceActions
+	Match    CompositeKeyMatchMethod
 }
 
 // Format implements the NodeFormatter interface.
@@ -665,6 +760,10 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 		ctx.WriteByte(')')
 	}
 
+	if node.Match != MatchUnspecified {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.Match.String())
+	}
 	ctx.FormatNode(&node.Actions)
 }
 
@@ -844,6 +943,7 @@ func (node *RangePartition) Format(ctx *FmtCtx) {
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
 	IfNotExists   bool
+	Temporary     bool
 	Table         NormalizableTableName
 	Interleave    *InterleaveDef
 	PartitionBy   *PartitionBy
@@ -860,7 +960,11 @@ func (node *CreateTable) As() bool {
 
 // Format implements the NodeFormatter interface.
 func (node *CreateTable) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE TABLE ")
+	ctx.WriteString("CREATE ")
+	if node.Temporary {
+		ctx.WriteString("TEMPORARY ")
+	}
+	ctx.WriteString("TABLE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
@@ -935,6 +1039,7 @@ func (node *CreateTable) HoistConstraints() {
 					ToCols:   targetCol,
 					Name:     col.References.ConstraintName,
 					Actions:  col.References.Actions,
+					Match:    col.References.Match,
 				})
 				col.References.Table = NormalizableTableName{}
 			}
@@ -959,6 +1064,128 @@ func (node *CreateSequence) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Options)
 }
 
+// CreateType represents a CREATE TYPE ... AS ENUM statement.
+type CreateType struct {
+	Name       NormalizableTableName
+	EnumLabels []string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TYPE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" AS ENUM (")
+	for i, label := range node.EnumLabels {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		lex.EncodeSQLStringWithFlags(ctx.Buffer, label, ctx.flags.EncodeFlags())
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateFunction represents a CREATE FUNCTION statement.
+type CreateFunction struct {
+	Name    NormalizableTableName
+	Replace bool
+	Args    FuncArgs
+	// ReturnType is nil for a trigger function.
+	ReturnType     coltypes.T
+	ReturnsTrigger bool
+	Options        FunctionOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("FUNCTION ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Args)
+	ctx.WriteString(") RETURNS ")
+	if node.ReturnsTrigger {
+		ctx.WriteString("TRIGGER")
+	} else {
+		node.ReturnType.Format(ctx.Buffer, ctx.flags.EncodeFlags())
+	}
+	ctx.FormatNode(&node.Options)
+}
+
+// FuncArg represents an argument in the signature of a user-defined
+// function.
+type FuncArg struct {
+	// Name is empty if the argument is only referred to by position.
+	Name Name
+	Type coltypes.T
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArg) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	node.Type.Format(ctx.Buffer, ctx.flags.EncodeFlags())
+}
+
+// FuncArgs represents a list of function arguments.
+type FuncArgs []FuncArg
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArgs) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// FunctionOptions represents a list of options of a CREATE FUNCTION
+// statement.
+type FunctionOptions []FunctionOption
+
+// Format implements the NodeFormatter interface.
+func (node *FunctionOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		option := &(*node)[i]
+		ctx.WriteByte(' ')
+		ctx.WriteString(option.Name)
+		switch option.Name {
+		case FuncOptAs:
+			ctx.WriteByte(' ')
+			lex.EncodeSQLStringWithFlags(ctx.Buffer, option.StrVal, ctx.flags.EncodeFlags())
+		case FuncOptLanguage:
+			ctx.WriteByte(' ')
+			lang := Name(option.StrVal)
+			ctx.FormatNode(&lang)
+		case FuncOptImmutable, FuncOptStable, FuncOptVolatile:
+		default:
+			panic(fmt.Sprintf("unexpected FunctionOption: %v", option))
+		}
+	}
+}
+
+// FunctionOption represents an option of a CREATE FUNCTION statement.
+type FunctionOption struct {
+	Name string
+
+	// StrVal is set for the AS and LANGUAGE options.
+	StrVal string
+}
+
+// Names of options on CREATE FUNCTION.
+const (
+	FuncOptAs        = "AS"
+	FuncOptLanguage  = "LANGUAGE"
+	FuncOptImmutable = "IMMUTABLE"
+	FuncOptStable    = "STABLE"
+	FuncOptVolatile  = "VOLATILE"
+)
+
 // SequenceOptions represents a list of sequence options.
 type SequenceOptions []SequenceOption
 
@@ -968,8 +1195,20 @@ func (node *SequenceOptions) Format(ctx *FmtCtx) {
 		option := &(*node)[i]
 		ctx.WriteByte(' ')
 		switch option.Name {
+		case SeqOptAs:
+			ctx.WriteString(option.Name)
+			ctx.WriteByte(' ')
+			ctx.WriteString(option.AsType.String())
 		case SeqOptCycle, SeqOptNoCycle:
 			ctx.WriteString(option.Name)
+		case SeqOptOwnedBy:
+			ctx.WriteString(option.Name)
+			ctx.WriteByte(' ')
+			if option.ColumnItemVal == nil {
+				ctx.WriteString("NONE")
+			} else {
+				ctx.FormatNode(option.ColumnItemVal)
+			}
 		case SeqOptCache:
 			ctx.WriteString(option.Name)
 			ctx.WriteByte(' ')
@@ -1011,6 +1250,12 @@ type SequenceOption struct {
 
 	IntVal *int64
 
+	// AsType is set for the AS option.
+	AsType coltypes.T
+
+	// ColumnItemVal is set for the OWNED BY option; nil means OWNED BY NONE.
+	ColumnItemVal *ColumnItem
+
 	OptionalWord bool
 }
 
@@ -1026,10 +1271,6 @@ const (
 	SeqOptMaxValue  = "MAXVALUE"
 	SeqOptStart     = "START"
 	SeqOptVirtual   = "VIRTUAL"
-
-	// Avoid unused warning for constants.
-	_ = SeqOptAs
-	_ = SeqOptOwnedBy
 )
 
 // CreateUser represents a CREATE USER statement.
@@ -1100,14 +1341,19 @@ func (node *CreateRole) Format(ctx *FmtCtx) {
 
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
-	Name        NormalizableTableName
-	ColumnNames NameList
-	AsSource    *Select
+	Name         NormalizableTableName
+	ColumnNames  NameList
+	AsSource     *Select
+	Materialized bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *CreateView) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE VIEW ")
+	ctx.WriteString("CREATE ")
+	if node.Materialized {
+		ctx.WriteString("MATERIALIZED ")
+	}
+	ctx.WriteString("VIEW ")
 	ctx.FormatNode(&node.Name)
 
 	if len(node.ColumnNames) > 0 {
@@ -1139,3 +1385,80 @@ func (node *CreateStats) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	ctx.FormatNode(&node.Table)
 }
+
+// TriggerTiming specifies when a trigger fires relative to the write of
+// a row.
+type TriggerTiming int
+
+// The values of TriggerTiming.
+const (
+	TriggerBefore TriggerTiming = iota
+	TriggerAfter
+)
+
+var triggerTimingName = [...]string{
+	TriggerBefore: "BEFORE",
+	TriggerAfter:  "AFTER",
+}
+
+func (t TriggerTiming) String() string {
+	return triggerTimingName[t]
+}
+
+// TriggerEvent is a kind of row mutation that fires a trigger.
+type TriggerEvent int
+
+// The values of TriggerEvent.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+var triggerEventName = [...]string{
+	TriggerInsert: "INSERT",
+	TriggerUpdate: "UPDATE",
+	TriggerDelete: "DELETE",
+}
+
+func (e TriggerEvent) String() string {
+	return triggerEventName[e]
+}
+
+// TriggerEvents represents the list of events of a CREATE TRIGGER
+// statement.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name     Name
+	Timing   TriggerTiming
+	Events   TriggerEvents
+	Table    NormalizableTableName
+	FuncName NormalizableTableName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Timing.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" FOR EACH ROW EXECUTE FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("()")
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -1245,6 +1246,116 @@ func (d *DCollatedString) IsComposite() bool {
 	return true
 }
 
+// DEnum is the Datum for values of enum types. The value is represented by
+// the ordinal of its label in the enum, which is also what defines its sort
+// order. The struct members are intended to be immutable.
+type DEnum struct {
+	EnumTyp *types.TEnum
+	Ordinal int
+}
+
+// NewDEnum is a helper routine to create a *DEnum.
+func NewDEnum(typ *types.TEnum, ordinal int) *DEnum {
+	return &DEnum{EnumTyp: typ, Ordinal: ordinal}
+}
+
+// ParseDEnum returns the DEnum of the given enum type with the given label.
+func ParseDEnum(typ *types.TEnum, label string) (*DEnum, error) {
+	if typ.TypeID == 0 {
+		return nil, makeParseError(label, typ, nil)
+	}
+	ord := typ.LabelOrdinal(label)
+	if ord < 0 {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidTextRepresentationError,
+			"invalid input value for enum %s: %q", typ.TypeName, label)
+	}
+	return NewDEnum(typ, ord), nil
+}
+
+// Label returns the label of the enum value.
+func (d *DEnum) Label() string {
+	return d.EnumTyp.Labels[d.Ordinal]
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DEnum) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DEnum) Format(ctx *FmtCtx) {
+	buf, f := ctx.Buffer, ctx.flags
+	if f.HasFlags(fmtUnicodeStrings) {
+		buf.WriteString(d.Label())
+	} else {
+		lex.EncodeSQLStringWithFlags(buf, d.Label(), f.EncodeFlags())
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DEnum) ResolvedType() types.T {
+	return d.EnumTyp
+}
+
+// Compare implements the Datum interface.
+func (d *DEnum) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DEnum)
+	if !ok || d.EnumTyp.TypeID != v.EnumTyp.TypeID {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	if d.Ordinal < v.Ordinal {
+		return -1
+	}
+	if d.Ordinal > v.Ordinal {
+		return 1
+	}
+	return 0
+}
+
+// Prev implements the Datum interface.
+func (d *DEnum) Prev(_ *EvalContext) (Datum, bool) {
+	if d.Ordinal == 0 {
+		return nil, false
+	}
+	return NewDEnum(d.EnumTyp, d.Ordinal-1), true
+}
+
+// Next implements the Datum interface.
+func (d *DEnum) Next(_ *EvalContext) (Datum, bool) {
+	if d.Ordinal >= len(d.EnumTyp.Labels)-1 {
+		return nil, false
+	}
+	return NewDEnum(d.EnumTyp, d.Ordinal+1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DEnum) IsMax(_ *EvalContext) bool {
+	return d.Ordinal == len(d.EnumTyp.Labels)-1
+}
+
+// IsMin implements the Datum interface.
+func (d *DEnum) IsMin(_ *EvalContext) bool {
+	return d.Ordinal == 0
+}
+
+// Min implements the Datum interface.
+func (d *DEnum) Min(_ *EvalContext) (Datum, bool) {
+	return NewDEnum(d.EnumTyp, 0), true
+}
+
+// Max implements the Datum interface.
+func (d *DEnum) Max(_ *EvalContext) (Datum, bool) {
+	return NewDEnum(d.EnumTyp, len(d.EnumTyp.Labels)-1), true
+}
+
+// Size implements the Datum interface.
+func (d *DEnum) Size() uintptr {
+	// The enum type is shared between the values of the type.
+	return unsafe.Sizeof(*d)
+}
+
 // DBytes is the bytes Datum. The underlying type is a string because we want
 // the immutability, but this may contain arbitrary bytes.
 type DBytes string
@@ -1818,6 +1929,107 @@ func (d *DTime) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DTimeTZ is the time with time zone Datum.
+type DTimeTZ struct {
+	timetz.TimeTZ
+}
+
+// NewDTimeTZ creates a DTimeTZ from a TimeTZ.
+func NewDTimeTZ(t timetz.TimeTZ) *DTimeTZ {
+	return &DTimeTZ{TimeTZ: t}
+}
+
+// ParseDTimeTZ parses and returns the *DTimeTZ Datum value represented by
+// the provided string, or an error if parsing is unsuccessful. If the
+// string does not specify an offset, the offset of loc is used.
+func ParseDTimeTZ(s string, loc *time.Location) (*DTimeTZ, error) {
+	t, err := parseTimestampInLocation("1970-01-01 "+s, loc, types.TimeTZ)
+	if err != nil {
+		// Build our own error message to avoid exposing the dummy date.
+		return nil, makeParseError(s, types.TimeTZ, nil)
+	}
+	ret := timetz.MakeTimeTZFromTime(t)
+	if ret.OffsetSecs > timetz.MaxOffsetSecs || ret.OffsetSecs < -timetz.MaxOffsetSecs {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidTimeZoneDisplacementValueError,
+			"time zone displacement out of range: %q", s)
+	}
+	return NewDTimeTZ(ret), nil
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTimeTZ) ResolvedType() types.T {
+	return types.TimeTZ
+}
+
+// Compare implements the Datum interface.
+func (d *DTimeTZ) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTimeTZ)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return d.TimeTZ.Compare(v.TimeTZ)
+}
+
+// Prev implements the Datum interface.
+func (d *DTimeTZ) Prev(_ *EvalContext) (Datum, bool) {
+	// Values that represent the same UTC time at different offsets are
+	// distinct, so there is no simple predecessor.
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTimeTZ) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+var dTimeTZMin = NewDTimeTZ(timetz.Min)
+var dTimeTZMax = NewDTimeTZ(timetz.Max)
+
+// IsMax implements the Datum interface.
+func (d *DTimeTZ) IsMax(_ *EvalContext) bool {
+	return d.TimeTZ == dTimeTZMax.TimeTZ
+}
+
+// IsMin implements the Datum interface.
+func (d *DTimeTZ) IsMin(_ *EvalContext) bool {
+	return d.TimeTZ == dTimeTZMin.TimeTZ
+}
+
+// Max implements the Datum interface.
+func (d *DTimeTZ) Max(_ *EvalContext) (Datum, bool) {
+	return dTimeTZMax, true
+}
+
+// Min implements the Datum interface.
+func (d *DTimeTZ) Min(_ *EvalContext) (Datum, bool) {
+	return dTimeTZMin, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTimeTZ) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTimeTZ) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lex.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.TimeTZ.String())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTimeTZ) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
 // DTimestamp is the timestamp Datum.
 type DTimestamp struct {
 	time.Time
@@ -2447,6 +2659,8 @@ func AsJSON(d Datum) (json.JSON, error) {
 		return json.FromString(string(*t)), nil
 	case *DCollatedString:
 		return json.FromString(t.Contents), nil
+	case *DEnum:
+		return json.FromString(t.Label()), nil
 	case *DJSON:
 		return t.JSON, nil
 	case *DArray:
@@ -2469,7 +2683,7 @@ func AsJSON(d Datum) (json.JSON, error) {
 			builder.Add(fmt.Sprintf("f%d", i+1), j)
 		}
 		return builder.Build(), nil
-	case *DTimestamp, *DTimestampTZ, *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DBitArray:
+	case *DTimestamp, *DTimestampTZ, *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings)), nil
 	default:
 		if d == DNull {
@@ -3526,6 +3740,9 @@ func DatumTypeSize(t types.T) (uintptr, bool) {
 	case types.TCollatedString:
 		return unsafe.Sizeof(DCollatedString{"", "", nil}), variableSize
 
+	case *types.TEnum:
+		return unsafe.Sizeof(DEnum{}), fixedSize
+
 	case types.TTuple:
 		sz := uintptr(0)
 		variable := false
@@ -3568,6 +3785,7 @@ var baseDatumTypeSizes = map[types.T]struct {
 	types.Bytes:       {unsafe.Sizeof(DBytes("")), variableSize},
 	types.Date:        {unsafe.Sizeof(DDate(0)), fixedSize},
 	types.Time:        {unsafe.Sizeof(DTime(0)), fixedSize},
+	types.TimeTZ:      {unsafe.Sizeof(DTimeTZ{}), fixedSize},
 	types.Timestamp:   {unsafe.Sizeof(DTimestamp{}), fixedSize},
 	types.TimestampTZ: {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.Interval:    {unsafe.Sizeof(DInterval{}), fixedSize},
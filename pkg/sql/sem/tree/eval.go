@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -573,6 +574,44 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(left.(*DInterval).Duration)), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.Date,
+			RightType:  types.TimeTZ,
+			ReturnType: types.TimestampTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				d := MakeDTimestampTZFromDate(time.UTC, left.(*DDate))
+				t := time.Duration(right.(*DTimeTZ).UTCMicros()) * time.Microsecond
+				return MakeDTimestampTZ(d.Add(t), time.Microsecond), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Date,
+			ReturnType: types.TimestampTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				d := MakeDTimestampTZFromDate(time.UTC, right.(*DDate))
+				t := time.Duration(left.(*DTimeTZ).UTCMicros()) * time.Microsecond
+				return MakeDTimestampTZ(d.Add(t), time.Microsecond), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ).TimeTZ
+				return NewDTimeTZ(t.Add(right.(*DInterval).Duration)), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.Interval,
+			RightType:  types.TimeTZ,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := right.(*DTimeTZ).TimeTZ
+				return NewDTimeTZ(t.Add(left.(*DInterval).Duration)), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -807,6 +846,15 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(right.(*DInterval).Duration.Mul(-1))), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ).TimeTZ
+				return NewDTimeTZ(t.Add(right.(*DInterval).Duration.Mul(-1))), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -1622,6 +1670,69 @@ func init() {
 	}
 }
 
+func init() {
+	// Array containment and overlap comparisons.
+	for _, t := range types.AnyNonArray {
+		CmpOps[Contains] = append(CmpOps[Contains], &CmpOp{
+			LeftType:  types.TArray{Typ: t},
+			RightType: types.TArray{Typ: t},
+			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(ArrayContains(ctx, MustBeDArray(left), MustBeDArray(right)))), nil
+			},
+		})
+
+		CmpOps[ContainedBy] = append(CmpOps[ContainedBy], &CmpOp{
+			LeftType:  types.TArray{Typ: t},
+			RightType: types.TArray{Typ: t},
+			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(ArrayContains(ctx, MustBeDArray(right), MustBeDArray(left)))), nil
+			},
+		})
+
+		CmpOps[Overlaps] = append(CmpOps[Overlaps], &CmpOp{
+			LeftType:  types.TArray{Typ: t},
+			RightType: types.TArray{Typ: t},
+			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(ArrayOverlaps(ctx, MustBeDArray(left), MustBeDArray(right)))), nil
+			},
+		})
+	}
+}
+
+// ArrayContains returns whether every element of needles is equal to some
+// element of haystack. NULL elements are never equal to anything, so needles
+// containing a NULL are never contained.
+func ArrayContains(ctx *EvalContext, haystack, needles *DArray) bool {
+	for _, needle := range needles.Array {
+		if needle == DNull || !arrayHasElement(ctx, haystack, needle) {
+			return false
+		}
+	}
+	return true
+}
+
+// ArrayOverlaps returns whether the two arrays have a non-NULL element in
+// common.
+func ArrayOverlaps(ctx *EvalContext, left, right *DArray) bool {
+	for _, elem := range left.Array {
+		if elem != DNull && arrayHasElement(ctx, right, elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// arrayHasElement returns whether the array has a non-NULL element equal to
+// the given non-NULL datum.
+func arrayHasElement(ctx *EvalContext, arr *DArray, d Datum) bool {
+	for _, elem := range arr.Array {
+		if elem != DNull && elem.Compare(ctx, d) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	for op, overload := range CmpOps {
 		for i, impl := range overload {
@@ -1678,6 +1789,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Date, types.Date),
 		makeEqFn(types.Decimal, types.Decimal),
 		makeEqFn(types.FamCollatedString, types.FamCollatedString),
+		makeEqFn(types.FamEnum, types.FamEnum),
 		makeEqFn(types.Float, types.Float),
 		makeEqFn(types.INet, types.INet),
 		makeEqFn(types.Int, types.Int),
@@ -1686,6 +1798,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Oid, types.Oid),
 		makeEqFn(types.String, types.String),
 		makeEqFn(types.Time, types.Time),
+		makeEqFn(types.TimeTZ, types.TimeTZ),
 		makeEqFn(types.Timestamp, types.Timestamp),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ),
 		makeEqFn(types.UUID, types.UUID),
@@ -1722,6 +1835,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Date, types.Date),
 		makeLtFn(types.Decimal, types.Decimal),
 		makeLtFn(types.FamCollatedString, types.FamCollatedString),
+		makeLtFn(types.FamEnum, types.FamEnum),
 		makeLtFn(types.Float, types.Float),
 		makeLtFn(types.INet, types.INet),
 		makeLtFn(types.Int, types.Int),
@@ -1729,6 +1843,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Oid, types.Oid),
 		makeLtFn(types.String, types.String),
 		makeLtFn(types.Time, types.Time),
+		makeLtFn(types.TimeTZ, types.TimeTZ),
 		makeLtFn(types.Timestamp, types.Timestamp),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ),
 		makeLtFn(types.UUID, types.UUID),
@@ -1765,6 +1880,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Date, types.Date),
 		makeLeFn(types.Decimal, types.Decimal),
 		makeLeFn(types.FamCollatedString, types.FamCollatedString),
+		makeLeFn(types.FamEnum, types.FamEnum),
 		makeLeFn(types.Float, types.Float),
 		makeLeFn(types.INet, types.INet),
 		makeLeFn(types.Int, types.Int),
@@ -1772,6 +1888,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Oid, types.Oid),
 		makeLeFn(types.String, types.String),
 		makeLeFn(types.Time, types.Time),
+		makeLeFn(types.TimeTZ, types.TimeTZ),
 		makeLeFn(types.Timestamp, types.Timestamp),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ),
 		makeLeFn(types.UUID, types.UUID),
@@ -1816,6 +1933,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Date, types.Date),
 		makeIsFn(types.Decimal, types.Decimal),
 		makeIsFn(types.FamCollatedString, types.FamCollatedString),
+		makeIsFn(types.FamEnum, types.FamEnum),
 		makeIsFn(types.Float, types.Float),
 		makeIsFn(types.INet, types.INet),
 		makeIsFn(types.Int, types.Int),
@@ -1824,6 +1942,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Oid, types.Oid),
 		makeIsFn(types.String, types.String),
 		makeIsFn(types.Time, types.Time),
+		makeIsFn(types.TimeTZ, types.TimeTZ),
 		makeIsFn(types.Timestamp, types.Timestamp),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ),
 		makeIsFn(types.UUID, types.UUID),
@@ -1863,6 +1982,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Date),
 		makeEvalTupleIn(types.Decimal),
 		makeEvalTupleIn(types.FamCollatedString),
+		makeEvalTupleIn(types.FamEnum),
 		makeEvalTupleIn(types.FamTuple),
 		makeEvalTupleIn(types.Float),
 		makeEvalTupleIn(types.INet),
@@ -1872,6 +1992,7 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Oid),
 		makeEvalTupleIn(types.String),
 		makeEvalTupleIn(types.Time),
+		makeEvalTupleIn(types.TimeTZ),
 		makeEvalTupleIn(types.Timestamp),
 		makeEvalTupleIn(types.TimestampTZ),
 		makeEvalTupleIn(types.UUID),
@@ -2021,6 +2142,18 @@ var CmpOps = map[ComparisonOperator]cmpOpOverload{
 			},
 		},
 	},
+
+	Overlaps: {
+		&CmpOp{
+			LeftType:  types.INet,
+			RightType: types.INet,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				ipAddr := MustBeDIPAddr(left).IPAddr
+				other := MustBeDIPAddr(right).IPAddr
+				return MakeDBool(DBool(ipAddr.ContainsOrContainedBy(&other))), nil
+			},
+		},
+	},
 }
 
 // This map contains the inverses for operators in the CmpOps map that have
@@ -2346,6 +2479,10 @@ type EvalPlanner interface {
 
 	// EvalSubquery returns the Datum for the given subquery node.
 	EvalSubquery(expr *Subquery) (Datum, error)
+
+	// SendNotification sends a notification on the given channel when the
+	// current transaction commits.
+	SendNotification(ctx context.Context, channel, payload string) error
 }
 
 // SessionBoundInternalExecutor is a subset of sqlutil.InternalExecutor used by
@@ -2638,6 +2775,76 @@ func (ctx *EvalContext) GetLocation() *time.Location {
 	return ctx.SessionData.DataConversion.Location
 }
 
+// TimeZoneStringToLocation looks up the time zone with the given IANA name,
+// as used by SET TIME ZONE and AT TIME ZONE. The lookup falls back to the
+// upper and title case versions of the name, so that e.g. 'utc' is accepted.
+// If no time zone is found, the error of the lookup of the original name is
+// returned.
+func TimeZoneStringToLocation(name string) (*time.Location, error) {
+	loc, err := timeutil.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+	if loc, err1 := timeutil.LoadLocation(strings.ToUpper(name)); err1 == nil {
+		return loc, nil
+	}
+	if loc, err1 := timeutil.LoadLocation(strings.ToTitle(name)); err1 == nil {
+		return loc, nil
+	}
+	return nil, err
+}
+
+// TimeZoneToLocation converts the time zone operand of AT TIME ZONE or
+// timezone() to a *time.Location. A string is looked up as a time zone name,
+// and an interval is used as a fixed offset east of UTC.
+func TimeZoneToLocation(zone Datum) (*time.Location, error) {
+	switch t := zone.(type) {
+	case *DString:
+		loc, err := TimeZoneStringToLocation(string(*t))
+		if err != nil {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"time zone %q not recognized", string(*t))
+		}
+		return loc, nil
+	case *DInterval:
+		if t.Months != 0 || t.Days != 0 {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"interval time zone %s must not include months or days", t)
+		}
+		return timeutil.FixedOffsetTimeZoneToLocation(
+			int(t.Nanos/int64(time.Second)), t.String()), nil
+	}
+	return nil, errors.Errorf("unsupported time zone type %s", zone.ResolvedType())
+}
+
+// EvalTimestampAtTimeZone evaluates `ts AT TIME ZONE zone` for a TIMESTAMP:
+// the wall clock time of ts is interpreted in the given time zone, and the
+// corresponding TIMESTAMPTZ is returned.
+func EvalTimestampAtTimeZone(zone Datum, ts *DTimestamp) (Datum, error) {
+	loc, err := TimeZoneToLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+	t := ts.Time.UTC()
+	return MakeDTimestampTZ(time.Date(
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc,
+	), time.Microsecond), nil
+}
+
+// EvalTimestampTZAtTimeZone evaluates `ts AT TIME ZONE zone` for a
+// TIMESTAMPTZ: the TIMESTAMP returned is the wall clock time of ts in the
+// given time zone.
+func EvalTimestampTZAtTimeZone(zone Datum, ts *DTimestampTZ) (Datum, error) {
+	loc, err := TimeZoneToLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+	t := ts.Time.In(loc)
+	return MakeDTimestamp(time.Date(
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC,
+	), time.Microsecond), nil
+}
+
 // Ctx returns the session's context.
 func (ctx *EvalContext) Ctx() context.Context {
 	return ctx.Context
@@ -3054,7 +3261,7 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 				ctx.SessionData.DataConversion.GetFloatPrec(), 64)
 		case *DBool, *DInt, *DDecimal:
 			s = d.String()
-		case *DTimestamp, *DTimestampTZ, *DDate, *DTime:
+		case *DTimestamp, *DTimestampTZ, *DDate, *DTime, *DTimeTZ:
 			s = AsStringWithFlags(d, FmtBareStrings)
 		case *DTuple:
 			s = AsStringWithFlags(d, FmtPgwireText)
@@ -3073,6 +3280,8 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			s = string(*t)
 		case *DCollatedString:
 			s = t.Contents
+		case *DEnum:
+			s = t.Label()
 		case *DBytes:
 			s = lex.EncodeByteArrayToRawBytes(string(*t),
 				ctx.SessionData.DataConversion.BytesEncodeFormat, false /* skipHexPrefix */)
@@ -3158,6 +3367,8 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			return ParseDTime(d.Contents)
 		case *DTime:
 			return d, nil
+		case *DTimeTZ:
+			return MakeDTime(d.TimeOfDay), nil
 		case *DTimestamp:
 			return MakeDTime(timeofday.FromTime(d.Time)), nil
 		case *DTimestampTZ:
@@ -3166,6 +3377,20 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 			return MakeDTime(timeofday.Min.Add(d.Duration)), nil
 		}
 
+	case *coltypes.TTimeTZ:
+		switch d := d.(type) {
+		case *DString:
+			return ParseDTimeTZ(string(*d), ctx.GetLocation())
+		case *DCollatedString:
+			return ParseDTimeTZ(d.Contents, ctx.GetLocation())
+		case *DTime:
+			return NewDTimeTZ(timetz.MakeTimeTZFromLocation(timeofday.TimeOfDay(*d), ctx.GetLocation())), nil
+		case *DTimeTZ:
+			return d, nil
+		case *DTimestampTZ:
+			return NewDTimeTZ(timetz.MakeTimeTZFromTime(d.Time.In(ctx.GetLocation()))), nil
+		}
+
 	case *coltypes.TTimestamp:
 		// TODO(knz): Timestamp from float, decimal.
 		switch d := d.(type) {
@@ -3244,6 +3469,20 @@ func PerformCast(ctx *EvalContext, d Datum, t coltypes.CastTargetType) (Datum, e
 		case *DJSON:
 			return v, nil
 		}
+	case *coltypes.TUserDefined:
+		if typ.Typ == nil {
+			return nil, NewUndefinedTypeError(typ.Name)
+		}
+		switch v := d.(type) {
+		case *DString:
+			return ParseDEnum(typ.Typ, string(*v))
+		case *DCollatedString:
+			return ParseDEnum(typ.Typ, v.Contents)
+		case *DEnum:
+			if v.EnumTyp.TypeID == typ.Typ.TypeID {
+				return v, nil
+			}
+		}
 	case *coltypes.TArray:
 		switch v := d.(type) {
 		case *DString:
@@ -3627,6 +3866,9 @@ func (expr *IsOfTypeExpr) Eval(ctx *EvalContext) (Datum, error) {
 
 	for _, t := range expr.Types {
 		wantTyp := coltypes.CastTargetToDatumType(t)
+		if _, isEnum := wantTyp.(*types.TEnum); isEnum && !datumTyp.Equivalent(wantTyp) {
+			continue
+		}
 		if datumTyp.FamilyEqual(wantTyp) {
 			return MakeDBool(DBool(!expr.Not)), nil
 		}
@@ -3868,6 +4110,11 @@ func (t *DTime) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DTimeTZ) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DFloat) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -3908,6 +4155,11 @@ func (t *DCollatedString) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DEnum) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DTimestamp) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -314,6 +314,7 @@ const (
 	JSONExists
 	JSONSomeExists
 	JSONAllExists
+	Overlaps
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -361,6 +362,7 @@ var comparisonOpName = [...]string{
 	JSONExists:        "?",
 	JSONSomeExists:    "?|",
 	JSONAllExists:     "?&",
+	Overlaps:          "&&",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -1159,6 +1161,13 @@ type FuncExpr struct {
 	Filter    Expr
 	WindowDef *WindowDef
 
+	// AggType is used to specify the type of aggregation.
+	AggType AggType
+	// OrderBy is used for ordered-set aggregates to specify the ordering
+	// of the aggregated values:
+	// PERCENTILE_DISC(0.5) WITHIN GROUP (ORDER BY k)
+	OrderBy OrderBy
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1191,6 +1200,20 @@ func NewTypedFuncExpr(
 	return f
 }
 
+// AggType specifies the type of aggregation.
+type AggType int
+
+// FuncExpr.AggType
+const (
+	_ AggType = iota
+	// GeneralAgg is used for general-purpose aggregate functions.
+	// array_agg(col1 ORDER BY col2)
+	GeneralAgg
+	// OrderedSetAgg is used for ordered-set aggregate functions.
+	// percentile_disc(fraction) WITHIN GROUP (ORDER BY col)
+	OrderedSetAgg
+)
+
 // ResolvedOverload returns the builtin definition; can only be called after
 // Resolve (which happens during TypeCheck).
 func (node *FuncExpr) ResolvedOverload() *Overload {
@@ -1204,11 +1227,31 @@ func (node *FuncExpr) GetAggregateConstructor() func(*EvalContext, Datums) Aggre
 		return nil
 	}
 	return func(evalCtx *EvalContext, arguments Datums) AggregateFunc {
-		types := typesOfExprs(node.Exprs)
+		types := typesOfExprs(node.AggregateArgs())
 		return node.fn.AggregateFunc(types, evalCtx, arguments)
 	}
 }
 
+// IsOrderedSetAggregate returns true iff the function is being applied as
+// an ordered-set aggregate, using WITHIN GROUP.
+func (node *FuncExpr) IsOrderedSetAggregate() bool {
+	return node.AggType == OrderedSetAgg
+}
+
+// AggregateArgs returns the arguments of the function in the order in which
+// they are passed to the aggregate. For ordered-set aggregates the aggregated
+// WITHIN GROUP column comes first, followed by the direct arguments.
+func (node *FuncExpr) AggregateArgs() Exprs {
+	if !node.IsOrderedSetAggregate() {
+		return node.Exprs
+	}
+	args := make(Exprs, 0, len(node.OrderBy)+len(node.Exprs))
+	for _, o := range node.OrderBy {
+		args = append(args, o.Expr)
+	}
+	return append(args, node.Exprs...)
+}
+
 // GetWindowConstructor returns a window function constructor if the
 // FuncExpr is a built-in window function.
 func (node *FuncExpr) GetWindowConstructor() func(*EvalContext) WindowFunc {
@@ -1281,6 +1324,11 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 	ctx.WriteString(typ)
 	ctx.FormatNode(&node.Exprs)
 	ctx.WriteByte(')')
+	if node.IsOrderedSetAggregate() {
+		ctx.WriteString(" WITHIN GROUP (")
+		ctx.FormatNode(&node.OrderBy)
+		ctx.WriteByte(')')
+	}
 	if ctx.HasFlags(FmtParsable) && node.typ != nil {
 		if node.fnProps.AmbiguousReturnType {
 			if typ, err := coltypes.DatumTypeToColumnType(node.typ); err == nil {
@@ -1438,11 +1486,14 @@ var (
 	stringCastTypes = []types.T{types.Unknown, types.Bool, types.Int, types.Float, types.Decimal, types.String, types.FamCollatedString,
 		types.BitArray,
 		types.FamArray, types.FamTuple,
-		types.Bytes, types.Timestamp, types.TimestampTZ, types.Interval, types.UUID, types.Date, types.Time, types.Oid, types.INet, types.JSON}
+		types.Bytes, types.Timestamp, types.TimestampTZ, types.Interval, types.UUID, types.Date, types.Time, types.TimeTZ, types.Oid, types.INet, types.JSON,
+		types.FamEnum}
 	bytesCastTypes = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Bytes, types.UUID}
 	dateCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Date, types.Timestamp, types.TimestampTZ, types.Int}
-	timeCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Time,
+	timeCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Time, types.TimeTZ,
 		types.Timestamp, types.TimestampTZ, types.Interval}
+	timeTZCastTypes = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Time, types.TimeTZ,
+		types.TimestampTZ}
 	timestampCastTypes = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Date, types.Timestamp, types.TimestampTZ, types.Int}
 	intervalCastTypes  = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Int, types.Time, types.Interval, types.Float, types.Decimal}
 	oidCastTypes       = []types.T{types.Unknown, types.String, types.FamCollatedString, types.Int, types.Oid}
@@ -1450,6 +1501,7 @@ var (
 	inetCastTypes      = []types.T{types.Unknown, types.String, types.FamCollatedString, types.INet}
 	arrayCastTypes     = []types.T{types.Unknown, types.String}
 	jsonCastTypes      = []types.T{types.Unknown, types.String, types.JSON}
+	enumCastTypes      = []types.T{types.Unknown, types.String, types.FamCollatedString, types.FamEnum}
 )
 
 // validCastTypes returns a set of types that can be cast into the provided type.
@@ -1473,6 +1525,8 @@ func validCastTypes(t types.T) []types.T {
 		return dateCastTypes
 	case types.Time:
 		return timeCastTypes
+	case types.TimeTZ:
+		return timeTZCastTypes
 	case types.Timestamp, types.TimestampTZ:
 		return timestampCastTypes
 	case types.Interval:
@@ -1494,6 +1548,8 @@ func validCastTypes(t types.T) []types.T {
 			ret := make([]types.T, len(arrayCastTypes))
 			copy(ret, arrayCastTypes)
 			return ret
+		} else if t.FamilyEqual(types.FamEnum) {
+			return enumCastTypes
 		}
 		return nil
 	}
@@ -1646,6 +1702,7 @@ func (node *DBool) String() string            { return AsString(node) }
 func (node *DBytes) String() string           { return AsString(node) }
 func (node *DDate) String() string            { return AsString(node) }
 func (node *DTime) String() string            { return AsString(node) }
+func (node *DTimeTZ) String() string          { return AsString(node) }
 func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
@@ -1655,6 +1712,7 @@ func (node *DUuid) String() string            { return AsString(node) }
 func (node *DIPAddr) String() string          { return AsString(node) }
 func (node *DString) String() string          { return AsString(node) }
 func (node *DCollatedString) String() string  { return AsString(node) }
+func (node *DEnum) String() string            { return AsString(node) }
 func (node *DTimestamp) String() string       { return AsString(node) }
 func (node *DTimestampTZ) String() string     { return AsString(node) }
 func (node *DTuple) String() string           { return AsString(node) }
@@ -1664,6 +1722,7 @@ func (node *DOidWrapper) String() string      { return AsString(node) }
 func (node *Exprs) String() string            { return AsString(node) }
 func (node *ArrayFlatten) String() string     { return AsString(node) }
 func (node *FuncExpr) String() string         { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *IfExpr) String() string           { return AsString(node) }
 func (node *IfErrExpr) String() string        { return AsString(node) }
 func (node *IndexedVar) String() string       { return AsString(node) }
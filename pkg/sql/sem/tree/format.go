@@ -156,6 +156,9 @@ type FmtCtx struct {
 	// tableNameFormatter will be called on all NormalizableTableNames if it is
 	// non-nil.
 	tableNameFormatter func(*FmtCtx, *NormalizableTableName)
+	// functionNameFormatter will be called on all ResolvableFunctionReferences
+	// if it is non-nil.
+	functionNameFormatter func(*FmtCtx, *ResolvableFunctionReference)
 	// placeholderFormat is an optional interceptor for Placeholder.Format calls;
 	// it can be used to format placeholders differently than normal.
 	placeholderFormat func(ctx *FmtCtx, p *Placeholder)
@@ -173,6 +176,15 @@ func (ctx *FmtCtx) WithReformatTableNames(fn func(*FmtCtx, *NormalizableTableNam
 	return ctx
 }
 
+// WithReformatFunctionNames modifies FmtCtx to instructs the pretty-printer
+// to substitute the printing of function names using the provided function.
+func (ctx *FmtCtx) WithReformatFunctionNames(
+	fn func(*FmtCtx, *ResolvableFunctionReference),
+) *FmtCtx {
+	ctx.functionNameFormatter = fn
+	return ctx
+}
+
 // CopyWithFlags creates a new FmtCtx with different formatting flags
 // to become those specified, but the same formatting target.
 func (ctx *FmtCtx) CopyWithFlags(f FmtFlags) FmtCtx {
@@ -78,6 +78,12 @@ type FunctionProperties struct {
 	// Class is the kind of built-in function (normal/aggregate/window/etc.)
 	Class FunctionClass
 
+	// OrderedSetAggregate is set to true when an aggregate function can
+	// only be used with WITHIN GROUP (ORDER BY ...). The sorted column is
+	// passed to the overload as its first argument, followed by the direct
+	// arguments of the function.
+	OrderedSetAggregate bool
+
 	// Category is used to generate documentation strings.
 	Category string
 
@@ -39,7 +39,11 @@ type ResolvableFunctionReference struct {
 
 // Format implements the NodeFormatter interface.
 func (fn *ResolvableFunctionReference) Format(ctx *FmtCtx) {
-	ctx.FormatNode(fn.FunctionReference)
+	if ctx.functionNameFormatter != nil {
+		ctx.functionNameFormatter(ctx, fn)
+	} else {
+		ctx.FormatNode(fn.FunctionReference)
+	}
 }
 func (fn *ResolvableFunctionReference) String() string { return AsString(fn) }
 
@@ -66,6 +70,15 @@ func (fn *ResolvableFunctionReference) Resolve(
 	}
 }
 
+// MaybeUserDefinedFunction returns true if err is the error returned by
+// Resolve for a name that is not the name of a builtin function. The name
+// may then refer to a user-defined function, which is only resolved during
+// type checking.
+func MaybeUserDefinedFunction(err error) bool {
+	pgErr, ok := pgerror.GetPGCause(err)
+	return ok && pgErr.Code == pgerror.CodeUndefinedFunctionError
+}
+
 // WrapFunction creates a new ResolvableFunctionReference
 // holding a pre-resolved function. Helper for grammar rules.
 func WrapFunction(n string) ResolvableFunctionReference {
@@ -51,6 +51,11 @@ type Overload struct {
 	WindowFunc    func([]types.T, *EvalContext) WindowFunc
 	Fn            func(*EvalContext, Datums) (Datum, error)
 	Generator     GeneratorFactory
+
+	// InlineBody is set on the overloads of user-defined functions that the
+	// optimizer may inline. It is the type checked body of the function, in
+	// which the arguments are IndexedVars.
+	InlineBody TypedExpr
 }
 
 // params implements the overloadImpl interface.
@@ -97,6 +97,8 @@ func parseStringAs(t types.T, s string, ctx locationContext) (Datum, error) {
 		return NewDString(s), nil
 	case types.Time:
 		return ParseDTime(s)
+	case types.TimeTZ:
+		return ParseDTimeTZ(s, ctx.GetLocation())
 	case types.Timestamp:
 		return ParseDTimestamp(s, time.Microsecond)
 	case types.TimestampTZ:
@@ -104,6 +106,9 @@ func parseStringAs(t types.T, s string, ctx locationContext) (Datum, error) {
 	case types.UUID:
 		return ParseDUuidFromString(s)
 	default:
+		if enumTyp, ok := t.(*types.TEnum); ok {
+			return ParseDEnum(enumTyp, s)
+		}
 		return nil, nil
 	}
 }
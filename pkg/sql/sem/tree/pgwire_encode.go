@@ -45,6 +45,8 @@ func (d *DTuple) pgwireFormat(ctx *FmtCtx) {
 			pgwireFormatStringInTuple(ctx.Buffer, string(*dv))
 		case *DCollatedString:
 			pgwireFormatStringInTuple(ctx.Buffer, dv.Contents)
+		case *DEnum:
+			pgwireFormatStringInTuple(ctx.Buffer, dv.Label())
 		default:
 			ctx.FormatNode(v)
 		}
@@ -99,6 +101,8 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 			pgwireFormatStringInArray(ctx.Buffer, string(*dv))
 		case *DCollatedString:
 			pgwireFormatStringInArray(ctx.Buffer, dv.Contents)
+		case *DEnum:
+			pgwireFormatStringInArray(ctx.Buffer, dv.Label())
 		default:
 			ctx.FormatNode(v)
 		}
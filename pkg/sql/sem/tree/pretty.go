@@ -213,6 +213,13 @@ func (node TableExprs) doc(p *PrettyCfg) pretty.Doc {
 	return pretty.Join(",", d...)
 }
 
+func (node TableExprs) docRow(p *PrettyCfg, lbl string) pretty.RLTableRow {
+	if len(node) == 0 {
+		return emptyRow
+	}
+	return p.row(lbl, node.doc(p))
+}
+
 func (node *Where) doc(p *PrettyCfg) pretty.Doc {
 	return p.unrow(node.docRow(p))
 }
@@ -488,6 +495,9 @@ func (node *Select) docTable(p *PrettyCfg) []pretty.RLTableRow {
 	}
 	items = append(items, node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
+	if len(node.Locking) > 0 {
+		items = append(items, p.row("", p.Doc(&node.Locking)))
+	}
 	return items
 }
 
@@ -569,7 +579,11 @@ func (node *With) docRow(p *PrettyCfg) pretty.RLTableRow {
 			pretty.Bracket("AS (", p.Doc(cte.Stmt), ")"),
 		)
 	}
-	return p.row("WITH", pretty.Join(",", d...))
+	kw := "WITH"
+	if node.Recursive {
+		kw = "WITH RECURSIVE"
+	}
+	return p.row(kw, pretty.Join(",", d...))
 }
 
 func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
@@ -588,6 +602,12 @@ func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(node.Expr)
+	if node.Lateral {
+		d = pretty.Concat(
+			pretty.Text("LATERAL "),
+			d,
+		)
+	}
 	if node.IndexFlags != nil {
 		d = pretty.Concat(
 			d,
@@ -627,6 +647,12 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 	} else {
 		d = pretty.Concat(d, pretty.Text("()"))
 	}
+	if node.IsOrderedSetAggregate() {
+		d = pretty.Fold(pretty.ConcatSpace,
+			d,
+			pretty.Text("WITHIN GROUP"),
+			pretty.Bracket("(", p.Doc(&node.OrderBy), ")"))
+	}
 	if node.Filter != nil {
 		d = pretty.Fold(pretty.ConcatSpace,
 			d,
@@ -800,10 +826,15 @@ func (node *Insert) doc(p *PrettyCfg) pretty.Doc {
 
 	if node.OnConflict != nil && !node.OnConflict.IsUpsertAlias() {
 		cond := pretty.Nil
-		if len(node.OnConflict.Columns) > 0 {
+		if node.OnConflict.Constraint != "" {
+			cond = pretty.ConcatSpace(pretty.Text("ON CONSTRAINT"), p.Doc(&node.OnConflict.Constraint))
+		} else if len(node.OnConflict.Columns) > 0 {
 			cond = pretty.Bracket("(", p.Doc(&node.OnConflict.Columns), ")")
 		}
 		items = append(items, p.row("ON CONFLICT", cond))
+		if node.OnConflict.ArbiterPredicate != nil {
+			items = append(items, p.row("WHERE", p.Doc(node.OnConflict.ArbiterPredicate)))
+		}
 
 		if node.OnConflict.DoNothing {
 			items = append(items, p.row("DO", pretty.Text("NOTHING")))
@@ -942,11 +973,12 @@ func (p *PrettyCfg) exprDocWithParen(e Expr) pretty.Doc {
 }
 
 func (node *Update) doc(p *PrettyCfg) pretty.Doc {
-	items := make([]pretty.RLTableRow, 8)
+	items := make([]pretty.RLTableRow, 9)
 	items = append(items,
 		node.With.docRow(p),
 		p.row("UPDATE", p.Doc(node.Table)),
 		p.row("SET", p.Doc(&node.Exprs)),
+		node.From.docRow(p, "FROM"),
 		node.Where.docRow(p),
 		node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
@@ -955,10 +987,11 @@ func (node *Update) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *Delete) doc(p *PrettyCfg) pretty.Doc {
-	items := make([]pretty.RLTableRow, 6)
+	items := make([]pretty.RLTableRow, 7)
 	items = append(items,
 		node.With.docRow(p),
 		p.row("DELETE FROM", p.Doc(node.Table)),
+		node.Using.docRow(p, "USING"),
 		node.Where.docRow(p),
 		node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
@@ -1020,6 +1053,9 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	title := "CREATE TABLE "
+	if node.Temporary {
+		title = "CREATE TEMPORARY TABLE "
+	}
 	if node.IfNotExists {
 		title += "IF NOT EXISTS "
 	}
@@ -1058,8 +1094,12 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
+	title := "CREATE VIEW"
+	if node.Materialized {
+		title = "CREATE MATERIALIZED VIEW"
+	}
 	d := pretty.ConcatSpace(
-		pretty.Text("CREATE VIEW"),
+		pretty.Text(title),
 		p.Doc(&node.Name),
 	)
 	if len(node.ColumnNames) > 0 {
@@ -1275,6 +1315,9 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionBy != nil {
 		docs = append(docs, p.Doc(node.PartitionBy))
 	}
+	if node.Predicate != nil {
+		docs = append(docs, p.nestUnder(pretty.Text("WHERE"), p.Doc(node.Predicate)))
+	}
 	return pretty.Group(pretty.Stack(docs...))
 }
 
@@ -1360,16 +1403,23 @@ func (node *ColumnTableDef) doc(p *PrettyCfg) pretty.Doc {
 				),
 			)
 		}
+		if node.References.Match != MatchUnspecified {
+			d = pretty.ConcatSpace(d, pretty.Text(node.References.Match.String()))
+		}
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			d = p.nestUnder(d, ref)
 		}
 		docs = append(docs, d)
 	}
 	if node.IsComputed() {
+		closing := ") STORED"
+		if node.Computed.Virtual {
+			closing = ") VIRTUAL"
+		}
 		docs = append(docs, pretty.Bracket(
 			"AS (",
 			p.Doc(node.Computed.Expr),
-			") STORED",
+			closing,
 		))
 	}
 	if node.HasColumnFamily() {
@@ -89,13 +89,17 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 
 // ShowColumns represents a SHOW COLUMNS statement.
 type ShowColumns struct {
-	Table NormalizableTableName
+	Table       NormalizableTableName
+	WithComment bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *ShowColumns) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW COLUMNS FROM ")
 	ctx.FormatNode(&node.Table)
+	if node.WithComment {
+		ctx.WriteString(" WITH COMMENT")
+	}
 }
 
 // ShowDatabases represents a SHOW DATABASES statement.
@@ -199,6 +203,7 @@ func (node *ShowSchemas) Format(ctx *FmtCtx) {
 // ShowTables represents a SHOW TABLES statement.
 type ShowTables struct {
 	TableNamePrefix
+	WithComment bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -208,6 +213,9 @@ func (node *ShowTables) Format(ctx *FmtCtx) {
 		ctx.WriteString(" FROM ")
 		ctx.FormatNode(&node.TableNamePrefix)
 	}
+	if node.WithComment {
+		ctx.WriteString(" WITH COMMENT")
+	}
 }
 
 // ShowConstraints represents a SHOW CONSTRAINTS statement.
@@ -113,6 +113,9 @@ func CanWriteData(stmt Statement) bool {
 	// CockroachDB extensions.
 	case *Split, *Relocate, *Scatter:
 		return true
+	// Notifications are written to a system table.
+	case *Notify:
+		return true
 	}
 	return false
 }
@@ -185,6 +188,12 @@ func (*AlterSequence) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterSequence) StatementTag() string { return "ALTER SEQUENCE" }
 
+// StatementType implements the Statement interface.
+func (*AlterType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterType) StatementTag() string { return "ALTER TYPE" }
+
 // StatementType implements the Statement interface.
 func (*AlterUserSetPassword) StatementType() StatementType { return RowsAffected }
 
@@ -233,6 +242,24 @@ func (*CancelSessions) StatementTag() string { return "CANCEL SESSIONS" }
 
 func (*CancelSessions) independentFromParallelizedPriors() {}
 
+// StatementType implements the Statement interface.
+func (*CommentOnColumn) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnColumn) StatementTag() string { return "COMMENT ON COLUMN" }
+
+// StatementType implements the Statement interface.
+func (*CommentOnIndex) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnIndex) StatementTag() string { return "COMMENT ON INDEX" }
+
+// StatementType implements the Statement interface.
+func (*CommentOnTable) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnTable) StatementTag() string { return "COMMENT ON TABLE" }
+
 // StatementType implements the Statement interface.
 func (*CommitTransaction) StatementType() StatementType { return Ack }
 
@@ -302,7 +329,12 @@ func (*CreateRole) hiddenFromShowQueries() {}
 func (*CreateView) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*CreateView) StatementTag() string { return "CREATE VIEW" }
+func (n *CreateView) StatementTag() string {
+	if n.Materialized {
+		return "CREATE MATERIALIZED VIEW"
+	}
+	return "CREATE VIEW"
+}
 
 // StatementType implements the Statement interface.
 func (*CreateSequence) StatementType() StatementType { return DDL }
@@ -310,6 +342,24 @@ func (*CreateSequence) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateSequence) StatementTag() string { return "CREATE SEQUENCE" }
 
+// StatementType implements the Statement interface.
+func (*CreateFunction) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateFunction) StatementTag() string { return "CREATE FUNCTION" }
+
+// StatementType implements the Statement interface.
+func (*CreateTrigger) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateTrigger) StatementTag() string { return "CREATE TRIGGER" }
+
+// StatementType implements the Statement interface.
+func (*CreateType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateType) StatementTag() string { return "CREATE TYPE" }
+
 // StatementType implements the Statement interface.
 func (*CreateStats) StatementType() StatementType { return DDL }
 
@@ -362,7 +412,12 @@ func (*DropTable) StatementTag() string { return "DROP TABLE" }
 func (*DropView) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropView) StatementTag() string { return "DROP VIEW" }
+func (n *DropView) StatementTag() string {
+	if n.IsMaterialized {
+		return "DROP MATERIALIZED VIEW"
+	}
+	return "DROP VIEW"
+}
 
 // StatementType implements the Statement interface.
 func (*DropSequence) StatementType() StatementType { return DDL }
@@ -370,6 +425,24 @@ func (*DropSequence) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropSequence) StatementTag() string { return "DROP SEQUENCE" }
 
+// StatementType implements the Statement interface.
+func (*DropFunction) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropFunction) StatementTag() string { return "DROP FUNCTION" }
+
+// StatementType implements the Statement interface.
+func (*DropTrigger) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTrigger) StatementTag() string { return "DROP TRIGGER" }
+
+// StatementType implements the Statement interface.
+func (*DropType) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropType) StatementTag() string { return "DROP TYPE" }
+
 // StatementType implements the Statement interface.
 func (*DropUser) StatementType() StatementType { return RowsAffected }
 
@@ -426,6 +499,18 @@ func (n *Import) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*Import) StatementTag() string { return "IMPORT" }
 
+// StatementType implements the Statement interface.
+func (*Listen) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Listen) StatementTag() string { return "LISTEN" }
+
+// StatementType implements the Statement interface.
+func (*Notify) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Notify) StatementTag() string { return "NOTIFY" }
+
 // StatementType implements the Statement interface.
 func (*ParenSelect) StatementType() StatementType { return Rows }
 
@@ -438,6 +523,12 @@ func (*Prepare) StatementType() StatementType { return Ack }
 // StatementTag returns a short string identifying the type of statement.
 func (*Prepare) StatementTag() string { return "PREPARE" }
 
+// StatementType implements the Statement interface.
+func (*RefreshMaterializedView) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*RefreshMaterializedView) StatementTag() string { return "REFRESH MATERIALIZED VIEW" }
+
 // StatementType implements the Statement interface.
 func (*ReleaseSavepoint) StatementType() StatementType { return Ack }
 
@@ -777,109 +868,131 @@ func (*UnionClause) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*UnionClause) StatementTag() string { return "UNION" }
 
+// StatementType implements the Statement interface.
+func (*Unlisten) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Unlisten) StatementTag() string { return "UNLISTEN" }
+
 // StatementType implements the Statement interface.
 func (*ValuesClause) StatementType() StatementType { return Rows }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
-func (n *AlterIndex) String() string                { return AsString(n) }
-func (n *AlterTable) String() string                { return AsString(n) }
-func (n *AlterTableCmds) String() string            { return AsString(n) }
-func (n *AlterTableAddColumn) String() string       { return AsString(n) }
-func (n *AlterTableAddConstraint) String() string   { return AsString(n) }
-func (n *AlterTableAlterColumnType) String() string { return AsString(n) }
-func (n *AlterTableDropColumn) String() string      { return AsString(n) }
-func (n *AlterTableDropConstraint) String() string  { return AsString(n) }
-func (n *AlterTableDropNotNull) String() string     { return AsString(n) }
-func (n *AlterTableDropStored) String() string      { return AsString(n) }
-func (n *AlterTableSetDefault) String() string      { return AsString(n) }
-func (n *AlterUserSetPassword) String() string      { return AsString(n) }
-func (n *AlterSequence) String() string             { return AsString(n) }
-func (n *Backup) String() string                    { return AsString(n) }
-func (n *BeginTransaction) String() string          { return AsString(n) }
-func (n *ControlJobs) String() string               { return AsString(n) }
-func (n *CancelQueries) String() string             { return AsString(n) }
-func (n *CancelSessions) String() string            { return AsString(n) }
-func (n *CommitTransaction) String() string         { return AsString(n) }
-func (n *CopyFrom) String() string                  { return AsString(n) }
-func (n *CreateChangefeed) String() string          { return AsString(n) }
-func (n *CreateDatabase) String() string            { return AsString(n) }
-func (n *CreateIndex) String() string               { return AsString(n) }
-func (n *CreateRole) String() string                { return AsString(n) }
-func (n *CreateTable) String() string               { return AsString(n) }
-func (n *CreateSequence) String() string            { return AsString(n) }
-func (n *CreateStats) String() string               { return AsString(n) }
-func (n *CreateUser) String() string                { return AsString(n) }
-func (n *CreateView) String() string                { return AsString(n) }
-func (n *Deallocate) String() string                { return AsString(n) }
-func (n *Delete) String() string                    { return AsString(n) }
-func (n *DropDatabase) String() string              { return AsString(n) }
-func (n *DropIndex) String() string                 { return AsString(n) }
-func (n *DropRole) String() string                  { return AsString(n) }
-func (n *DropTable) String() string                 { return AsString(n) }
-func (n *DropView) String() string                  { return AsString(n) }
-func (n *DropSequence) String() string              { return AsString(n) }
-func (n *DropUser) String() string                  { return AsString(n) }
-func (n *Execute) String() string                   { return AsString(n) }
-func (n *Explain) String() string                   { return AsString(n) }
-func (n *Export) String() string                    { return AsString(n) }
-func (n *Grant) String() string                     { return AsString(n) }
-func (n *GrantRole) String() string                 { return AsString(n) }
-func (n *Insert) String() string                    { return AsString(n) }
-func (n *Import) String() string                    { return AsString(n) }
-func (n *ParenSelect) String() string               { return AsString(n) }
-func (n *Prepare) String() string                   { return AsString(n) }
-func (n *ReleaseSavepoint) String() string          { return AsString(n) }
-func (n *Relocate) String() string                  { return AsString(n) }
-func (n *RenameColumn) String() string              { return AsString(n) }
-func (n *RenameDatabase) String() string            { return AsString(n) }
-func (n *RenameIndex) String() string               { return AsString(n) }
-func (n *RenameTable) String() string               { return AsString(n) }
-func (n *Restore) String() string                   { return AsString(n) }
-func (n *Revoke) String() string                    { return AsString(n) }
-func (n *RevokeRole) String() string                { return AsString(n) }
-func (n *RollbackToSavepoint) String() string       { return AsString(n) }
-func (n *RollbackTransaction) String() string       { return AsString(n) }
-func (n *Savepoint) String() string                 { return AsString(n) }
-func (n *Scatter) String() string                   { return AsString(n) }
-func (n *Scrub) String() string                     { return AsString(n) }
-func (n *Select) String() string                    { return AsString(n) }
-func (n *SelectClause) String() string              { return AsString(n) }
-func (n *SetClusterSetting) String() string         { return AsString(n) }
-func (n *SetZoneConfig) String() string             { return AsString(n) }
-func (n *SetSessionCharacteristics) String() string { return AsString(n) }
-func (n *SetTransaction) String() string            { return AsString(n) }
-func (n *SetTracing) String() string                { return AsString(n) }
-func (n *SetVar) String() string                    { return AsString(n) }
-func (n *ShowBackup) String() string                { return AsString(n) }
-func (n *ShowClusterSetting) String() string        { return AsString(n) }
-func (n *ShowColumns) String() string               { return AsString(n) }
-func (n *ShowConstraints) String() string           { return AsString(n) }
-func (n *ShowCreate) String() string                { return AsString(n) }
-func (n *ShowDatabases) String() string             { return AsString(n) }
-func (n *ShowGrants) String() string                { return AsString(n) }
-func (n *ShowHistogram) String() string             { return AsString(n) }
-func (n *ShowIndex) String() string                 { return AsString(n) }
-func (n *ShowJobs) String() string                  { return AsString(n) }
-func (n *ShowQueries) String() string               { return AsString(n) }
-func (n *ShowRanges) String() string                { return AsString(n) }
-func (n *ShowRoleGrants) String() string            { return AsString(n) }
-func (n *ShowRoles) String() string                 { return AsString(n) }
-func (n *ShowSchemas) String() string               { return AsString(n) }
-func (n *ShowSessions) String() string              { return AsString(n) }
-func (n *ShowSyntax) String() string                { return AsString(n) }
-func (n *ShowTableStats) String() string            { return AsString(n) }
-func (n *ShowTables) String() string                { return AsString(n) }
-func (n *ShowTraceForSession) String() string       { return AsString(n) }
-func (n *ShowTransactionStatus) String() string     { return AsString(n) }
-func (n *ShowUsers) String() string                 { return AsString(n) }
-func (n *ShowVar) String() string                   { return AsString(n) }
-func (n *ShowZoneConfig) String() string            { return AsString(n) }
-func (n *ShowFingerprints) String() string          { return AsString(n) }
-func (n *Split) String() string                     { return AsString(n) }
-func (l *StatementList) String() string             { return AsString(l) }
-func (n *Truncate) String() string                  { return AsString(n) }
-func (n *UnionClause) String() string               { return AsString(n) }
-func (n *Update) String() string                    { return AsString(n) }
-func (n *ValuesClause) String() string              { return AsString(n) }
+func (n *AlterIndex) String() string                 { return AsString(n) }
+func (n *AlterTable) String() string                 { return AsString(n) }
+func (n *AlterTableCmds) String() string             { return AsString(n) }
+func (n *AlterTableAddColumn) String() string        { return AsString(n) }
+func (n *AlterTableAddConstraint) String() string    { return AsString(n) }
+func (n *AlterTableAlterColumnType) String() string  { return AsString(n) }
+func (n *AlterTableDropColumn) String() string       { return AsString(n) }
+func (n *AlterTableDropConstraint) String() string   { return AsString(n) }
+func (n *AlterTableDropNotNull) String() string      { return AsString(n) }
+func (n *AlterTableDropStored) String() string       { return AsString(n) }
+func (n *AlterTableRenameConstraint) String() string { return AsString(n) }
+func (n *AlterTableSetDefault) String() string       { return AsString(n) }
+func (n *AlterTableSetNotNull) String() string       { return AsString(n) }
+func (n *AlterUserSetPassword) String() string       { return AsString(n) }
+func (n *AlterSequence) String() string              { return AsString(n) }
+func (n *AlterType) String() string                  { return AsString(n) }
+func (n *Backup) String() string                     { return AsString(n) }
+func (n *BeginTransaction) String() string           { return AsString(n) }
+func (n *ControlJobs) String() string                { return AsString(n) }
+func (n *CancelQueries) String() string              { return AsString(n) }
+func (n *CancelSessions) String() string             { return AsString(n) }
+func (n *CommentOnColumn) String() string            { return AsString(n) }
+func (n *CommentOnIndex) String() string             { return AsString(n) }
+func (n *CommentOnTable) String() string             { return AsString(n) }
+func (n *CommitTransaction) String() string          { return AsString(n) }
+func (n *CopyFrom) String() string                   { return AsString(n) }
+func (n *CreateChangefeed) String() string           { return AsString(n) }
+func (n *CreateDatabase) String() string             { return AsString(n) }
+func (n *CreateIndex) String() string                { return AsString(n) }
+func (n *CreateFunction) String() string             { return AsString(n) }
+func (n *CreateRole) String() string                 { return AsString(n) }
+func (n *CreateTable) String() string                { return AsString(n) }
+func (n *CreateSequence) String() string             { return AsString(n) }
+func (n *CreateStats) String() string                { return AsString(n) }
+func (n *CreateTrigger) String() string              { return AsString(n) }
+func (n *CreateType) String() string                 { return AsString(n) }
+func (n *CreateUser) String() string                 { return AsString(n) }
+func (n *CreateView) String() string                 { return AsString(n) }
+func (n *Deallocate) String() string                 { return AsString(n) }
+func (n *Delete) String() string                     { return AsString(n) }
+func (n *DropDatabase) String() string               { return AsString(n) }
+func (n *DropIndex) String() string                  { return AsString(n) }
+func (n *DropFunction) String() string               { return AsString(n) }
+func (n *DropRole) String() string                   { return AsString(n) }
+func (n *DropTable) String() string                  { return AsString(n) }
+func (n *DropTrigger) String() string                { return AsString(n) }
+func (n *DropView) String() string                   { return AsString(n) }
+func (n *DropSequence) String() string               { return AsString(n) }
+func (n *DropType) String() string                   { return AsString(n) }
+func (n *DropUser) String() string                   { return AsString(n) }
+func (n *Execute) String() string                    { return AsString(n) }
+func (n *Explain) String() string                    { return AsString(n) }
+func (n *Export) String() string                     { return AsString(n) }
+func (n *Grant) String() string                      { return AsString(n) }
+func (n *GrantRole) String() string                  { return AsString(n) }
+func (n *Insert) String() string                     { return AsString(n) }
+func (n *Import) String() string                     { return AsString(n) }
+func (n *Listen) String() string                     { return AsString(n) }
+func (n *Notify) String() string                     { return AsString(n) }
+func (n *ParenSelect) String() string                { return AsString(n) }
+func (n *Prepare) String() string                    { return AsString(n) }
+func (n *RefreshMaterializedView) String() string    { return AsString(n) }
+func (n *ReleaseSavepoint) String() string           { return AsString(n) }
+func (n *Relocate) String() string                   { return AsString(n) }
+func (n *RenameColumn) String() string               { return AsString(n) }
+func (n *RenameDatabase) String() string             { return AsString(n) }
+func (n *RenameIndex) String() string                { return AsString(n) }
+func (n *RenameTable) String() string                { return AsString(n) }
+func (n *Restore) String() string                    { return AsString(n) }
+func (n *Revoke) String() string                     { return AsString(n) }
+func (n *RevokeRole) String() string                 { return AsString(n) }
+func (n *RollbackToSavepoint) String() string        { return AsString(n) }
+func (n *RollbackTransaction) String() string        { return AsString(n) }
+func (n *Savepoint) String() string                  { return AsString(n) }
+func (n *Scatter) String() string                    { return AsString(n) }
+func (n *Scrub) String() string                      { return AsString(n) }
+func (n *Select) String() string                     { return AsString(n) }
+func (n *SelectClause) String() string               { return AsString(n) }
+func (n *SetClusterSetting) String() string          { return AsString(n) }
+func (n *SetZoneConfig) String() string              { return AsString(n) }
+func (n *SetSessionCharacteristics) String() string  { return AsString(n) }
+func (n *SetTransaction) String() string             { return AsString(n) }
+func (n *SetTracing) String() string                 { return AsString(n) }
+func (n *SetVar) String() string                     { return AsString(n) }
+func (n *ShowBackup) String() string                 { return AsString(n) }
+func (n *ShowClusterSetting) String() string         { return AsString(n) }
+func (n *ShowColumns) String() string                { return AsString(n) }
+func (n *ShowConstraints) String() string            { return AsString(n) }
+func (n *ShowCreate) String() string                 { return AsString(n) }
+func (n *ShowDatabases) String() string              { return AsString(n) }
+func (n *ShowGrants) String() string                 { return AsString(n) }
+func (n *ShowHistogram) String() string              { return AsString(n) }
+func (n *ShowIndex) String() string                  { return AsString(n) }
+func (n *ShowJobs) String() string                   { return AsString(n) }
+func (n *ShowQueries) String() string                { return AsString(n) }
+func (n *ShowRanges) String() string                 { return AsString(n) }
+func (n *ShowRoleGrants) String() string             { return AsString(n) }
+func (n *ShowRoles) String() string                  { return AsString(n) }
+func (n *ShowSchemas) String() string                { return AsString(n) }
+func (n *ShowSessions) String() string               { return AsString(n) }
+func (n *ShowSyntax) String() string                 { return AsString(n) }
+func (n *ShowTableStats) String() string             { return AsString(n) }
+func (n *ShowTables) String() string                 { return AsString(n) }
+func (n *ShowTraceForSession) String() string        { return AsString(n) }
+func (n *ShowTransactionStatus) String() string      { return AsString(n) }
+func (n *ShowUsers) String() string                  { return AsString(n) }
+func (n *ShowVar) String() string                    { return AsString(n) }
+func (n *ShowZoneConfig) String() string             { return AsString(n) }
+func (n *ShowFingerprints) String() string           { return AsString(n) }
+func (n *Split) String() string                      { return AsString(n) }
+func (l *StatementList) String() string              { return AsString(l) }
+func (n *Truncate) String() string                   { return AsString(n) }
+func (n *UnionClause) String() string                { return AsString(n) }
+func (n *Unlisten) String() string                   { return AsString(n) }
+func (n *Update) String() string                     { return AsString(n) }
+func (n *ValuesClause) String() string               { return AsString(n) }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
@@ -63,6 +64,8 @@ func SampleDatum(t types.T) Datum {
 		return NewDDate(123123)
 	case types.Time:
 		return MakeDTime(timeofday.FromInt(789))
+	case types.TimeTZ:
+		return NewDTimeTZ(timetz.MakeTimeTZ(timeofday.FromInt(789), -3600))
 	case types.Timestamp:
 		return MakeDTimestamp(timeutil.Unix(123, 123), time.Second)
 	case types.TimestampTZ:
@@ -62,9 +62,88 @@ type SemaContext struct {
 	// globally for the entire txn and this field would not be needed.
 	AsOfTimestamp *hlc.Timestamp
 
+	// TypeResolver is used to resolve the names of user-defined types. If
+	// nil, a user-defined type can only be resolved from the desired type
+	// of the expression that refers to it.
+	TypeResolver TypeResolver
+
+	// FunctionResolver is used to resolve the names of user-defined
+	// functions. If nil, only builtin functions can be called.
+	FunctionResolver FunctionResolver
+
 	Properties SemaProperties
 }
 
+// TypeResolver resolves the names of user-defined types.
+type TypeResolver interface {
+	// ResolveType returns the user-defined type with the given name.
+	ResolveType(name string) (*types.TEnum, error)
+}
+
+// FunctionResolver resolves the names of user-defined functions.
+type FunctionResolver interface {
+	// ResolveFunction returns the user-defined function with the given
+	// name, or nil if there is none.
+	ResolveFunction(name *UnresolvedName) (*FunctionDefinition, error)
+}
+
+// NewUndefinedTypeError creates an error that represents a missing
+// user-defined type.
+func NewUndefinedTypeError(name string) error {
+	return pgerror.NewErrorf(pgerror.CodeUndefinedObjectError, "type %q does not exist", name)
+}
+
+// resolveUserDefinedType resolves t if it refers to a user-defined type.
+// The desired type is used first, which allows the default and computed
+// expressions of a column to be type checked without a TypeResolver.
+func (sc *SemaContext) resolveUserDefinedType(t coltypes.CastTargetType, desired types.T) error {
+	ud, ok := t.(*coltypes.TUserDefined)
+	if !ok {
+		return nil
+	}
+	if d, ok := desired.(*types.TEnum); ok && d.TypeName == ud.Name {
+		ud.Typ = d
+		return nil
+	}
+	if sc != nil && sc.TypeResolver != nil {
+		typ, err := sc.TypeResolver.ResolveType(ud.Name)
+		if err != nil {
+			return err
+		}
+		ud.Typ = typ
+		return nil
+	}
+	if ud.Typ == nil {
+		return NewUndefinedTypeError(ud.Name)
+	}
+	return nil
+}
+
+// resolveUserDefinedFunction resolves fn with the FunctionResolver after
+// the resolution of a builtin function failed with resolveErr. resolveErr
+// is returned if there is no user-defined function with that name either.
+func (sc *SemaContext) resolveUserDefinedFunction(
+	fn *ResolvableFunctionReference, resolveErr error,
+) (*FunctionDefinition, error) {
+	name, ok := fn.FunctionReference.(*UnresolvedName)
+	if !ok || sc == nil || sc.FunctionResolver == nil || !MaybeUserDefinedFunction(resolveErr) {
+		return nil, resolveErr
+	}
+	def, err := sc.FunctionResolver.ResolveFunction(name)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, resolveErr
+	}
+	if sc.Properties.required.rejectFlags&RejectUserDefinedFunctions != 0 {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"user-defined functions are not allowed in %s", sc.Properties.required.context)
+	}
+	fn.FunctionReference = def
+	return def, nil
+}
+
 // SemaProperties is a holder for required and derived properties
 // during semantic analysis. It provides scoping semantics via its
 // Restore() method, see below.
@@ -142,6 +221,10 @@ const (
 	// RejectSubqueries rejects subqueries in scalar contexts.
 	RejectSubqueries
 
+	// RejectUserDefinedFunctions rejects calls to user-defined functions,
+	// e.g. in expressions that are stored in descriptors.
+	RejectUserDefinedFunctions
+
 	// RejectSpecial is used in common places like the LIMIT clause.
 	RejectSpecial SemaRejectFlags = RejectAggregates | RejectGenerators | RejectWindowApplications
 )
@@ -397,6 +480,9 @@ func isCastDeepValid(castFrom, castTo types.T) bool {
 	if castTo.FamilyEqual(types.FamArray) && castFrom.FamilyEqual(types.FamArray) {
 		return isCastDeepValid(castFrom.(types.TArray).Typ, castTo.(types.TArray).Typ)
 	}
+	if castTo.FamilyEqual(types.FamEnum) && castFrom.FamilyEqual(types.FamEnum) {
+		return castFrom.Equivalent(castTo)
+	}
 	for _, t := range validCastTypes(castTo) {
 		if castFrom.FamilyEqual(t) {
 			return true
@@ -406,13 +492,16 @@ func isCastDeepValid(castFrom, castTo types.T) bool {
 }
 
 // TypeCheck implements the Expr interface.
-func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ types.T) (TypedExpr, error) {
+func (expr *CastExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, error) {
+	if err := ctx.resolveUserDefinedType(expr.Type, desired); err != nil {
+		return nil, err
+	}
 	returnType := expr.castType()
 
 	// The desired type provided to a CastExpr is ignored. Instead,
 	// types.Any is passed to the child of the cast. There are two
 	// exceptions, described below.
-	desired := types.Any
+	desired = types.Any
 	switch {
 	case isConstant(expr.Expr):
 		if canConstantBecome(expr.Expr.(Constant), returnType) {
@@ -486,6 +575,9 @@ func (expr *IndirectionExpr) TypeCheck(ctx *SemaContext, desired types.T) (Typed
 
 // TypeCheck implements the Expr interface.
 func (expr *AnnotateTypeExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, error) {
+	if err := ctx.resolveUserDefinedType(expr.Type, desired); err != nil {
+		return nil, err
+	}
 	annotType := expr.annotationType()
 	subExpr, err := typeCheckAndRequire(ctx, expr.Expr, annotType,
 		fmt.Sprintf("type annotation for %v as %s, found", expr.Expr, annotType))
@@ -641,13 +733,14 @@ func (expr *ComparisonExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedE
 }
 
 var (
-	errOrderByIndexInWindow = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in window definition is not supported")
-	errStarNotAllowed       = pgerror.NewError(pgerror.CodeSyntaxError, "cannot use \"*\" in this context")
-	errInvalidDefaultUsage  = pgerror.NewError(pgerror.CodeSyntaxError, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
-	errInvalidMaxUsage      = pgerror.NewError(pgerror.CodeSyntaxError, "MAXVALUE can only appear within a range partition expression")
-	errInvalidMinUsage      = pgerror.NewError(pgerror.CodeSyntaxError, "MINVALUE can only appear within a range partition expression")
-	errPrivateFunction      = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "function reserved for internal use")
-	errInsufficientPriv     = pgerror.NewError(pgerror.CodeInsufficientPrivilegeError, "insufficient privilege")
+	errOrderByIndexInWindow      = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in window definition is not supported")
+	errOrderByIndexInWithinGroup = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "ORDER BY INDEX in WITHIN GROUP is not supported")
+	errStarNotAllowed            = pgerror.NewError(pgerror.CodeSyntaxError, "cannot use \"*\" in this context")
+	errInvalidDefaultUsage       = pgerror.NewError(pgerror.CodeSyntaxError, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
+	errInvalidMaxUsage           = pgerror.NewError(pgerror.CodeSyntaxError, "MAXVALUE can only appear within a range partition expression")
+	errInvalidMinUsage           = pgerror.NewError(pgerror.CodeSyntaxError, "MINVALUE can only appear within a range partition expression")
+	errPrivateFunction           = pgerror.NewError(pgerror.CodeFeatureNotSupportedError, "function reserved for internal use")
+	errInsufficientPriv          = pgerror.NewError(pgerror.CodeInsufficientPrivilegeError, "insufficient privilege")
 )
 
 // NewAggInAggError creates an error for the case when an aggregate function is
@@ -747,7 +840,9 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 	}
 	def, err := expr.Func.Resolve(searchPath)
 	if err != nil {
-		return nil, err
+		if def, err = ctx.resolveUserDefinedFunction(&expr.Func, err); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := ctx.checkFunctionUsage(expr, def); err != nil {
@@ -766,7 +861,25 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 		ctx.Properties.Derived.inFuncExpr = true
 	}
 
-	typedSubExprs, fns, err := typeCheckOverloadedExprs(ctx, desired, def.Definition, false, expr.Exprs...)
+	if err := expr.checkOrderedSetAggregate(def); err != nil {
+		return nil, err
+	}
+	if expr.IsOrderedSetAggregate() {
+		// The direct arguments of ordered-set aggregates are fractions. An
+		// array literal of numeric constants would otherwise be typed as
+		// DECIMAL[], which no overload accepts, so type it as FLOAT[] up front.
+		for i, arg := range expr.Exprs {
+			if arr, ok := arg.(*Array); ok {
+				typedArr, err := arr.TypeCheck(ctx, types.TArray{Typ: types.Float})
+				if err != nil {
+					return nil, err
+				}
+				expr.Exprs[i] = typedArr
+			}
+		}
+	}
+
+	typedSubExprs, fns, err := typeCheckOverloadedExprs(ctx, desired, def.Definition, false, expr.AggregateArgs()...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s()", def.Name)
 	}
@@ -861,8 +974,16 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 		expr.Filter = typedFilter
 	}
 
-	for i, subExpr := range typedSubExprs {
-		expr.Exprs[i] = subExpr
+	if expr.IsOrderedSetAggregate() {
+		// The WITHIN GROUP column was type checked as the first argument.
+		expr.OrderBy[0].Expr = typedSubExprs[0]
+		for i, subExpr := range typedSubExprs[1:] {
+			expr.Exprs[i] = subExpr
+		}
+	} else {
+		for i, subExpr := range typedSubExprs {
+			expr.Exprs[i] = subExpr
+		}
 	}
 	expr.fn = overloadImpl
 	expr.fnProps = &def.FunctionProperties
@@ -882,6 +1003,35 @@ func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, e
 	return expr, nil
 }
 
+// checkOrderedSetAggregate verifies that WITHIN GROUP is used if and only if
+// the function is an ordered-set aggregate, and that it is used in a way we
+// support.
+func (expr *FuncExpr) checkOrderedSetAggregate(def *FunctionDefinition) error {
+	if !expr.IsOrderedSetAggregate() {
+		if def.OrderedSetAggregate {
+			return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+				"WITHIN GROUP is required for ordered-set aggregate %s()", &expr.Func)
+		}
+		return nil
+	}
+	if !def.OrderedSetAggregate {
+		return pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"%s() is not an ordered-set aggregate, so it cannot have WITHIN GROUP", &expr.Func)
+	}
+	if expr.IsWindowFunctionApplication() {
+		return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"OVER is not supported for ordered-set aggregate %s()", &expr.Func)
+	}
+	if len(expr.OrderBy) != 1 {
+		return pgerror.NewErrorf(pgerror.CodeSyntaxError,
+			"ordered-set aggregate %s() requires exactly one WITHIN GROUP ORDER BY column", &expr.Func)
+	}
+	if expr.OrderBy[0].OrderType != OrderByColumn {
+		return errOrderByIndexInWithinGroup
+	}
+	return nil
+}
+
 // TypeCheck checks that offsets of the window frame (if present) are of the
 // appropriate type.
 func (f *WindowFrame) TypeCheck(ctx *SemaContext, windowDef *WindowDef) error {
@@ -991,6 +1141,11 @@ func (expr *IfExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, err
 
 // TypeCheck implements the Expr interface.
 func (expr *IsOfTypeExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, error) {
+	for _, t := range expr.Types {
+		if err := ctx.resolveUserDefinedType(t, nil /* desired */); err != nil {
+			return nil, err
+		}
+	}
 	exprTyped, err := expr.Expr.TypeCheck(ctx, types.Any)
 	if err != nil {
 		return nil, err
@@ -1155,6 +1310,12 @@ func (expr DefaultVal) TypeCheck(_ *SemaContext, desired types.T) (TypedExpr, er
 	return nil, errInvalidDefaultUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(_ *SemaContext, desired types.T) (TypedExpr, error) {
+	return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
+		"%s can only appear in a GROUP BY clause", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr MinVal) TypeCheck(_ *SemaContext, desired types.T) (TypedExpr, error) {
 	return nil, errInvalidMinUsage
@@ -1331,6 +1492,10 @@ func (d *DString) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { retu
 // identity function for Datum.
 func (d *DCollatedString) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DEnum) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DBytes) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
@@ -1351,6 +1516,10 @@ func (d *DDate) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return
 // identity function for Datum.
 func (d *DTime) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DTimeTZ) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTimestamp) TypeCheck(_ *SemaContext, _ types.T) (TypedExpr, error) { return d, nil }
@@ -1669,9 +1838,10 @@ func typeCheckComparisonOp(
 	// Throw a typing error if overload resolution found either no compatible candidates
 	// or if it found an ambiguity.
 	collationMismatch := leftReturn.FamilyEqual(types.FamCollatedString) && !leftReturn.Equivalent(rightReturn)
-	if len(fns) != 1 || collationMismatch {
+	enumMismatch := leftReturn.FamilyEqual(types.FamEnum) && !leftReturn.Equivalent(rightReturn)
+	if len(fns) != 1 || collationMismatch || enumMismatch {
 		sig := fmt.Sprintf(compSignatureFmt, leftReturn, op, rightReturn)
-		if len(fns) == 0 || collationMismatch {
+		if len(fns) == 0 || collationMismatch || enumMismatch {
 			return nil, nil, nil, false,
 				pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError, unsupportedCompErrFmt, sig)
 		}
@@ -16,7 +16,8 @@ package tree
 
 // With represents a WITH statement.
 type With struct {
-	CTEList []*CTE
+	Recursive bool
+	CTEList   []*CTE
 }
 
 // CTE represents a common table expression inside of a WITH clause.
@@ -25,12 +26,40 @@ type CTE struct {
 	Stmt Statement
 }
 
+// RecursiveUnion returns the UNION clause of a CTE defined in a WITH
+// RECURSIVE clause, or nil if the CTE does not have the form
+// <non-recursive term> UNION [ALL] <recursive term>.
+func (cte *CTE) RecursiveUnion() *UnionClause {
+	sel, ok := cte.Stmt.(*Select)
+	if !ok {
+		return nil
+	}
+	for {
+		if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil {
+			return nil
+		}
+		paren, ok := sel.Select.(*ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	union, ok := sel.Select.(*UnionClause)
+	if !ok || union.Type != UnionOp {
+		return nil
+	}
+	return union
+}
+
 // Format implements the NodeFormatter interface.
 func (node *With) Format(ctx *FmtCtx) {
 	if node == nil {
 		return
 	}
 	ctx.WriteString("WITH ")
+	if node.Recursive {
+		ctx.WriteString("RECURSIVE ")
+	}
 	for i, cte := range node.CTEList {
 		if i != 0 {
 			ctx.WriteString(", ")
@@ -67,6 +67,8 @@ var (
 	Date T = tDate{}
 	// Time is the type of a DTime. Can be compared with ==.
 	Time T = tTime{}
+	// TimeTZ is the type of a DTimeTZ. Can be compared with ==.
+	TimeTZ T = tTimeTZ{}
 	// Timestamp is the type of a DTimestamp. Can be compared with ==.
 	Timestamp T = tTimestamp{}
 	// TimestampTZ is the type of a DTimestampTZ. Can be compared with ==.
@@ -96,6 +98,7 @@ var (
 		Bytes,
 		Date,
 		Time,
+		TimeTZ,
 		Timestamp,
 		TimestampTZ,
 		Interval,
@@ -108,6 +111,8 @@ var (
 	// FamCollatedString is the type family of a DString. CANNOT be
 	// compared with ==.
 	FamCollatedString T = TCollatedString{}
+	// FamEnum is the type family of a DEnum. CANNOT be compared with ==.
+	FamEnum T = &TEnum{}
 	// FamTuple is the type family of a DTuple. CANNOT be compared with ==.
 	FamTuple T = TTuple{}
 	// FamArray is the type family of a DArray. CANNOT be compared with ==.
@@ -224,6 +229,77 @@ func (t TCollatedString) IsAmbiguous() bool {
 	return t.Locale == ""
 }
 
+// UserDefinedTypeOidOffset is added to the descriptor ID of a user-defined
+// type to form its Oid, so that it does not collide with built-in types.
+const UserDefinedTypeOidOffset = 100000
+
+// TEnum is the type of an enum created with CREATE TYPE ... AS ENUM. A
+// TEnum with a zero TypeID is the wildcard enum type.
+type TEnum struct {
+	// TypeID is the ID of the descriptor of the type.
+	TypeID uint32
+	// TypeName is the name of the type.
+	TypeName string
+	// Labels are the labels of the enum, in sort order.
+	Labels []string
+	// ReadOnly indicates, for each label, whether values of that label
+	// cannot be written yet because the label is still being added.
+	ReadOnly []bool
+}
+
+// String implements the fmt.Stringer interface.
+func (t *TEnum) String() string {
+	if t.TypeID == 0 {
+		return "anyenum"
+	}
+	return t.TypeName
+}
+
+// Equivalent implements the T interface.
+func (t *TEnum) Equivalent(other T) bool {
+	if other == Any {
+		return true
+	}
+	u, ok := UnwrapType(other).(*TEnum)
+	if ok {
+		return t.TypeID == 0 || u.TypeID == 0 || t.TypeID == u.TypeID
+	}
+	return false
+}
+
+// FamilyEqual implements the T interface.
+func (*TEnum) FamilyEqual(other T) bool {
+	_, ok := UnwrapType(other).(*TEnum)
+	return ok
+}
+
+// Oid implements the T interface.
+func (t *TEnum) Oid() oid.Oid {
+	if t.TypeID == 0 {
+		return oid.T_anyenum
+	}
+	return oid.Oid(t.TypeID + UserDefinedTypeOidOffset)
+}
+
+// SQLName implements the T interface.
+func (t *TEnum) SQLName() string { return t.String() }
+
+// IsAmbiguous implements the T interface.
+func (t *TEnum) IsAmbiguous() bool {
+	return t.TypeID == 0
+}
+
+// LabelOrdinal returns the position of label in the enum, or -1 if the
+// label is not part of the enum.
+func (t *TEnum) LabelOrdinal(label string) int {
+	for i := range t.Labels {
+		if t.Labels[i] == label {
+			return i
+		}
+	}
+	return -1
+}
+
 type tBytes struct{}
 
 func (tBytes) String() string           { return "bytes" }
@@ -251,6 +327,15 @@ func (tTime) Oid() oid.Oid             { return oid.T_time }
 func (tTime) SQLName() string          { return "time" }
 func (tTime) IsAmbiguous() bool        { return false }
 
+type tTimeTZ struct{}
+
+func (tTimeTZ) String() string           { return "timetz" }
+func (tTimeTZ) Equivalent(other T) bool  { return UnwrapType(other) == TimeTZ || other == Any }
+func (tTimeTZ) FamilyEqual(other T) bool { return UnwrapType(other) == TimeTZ }
+func (tTimeTZ) Oid() oid.Oid             { return oid.T_timetz }
+func (tTimeTZ) SQLName() string          { return "time with time zone" }
+func (tTimeTZ) IsAmbiguous() bool        { return false }
+
 type tTimestamp struct{}
 
 func (tTimestamp) String() string { return "timestamp" }
@@ -505,7 +590,8 @@ func IsValidArrayElementType(t T) bool {
 	case JSON:
 		return false
 	default:
-		return true
+		_, isEnum := t.(*TEnum)
+		return !isEnum
 	}
 }
 
@@ -517,6 +603,8 @@ func IsDateTimeType(t T) bool {
 		return true
 	case Time:
 		return true
+	case TimeTZ:
+		return true
 	case Timestamp:
 		return true
 	case TimestampTZ:
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
@@ -49,6 +50,15 @@ func (p *planner) IncrementSequence(ctx context.Context, seqName *tree.TableName
 	if seqOpts.Virtual {
 		rowid := builtins.GenerateUniqueInt(p.EvalContext().NodeID)
 		val = int64(rowid)
+	} else if seqOpts.CacheSize > 1 {
+		val, err = p.SessionData().SequenceState.NextCachedValue(
+			uint32(descriptor.ID), uint32(descriptor.Version),
+			func() (int64, int64, int64, error) {
+				return reserveSequenceValues(ctx, p.txn.DB(), descriptor)
+			})
+		if err != nil {
+			return 0, err
+		}
 	} else {
 		seqValueKey := keys.MakeSequenceKey(uint32(descriptor.ID))
 		val, err = client.IncrementValRetryable(
@@ -71,6 +81,62 @@ func (p *planner) IncrementSequence(ctx context.Context, seqName *tree.TableName
 	return val, nil
 }
 
+// reserveSequenceValues increments the value of a sequence with a CACHE
+// size larger than 1 by a whole block of values at once, so that a session
+// only touches the sequence's key once every CacheSize calls to nextval().
+// It returns the first value of the block, the increment between
+// successive values and the number of values in the block, which is less
+// than CacheSize if the block straddles the bounds of the sequence.
+func reserveSequenceValues(
+	ctx context.Context, db *client.DB, descriptor *sqlbase.TableDescriptor,
+) (first, increment, num int64, err error) {
+	seqOpts := descriptor.SequenceOpts
+	increment = seqOpts.Increment
+	num = seqOpts.CacheSize
+	// Shrink the block if reserving it in one go would overflow.
+	if increment == math.MinInt64 {
+		num = 1
+	} else if limit := math.MaxInt64 / absInt64(increment); num > limit {
+		num = limit
+	}
+
+	seqValueKey := keys.MakeSequenceKey(uint32(descriptor.ID))
+	end, err := client.IncrementValRetryable(ctx, db, seqValueKey, increment*num)
+	if err != nil {
+		switch err.(type) {
+		case *roachpb.IntegerOverflowError:
+			return 0, 0, 0, boundsExceededError(descriptor)
+		default:
+			return 0, 0, 0, err
+		}
+	}
+	first = end - increment*(num-1)
+	if first > seqOpts.MaxValue || first < seqOpts.MinValue {
+		return 0, 0, 0, boundsExceededError(descriptor)
+	}
+
+	// Only hand out the values of the block that are within bounds. The
+	// distance to the bound is computed on unsigned integers as it may not fit
+	// in an int64.
+	var distance uint64
+	if increment > 0 {
+		distance = uint64(seqOpts.MaxValue - first)
+	} else {
+		distance = uint64(first - seqOpts.MinValue)
+	}
+	if inBounds := distance/uint64(absInt64(increment)) + 1; inBounds < uint64(num) {
+		num = int64(inBounds)
+	}
+	return first, increment, num, nil
+}
+
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func boundsExceededError(descriptor *sqlbase.TableDescriptor) error {
 	seqOpts := descriptor.SequenceOpts
 	isAscending := seqOpts.Increment > 0
@@ -140,6 +206,9 @@ func (p *planner) SetSequenceValue(
 		return err
 	}
 
+	// Values cached by this session predate the new value.
+	p.SessionData().SequenceState.InvalidateCachedValues(uint32(descriptor.ID))
+
 	// TODO(vilterp): not supposed to mix usage of Inc and Put on a key,
 	// according to comments on Inc operation. Switch to Inc if `desired-current`
 	// overflows correctly.
@@ -192,10 +261,19 @@ func assignSequenceOptions(
 	opts *sqlbase.TableDescriptor_SequenceOpts, optsNode tree.SequenceOptions, setDefaults bool,
 ) error {
 	// All other defaults are dependent on the value of increment,
-	// i.e. whether the sequence is ascending or descending.
+	// i.e. whether the sequence is ascending or descending, and on the
+	// integer type of the sequence.
+	oldTypeMin, oldTypeMax := sequenceTypeBounds(opts.AsIntegerType)
 	for _, option := range optsNode {
-		if option.Name == tree.SeqOptIncrement {
+		switch option.Name {
+		case tree.SeqOptIncrement:
 			opts.Increment = *option.IntVal
+		case tree.SeqOptAs:
+			typeName, err := sequenceIntegerTypeName(option.AsType)
+			if err != nil {
+				return err
+			}
+			opts.AsIntegerType = typeName
 		}
 	}
 	if opts.Increment == 0 {
@@ -203,18 +281,27 @@ func assignSequenceOptions(
 			pgerror.CodeInvalidParameterValueError, "INCREMENT must not be zero")
 	}
 	isAscending := opts.Increment > 0
+	typeMin, typeMax := sequenceTypeBounds(opts.AsIntegerType)
 
 	// Set increment-dependent defaults.
 	if setDefaults {
 		if isAscending {
 			opts.MinValue = 1
-			opts.MaxValue = math.MaxInt64
+			opts.MaxValue = typeMax
 			opts.Start = opts.MinValue
 		} else {
-			opts.MinValue = math.MinInt64
+			opts.MinValue = typeMin
 			opts.MaxValue = -1
 			opts.Start = opts.MaxValue
 		}
+	} else {
+		// Bounds that were implied by the previous type follow the new type.
+		if opts.MinValue == oldTypeMin {
+			opts.MinValue = typeMin
+		}
+		if opts.MaxValue == oldTypeMax {
+			opts.MaxValue = typeMax
+		}
 	}
 
 	// Fill in all other options.
@@ -239,23 +326,31 @@ func assignSequenceOptions(
 			case v < 1:
 				return pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
 					"CACHE (%d) must be greater than zero", v)
-			case v == 1:
-				// Do nothing; this is the default.
-			case v > 1:
-				return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
-					"CACHE values larger than 1 are not supported, found %d", v)
+			default:
+				opts.CacheSize = v
 			}
-		case tree.SeqOptIncrement:
+		case tree.SeqOptIncrement, tree.SeqOptAs:
 			// Do nothing; this has already been set.
+		case tree.SeqOptOwnedBy:
+			// Do nothing; this is handled by processSequenceOwnedBy, as it
+			// requires resolving the owning table.
 		case tree.SeqOptMinValue:
 			// A value of nil represents the user explicitly saying `NO MINVALUE`.
 			if option.IntVal != nil {
 				opts.MinValue = *option.IntVal
+			} else if isAscending {
+				opts.MinValue = 1
+			} else {
+				opts.MinValue = typeMin
 			}
 		case tree.SeqOptMaxValue:
 			// A value of nil represents the user explicitly saying `NO MAXVALUE`.
 			if option.IntVal != nil {
 				opts.MaxValue = *option.IntVal
+			} else if isAscending {
+				opts.MaxValue = typeMax
+			} else {
+				opts.MaxValue = -1
 			}
 		case tree.SeqOptStart:
 			opts.Start = *option.IntVal
@@ -274,6 +369,19 @@ func assignSequenceOptions(
 		}
 	}
 
+	if opts.MinValue < typeMin || opts.MinValue > typeMax {
+		return pgerror.NewErrorf(
+			pgerror.CodeInvalidParameterValueError,
+			"MINVALUE (%d) is out of range for sequence data type %s",
+			opts.MinValue, sequenceTypeDisplayName(opts.AsIntegerType))
+	}
+	if opts.MaxValue < typeMin || opts.MaxValue > typeMax {
+		return pgerror.NewErrorf(
+			pgerror.CodeInvalidParameterValueError,
+			"MAXVALUE (%d) is out of range for sequence data type %s",
+			opts.MaxValue, sequenceTypeDisplayName(opts.AsIntegerType))
+	}
+
 	if opts.Start > opts.MaxValue {
 		return pgerror.NewErrorf(
 			pgerror.CodeInvalidParameterValueError,
@@ -288,6 +396,42 @@ func assignSequenceOptions(
 	return nil
 }
 
+// sequenceIntegerTypeName returns the canonical name of the integer type
+// given in the AS option of a sequence, or an error if the type cannot be
+// used for a sequence.
+func sequenceIntegerTypeName(typ coltypes.T) (string, error) {
+	if t, ok := typ.(*coltypes.TInt); ok {
+		switch t.Width {
+		case 16, 32, 64:
+			return t.TypeName(), nil
+		case 0:
+			return coltypes.IntegerTypeNames[64], nil
+		}
+	}
+	return "", pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+		"sequence type must be smallint, integer, or bigint, found %s", typ)
+}
+
+// sequenceTypeBounds returns the range of values of the integer type of a
+// sequence. Sequences without an AS option are of type INT8.
+func sequenceTypeBounds(typeName string) (int64, int64) {
+	switch typeName {
+	case coltypes.IntegerTypeNames[16]:
+		return math.MinInt16, math.MaxInt16
+	case coltypes.IntegerTypeNames[32]:
+		return math.MinInt32, math.MaxInt32
+	default:
+		return math.MinInt64, math.MaxInt64
+	}
+}
+
+func sequenceTypeDisplayName(typeName string) string {
+	if typeName == "" {
+		return coltypes.IntegerTypeNames[64]
+	}
+	return typeName
+}
+
 // maybeAddSequenceDependencies adds references between the column and sequence descriptors,
 // if the column has a DEFAULT expression that uses one or more sequences. (Usually just one,
 // e.g. `DEFAULT nextval('my_sequence')`.
@@ -350,6 +494,11 @@ func removeSequenceDependencies(
 		if err := getDescriptorByID(params.ctx, params.p.txn, sequenceID, &seqDesc); err != nil {
 			return err
 		}
+		if seqDesc.Dropped() {
+			// The sequence is being dropped, e.g. by a DROP DATABASE that
+			// also drops this table. No need to modify it further.
+			continue
+		}
 		// Find an item in seqDesc.DependedOnBy which references tableDesc.
 		refIdx := -1
 		for i, reference := range seqDesc.DependedOnBy {
@@ -371,6 +520,145 @@ func removeSequenceDependencies(
 	return nil
 }
 
+// processSequenceOwnedBy applies the OWNED BY option, if any, to the given
+// sequence descriptor: the column previously owning the sequence loses its
+// reference to it and the new owning column gains one. The sequence
+// descriptor is mutated but not saved to persistent storage; the caller must
+// save it.
+func (p *planner) processSequenceOwnedBy(
+	ctx context.Context, seqDesc *sqlbase.TableDescriptor, optsNode tree.SequenceOptions,
+) error {
+	var ownedBy *tree.SequenceOption
+	for i := range optsNode {
+		if optsNode[i].Name == tree.SeqOptOwnedBy {
+			ownedBy = &optsNode[i]
+		}
+	}
+	if ownedBy == nil {
+		return nil
+	}
+
+	if err := p.removeSequenceOwnership(ctx, seqDesc); err != nil {
+		return err
+	}
+	if ownedBy.ColumnItemVal == nil {
+		// OWNED BY NONE.
+		return nil
+	}
+
+	table := tree.NormalizableTableName{TableNameReference: &ownedBy.ColumnItemVal.TableName}
+	tn, err := table.Normalize()
+	if err != nil {
+		return err
+	}
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, requireTableDesc)
+	if err != nil {
+		return err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return err
+	}
+	if tableDesc.GetNamespaceParentID() != seqDesc.GetNamespaceParentID() {
+		return pgerror.NewError(pgerror.CodeObjectNotInPrerequisiteStateError,
+			"sequence must be in same schema as table it is linked to")
+	}
+	col, err := tableDesc.FindActiveColumnByName(string(ownedBy.ColumnItemVal.ColumnName))
+	if err != nil {
+		return err
+	}
+	// Get a pointer to the column descriptor that is actually in tableDesc.
+	colDesc, err := tableDesc.FindColumnByID(col.ID)
+	if err != nil {
+		return err
+	}
+
+	colDesc.OwnsSequenceIds = append(colDesc.OwnsSequenceIds, seqDesc.ID)
+	seqDesc.SequenceOpts.OwnerTableID = tableDesc.ID
+	seqDesc.SequenceOpts.OwnerColumnID = colDesc.ID
+	return p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID)
+}
+
+// removeSequenceOwnership removes the reference from the column owning the
+// given sequence, if any, to the sequence, and clears the owner of the
+// sequence. The sequence descriptor is mutated but not saved to persistent
+// storage; the caller must save it.
+func (p *planner) removeSequenceOwnership(
+	ctx context.Context, seqDesc *sqlbase.TableDescriptor,
+) error {
+	opts := seqDesc.SequenceOpts
+	if opts.OwnerTableID == sqlbase.InvalidID {
+		return nil
+	}
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, opts.OwnerTableID)
+	if err != nil {
+		return err
+	}
+	ownerColumnID := opts.OwnerColumnID
+	opts.OwnerTableID = sqlbase.InvalidID
+	opts.OwnerColumnID = 0
+	if tableDesc.Dropped() {
+		// The owning table is being dropped. No need to modify it further.
+		return nil
+	}
+	col, err := tableDesc.FindColumnByID(ownerColumnID)
+	if err != nil {
+		// The owning column is being dropped. No need to modify it further.
+		return nil
+	}
+	for i, id := range col.OwnsSequenceIds {
+		if id == seqDesc.ID {
+			col.OwnsSequenceIds = append(col.OwnsSequenceIds[:i], col.OwnsSequenceIds[i+1:]...)
+			break
+		}
+	}
+	return p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID)
+}
+
+// canDropOwnedSequences returns an error if one of the sequences owned by the
+// given column is still used by an object for which isDropped returns false.
+// The owned sequences are dropped along with the column or its table, but
+// dropping the DEFAULT expressions of other columns is not supported.
+func (p *planner) canDropOwnedSequences(
+	ctx context.Context,
+	col *sqlbase.ColumnDescriptor,
+	typeName, objName string,
+	isDropped func(ref sqlbase.TableDescriptor_Reference) bool,
+) error {
+	for _, sequenceID := range col.OwnsSequenceIds {
+		seqDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, sequenceID)
+		if err != nil {
+			return err
+		}
+		for _, ref := range seqDesc.DependedOnBy {
+			if !isDropped(ref) {
+				return pgerror.NewErrorf(pgerror.CodeDependentObjectsStillExistError,
+					"cannot drop %s %q because other objects depend on sequence %q owned by it",
+					typeName, objName, seqDesc.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// dropOwnedSequences drops the sequences owned by the given column. The
+// caller must have checked canDropOwnedSequences.
+func (p *planner) dropOwnedSequences(ctx context.Context, col *sqlbase.ColumnDescriptor) error {
+	for _, sequenceID := range col.OwnsSequenceIds {
+		seqDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, sequenceID)
+		if err != nil {
+			return err
+		}
+		if seqDesc.Dropped() {
+			// This sequence is already getting dropped. Don't do it twice.
+			continue
+		}
+		if err := p.dropSequenceImpl(ctx, seqDesc, tree.DropCascade); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getUsedSequenceNames returns the name of the sequence passed to
 // a call to nextval in the given expression, or nil if there is
 // no call to nextval.
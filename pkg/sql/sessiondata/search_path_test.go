@@ -58,3 +58,44 @@ func TestImpliedSearchPath(t *testing.T) {
 		})
 	}
 }
+
+func TestImpliedSearchPathWithTemporarySchema(t *testing.T) {
+	const tempSchemaName = `pg_temp_1_1`
+	testCases := []struct {
+		explicitSearchPath                         []string
+		expectedSearchPath                         []string
+		expectedSearchPathWithoutImplicitPgCatalog []string
+	}{
+		{[]string{}, []string{tempSchemaName, `pg_catalog`}, []string{}},
+		{[]string{`pg_catalog`}, []string{tempSchemaName, `pg_catalog`}, []string{`pg_catalog`}},
+		{[]string{`foobar`}, []string{tempSchemaName, `pg_catalog`, `foobar`}, []string{`foobar`}},
+		{[]string{`foobar`, `pg_temp`}, []string{`pg_catalog`, `foobar`, tempSchemaName}, []string{`foobar`, tempSchemaName}},
+		{[]string{`pg_temp`, `pg_catalog`}, []string{tempSchemaName, `pg_catalog`}, []string{tempSchemaName, `pg_catalog`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.explicitSearchPath, ","), func(t *testing.T) {
+			searchPath := MakeSearchPath(tc.explicitSearchPath).WithTemporarySchemaName(tempSchemaName)
+			actualSearchPath := make([]string, 0)
+			iter := searchPath.Iter()
+			for p, ok := iter.Next(); ok; p, ok = iter.Next() {
+				actualSearchPath = append(actualSearchPath, p)
+			}
+			if !reflect.DeepEqual(tc.expectedSearchPath, actualSearchPath) {
+				t.Errorf(`Expected search path to be %#v, but was %#v.`, tc.expectedSearchPath, actualSearchPath)
+			}
+		})
+
+		t.Run(strings.Join(tc.explicitSearchPath, ",")+"/no-pg-catalog", func(t *testing.T) {
+			searchPath := MakeSearchPath(tc.explicitSearchPath).WithTemporarySchemaName(tempSchemaName)
+			actualSearchPath := make([]string, 0)
+			iter := searchPath.IterWithoutImplicitPGCatalog()
+			for p, ok := iter.Next(); ok; p, ok = iter.Next() {
+				actualSearchPath = append(actualSearchPath, p)
+			}
+			if !reflect.DeepEqual(tc.expectedSearchPathWithoutImplicitPgCatalog, actualSearchPath) {
+				t.Errorf(`Expected search path to be %#v, but was %#v.`, tc.expectedSearchPathWithoutImplicitPgCatalog, actualSearchPath)
+			}
+		})
+	}
+}
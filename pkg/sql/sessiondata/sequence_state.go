@@ -33,13 +33,32 @@ type SequenceState struct {
 		// lastSequenceIncremented records the descriptor id of the last sequence
 		// nextval() was called on in this session.
 		lastSequenceIncremented uint32
+
+		// cachedValues stores, by descriptor id, the blocks of values reserved
+		// by this session for sequences with a CACHE size larger than 1.
+		cachedValues map[uint32]*sequenceCacheEntry
 	}
 }
 
+// sequenceCacheEntry is a block of values reserved for a session by a single
+// increment of a sequence.
+type sequenceCacheEntry struct {
+	// version is the version of the sequence descriptor the block was reserved
+	// under. The block is discarded when the sequence is altered.
+	version uint32
+	// nextValue is the next value to hand out; successive values differ by
+	// increment.
+	nextValue int64
+	increment int64
+	// remaining is the number of values left in the block.
+	remaining int64
+}
+
 // NewSequenceState creates a SequenceState.
 func NewSequenceState() *SequenceState {
 	ss := SequenceState{}
 	ss.mu.latestValues = make(map[uint32]int64)
+	ss.mu.cachedValues = make(map[uint32]*sequenceCacheEntry)
 	return &ss
 }
 
@@ -115,3 +134,43 @@ func (ss *SequenceState) Export() (map[uint32]int64, uint32) {
 	}
 	return res, ss.mu.lastSequenceIncremented
 }
+
+// NextCachedValue returns the next value of the block of values cached for the
+// given sequence. If the block is exhausted or was reserved under a different
+// descriptor version, fetch is called to reserve a new block of num values
+// starting at first. The lock is held while fetching so that statements
+// running in parallel on the session do not reserve more than one block.
+func (ss *SequenceState) NextCachedValue(
+	seqID uint32, version uint32, fetch func() (first, increment, num int64, err error),
+) (int64, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	entry, ok := ss.mu.cachedValues[seqID]
+	if !ok || entry.version != version || entry.remaining <= 0 {
+		first, increment, num, err := fetch()
+		if err != nil {
+			return 0, err
+		}
+		entry = &sequenceCacheEntry{
+			version:   version,
+			nextValue: first,
+			increment: increment,
+			remaining: num,
+		}
+		ss.mu.cachedValues[seqID] = entry
+	}
+
+	val := entry.nextValue
+	entry.nextValue += entry.increment
+	entry.remaining--
+	return val, nil
+}
+
+// InvalidateCachedValues discards the values cached for the given sequence,
+// so that the next call to NextCachedValue reserves a new block.
+func (ss *SequenceState) InvalidateCachedValues(seqID uint32) {
+	ss.mu.Lock()
+	delete(ss.mu.cachedValues, seqID)
+	ss.mu.Unlock()
+}
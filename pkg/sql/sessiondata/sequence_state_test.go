@@ -0,0 +1,81 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sessiondata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSequenceCachedValues(t *testing.T) {
+	ss := NewSequenceState()
+
+	// fetch reserves blocks of 3 values with an increment of 2, mimicking a
+	// sequence whose key is incremented by 6 at a time.
+	var fetches int
+	var end int64
+	fetch := func() (int64, int64, int64, error) {
+		fetches++
+		end += 6
+		return end - 4, 2, 3, nil
+	}
+
+	var got []int64
+	for i := 0; i < 7; i++ {
+		v, err := ss.NextCachedValue(1, 1, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	expected := []int64{2, 4, 6, 8, 10, 12, 14}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+	if fetches != 3 {
+		t.Fatalf("expected 3 fetches, got %d", fetches)
+	}
+
+	// A new descriptor version discards the remaining values.
+	if v, err := ss.NextCachedValue(1, 2, fetch); err != nil {
+		t.Fatal(err)
+	} else if v != 20 {
+		t.Fatalf("expected 20, got %d", v)
+	}
+
+	// So does an explicit invalidation.
+	ss.InvalidateCachedValues(1)
+	if v, err := ss.NextCachedValue(1, 2, fetch); err != nil {
+		t.Fatal(err)
+	} else if v != 26 {
+		t.Fatalf("expected 26, got %d", v)
+	}
+
+	// Errors are passed through and nothing is cached.
+	ss.InvalidateCachedValues(1)
+	fetchErr := errors.New("boom")
+	if _, err := ss.NextCachedValue(1, 2, func() (int64, int64, int64, error) {
+		return 0, 0, 0, fetchErr
+	}); err != fetchErr {
+		t.Fatalf("expected %v, got %v", fetchErr, err)
+	}
+	if v, err := ss.NextCachedValue(1, 2, fetch); err != nil {
+		t.Fatal(err)
+	} else if v != 32 {
+		t.Fatalf("expected 32, got %d", v)
+	}
+}
@@ -25,7 +25,7 @@ import (
 //   Notes: postgres does not have a SHOW COLUMNS statement.
 //          mysql only returns columns you have privileges on.
 func (p *planner) ShowColumns(ctx context.Context, n *tree.ShowColumns) (planNode, error) {
-	const getColumnsQuery = `
+	const selectColumns = `
 SELECT
   column_name AS column_name,
   crdb_sql_type AS data_type,
@@ -33,7 +33,8 @@ SELECT
   column_default,
   generation_expression,
   IF(inames[1] IS NULL, ARRAY[]:::STRING[], inames) AS indices,
-  is_hidden::BOOL
+  is_hidden::BOOL`
+	const fromColumns = `
 FROM
   (SELECT column_name, crdb_sql_type, is_nullable, column_default, generation_expression, ordinal_position, is_hidden,
           array_agg(index_name) AS inames
@@ -47,7 +48,28 @@ FROM
            WHERE (length(%[1]s)=0 OR table_catalog=%[1]s) AND table_schema=%[5]s AND table_name=%[2]s)
          USING(column_name)
     GROUP BY column_name, crdb_sql_type, is_nullable, column_default, generation_expression, ordinal_position, is_hidden
-   )
+   )`
+	const orderBy = `
 ORDER BY ordinal_position`
-	return p.showTableDetails(ctx, "SHOW COLUMNS", n.Table, getColumnsQuery)
+	const getColumnsQuery = selectColumns + fromColumns + orderBy
+
+	// The comments of the columns are found in pg_description. Columns are
+	// identified by the OID of their table and their position in
+	// pg_attribute.
+	const getColumnsWithCommentQuery = selectColumns + `,
+  COALESCE(comment, '') AS comment` + fromColumns + `
+  LEFT OUTER JOIN
+  (SELECT a.attname AS column_name, d.description AS comment
+     FROM %[4]s.pg_catalog.pg_class AS c
+     JOIN %[4]s.pg_catalog.pg_namespace AS ns ON c.relnamespace = ns.oid
+     JOIN %[4]s.pg_catalog.pg_attribute AS a ON a.attrelid = c.oid
+     JOIN %[4]s.pg_catalog.pg_description AS d ON d.objoid = c.oid AND d.objsubid = a.attnum
+    WHERE c.relname = %[2]s AND c.relkind != 'i' AND ns.nspname = %[5]s)
+  USING(column_name)` + orderBy
+
+	query := getColumnsQuery
+	if n.WithComment {
+		query = getColumnsWithCommentQuery
+	}
+	return p.showTableDetails(ctx, "SHOW COLUMNS", n.Table, query)
 }
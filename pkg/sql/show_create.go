@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/pkg/errors"
@@ -46,14 +47,22 @@ func ShowCreateView(
 	ctx context.Context, tn *tree.Name, desc *sqlbase.TableDescriptor,
 ) (string, error) {
 	f := tree.NewFmtCtxWithBuf(tree.FmtSimple)
-	f.WriteString("CREATE VIEW ")
+	if desc.IsMaterializedView() {
+		f.WriteString("CREATE MATERIALIZED VIEW ")
+	} else {
+		f.WriteString("CREATE VIEW ")
+	}
 	f.FormatNode(tn)
 	f.WriteString(" (")
+	sep := ""
 	for i := range desc.Columns {
-		if i > 0 {
-			f.WriteString(", ")
+		if desc.Columns[i].Hidden {
+			// The rowid column of a materialized view.
+			continue
 		}
+		f.WriteString(sep)
 		f.FormatNameP(&desc.Columns[i].Name)
+		sep = ", "
 	}
 	f.WriteString(") AS ")
 	f.WriteString(desc.ViewQuery)
@@ -104,6 +113,10 @@ func printForeignKeyConstraint(
 	formatQuoteNames(buf, refNames...)
 	buf.WriteByte(')')
 	idx.ColNamesString()
+	if fk.Match != sqlbase.ForeignKeyReference_UNSPECIFIED {
+		buf.WriteString(" MATCH ")
+		buf.WriteString(fk.Match.String())
+	}
 	if fk.OnDelete != sqlbase.ForeignKeyReference_NO_ACTION {
 		buf.WriteString(" ON DELETE ")
 		buf.WriteString(fk.OnDelete.String())
@@ -124,10 +137,16 @@ func ShowCreateSequence(
 	f.WriteString("CREATE SEQUENCE ")
 	f.FormatNode(tn)
 	opts := desc.SequenceOpts
+	if opts.AsIntegerType != "" {
+		f.Printf(" AS %s", opts.AsIntegerType)
+	}
 	f.Printf(" MINVALUE %d", opts.MinValue)
 	f.Printf(" MAXVALUE %d", opts.MaxValue)
 	f.Printf(" INCREMENT %d", opts.Increment)
 	f.Printf(" START %d", opts.Start)
+	if opts.CacheSize > 1 {
+		f.Printf(" CACHE %d", opts.CacheSize)
+	}
 	if opts.Virtual {
 		f.Printf(" VIRTUAL")
 	}
@@ -189,7 +208,11 @@ func ShowCreateTable(
 		if idx.ID != desc.PrimaryIndex.ID {
 			// Showing the primary index is handled above.
 			f.WriteString(",\n\t")
-			f.WriteString(idx.SQLString(&sqlbase.AnonymousTable))
+			idxStr, err := idx.SQLString(&sqlbase.AnonymousTable, desc)
+			if err != nil {
+				return "", err
+			}
+			f.WriteString(idxStr)
 			// Showing the INTERLEAVE and PARTITION BY for the primary index are
 			// handled last.
 			if err := showCreateInterleave(ctx, idx, f.Buffer, dbPrefix, lCtx); err != nil {
@@ -200,6 +223,10 @@ func ShowCreateTable(
 			); err != nil {
 				return "", err
 			}
+			if idx.IsPartial() {
+				f.WriteString(" WHERE ")
+				f.WriteString(idx.Predicate)
+			}
 		}
 	}
 
@@ -218,6 +245,9 @@ func ShowCreateTable(
 	}
 
 	for _, e := range desc.Checks {
+		if e.IsNonNullConstraint {
+			continue
+		}
 		f.WriteString(",\n\t")
 		if len(e.Name) > 0 {
 			f.WriteString("CONSTRAINT ")
@@ -240,9 +270,47 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	showComments(tn, desc, &f.FmtCtx)
+
 	return f.CloseAndGetString(), nil
 }
 
+// showComments appends to the given buffer the COMMENT ON statements for
+// the comments set on the table, its columns and its indexes, if any.
+func showComments(tn *tree.Name, desc *sqlbase.TableDescriptor, f *tree.FmtCtx) {
+	if desc.Comment != "" {
+		f.WriteString(";\nCOMMENT ON TABLE ")
+		f.FormatNode(tn)
+		f.WriteString(" IS ")
+		lex.EncodeSQLString(f.Buffer, desc.Comment)
+	}
+	for i := range desc.Columns {
+		col := &desc.Columns[i]
+		if col.Comment == "" {
+			continue
+		}
+		f.WriteString(";\nCOMMENT ON COLUMN ")
+		f.FormatNode(tn)
+		f.WriteByte('.')
+		f.FormatNameP(&col.Name)
+		f.WriteString(" IS ")
+		lex.EncodeSQLString(f.Buffer, col.Comment)
+	}
+	allIdx := append([]sqlbase.IndexDescriptor{desc.PrimaryIndex}, desc.Indexes...)
+	for i := range allIdx {
+		idx := &allIdx[i]
+		if idx.Comment == "" {
+			continue
+		}
+		f.WriteString(";\nCOMMENT ON INDEX ")
+		f.FormatNode(tn)
+		f.WriteByte('@')
+		f.FormatNameP(&idx.Name)
+		f.WriteString(" IS ")
+		lex.EncodeSQLString(f.Buffer, idx.Comment)
+	}
+}
+
 // formatQuoteNames quotes and adds commas between names.
 func formatQuoteNames(buf *bytes.Buffer, names ...string) {
 	f := tree.MakeFmtCtx(buf, tree.FmtSimple)
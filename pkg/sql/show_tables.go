@@ -46,7 +46,27 @@ func (p *planner) ShowTables(ctx context.Context, n *tree.ShowTables) (planNode,
    WHERE table_schema = %[2]s
 ORDER BY table_schema, table_name`
 
+	// The comments of the tables are found in pg_description.
+	const getTablesWithCommentQuery = `
+  SELECT i.table_name, COALESCE(d.description, '') AS comment
+    FROM %[1]s.information_schema.tables AS i
+    JOIN %[1]s.pg_catalog.pg_namespace AS ns ON ns.nspname = i.table_schema
+    JOIN %[1]s.pg_catalog.pg_class AS c
+      ON c.relnamespace = ns.oid AND c.relname = i.table_name AND c.relkind != 'i'
+    LEFT OUTER JOIN %[1]s.pg_catalog.pg_description AS d ON d.objoid = c.oid AND d.objsubid = 0
+   WHERE i.table_schema = %[2]s
+ORDER BY i.table_schema, i.table_name`
+
+	query := getTablesQuery
+	if n.WithComment {
+		query = getTablesWithCommentQuery
+	}
+	scName := n.Schema()
+	if p.Tables().isTemporarySchema(scName) {
+		// Resolve the pg_temp alias.
+		scName = p.Tables().temporarySchemaName
+	}
 	return p.delegateQuery(ctx, "SHOW TABLES",
-		fmt.Sprintf(getTablesQuery, &n.CatalogName, lex.EscapeSQLString(n.Schema())),
+		fmt.Sprintf(query, &n.CatalogName, lex.EscapeSQLString(scName)),
 		func(_ context.Context) error { return nil }, nil)
 }
@@ -201,8 +201,7 @@ func spanForIndexValues(
 	values []tree.Datum,
 	keyPrefix []byte,
 ) (roachpb.Span, error) {
-	// Currently, we only offer MATCH FULL, so this checks to ensure that if a
-	// reference is composed of all NULLs, then we don't cascade it.
+	// A reference composed of only NULLs is never cascaded.
 	nulls := true
 	for _, rowIndex := range indexColIDs {
 		if values[rowIndex] != tree.DNull {
@@ -254,14 +253,24 @@ func batchRequestForIndexValues(
 		}
 	}
 
+	// If any of the referenced values is NULL, then no row can reference them
+	// with an explicit match type: with MATCH SIMPLE a referencing row
+	// containing a NULL doesn't reference anything, and MATCH FULL doesn't
+	// allow mixing NULL and non-NULL values. So there is nothing to cascade.
+	skipNulls := referencingIndex.ForeignKey.Match != ForeignKeyReference_UNSPECIFIED
+
 	var req roachpb.BatchRequest
 	for i := values.startIndex; i < values.endIndex; i++ {
+		row := values.originalValues.At(i)
+		if skipNulls && hasNullValue(row, colIDtoRowIndex) {
+			continue
+		}
 		span, err := spanForIndexValues(
 			referencingTable,
 			referencingIndex,
 			prefixLen,
 			colIDtoRowIndex,
-			values.originalValues.At(i),
+			row,
 			keyPrefix,
 		)
 		if err != nil {
@@ -274,6 +283,16 @@ func batchRequestForIndexValues(
 	return req, colIDtoRowIndex, nil
 }
 
+// hasNullValue returns true if any of the values of the given columns is NULL.
+func hasNullValue(values tree.Datums, colIDtoRowIndex map[ColumnID]int) bool {
+	for _, rowIndex := range colIDtoRowIndex {
+		if values[rowIndex] == tree.DNull {
+			return true
+		}
+	}
+	return false
+}
+
 // spanForPKValues creates a span against the primary index of a table and is
 // used to fetch rows for cascading.
 func spanForPKValues(
@@ -342,6 +361,7 @@ func (c *cascader) addIndexPKRowFetcher(
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
+		c.evalCtx,
 		RowFetcherTableArgs{
 			Desc:             table,
 			Index:            index,
@@ -404,6 +424,7 @@ func (c *cascader) addRowDeleter(table *TableDescriptor) (RowDeleter, RowFetcher
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
+		c.evalCtx,
 		tableArgs,
 	); err != nil {
 		return RowDeleter{}, RowFetcher{}, err
@@ -463,6 +484,7 @@ func (c *cascader) addRowUpdater(table *TableDescriptor) (RowUpdater, RowFetcher
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
+		c.evalCtx,
 		tableArgs,
 	); err != nil {
 		return RowUpdater{}, RowFetcher{}, err
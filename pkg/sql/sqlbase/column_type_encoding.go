@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
 
@@ -105,6 +106,11 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeVarintAscending(b, int64(*t)), nil
 		}
 		return encoding.EncodeVarintDescending(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		if dir == encoding.Ascending {
+			return encoding.EncodeTimeTZAscending(b, t.TimeTZ), nil
+		}
+		return encoding.EncodeTimeTZDescending(b, t.TimeTZ), nil
 	case *tree.DTimestamp:
 		if dir == encoding.Ascending {
 			return encoding.EncodeTimeAscending(b, t.Time), nil
@@ -164,6 +170,13 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeVarintAscending(b, int64(t.DInt)), nil
 		}
 		return encoding.EncodeVarintDescending(b, int64(t.DInt)), nil
+	case *tree.DEnum:
+		// Enums are encoded by the ordinal of their label, so that the
+		// key order matches the declaration order of the members.
+		if dir == encoding.Ascending {
+			return encoding.EncodeVarintAscending(b, int64(t.Ordinal)), nil
+		}
+		return encoding.EncodeVarintDescending(b, int64(t.Ordinal)), nil
 	}
 	return nil, errors.Errorf("unable to encode table key: %T", val)
 }
@@ -267,6 +280,14 @@ func DecodeTableKey(
 			rkey, t, err = encoding.DecodeVarintDescending(key)
 		}
 		return a.NewDTime(tree.DTime(t)), rkey, err
+	case types.TimeTZ:
+		var t timetz.TimeTZ
+		if dir == encoding.Ascending {
+			rkey, t, err = encoding.DecodeTimeTZAscending(key)
+		} else {
+			rkey, t, err = encoding.DecodeTimeTZDescending(key)
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: t}), rkey, err
 	case types.Timestamp:
 		var t time.Time
 		if dir == encoding.Ascending {
@@ -333,6 +354,18 @@ func DecodeTableKey(
 				return nil, nil, err
 			}
 			return tree.NewDCollatedString(r, t.Locale, &a.env), rkey, err
+		case *types.TEnum:
+			var i int64
+			if dir == encoding.Ascending {
+				rkey, i, err = encoding.DecodeVarintAscending(key)
+			} else {
+				rkey, i, err = encoding.DecodeVarintDescending(key)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			d, err := decodeEnumOrdinal(t, i)
+			return d, rkey, err
 		}
 		return nil, nil, errors.Errorf("TODO(pmattis): decoded index key: %s", valType)
 	}
@@ -374,6 +407,8 @@ func EncodeTableValue(
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
 	case *tree.DTime:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeTimeTZValue(appendTo, uint32(colID), t.TimeTZ), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeTimeValue(appendTo, uint32(colID), t.Time), nil
 	case *tree.DTimestampTZ:
@@ -402,6 +437,8 @@ func EncodeTableValue(
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Contents)), nil
 	case *tree.DOid:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.DInt)), nil
+	case *tree.DEnum:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.Ordinal)), nil
 	}
 	return nil, errors.Errorf("unable to encode table value: %T", val)
 }
@@ -486,6 +523,12 @@ func decodeUntaggedDatum(a *DatumAlloc, t types.T, buf []byte) (tree.Datum, []by
 			return nil, b, err
 		}
 		return a.NewDTime(tree.DTime(data)), b, nil
+	case types.TimeTZ:
+		b, data, err := encoding.DecodeUntaggedTimeTZValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: data}), b, nil
 	case types.Timestamp:
 		b, data, err := encoding.DecodeUntaggedTimeValue(buf)
 		if err != nil {
@@ -539,6 +582,13 @@ func decodeUntaggedDatum(a *DatumAlloc, t types.T, buf []byte) (tree.Datum, []by
 			return decodeArray(a, typ.Typ, buf)
 		case types.TTuple:
 			return decodeTuple(a, typ, buf)
+		case *types.TEnum:
+			b, data, err := encoding.DecodeUntaggedIntValue(buf)
+			if err != nil {
+				return nil, b, err
+			}
+			d, err := decodeEnumOrdinal(typ, data)
+			return d, b, err
 		}
 		return nil, buf, errors.Errorf("couldn't decode type %s", t)
 	}
@@ -678,6 +728,11 @@ func MarshalColumnValue(col ColumnDescriptor, val tree.Datum) (roachpb.Value, er
 			r.SetInt(int64(*v))
 			return r, nil
 		}
+	case ColumnType_TIMETZ:
+		if v, ok := val.(*tree.DTimeTZ); ok {
+			r.SetBytes(encoding.EncodeUntaggedTimeTZValue(nil, v.TimeTZ))
+			return r, nil
+		}
 	case ColumnType_TIMESTAMP:
 		if v, ok := val.(*tree.DTimestamp); ok {
 			r.SetTime(v.Time)
@@ -741,6 +796,11 @@ func MarshalColumnValue(col ColumnDescriptor, val tree.Datum) (roachpb.Value, er
 			r.SetInt(int64(v.DInt))
 			return r, nil
 		}
+	case ColumnType_ENUM:
+		if v, ok := val.(*tree.DEnum); ok {
+			r.SetInt(int64(v.Ordinal))
+			return r, nil
+		}
 	default:
 		return r, errors.Errorf("unsupported column type: %s", col.Type.SemanticType)
 	}
@@ -813,6 +873,16 @@ func UnmarshalColumnValue(a *DatumAlloc, typ ColumnType, value roachpb.Value) (t
 			return nil, err
 		}
 		return a.NewDTime(tree.DTime(v)), nil
+	case ColumnType_TIMETZ:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		_, t, err := encoding.DecodeUntaggedTimeTZValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: t}), nil
 	case ColumnType_TIMESTAMP:
 		v, err := value.GetTime()
 		if err != nil {
@@ -870,11 +940,25 @@ func UnmarshalColumnValue(a *DatumAlloc, typ ColumnType, value roachpb.Value) (t
 			return nil, err
 		}
 		return a.NewDOid(tree.MakeDOid(tree.DInt(v))), nil
+	case ColumnType_ENUM:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return decodeEnumOrdinal(typ.enumType(), v)
 	default:
 		return nil, errors.Errorf("unsupported column type: %s", typ.SemanticType)
 	}
 }
 
+// decodeEnumOrdinal returns the enum datum with the given ordinal.
+func decodeEnumOrdinal(typ *types.TEnum, ordinal int64) (tree.Datum, error) {
+	if ordinal < 0 || ordinal >= int64(len(typ.Labels)) {
+		return nil, errors.Errorf("invalid ordinal %d for enum %s", ordinal, typ)
+	}
+	return tree.NewDEnum(typ, int(ordinal)), nil
+}
+
 // encodeTuple produces the value encoding for a tuple.
 func encodeTuple(t *tree.DTuple, appendTo []byte, colID uint32, scratch []byte) ([]byte, error) {
 	appendTo = encoding.EncodeValueTag(appendTo, colID, encoding.Tuple)
@@ -1106,6 +1190,8 @@ func datumTypeToArrayElementEncodingType(t types.T) (encoding.Type, error) {
 	// persisted with incorrect elementType values.
 	case types.Date, types.Time:
 		return encoding.Int, nil
+	case types.TimeTZ:
+		return encoding.TimeTZ, nil
 	case types.Interval:
 		return encoding.Duration, nil
 	case types.Bool:
@@ -1168,6 +1254,8 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
 	case *tree.DTime:
 		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeUntaggedTimeTZValue(b, t.TimeTZ), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeUntaggedTimeValue(b, t.Time), nil
 	case *tree.DTimestampTZ:
@@ -94,6 +94,15 @@ func DatumTypeToColumnType(ptyp types.T) (ColumnType, error) {
 		}
 		ctyp.TupleLabels = t.Labels
 		return ctyp, nil
+	case *types.TEnum:
+		ctyp.SemanticType = ColumnType_ENUM
+		ctyp.EnumTypeID = ID(t.TypeID)
+		ctyp.EnumTypeName = t.TypeName
+		ctyp.EnumMembers = make([]EnumMember, len(t.Labels))
+		for i := range t.Labels {
+			ctyp.EnumMembers[i].Label = t.Labels[i]
+			ctyp.EnumMembers[i].ReadOnly = t.ReadOnly != nil && t.ReadOnly[i]
+		}
 	default:
 		semanticType, err := datumTypeToColumnSemanticType(ptyp)
 		if err != nil {
@@ -185,9 +194,14 @@ func PopulateTypeAttrs(base ColumnType, typ coltypes.T) (ColumnType, error) {
 	case *coltypes.TName:
 	case *coltypes.TOid:
 	case *coltypes.TTime:
+	case *coltypes.TTimeTZ:
 	case *coltypes.TTimestamp:
 	case *coltypes.TTimestampTZ:
 	case *coltypes.TUUID:
+	case *coltypes.TUserDefined:
+		if t.Typ == nil {
+			return ColumnType{}, tree.NewUndefinedTypeError(t.Name)
+		}
 	default:
 		return ColumnType{}, errors.Errorf("unexpected type %T", t)
 	}
@@ -291,6 +305,8 @@ func (c *ColumnType) SQLString() string {
 		}
 	case ColumnType_ARRAY:
 		return c.elementColumnType().SQLString() + "[]"
+	case ColumnType_ENUM:
+		return (&coltypes.TUserDefined{Name: c.EnumTypeName}).String()
 	}
 	if c.VisibleType != ColumnType_NONE {
 		return c.VisibleType.String()
@@ -349,6 +365,8 @@ func (c *ColumnType) InformationSchemaVisibleType() string {
 
 	case ColumnType_DECIMAL:
 		return "numeric"
+	case ColumnType_TIMETZ:
+		return "time with time zone"
 	case ColumnType_TIMESTAMPTZ:
 		return "timestamp with time zone"
 	case ColumnType_BYTES:
@@ -359,6 +377,8 @@ func (c *ColumnType) InformationSchemaVisibleType() string {
 		return "record"
 	case ColumnType_ARRAY:
 		return "ARRAY"
+	case ColumnType_ENUM:
+		return "USER-DEFINED"
 	}
 
 	// The name of the remaining semantic type constants are suitable
@@ -515,6 +535,8 @@ func datumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error
 		return ColumnType_DATE, nil
 	case types.Time:
 		return ColumnType_TIME, nil
+	case types.TimeTZ:
+		return ColumnType_TIMETZ, nil
 	case types.Timestamp:
 		return ColumnType_TIMESTAMP, nil
 	case types.TimestampTZ:
@@ -542,6 +564,9 @@ func datumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error
 		if ptyp.FamilyEqual(types.FamTuple) {
 			return ColumnType_TUPLE, nil
 		}
+		if ptyp.FamilyEqual(types.FamEnum) {
+			return ColumnType_ENUM, nil
+		}
 		if wrapper, ok := ptyp.(types.TOidWrapper); ok {
 			return datumTypeToColumnSemanticType(wrapper.T)
 		}
@@ -572,6 +597,8 @@ func columnSemanticTypeToDatumType(c *ColumnType, k ColumnType_SemanticType) typ
 		return types.Date
 	case ColumnType_TIME:
 		return types.Time
+	case ColumnType_TIMETZ:
+		return types.TimeTZ
 	case ColumnType_TIMESTAMP:
 		return types.Timestamp
 	case ColumnType_TIMESTAMPTZ:
@@ -601,10 +628,27 @@ func columnSemanticTypeToDatumType(c *ColumnType, k ColumnType_SemanticType) typ
 		return types.IntVector
 	case ColumnType_OIDVECTOR:
 		return types.OidVector
+	case ColumnType_ENUM:
+		return c.enumType()
 	}
 	return nil
 }
 
+// enumType returns the types.TEnum for an ENUM column type.
+func (c *ColumnType) enumType() *types.TEnum {
+	t := &types.TEnum{
+		TypeID:   uint32(c.EnumTypeID),
+		TypeName: c.EnumTypeName,
+		Labels:   make([]string, len(c.EnumMembers)),
+		ReadOnly: make([]bool, len(c.EnumMembers)),
+	}
+	for i := range c.EnumMembers {
+		t.Labels[i] = c.EnumMembers[i].Label
+		t.ReadOnly[i] = c.EnumMembers[i].ReadOnly
+	}
+	return t
+}
+
 // ToDatumType converts the ColumnType to a types.T (type of in-memory
 // representations). It returns nil if there is no such type.
 //
@@ -698,6 +742,16 @@ func CheckValueWidth(typ ColumnType, val tree.Datum, name string) error {
 				}
 			}
 		}
+	case ColumnType_ENUM:
+		if v, ok := val.(*tree.DEnum); ok {
+			// Values of a label that is still being added cannot be written
+			// until all the nodes know about the label.
+			if v.Ordinal >= len(typ.EnumMembers) || typ.EnumMembers[v.Ordinal].ReadOnly {
+				return pgerror.NewErrorf(pgerror.CodeUnsafeNewEnumValueUsageError,
+					"unsafe use of new value %q of enum type %s (column %q)",
+					v.Label(), typ.EnumTypeName, name)
+			}
+		}
 	}
 	return nil
 }
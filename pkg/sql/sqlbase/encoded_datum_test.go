@@ -225,6 +225,10 @@ func TestEncDatumCompare(t *testing.T) {
 		if kind == ColumnType_COLLATEDSTRING {
 			typ.Locale = RandCollationLocale(rng)
 		}
+		if kind == ColumnType_ENUM {
+			typ.EnumTypeID = 1
+			typ.EnumMembers = []EnumMember{{Label: "a"}, {Label: "b"}, {Label: "c"}}
+		}
 
 		// Generate two datums d1 < d2
 		var d1, d2 tree.Datum
@@ -324,7 +324,8 @@ func ConvertBatchError(ctx context.Context, tableDesc *TableDescriptor, b *clien
 			ValNeededForCol:  valNeededForCol,
 		}
 		if err := rf.Init(
-			false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &DatumAlloc{}, tableArgs,
+			false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &DatumAlloc{},
+			nil /* evalCtx */, tableArgs,
 		); err != nil {
 			return err
 		}
@@ -463,18 +463,39 @@ func checkIdx(
 	row tree.Datums,
 ) error {
 	for i, fk := range fks[idx] {
-		nulls := true
+		nulls, nonNulls := false, false
 		for _, colID := range fk.searchIdx.ColumnIDs[:fk.prefixLen] {
 			found, ok := fk.ids[colID]
 			if !ok {
 				panic(fmt.Sprintf("fk ids (%v) missing column id %d", fk.ids, colID))
 			}
-			if row[found] != tree.DNull {
-				nulls = false
-				break
+			if row[found] == tree.DNull {
+				nulls = true
+			} else {
+				nonNulls = true
 			}
 		}
-		if nulls {
+		if !nonNulls {
+			// A row with only NULL values never references another row, and
+			// is never referenced.
+			continue
+		}
+		// Without a MATCH clause, the rows mixing NULL and non-NULL values are
+		// checked like the others.
+		if nulls && fk.match != ForeignKeyReference_UNSPECIFIED {
+			if fk.dir == CheckInserts && fk.match == ForeignKeyReference_FULL {
+				fkValues := make(tree.Datums, fk.prefixLen)
+				for valueIdx, colID := range fk.searchIdx.ColumnIDs[:fk.prefixLen] {
+					fkValues[valueIdx] = row[fk.ids[colID]]
+				}
+				return pgerror.NewErrorf(pgerror.CodeForeignKeyViolationError,
+					"foreign key violation: MATCH FULL does not allow mixing of null and nonnull values %s for %q",
+					fkValues, fk.writeIdx.ForeignKey.Name)
+			}
+			// With MATCH SIMPLE, a row with a NULL value doesn't reference
+			// another row. Conversely, a row with a NULL value is never
+			// referenced, since the referencing rows would have to contain
+			// NULL values too.
 			continue
 		}
 		if err := checker.addCheck(row, &fks[idx][i]); err != nil {
@@ -634,6 +655,9 @@ type baseFKHelper struct {
 	searchPrefix []byte           // prefix of keys in searchIdx
 	ids          map[ColumnID]int // col IDs
 	dir          FKCheck          // direction of check
+	// match is the match type of the foreign key, which is recorded on the
+	// referencing side.
+	match ForeignKeyReference_Match
 }
 
 func makeBaseFKHelper(
@@ -659,6 +683,11 @@ func makeBaseFKHelper(
 		b.prefixLen = len(writeIdx.ColumnIDs)
 	}
 	b.searchIdx = searchIdx
+	if dir == CheckInserts {
+		b.match = ref.Match
+	} else {
+		b.match = searchIdx.ForeignKey.Match
+	}
 	tableArgs := RowFetcherTableArgs{
 		Desc:             b.searchTable,
 		Index:            b.searchIdx,
@@ -666,13 +695,15 @@ func makeBaseFKHelper(
 		IsSecondaryIndex: b.searchIdx.ID != b.searchTable.PrimaryIndex.ID,
 		Cols:             b.searchTable.Columns,
 	}
-	err = b.rf.Init(false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, alloc, tableArgs)
+	err = b.rf.Init(
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, alloc,
+		nil /* evalCtx */, tableArgs,
+	)
 	if err != nil {
 		return b, err
 	}
 
-	// Check for all NULL values, since these can skip FK checking in MATCH FULL
-	// TODO(bram): add MATCH SIMPLE and fix MATCH FULL #30026
+	// Check for all NULL values, since these can skip FK checking.
 	b.ids = make(map[ColumnID]int, len(writeIdx.ColumnIDs))
 	nulls := true
 	var missingColumns []string
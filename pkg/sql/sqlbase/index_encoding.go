@@ -15,6 +15,7 @@
 package sqlbase
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 
@@ -700,10 +701,11 @@ func EncodeInvertedIndexKeys(
 	return EncodeInvertedIndexTableKeys(val, keyPrefix)
 }
 
-// EncodeInvertedIndexTableKeys encodes the paths in a JSON `val` and
-// concatenates it with `inKey`and returns a list of buffers per
-// path. The encoded values is guaranteed to be lexicographically
-// sortable, but not guaranteed to be round-trippable during decoding.
+// EncodeInvertedIndexTableKeys encodes the paths in a JSON `val`, or the
+// elements of an array `val`, and concatenates it with `inKey`and returns a
+// list of buffers per path or element. The encoded values is guaranteed to be
+// lexicographically sortable, but not guaranteed to be round-trippable during
+// decoding.
 func EncodeInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, err error) {
 	if val == tree.DNull {
 		return [][]byte{encoding.EncodeNullAscending(inKey)}, nil
@@ -711,8 +713,44 @@ func EncodeInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, e
 	switch t := tree.UnwrapDatum(nil, val).(type) {
 	case *tree.DJSON:
 		return json.EncodeInvertedIndexKeys(inKey, (t.JSON))
+	case *tree.DArray:
+		return encodeArrayInvertedIndexKeys(t, inKey)
 	}
-	return nil, pgerror.NewError(pgerror.CodeInternalError, "trying to apply inverted index to non JSON type")
+	return nil, pgerror.NewError(pgerror.CodeInternalError,
+		"trying to apply inverted index to non JSON or ARRAY type")
+}
+
+// encodeArrayInvertedIndexKeys returns a key for each distinct non-NULL
+// element of the array, made of `inKey` followed by the ascending key
+// encoding of the element. NULL elements are not indexed, since they never
+// satisfy the containment or overlap operators. An empty array has no keys.
+func encodeArrayInvertedIndexKeys(val *tree.DArray, inKey []byte) (key [][]byte, err error) {
+	outKeys := make([][]byte, 0, len(val.Array))
+	for _, d := range val.Array {
+		if d == tree.DNull {
+			continue
+		}
+		outKey := make([]byte, len(inKey))
+		copy(outKey, inKey)
+		outKey, err = EncodeTableKey(outKey, d, encoding.Ascending)
+		if err != nil {
+			return nil, err
+		}
+		outKeys = append(outKeys, outKey)
+	}
+
+	// Remove the keys of duplicate elements.
+	sort.Slice(outKeys, func(i, j int) bool {
+		return bytes.Compare(outKeys[i], outKeys[j]) < 0
+	})
+	for i := 1; i < len(outKeys); {
+		if bytes.Equal(outKeys[i], outKeys[i-1]) {
+			outKeys = append(outKeys[:i], outKeys[i+1:]...)
+		} else {
+			i++
+		}
+	}
+	return outKeys, nil
 }
 
 // EncodeSecondaryIndex encodes key/values for a secondary
@@ -816,21 +854,41 @@ func EncodeSecondaryIndex(
 // maps ColumnIDs to indices in `values`. secondaryIndexEntries is the return
 // value (passed as a parameter so the caller can reuse between rows) and is
 // expected to be the same length as indexes.
+//
+// The entry of a partial index whose predicate the row does not satisfy has
+// an empty key; partialIndexes is used to evaluate the predicates and can
+// only be nil if none of the indexes are partial.
 func EncodeSecondaryIndexes(
 	tableDesc *TableDescriptor,
 	indexes []IndexDescriptor,
 	colMap map[ColumnID]int,
 	values []tree.Datum,
 	secondaryIndexEntries []IndexEntry,
+	partialIndexes *PartialIndexPredicates,
 ) ([]IndexEntry, error) {
 	if len(secondaryIndexEntries) != len(indexes) {
 		panic("Length of secondaryIndexEntries is not equal to the number of indexes.")
 	}
 	for i := range indexes {
+		if indexes[i].IsPartial() {
+			ok, err := partialIndexes.Satisfies(&indexes[i], colMap, values)
+			if err != nil {
+				return secondaryIndexEntries, err
+			}
+			if !ok {
+				secondaryIndexEntries[i] = IndexEntry{}
+				continue
+			}
+		}
 		entries, err := EncodeSecondaryIndex(tableDesc, &indexes[i], colMap, values)
 		if err != nil {
 			return secondaryIndexEntries, err
 		}
+		if len(entries) == 0 {
+			// An inverted index has no entries for an empty array.
+			secondaryIndexEntries[i] = IndexEntry{}
+			continue
+		}
 		secondaryIndexEntries[i] = entries[0]
 
 		// This is specifically for inverted indexes which can have more than one entry
@@ -0,0 +1,147 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sqlbase
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+)
+
+// PredicateColumnIDs returns the IDs of the columns referenced by the
+// predicate of a partial index.
+func (desc *IndexDescriptor) PredicateColumnIDs(table *TableDescriptor) ([]ColumnID, error) {
+	if !desc.IsPartial() {
+		return nil, nil
+	}
+	parsed, err := parser.ParseExpr(desc.Predicate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse predicate of index %q", desc.Name)
+	}
+
+	var colIDs []ColumnID
+	visitFn := func(expr tree.Expr) (err error, recurse bool, newExpr tree.Expr) {
+		if vBase, ok := expr.(tree.VarName); ok {
+			v, err := vBase.NormalizeVarName()
+			if err != nil {
+				return err, false, nil
+			}
+			if c, ok := v.(*tree.ColumnItem); ok {
+				col, err := table.FindActiveColumnByName(string(c.ColumnName))
+				if err != nil {
+					return errors.Errorf("column %q not found for predicate of index %q",
+						c.ColumnName, desc.Name), false, nil
+				}
+				colIDs = append(colIDs, col.ID)
+			}
+			return nil, false, v
+		}
+		return nil, true, expr
+	}
+	if _, err := tree.SimpleVisit(parsed, visitFn); err != nil {
+		return nil, err
+	}
+	return colIDs, nil
+}
+
+// MakePartialIndexPredicate parses and type checks the predicate of a partial
+// index of the given table. The column references of the result are indexed
+// vars that refer to the columns of the table by ordinal.
+func MakePartialIndexPredicate(
+	tableDesc *TableDescriptor, index *IndexDescriptor,
+) (tree.TypedExpr, error) {
+	expr, err := parser.ParseExpr(index.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := &descContainer{tableDesc.Columns}
+	ivarHelper := tree.MakeIndexedVarHelper(iv, len(tableDesc.Columns))
+	tn := tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name))
+	sourceInfo := NewSourceInfoForSingleTable(
+		tn, ResultColumnsFromColDescs(tableDesc.Columns),
+	)
+
+	semaCtx := tree.MakeSemaContext(false)
+	semaCtx.IVarContainer = iv
+
+	// Predicates can only contain immutable function calls, which don't
+	// depend on the search path of the session.
+	expr, _, _, err = ResolveNames(
+		expr, MakeMultiSourceInfo(sourceInfo), ivarHelper, sessiondata.SearchPath{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.TypeCheck(expr, &semaCtx, types.Bool)
+}
+
+// PartialIndexPredicates evaluates the predicates of the partial indexes of a
+// table, to determine which rows of the table have entries in them.
+type PartialIndexPredicates struct {
+	tableDesc *TableDescriptor
+
+	// exprs caches the predicate of each partial index, by index ID. It is
+	// populated as the indexes are encountered.
+	exprs map[IndexID]tree.TypedExpr
+	ivars RowIndexedVarContainer
+
+	// Predicates are immutable, so their evaluation needs no session state.
+	evalCtx tree.EvalContext
+}
+
+// MakePartialIndexPredicates returns a PartialIndexPredicates for the partial
+// indexes of the given table.
+func MakePartialIndexPredicates(tableDesc *TableDescriptor) PartialIndexPredicates {
+	return PartialIndexPredicates{tableDesc: tableDesc}
+}
+
+// Satisfies returns whether the row with the given values belongs in the given
+// index, that is if the index is not partial or if the row satisfies its
+// predicate. colMap maps the IDs of the columns of the table to their position
+// in values.
+func (p *PartialIndexPredicates) Satisfies(
+	index *IndexDescriptor, colMap map[ColumnID]int, values []tree.Datum,
+) (bool, error) {
+	if !index.IsPartial() {
+		return true, nil
+	}
+	expr, ok := p.exprs[index.ID]
+	if !ok {
+		var err error
+		if expr, err = MakePartialIndexPredicate(p.tableDesc, index); err != nil {
+			return false, err
+		}
+		if p.exprs == nil {
+			p.exprs = make(map[IndexID]tree.TypedExpr)
+		}
+		p.exprs[index.ID] = expr
+	}
+
+	p.ivars = RowIndexedVarContainer{
+		CurSourceRow: values,
+		Cols:         p.tableDesc.Columns,
+		Mapping:      colMap,
+	}
+	p.evalCtx.IVarContainer = &p.ivars
+	d, err := expr.Eval(&p.evalCtx)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/scrub"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -87,6 +89,15 @@ type tableInfo struct {
 	// index (into cols); -1 if we don't need the value for that column.
 	indexColIdx []int
 
+	// The indexes into cols of the needed virtual computed columns. These are
+	// not stored in the primary index and are computed from the other columns
+	// once the rest of the row is decoded.
+	virtualColIdxs []int
+	// virtualExprs contains the expression of each column in virtualColIdxs.
+	virtualExprs []tree.TypedExpr
+	// virtualIVarContainer is used to evaluate virtualExprs over decodedRow.
+	virtualIVarContainer RowIndexedVarContainer
+
 	// -- Fields updated during a scan --
 
 	keyValTypes []ColumnType
@@ -208,6 +219,12 @@ type RowFetcher struct {
 	// when beginning a new scan.
 	traceKV bool
 
+	// lockingStrength and lockingWaitPolicy specify the row-level locks
+	// acquired by the scans, as requested by a locking clause (FOR UPDATE,
+	// etc). See SetLocking.
+	lockingStrength   tree.LockingStrength
+	lockingWaitPolicy tree.LockingWaitPolicy
+
 	// -- Fields updated during a scan --
 
 	kvFetcher      kvFetcher
@@ -237,14 +254,23 @@ type RowFetcher struct {
 
 	// Buffered allocation of decoded datums.
 	alloc *DatumAlloc
+
+	// evalCtx is used to compute the values of virtual computed columns. It
+	// can be nil if no such column is needed.
+	evalCtx *tree.EvalContext
 }
 
 // Init sets up a RowFetcher for a given table and index. If we are using a
 // non-primary index, tables.ValNeededForCol can only refer to columns in the
-// index.
+// index. The evalCtx is used to compute the needed virtual computed columns;
+// it can be nil if there are none.
 func (rf *RowFetcher) Init(
 	reverse,
-	returnRangeInfo bool, isCheck bool, alloc *DatumAlloc, tables ...RowFetcherTableArgs,
+	returnRangeInfo bool,
+	isCheck bool,
+	alloc *DatumAlloc,
+	evalCtx *tree.EvalContext,
+	tables ...RowFetcherTableArgs,
 ) error {
 	if len(tables) == 0 {
 		panic("no tables to fetch from")
@@ -253,6 +279,7 @@ func (rf *RowFetcher) Init(
 	rf.reverse = reverse
 	rf.returnRangeInfo = returnRangeInfo
 	rf.alloc = alloc
+	rf.evalCtx = evalCtx
 	rf.allEquivSignatures = make(map[string]int, len(tables))
 	rf.isCheck = isCheck
 
@@ -313,12 +340,22 @@ func (rf *RowFetcher) Init(
 			}
 		}
 
+		// Virtual computed columns are not stored in the primary index. The
+		// needed ones are computed from the columns they reference instead.
+		valNeededForCol := tableArgs.ValNeededForCol
+		if !table.isSecondaryIndex {
+			valNeededForCol, err = rf.initVirtualCols(&table, valNeededForCol)
+			if err != nil {
+				return err
+			}
+		}
+
 		rf.knownPrefixLength = len(MakeIndexKeyPrefix(table.desc, table.index.ID))
 
 		var indexColumnIDs []ColumnID
 		indexColumnIDs, table.indexColumnDirs = table.index.FullColumnIDs()
 
-		table.neededValueColsByIdx = tableArgs.ValNeededForCol.Copy()
+		table.neededValueColsByIdx = valNeededForCol.Copy()
 		neededIndexCols := 0
 		table.indexColIdx = make([]int, len(indexColumnIDs))
 		for i, id := range indexColumnIDs {
@@ -431,13 +468,38 @@ func (rf *RowFetcher) StartScan(
 		firstBatchLimit++
 	}
 
-	f, err := makeKVFetcher(txn, spans, rf.reverse, limitBatches, firstBatchLimit, rf.returnRangeInfo)
+	f, err := makeKVFetcher(
+		txn, spans, rf.reverse, limitBatches, firstBatchLimit, rf.returnRangeInfo,
+		rf.lockingStrength != tree.ForNone, rf.waitPolicy(),
+	)
 	if err != nil {
 		return err
 	}
 	return rf.StartScanFrom(ctx, &f)
 }
 
+// SetLocking configures the row-level locks acquired by the scans started
+// afterwards. All lock strengths currently acquire exclusive locks.
+func (rf *RowFetcher) SetLocking(
+	strength tree.LockingStrength, waitPolicy tree.LockingWaitPolicy,
+) {
+	rf.lockingStrength = strength
+	rf.lockingWaitPolicy = waitPolicy
+}
+
+// waitPolicy returns the KV wait policy corresponding to the locking wait
+// policy of the fetcher.
+func (rf *RowFetcher) waitPolicy() roachpb.WaitPolicy {
+	switch rf.lockingWaitPolicy {
+	case tree.LockWaitSkip:
+		return roachpb.SKIP_LOCKED
+	case tree.LockWaitError:
+		return roachpb.ERROR
+	default:
+		return roachpb.BLOCK
+	}
+}
+
 // StartScanFrom initializes and starts a scan from the given kvFetcher. Can be
 // used multiple times.
 func (rf *RowFetcher) StartScanFrom(ctx context.Context, f kvFetcher) error {
@@ -491,6 +553,10 @@ func (rf *RowFetcher) nextKV(
 		rf.batchNumKvs = numKeys
 	}
 	if err != nil {
+		if _, ok := err.(*roachpb.LockNotAvailableError); ok && len(rf.tables) == 1 {
+			return false, kv, false, pgerror.NewErrorf(pgerror.CodeLockNotAvailableError,
+				"could not obtain lock on row in relation %q", rf.tables[0].desc.Name)
+		}
 		return ok, kv, false, err
 	}
 	if !ok {
@@ -1240,7 +1306,7 @@ func (rf *RowFetcher) finalizeRow() error {
 	for i := range table.cols {
 		if rf.valueColsFound == table.neededValueCols {
 			// Found all cols - done!
-			return nil
+			break
 		}
 		if table.neededCols.Contains(int(table.cols[i].ID)) && table.row[i].IsUnset() {
 			if !table.cols[i].Nullable {
@@ -1273,6 +1339,94 @@ func (rf *RowFetcher) finalizeRow() error {
 			rf.valueColsFound++
 		}
 	}
+	return rf.evalVirtualCols(table)
+}
+
+// initVirtualCols prepares the evaluation of the needed virtual computed
+// columns of a table that is scanned through its primary index. It returns
+// valNeededForCol updated to contain the columns they reference instead of
+// the virtual columns themselves.
+func (rf *RowFetcher) initVirtualCols(
+	table *tableInfo, valNeededForCol util.FastIntSet,
+) (util.FastIntSet, error) {
+	var virtualCols []ColumnDescriptor
+	for i := range table.cols {
+		if table.cols[i].IsVirtual() && table.neededCols.Contains(int(table.cols[i].ID)) {
+			table.virtualColIdxs = append(table.virtualColIdxs, i)
+			virtualCols = append(virtualCols, table.cols[i])
+		}
+	}
+	if len(virtualCols) == 0 {
+		return valNeededForCol, nil
+	}
+	if rf.evalCtx == nil {
+		return util.FastIntSet{}, errors.Errorf(
+			"virtual computed column %q cannot be evaluated", virtualCols[0].Name)
+	}
+
+	valNeededForCol = valNeededForCol.Copy()
+	for i := range virtualCols {
+		table.neededCols.Remove(int(virtualCols[i].ID))
+		valNeededForCol.Remove(table.virtualColIdxs[i])
+	}
+	for i := range virtualCols {
+		colIDs, err := virtualCols[i].ColumnsUsed(table.desc)
+		if err != nil {
+			return util.FastIntSet{}, err
+		}
+		for _, id := range colIDs {
+			idx, ok := table.colIdxMap[id]
+			if !ok {
+				return util.FastIntSet{}, errors.Errorf(
+					"column %d referenced by virtual computed column %q is not fetched",
+					id, virtualCols[i].Name)
+			}
+			table.neededCols.Add(int(id))
+			valNeededForCol.Add(idx)
+		}
+	}
+
+	var txCtx transform.ExprTransformContext
+	tn := tree.MakeUnqualifiedTableName(tree.Name(table.desc.Name))
+	exprs, err := MakeComputedExprs(virtualCols, table.desc, &tn, &txCtx, rf.evalCtx)
+	if err != nil {
+		return util.FastIntSet{}, err
+	}
+	table.virtualExprs = exprs
+	table.virtualIVarContainer = RowIndexedVarContainer{
+		Cols:    table.desc.Columns,
+		Mapping: table.colIdxMap,
+	}
+	return valNeededForCol, nil
+}
+
+// evalVirtualCols computes the values of the needed virtual computed columns
+// of a row whose other columns have been decoded.
+func (rf *RowFetcher) evalVirtualCols(table *tableInfo) error {
+	if len(table.virtualColIdxs) == 0 {
+		return nil
+	}
+	for i := range table.row {
+		if table.row[i].IsUnset() {
+			table.decodedRow[i] = tree.DNull
+			continue
+		}
+		if err := table.row[i].EnsureDecoded(&table.cols[i].Type, rf.alloc); err != nil {
+			return err
+		}
+		table.decodedRow[i] = table.row[i].Datum
+	}
+
+	table.virtualIVarContainer.CurSourceRow = table.decodedRow
+	rf.evalCtx.PushIVarContainer(&table.virtualIVarContainer)
+	defer rf.evalCtx.PopIVarContainer()
+	for i, idx := range table.virtualColIdxs {
+		d, err := table.virtualExprs[i].Eval(rf.evalCtx)
+		if err != nil {
+			return err
+		}
+		table.row[idx] = EncDatum{Datum: d}
+	}
 	return nil
 }
 
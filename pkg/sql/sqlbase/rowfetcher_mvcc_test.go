@@ -99,7 +99,7 @@ func TestRowFetcherMVCCMetadata(t *testing.T) {
 	var rf sqlbase.RowFetcher
 	if err := rf.Init(
 		false /* reverse */, false /* returnRangeInfo */, true /* isCheck */, &sqlbase.DatumAlloc{},
-		args...,
+		nil /* evalCtx */, args...,
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -67,7 +67,7 @@ func initFetcher(
 	}
 
 	if err := fetcher.Init(reverseScan, false /*reverse*/, false, /* isCheck */
-		alloc, fetcherArgs...); err != nil {
+		alloc, nil /* evalCtx */, fetcherArgs...); err != nil {
 		return nil, err
 	}
 
@@ -46,6 +46,9 @@ type rowHelper struct {
 	// Secondary indexes.
 	Indexes      []IndexDescriptor
 	indexEntries []IndexEntry
+	// partialIndexes determines which rows belong in the partial indexes
+	// among Indexes.
+	partialIndexes PartialIndexPredicates
 
 	// Computed during initialization for pretty-printing.
 	primIndexValDirs []encoding.Direction
@@ -58,7 +61,11 @@ type rowHelper struct {
 }
 
 func newRowHelper(desc *TableDescriptor, indexes []IndexDescriptor) rowHelper {
-	rh := rowHelper{TableDesc: desc, Indexes: indexes}
+	rh := rowHelper{
+		TableDesc:      desc,
+		Indexes:        indexes,
+		partialIndexes: MakePartialIndexPredicates(desc),
+	}
 
 	// Pre-compute the encoding directions of the index key values for
 	// pretty-printing in traces.
@@ -104,7 +111,7 @@ func (rh *rowHelper) encodeSecondaryIndexes(
 		rh.indexEntries = make([]IndexEntry, len(rh.Indexes))
 	}
 	rh.indexEntries, err = EncodeSecondaryIndexes(
-		rh.TableDesc, rh.Indexes, colIDtoRowIndex, values, rh.indexEntries)
+		rh.TableDesc, rh.Indexes, colIDtoRowIndex, values, rh.indexEntries, &rh.partialIndexes)
 	if err != nil {
 		return nil, err
 	}
@@ -322,6 +329,10 @@ func (ri *RowInserter) InsertRow(
 	putFn = insertInvertedPutFn
 	for i := range secondaryIndexEntries {
 		e := &secondaryIndexEntries[i]
+		if e.Key == nil {
+			// The row does not satisfy the predicate of a partial index.
+			continue
+		}
 		putFn(ctx, b, &e.Key, &e.Value, traceKV)
 	}
 
@@ -513,6 +524,43 @@ const (
 	RowUpdaterOnlyColumns rowUpdaterType = 1
 )
 
+// forEachVirtualColumnDep calls fn on the IDs of the columns referenced by
+// col if it is a virtual computed column.
+func forEachVirtualColumnDep(
+	tableDesc *TableDescriptor, col *ColumnDescriptor, fn func(ColumnID) error,
+) error {
+	if !col.IsVirtual() {
+		return nil
+	}
+	colIDs, err := col.ColumnsUsed(tableDesc)
+	if err != nil {
+		return err
+	}
+	for _, colID := range colIDs {
+		if err := fn(colID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachPredicateColumn calls fn on the IDs of the columns referenced by the
+// predicate of index if it is a partial index.
+func forEachPredicateColumn(
+	tableDesc *TableDescriptor, index *IndexDescriptor, fn func(ColumnID) error,
+) error {
+	colIDs, err := index.PredicateColumnIDs(tableDesc)
+	if err != nil {
+		return err
+	}
+	for _, colID := range colIDs {
+		if err := fn(colID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // MakeRowUpdater creates a RowUpdater for the given table.
 //
 // UpdateCols are the columns being updated and correspond to the updateValues
@@ -573,26 +621,43 @@ func makeRowUpdaterWithoutCascader(
 	}
 
 	// Secondary indexes needing updating.
-	needsUpdate := func(index IndexDescriptor) bool {
+	needsUpdate := func(index IndexDescriptor) (bool, error) {
 		if updateType == RowUpdaterOnlyColumns {
 			// Only update columns.
-			return false
+			return false, nil
 		}
 		// If the primary key changed, we need to update all of them.
 		if primaryKeyColChange {
-			return true
+			return true, nil
 		}
-		return index.RunOverAllColumns(func(id ColumnID) error {
+		isUpdated := func(id ColumnID) error {
 			if _, ok := updateColIDtoRowIndex[id]; ok {
 				return returnTruePseudoError
 			}
 			return nil
-		}) != nil
+		}
+		if index.RunOverAllColumns(isUpdated) != nil {
+			return true, nil
+		}
+		// The row can enter or leave a partial index when the columns
+		// referenced by its predicate are updated.
+		switch err := forEachPredicateColumn(tableDesc, &index, isUpdated); err {
+		case nil:
+			return false, nil
+		case returnTruePseudoError:
+			return true, nil
+		default:
+			return false, err
+		}
 	}
 
 	indexes := make([]IndexDescriptor, 0, len(tableDesc.Indexes)+len(tableDesc.Mutations))
 	for _, index := range tableDesc.Indexes {
-		if needsUpdate(index) {
+		update, err := needsUpdate(index)
+		if err != nil {
+			return RowUpdater{}, err
+		}
+		if update {
 			indexes = append(indexes, index)
 		}
 	}
@@ -607,7 +672,11 @@ func makeRowUpdaterWithoutCascader(
 	var deleteOnlyIndexes []IndexDescriptor
 	for _, m := range tableDesc.Mutations {
 		if index := m.GetIndex(); index != nil {
-			if needsUpdate(*index) {
+			update, err := needsUpdate(*index)
+			if err != nil {
+				return RowUpdater{}, err
+			}
+			if update {
 				switch m.State {
 				case DescriptorMutation_DELETE_ONLY:
 					if deleteOnlyIndexes == nil {
@@ -660,7 +729,8 @@ func makeRowUpdaterWithoutCascader(
 		ru.FetchCols = requestedCols[:len(requestedCols):len(requestedCols)]
 		ru.FetchColIDtoRowIndex = ColIDtoRowIndexFromCols(ru.FetchCols)
 
-		maybeAddCol := func(colID ColumnID) error {
+		var maybeAddCol func(colID ColumnID) error
+		maybeAddCol = func(colID ColumnID) error {
 			if _, ok := ru.FetchColIDtoRowIndex[colID]; !ok {
 				col, err := tableDesc.FindColumnByID(colID)
 				if err != nil {
@@ -668,6 +738,9 @@ func makeRowUpdaterWithoutCascader(
 				}
 				ru.FetchColIDtoRowIndex[col.ID] = len(ru.FetchCols)
 				ru.FetchCols = append(ru.FetchCols, *col)
+				// The value of a virtual column is computed from the columns it
+				// references, so these must be fetched too.
+				return forEachVirtualColumnDep(tableDesc, col, maybeAddCol)
 			}
 			return nil
 		}
@@ -692,13 +765,21 @@ func makeRowUpdaterWithoutCascader(
 				}
 			}
 		}
-		for _, index := range indexes {
-			if err := index.RunOverAllColumns(maybeAddCol); err != nil {
+		for i := range indexes {
+			if err := indexes[i].RunOverAllColumns(maybeAddCol); err != nil {
+				return RowUpdater{}, err
+			}
+			if err := forEachPredicateColumn(tableDesc, &indexes[i], maybeAddCol); err != nil {
 				return RowUpdater{}, err
 			}
 		}
-		for _, index := range deleteOnlyIndexes {
-			if err := index.RunOverAllColumns(maybeAddCol); err != nil {
+		for i := range deleteOnlyIndexes {
+			if err := deleteOnlyIndexes[i].RunOverAllColumns(maybeAddCol); err != nil {
+				return RowUpdater{}, err
+			}
+			if err := forEachPredicateColumn(
+				tableDesc, &deleteOnlyIndexes[i], maybeAddCol,
+			); err != nil {
 				return RowUpdater{}, err
 			}
 		}
@@ -867,10 +948,18 @@ func (ru *RowUpdater) UpdateRow(
 		var expValue interface{}
 		if !bytes.Equal(newSecondaryIndexEntry.Key, oldSecondaryIndexEntry.Key) {
 			ru.Fks.addCheckForIndex(ru.Helper.Indexes[i].ID, ru.Helper.Indexes[i].Type)
-			if traceKV {
-				log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+			// The key of a partial index entry is nil when the row does not
+			// satisfy the predicate of the index, in which case the row
+			// enters or leaves the index.
+			if oldSecondaryIndexEntry.Key != nil {
+				if traceKV {
+					log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+				}
+				batch.Del(oldSecondaryIndexEntry.Key)
+			}
+			if newSecondaryIndexEntry.Key == nil {
+				continue
 			}
-			batch.Del(oldSecondaryIndexEntry.Key)
 		} else if !newSecondaryIndexEntry.Value.EqualData(oldSecondaryIndexEntry.Value) {
 			expValue = &oldSecondaryIndexEntry.Value
 		} else {
@@ -887,6 +976,9 @@ func (ru *RowUpdater) UpdateRow(
 	// indexed will be handled separately.
 	if ru.DeleteHelper != nil {
 		for _, deletedSecondaryIndexEntry := range deleteOldSecondaryIndexEntries {
+			if deletedSecondaryIndexEntry.Key == nil {
+				continue
+			}
 			if traceKV {
 				log.VEventf(ctx, 2, "Del %s", deletedSecondaryIndexEntry.Key)
 			}
@@ -896,6 +988,9 @@ func (ru *RowUpdater) UpdateRow(
 
 	// We're removing all of the inverted index entries from the row being updated.
 	for i := len(ru.Helper.Indexes); i < len(oldSecondaryIndexEntries); i++ {
+		if oldSecondaryIndexEntries[i].Key == nil {
+			continue
+		}
 		if traceKV {
 			log.VEventf(ctx, 2, "Del %s", oldSecondaryIndexEntries[i].Key)
 		}
@@ -905,6 +1000,9 @@ func (ru *RowUpdater) UpdateRow(
 	putFn := insertInvertedPutFn
 	// We're adding all of the inverted index entries from the row being updated.
 	for i := len(ru.Helper.Indexes); i < len(newSecondaryIndexEntries); i++ {
+		if newSecondaryIndexEntries[i].Key == nil {
+			continue
+		}
 		putFn(ctx, b, &newSecondaryIndexEntries[i].Key, &newSecondaryIndexEntries[i].Value, traceKV)
 	}
 
@@ -1016,7 +1114,8 @@ func makeRowDeleterWithoutCascader(
 	fetchCols := requestedCols[:len(requestedCols):len(requestedCols)]
 	fetchColIDtoRowIndex := ColIDtoRowIndexFromCols(fetchCols)
 
-	maybeAddCol := func(colID ColumnID) error {
+	var maybeAddCol func(colID ColumnID) error
+	maybeAddCol = func(colID ColumnID) error {
 		if _, ok := fetchColIDtoRowIndex[colID]; !ok {
 			col, err := tableDesc.FindColumnByID(colID)
 			if err != nil {
@@ -1024,6 +1123,9 @@ func makeRowDeleterWithoutCascader(
 			}
 			fetchColIDtoRowIndex[col.ID] = len(fetchCols)
 			fetchCols = append(fetchCols, *col)
+			// The value of a virtual column is computed from the columns it
+			// references, so these must be fetched too.
+			return forEachVirtualColumnDep(tableDesc, col, maybeAddCol)
 		}
 		return nil
 	}
@@ -1032,7 +1134,8 @@ func makeRowDeleterWithoutCascader(
 			return RowDeleter{}, err
 		}
 	}
-	for _, index := range indexes {
+	for i := range indexes {
+		index := &indexes[i]
 		for _, colID := range index.ColumnIDs {
 			if err := maybeAddCol(colID); err != nil {
 				return RowDeleter{}, err
@@ -1044,6 +1147,11 @@ func makeRowDeleterWithoutCascader(
 				return RowDeleter{}, err
 			}
 		}
+		// The predicate of a partial index determines whether the row has an
+		// entry to delete in it.
+		if err := forEachPredicateColumn(tableDesc, index, maybeAddCol); err != nil {
+			return RowDeleter{}, err
+		}
 	}
 
 	rd := RowDeleter{
@@ -1080,6 +1188,10 @@ func (rd *RowDeleter) DeleteRow(
 
 	// Delete the row from any secondary indices.
 	for i, secondaryIndexEntry := range secondaryIndexEntries {
+		if secondaryIndexEntry.Key == nil {
+			// The row does not satisfy the predicate of a partial index.
+			continue
+		}
 		if traceKV {
 			log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(rd.Helper.secIndexValDirs[i], secondaryIndexEntry.Key))
 		}
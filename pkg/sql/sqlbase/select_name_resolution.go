@@ -144,6 +144,12 @@ func (v *NameResolutionVisitor) VisitPre(expr tree.Expr) (recurse bool, newNode
 	case *tree.FuncExpr:
 		fd, err := t.Func.Resolve(v.searchPath)
 		if err != nil {
+			if tree.MaybeUserDefinedFunction(err) {
+				// User-defined functions are resolved during type checking.
+				// They may need repeated evaluation.
+				v.foundDependentVars = true
+				break
+			}
 			v.err = err
 			return false, expr
 		}
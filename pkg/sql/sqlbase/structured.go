@@ -204,7 +204,12 @@ func (desc *IndexDescriptor) RunOverAllColumns(fn func(id ColumnID) error) error
 func (desc *IndexDescriptor) allocateName(tableDesc *TableDescriptor) {
 	segments := make([]string, 0, len(desc.ColumnNames)+2)
 	segments = append(segments, tableDesc.Name)
-	segments = append(segments, desc.ColumnNames...)
+	for _, name := range desc.ColumnNames {
+		if col, _, err := tableDesc.FindColumnByName(tree.Name(name)); err == nil && col.IsIndexExpr() {
+			name = indexExprName(*col.ComputeExpr)
+		}
+		segments = append(segments, name)
+	}
 	if desc.Unique {
 		segments = append(segments, "key")
 	} else {
@@ -225,11 +230,29 @@ func (desc *IndexDescriptor) allocateName(tableDesc *TableDescriptor) {
 	desc.Name = name
 }
 
+// indexExprName returns the name used for an index expression in
+// automatically-generated index names. As in PostgreSQL, a function call
+// is named after the function, and any other expression is named "expr".
+func indexExprName(expr string) string {
+	if e, err := parser.ParseExpr(expr); err == nil {
+		if f, ok := e.(*tree.FuncExpr); ok {
+			if n, ok := f.Func.FunctionReference.(*tree.UnresolvedName); ok {
+				return n.Parts[0]
+			}
+		}
+	}
+	return "expr"
+}
+
 // FillColumns sets the column names and directions in desc.
 func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 	desc.ColumnNames = make([]string, 0, len(elems))
 	desc.ColumnDirections = make([]IndexDescriptor_Direction, 0, len(elems))
 	for _, c := range elems {
+		if c.Expr != nil {
+			return pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"index expressions are not supported here: %s", tree.ErrString(c.Expr))
+		}
 		desc.ColumnNames = append(desc.ColumnNames, string(c.Column))
 		switch c.Direction {
 		case tree.Ascending, tree.DefaultDirection:
@@ -311,8 +334,11 @@ func (desc *IndexDescriptor) ColNamesString() string {
 }
 
 // SQLString returns the SQL string describing this index. If non-empty,
-// "ON tableName" is included in the output in the correct place.
-func (desc *IndexDescriptor) SQLString(tableName *tree.TableName) string {
+// "ON tableName" is included in the output in the correct place. The index
+// expressions of tableDesc are shown as such.
+func (desc *IndexDescriptor) SQLString(
+	tableName *tree.TableName, tableDesc *TableDescriptor,
+) (string, error) {
 	f := tree.NewFmtCtxWithBuf(tree.FmtSimple)
 	if desc.Unique {
 		f.WriteString("UNIQUE ")
@@ -327,7 +353,11 @@ func (desc *IndexDescriptor) SQLString(tableName *tree.TableName) string {
 	}
 	f.FormatNameP(&desc.Name)
 	f.WriteString(" (")
-	desc.ColNamesFormat(f)
+	elems, err := tableDesc.IndexElems(desc)
+	if err != nil {
+		return "", err
+	}
+	f.FormatNode(&elems)
 	f.WriteByte(')')
 
 	if len(desc.StoreColumnNames) > 0 {
@@ -340,7 +370,7 @@ func (desc *IndexDescriptor) SQLString(tableName *tree.TableName) string {
 		}
 		f.WriteByte(')')
 	}
-	return f.CloseAndGetString()
+	return f.CloseAndGetString(), nil
 }
 
 // SetID implements the DescriptorProto interface.
@@ -382,6 +412,28 @@ func (desc *TableDescriptor) IsSequence() bool {
 	return desc.SequenceOpts != nil
 }
 
+// IsMaterializedView returns true if the TableDescriptor describes a
+// materialized view, whose contents are stored like the rows of a table.
+func (desc *TableDescriptor) IsMaterializedView() bool {
+	return desc.IsView() && desc.MaterializedView
+}
+
+// IsTemporary returns true if the TableDescriptor describes a temporary
+// table, which is only visible to the session that created it.
+func (desc *TableDescriptor) IsTemporary() bool {
+	return desc.TemporarySchemaID != 0
+}
+
+// GetNamespaceParentID returns the ID under which the name of the table is
+// stored in the namespace table: the ID of its temporary schema for a
+// temporary table, or the ID of its parent database otherwise.
+func (desc *TableDescriptor) GetNamespaceParentID() ID {
+	if desc.IsTemporary() {
+		return desc.TemporarySchemaID
+	}
+	return desc.ParentID
+}
+
 // IsVirtualTable returns true if the TableDescriptor describes a
 // virtual Table (like the information_schema tables) and thus doesn't
 // need to be physically stored.
@@ -393,10 +445,11 @@ func (desc *TableDescriptor) IsVirtualTable() bool {
 // physical Table that needs to be stored in the kv layer, as opposed to a
 // different resource like a view or a virtual table. Physical tables have
 // primary keys, column families, and indexes (unlike virtual tables).
-// Sequences count as physical tables because their values are stored in
-// the KV layer.
+// Sequences and materialized views count as physical tables because their
+// values are stored in the KV layer.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
-	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable())
+	return desc.IsSequence() || desc.IsMaterializedView() ||
+		(desc.IsTable() && !desc.IsVirtualTable())
 }
 
 // KeysPerRow returns the maximum number of keys used to encode a row for the
@@ -659,6 +712,12 @@ func MustBeValueEncoded(semanticType ColumnType_SemanticType) bool {
 		semanticType == ColumnType_TUPLE
 }
 
+// IsPartial returns whether the index is a partial index, i.e. it only
+// contains entries for the rows that satisfy its predicate.
+func (desc *IndexDescriptor) IsPartial() bool {
+	return desc.Predicate != ""
+}
+
 // HasOldStoredColumns returns whether the index has stored columns in the old
 // format (data encoded the same way as if they were in an implicit column).
 func (desc *IndexDescriptor) HasOldStoredColumns() bool {
@@ -804,6 +863,10 @@ func (desc *TableDescriptor) allocateColumnFamilyIDs(columnNames map[string]Colu
 		if _, ok := columnsInFamilies[col.ID]; ok {
 			return
 		}
+		if col.IsVirtual() {
+			// Virtual computed columns are not stored in the primary index.
+			return
+		}
 		if _, ok := primaryIndexColIDs[col.ID]; ok {
 			// Primary index columns are required to be assigned to family 0.
 			desc.Families[0].ColumnNames = append(desc.Families[0].ColumnNames, col.Name)
@@ -1153,8 +1216,20 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 				idx := desc.Index
 				return errors.Errorf("mutation in state %s, direction %s, index %s, id %v", m.State, m.Direction, idx.Name, idx.ID)
 			}
+		case *DescriptorMutation_Constraint:
+			if unSetEnums {
+				return errors.Errorf("mutation in state %s, direction %s, constraint %q", m.State, m.Direction, desc.Constraint.Name)
+			}
+		case *DescriptorMutation_MaterializedViewRefresh:
+			if unSetEnums {
+				return errors.Errorf("mutation in state %s, direction %s, materialized view refresh", m.State, m.Direction)
+			}
+			if r := desc.MaterializedViewRefresh; len(r.IndexIDs) != len(r.NewIndexIDs) {
+				return errors.Errorf("materialized view refresh with %d indexes and %d new indexes",
+					len(r.IndexIDs), len(r.NewIndexIDs))
+			}
 		default:
-			return errors.Errorf("mutation in state %s, direction %s, and no column/index descriptor", m.State, m.Direction)
+			return errors.Errorf("mutation in state %s, direction %s, and no column/index/constraint descriptor", m.State, m.Direction)
 		}
 	}
 
@@ -1175,6 +1250,24 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 		}
 	}
 
+	triggerNames := make(map[string]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if err := validateName(t.Name, "trigger"); err != nil {
+			return err
+		}
+		if _, ok := triggerNames[t.Name]; ok {
+			return fmt.Errorf("duplicate trigger name: %q", t.Name)
+		}
+		triggerNames[t.Name] = struct{}{}
+		if len(t.Events) == 0 {
+			return fmt.Errorf("trigger %q has no events", t.Name)
+		}
+		if t.FunctionID == 0 {
+			return fmt.Errorf("invalid function ID %d for trigger %q", t.FunctionID, t.Name)
+		}
+	}
+
 	// Fill in any incorrect privileges that may have been missed due to mixed-versions.
 	// TODO(mberhault): remove this in 2.1 (maybe 2.2) when privilege-fixing migrations have been
 	// run again and mixed-version clusters always write "good" descriptors.
@@ -1232,6 +1325,10 @@ func (desc *TableDescriptor) validateColumnFamilies(
 				return nil, fmt.Errorf("family %q column %d should have name %q, but found name %q",
 					family.Name, colID, name, family.ColumnNames[i])
 			}
+			if col, err := desc.FindColumnByID(colID); err == nil && col.IsVirtual() {
+				return nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+					"virtual computed column %q cannot be part of family %q", name, family.Name)
+			}
 		}
 
 		for _, colID := range family.ColumnIDs {
@@ -1243,6 +1340,9 @@ func (desc *TableDescriptor) validateColumnFamilies(
 	}
 	for colID := range columnIDs {
 		if _, ok := colIDToFamilyID[colID]; !ok {
+			if col, err := desc.FindColumnByID(colID); err == nil && col.IsVirtual() {
+				continue
+			}
 			return nil, fmt.Errorf("column %d is not in any column family", colID)
 		}
 	}
@@ -1323,6 +1423,10 @@ func (desc *TableDescriptor) validateTableIndexes(
 	}
 
 	for _, colID := range desc.PrimaryIndex.ColumnIDs {
+		if col, err := desc.FindColumnByID(colID); err == nil && col.IsVirtual() {
+			return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"virtual computed column %q cannot be part of the primary key", col.Name)
+		}
 		famID, ok := colIDToFamilyID[colID]
 		if !ok || famID != FamilyID(0) {
 			return fmt.Errorf("primary key column %d is not in column family 0", colID)
@@ -1561,9 +1665,16 @@ func columnTypeIsIndexable(t ColumnType) bool {
 }
 
 // columnTypeIsInvertedIndexable returns whether the type t is valid to be indexed
-// using an inverted index.
+// using an inverted index. Arrays are indexed by their elements, which must be
+// indexable.
 func columnTypeIsInvertedIndexable(t ColumnType) bool {
-	return t.SemanticType == ColumnType_JSONB
+	switch t.SemanticType {
+	case ColumnType_JSONB:
+		return true
+	case ColumnType_ARRAY:
+		return columnTypeIsIndexable(*t.elementColumnType())
+	}
+	return false
 }
 
 func notIndexableError(cols []ColumnDescriptor, inverted bool) error {
@@ -1888,6 +1999,33 @@ func (desc *TableDescriptor) FindFamilyByID(id FamilyID) (*ColumnFamilyDescripto
 	return nil, fmt.Errorf("family-id \"%d\" does not exist", id)
 }
 
+// IndexElems returns the columns and directions of the given index, as
+// they would appear in a CREATE INDEX statement. The hidden columns that
+// store index expressions are replaced by their expression.
+func (desc *TableDescriptor) IndexElems(idx *IndexDescriptor) (tree.IndexElemList, error) {
+	elems := make(tree.IndexElemList, len(idx.ColumnNames))
+	for i, name := range idx.ColumnNames {
+		elems[i].Column = tree.Name(name)
+		if col, _, err := desc.FindColumnByName(tree.Name(name)); err == nil && col.IsIndexExpr() {
+			expr, err := parser.ParseExpr(*col.ComputeExpr)
+			if err != nil {
+				return nil, err
+			}
+			elems[i].Expr = expr
+		}
+		if idx.Type == IndexDescriptor_INVERTED {
+			continue
+		}
+		switch idx.ColumnDirections[i] {
+		case IndexDescriptor_ASC:
+			elems[i].Direction = tree.Ascending
+		case IndexDescriptor_DESC:
+			elems[i].Direction = tree.Descending
+		}
+	}
+	return elems, nil
+}
+
 // FindIndexByName finds the index with the specified name in the active
 // list or the mutations list. It returns true if the index is being dropped.
 func (desc *TableDescriptor) FindIndexByName(name string) (IndexDescriptor, bool, error) {
@@ -1931,6 +2069,51 @@ func (desc *TableDescriptor) RenameIndexDescriptor(index IndexDescriptor, name s
 	return fmt.Errorf("index with id = %d does not exist", id)
 }
 
+// RenameConstraint renames a constraint. The detail must be a summary returned
+// by GetConstraintInfo with a non-nil transaction. dependentViewRenameError is
+// called to build the error returned when a view depends on the index backing
+// the constraint.
+func (desc *TableDescriptor) RenameConstraint(
+	detail ConstraintDetail,
+	oldName, newName string,
+	dependentViewRenameError func(typeName string, viewID ID) error,
+) error {
+	switch detail.Kind {
+	case ConstraintTypePK, ConstraintTypeUnique:
+		for _, tableRef := range desc.DependedOnBy {
+			if tableRef.IndexID != detail.Index.ID {
+				continue
+			}
+			return dependentViewRenameError("index", tableRef.ID)
+		}
+		return desc.RenameIndexDescriptor(*detail.Index, newName)
+
+	case ConstraintTypeFK:
+		idx, err := desc.FindIndexByID(detail.Index.ID)
+		if err != nil {
+			return err
+		}
+		if !idx.ForeignKey.IsSet() || idx.ForeignKey.Name != oldName {
+			return errors.Errorf("foreign key %q not found on index %q", oldName, idx.Name)
+		}
+		idx.ForeignKey.Name = newName
+		return nil
+
+	case ConstraintTypeCheck:
+		for _, ck := range desc.Checks {
+			if ck.Name == oldName {
+				ck.Name = newName
+				return nil
+			}
+		}
+		return errors.Errorf("check constraint %q not found", oldName)
+
+	default:
+		return pgerror.Unimplemented(fmt.Sprintf("rename-constraint-%s", detail.Kind),
+			"renaming %s constraints is not supported", detail.Kind)
+	}
+}
+
 // FindIndexByID finds an index (active or inactive) with the specified ID.
 // Must return a pointer to the IndexDescriptor in the TableDescriptor, so that
 // callers can use returned values to modify the TableDesc.
@@ -2013,12 +2196,41 @@ func (desc *TableDescriptor) MakeMutationComplete(m DescriptorMutation) {
 			if err := desc.AddIndex(*t.Index, false); err != nil {
 				panic(err)
 			}
+
+		case *DescriptorMutation_Constraint:
+			switch t.Constraint.ConstraintType {
+			case ConstraintToUpdate_NOT_NULL:
+				// The existing rows have been validated: the column can be
+				// marked as not nullable, which makes the check constraint
+				// enforcing the NOT NULL constraint in the meantime redundant.
+				desc.removeCheck(t.Constraint.Name)
+				for i := range desc.Columns {
+					if desc.Columns[i].ID == t.Constraint.NotNullColumn {
+						desc.Columns[i].Nullable = false
+					}
+				}
+			case ConstraintToUpdate_FOREIGN_KEY:
+				_ = desc.ForeachNonDropIndex(func(idx *IndexDescriptor) error {
+					if idx.ForeignKey.IsSet() && idx.ForeignKey.Name == t.Constraint.Name {
+						idx.ForeignKey.Validity = ConstraintValidity_Validated
+					}
+					return nil
+				})
+			}
+
+		case *DescriptorMutation_MaterializedViewRefresh:
+			desc.swapMaterializedViewIndexes(t.MaterializedViewRefresh)
 		}
 
 	case DescriptorMutation_DROP:
 		switch t := m.Descriptor_.(type) {
 		case *DescriptorMutation_Column:
 			desc.RemoveColumnFromFamily(t.Column.ID)
+
+		case *DescriptorMutation_Constraint:
+			if t.Constraint.ConstraintType == ConstraintToUpdate_NOT_NULL {
+				desc.removeCheck(t.Constraint.Name)
+			}
 		}
 		// Nothing else to be done. The column/index was already removed from the
 		// set of column/index descriptors at mutation creation time.
@@ -2033,6 +2245,100 @@ func (desc *TableDescriptor) AddColumnMutation(
 	desc.addMutation(m)
 }
 
+// AddNotNullMutation adds a mutation to desc.Mutations that adds a NOT NULL
+// constraint to the given column once its existing values are validated. The
+// constraint is enforced on new writes in the meantime through the given check
+// constraint, which is added to desc.Checks.
+func (desc *TableDescriptor) AddNotNullMutation(ck TableDescriptor_CheckConstraint, colID ColumnID) {
+	desc.Checks = append(desc.Checks, &ck)
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_Constraint{
+			Constraint: &ConstraintToUpdate{
+				ConstraintType: ConstraintToUpdate_NOT_NULL,
+				Name:           ck.Name,
+				NotNullColumn:  colID,
+			},
+		},
+		Direction: DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
+// MakeNotNullCheckConstraint returns the check constraint that enforces a NOT
+// NULL constraint on the given column while the constraint is being added. Its
+// name is chosen not to collide with any of the names in inuseNames.
+func MakeNotNullCheckConstraint(
+	colName string, colID ColumnID, inuseNames map[string]struct{},
+) TableDescriptor_CheckConstraint {
+	name := fmt.Sprintf("%s_auto_not_null", colName)
+	for i := 1; ; i++ {
+		if _, ok := inuseNames[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s_auto_not_null%d", colName, i)
+	}
+	return TableDescriptor_CheckConstraint{
+		Expr: tree.Serialize(&tree.ComparisonExpr{
+			Operator: tree.IsDistinctFrom,
+			Left:     &tree.ColumnItem{ColumnName: tree.Name(colName)},
+			Right:    tree.DNull,
+		}),
+		Name:                name,
+		Validity:            ConstraintValidity_Unvalidated,
+		ColumnIDs:           []ColumnID{colID},
+		IsNonNullConstraint: true,
+	}
+}
+
+// PendingNotNullMutation returns the mutation adding a NOT NULL constraint to
+// the given column, if there is one.
+func (desc *TableDescriptor) PendingNotNullMutation(colID ColumnID) *ConstraintToUpdate {
+	for _, m := range desc.Mutations {
+		if c := m.GetConstraint(); c != nil &&
+			c.ConstraintType == ConstraintToUpdate_NOT_NULL && c.NotNullColumn == colID {
+			return c
+		}
+	}
+	return nil
+}
+
+// AddForeignKeyValidationMutation adds a mutation to desc.Mutations that
+// validates the existing rows against the named foreign key, which is marked
+// as validated once they all satisfy it.
+func (desc *TableDescriptor) AddForeignKeyValidationMutation(name string) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_Constraint{
+			Constraint: &ConstraintToUpdate{
+				ConstraintType: ConstraintToUpdate_FOREIGN_KEY,
+				Name:           name,
+			},
+		},
+		Direction: DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
+// HasPendingConstraintMutation returns whether a mutation is adding or
+// validating the named constraint.
+func (desc *TableDescriptor) HasPendingConstraintMutation(name string) bool {
+	for _, m := range desc.Mutations {
+		if c := m.GetConstraint(); c != nil && c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCheck removes the check constraint with the given name, if it exists.
+func (desc *TableDescriptor) removeCheck(name string) {
+	for i, ck := range desc.Checks {
+		if ck.Name == name {
+			desc.Checks = append(desc.Checks[:i], desc.Checks[i+1:]...)
+			return
+		}
+	}
+}
+
 // AddIndexMutation adds an index mutation to desc.Mutations.
 func (desc *TableDescriptor) AddIndexMutation(
 	idx IndexDescriptor, direction DescriptorMutation_Direction,
@@ -2054,6 +2360,87 @@ func (desc *TableDescriptor) AddIndexMutation(
 	return nil
 }
 
+// AddMaterializedViewRefreshMutation adds a mutation to desc.Mutations that
+// refreshes the contents of a materialized view.
+func (desc *TableDescriptor) AddMaterializedViewRefreshMutation() {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_MaterializedViewRefresh{
+			MaterializedViewRefresh: &MaterializedViewRefresh{},
+		},
+		Direction: DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
+// StartMaterializedViewRefresh allocates the IDs of the new indexes populated
+// by the given refresh of a materialized view, one for each of its current
+// indexes.
+func (desc *TableDescriptor) StartMaterializedViewRefresh(r *MaterializedViewRefresh) {
+	r.IndexIDs, r.NewIndexIDs = nil, nil
+	add := func(id IndexID) {
+		r.IndexIDs = append(r.IndexIDs, id)
+		r.NewIndexIDs = append(r.NewIndexIDs, desc.NextIndexID)
+		desc.NextIndexID++
+	}
+	add(desc.PrimaryIndex.ID)
+	for i := range desc.Indexes {
+		add(desc.Indexes[i].ID)
+	}
+}
+
+// MaterializedViewRefreshTarget returns a copy of the descriptor of a
+// materialized view whose indexes are the new indexes populated by the given
+// refresh. It has no mutations, so that writes through it only reach the new
+// indexes.
+func (desc *TableDescriptor) MaterializedViewRefreshTarget(
+	r *MaterializedViewRefresh,
+) (*TableDescriptor, error) {
+	newIndexIDs := r.newIndexIDs()
+	target := *desc
+	target.Mutations = nil
+	newID, ok := newIndexIDs[desc.PrimaryIndex.ID]
+	if !ok {
+		return nil, errors.Errorf("no new primary index in refresh of materialized view %q", desc.Name)
+	}
+	target.PrimaryIndex.ID = newID
+	// Indexes dropped since the refresh started have been removed from the
+	// descriptor already, and indexes cannot be added until it completes.
+	target.Indexes = make([]IndexDescriptor, 0, len(desc.Indexes))
+	for _, idx := range desc.Indexes {
+		if newID, ok := newIndexIDs[idx.ID]; ok {
+			idx.ID = newID
+			target.Indexes = append(target.Indexes, idx)
+		}
+	}
+	return &target, nil
+}
+
+// swapMaterializedViewIndexes replaces the indexes of a materialized view
+// with the new indexes populated by the given refresh.
+func (desc *TableDescriptor) swapMaterializedViewIndexes(r *MaterializedViewRefresh) {
+	newIndexIDs := r.newIndexIDs()
+	swap := func(id *IndexID) {
+		if newID, ok := newIndexIDs[*id]; ok {
+			*id = newID
+		}
+	}
+	swap(&desc.PrimaryIndex.ID)
+	for i := range desc.Indexes {
+		swap(&desc.Indexes[i].ID)
+	}
+	for i := range desc.DependedOnBy {
+		swap(&desc.DependedOnBy[i].IndexID)
+	}
+}
+
+func (r *MaterializedViewRefresh) newIndexIDs() map[IndexID]IndexID {
+	newIndexIDs := make(map[IndexID]IndexID, len(r.IndexIDs))
+	for i, id := range r.IndexIDs {
+		newIndexIDs[id] = r.NewIndexIDs[i]
+	}
+	return newIndexIDs
+}
+
 func (desc *TableDescriptor) addMutation(m DescriptorMutation) {
 	switch m.Direction {
 	case DescriptorMutation_ADD:
@@ -2078,6 +2465,10 @@ func (desc *TableDescriptor) FinalizeMutation() (MutationID, error) {
 // ColumnNeedsBackfill returns true if adding the given column requires a
 // backfill (dropping a column always requires a backfill).
 func ColumnNeedsBackfill(desc *ColumnDescriptor) bool {
+	if desc.IsVirtual() {
+		// The values of virtual computed columns are not stored.
+		return false
+	}
 	return desc.DefaultExpr != nil || !desc.Nullable || desc.IsComputed()
 }
 
@@ -2115,6 +2506,145 @@ func (desc *TableDescriptor) HasDrainingNames() bool {
 	return len(desc.DrainingNames) > 0
 }
 
+// EnumColumnTypes returns the types of the columns of the table, including
+// the columns in mutations, that use the enum type with the given ID.
+func (desc *TableDescriptor) EnumColumnTypes(typeID ID) []*ColumnType {
+	var res []*ColumnType
+	maybeAdd := func(col *ColumnDescriptor) {
+		if col.Type.SemanticType == ColumnType_ENUM && col.Type.EnumTypeID == typeID {
+			res = append(res, &col.Type)
+		}
+	}
+	for i := range desc.Columns {
+		maybeAdd(&desc.Columns[i])
+	}
+	for i := range desc.Mutations {
+		if col := desc.Mutations[i].GetColumn(); col != nil {
+			maybeAdd(col)
+		}
+	}
+	return res
+}
+
+// EnumTypeIDs returns the IDs of the enum types used by the public columns
+// of the table and by the columns being added to it.
+func (desc *TableDescriptor) EnumTypeIDs() []ID {
+	var ids []ID
+	maybeAdd := func(col *ColumnDescriptor) {
+		if col.Type.SemanticType != ColumnType_ENUM {
+			return
+		}
+		for _, id := range ids {
+			if id == col.Type.EnumTypeID {
+				return
+			}
+		}
+		ids = append(ids, col.Type.EnumTypeID)
+	}
+	for i := range desc.Columns {
+		maybeAdd(&desc.Columns[i])
+	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil && m.Direction == DescriptorMutation_ADD {
+			maybeAdd(col)
+		}
+	}
+	return ids
+}
+
+// TriggerFunctionIDs returns the IDs of the functions called by the
+// triggers of the table.
+func (desc *TableDescriptor) TriggerFunctionIDs() []ID {
+	var ids []ID
+	for i := range desc.Triggers {
+		found := false
+		for _, id := range ids {
+			if id == desc.Triggers[i].FunctionID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, desc.Triggers[i].FunctionID)
+		}
+	}
+	return ids
+}
+
+// FindTriggerByName returns the index of the trigger with the given name,
+// or -1 if there is none.
+func (desc *TableDescriptor) FindTriggerByName(name string) int {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasTriggers returns true if the table has a trigger that fires on the
+// given event.
+func (desc *TableDescriptor) HasTriggers(event TriggerDescriptor_Event) bool {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].FiresOn(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// FiresOn returns true if the trigger fires on the given event.
+func (t *TriggerDescriptor) FiresOn(event TriggerDescriptor_Event) bool {
+	for _, e := range t.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// HasReadOnlyEnumMembers returns true if a column of the table has an enum
+// member that was added by ALTER TYPE and cannot be written yet.
+func (desc *TableDescriptor) HasReadOnlyEnumMembers() bool {
+	hasReadOnly := func(col *ColumnDescriptor) bool {
+		for _, m := range col.Type.EnumMembers {
+			if m.ReadOnly {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range desc.Columns {
+		if hasReadOnly(&desc.Columns[i]) {
+			return true
+		}
+	}
+	for i := range desc.Mutations {
+		if col := desc.Mutations[i].GetColumn(); col != nil && hasReadOnly(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// MakeEnumMembersWritable clears the read-only flag of the enum members of
+// all the columns of the table.
+func (desc *TableDescriptor) MakeEnumMembersWritable() {
+	makeWritable := func(col *ColumnDescriptor) {
+		for i := range col.Type.EnumMembers {
+			col.Type.EnumMembers[i].ReadOnly = false
+		}
+	}
+	for i := range desc.Columns {
+		makeWritable(&desc.Columns[i])
+	}
+	for i := range desc.Mutations {
+		if col := desc.Mutations[i].GetColumn(); col != nil {
+			makeWritable(col)
+		}
+	}
+}
+
 // VisibleColumns returns all non hidden columns.
 func (desc *TableDescriptor) VisibleColumns() []ColumnDescriptor {
 	var cols []ColumnDescriptor
@@ -2199,6 +2729,167 @@ func (desc *DatabaseDescriptor) Validate() error {
 	return desc.Privileges.Validate(desc.GetID())
 }
 
+// SetID implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *TypeDescriptor) TypeName() string {
+	return "type"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// Types are never audited.
+func (desc *TypeDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the type descriptor is well formed: the name
+// and IDs must be valid and the enum labels must be unique.
+func (desc *TypeDescriptor) Validate() error {
+	if err := validateName(desc.Name, "type"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid type ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d", desc.ParentID)
+	}
+	labels := make(map[string]struct{}, len(desc.EnumMembers))
+	for _, m := range desc.EnumMembers {
+		if _, ok := labels[m.Label]; ok {
+			return fmt.Errorf("duplicate enum label %q", m.Label)
+		}
+		labels[m.Label] = struct{}{}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// EnumType returns the datum type of the enum described by the descriptor.
+func (desc *TypeDescriptor) EnumType() *types.TEnum {
+	t := &types.TEnum{
+		TypeID:   uint32(desc.ID),
+		TypeName: desc.Name,
+		Labels:   make([]string, len(desc.EnumMembers)),
+		ReadOnly: make([]bool, len(desc.EnumMembers)),
+	}
+	for i := range desc.EnumMembers {
+		t.Labels[i] = desc.EnumMembers[i].Label
+		t.ReadOnly[i] = desc.EnumMembers[i].ReadOnly
+	}
+	return t
+}
+
+// SetID implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *FunctionDescriptor) TypeName() string {
+	return "function"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// Functions are never audited.
+func (desc *FunctionDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the function descriptor is well formed: the name
+// and IDs must be valid, and the overloads must have different signatures
+// and a body. Trigger overloads have no arguments.
+func (desc *FunctionDescriptor) Validate() error {
+	if err := validateName(desc.Name, "function"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid function ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d", desc.ParentID)
+	}
+	if len(desc.Overloads) == 0 {
+		return fmt.Errorf("function %q has no overloads", desc.Name)
+	}
+	for i := range desc.Overloads {
+		o := &desc.Overloads[i]
+		if o.Body == "" {
+			return fmt.Errorf("overload %s of function %q has no body", o.Signature(), desc.Name)
+		}
+		if o.ReturnsTrigger && len(o.Args) != 0 {
+			return fmt.Errorf("trigger overload %s of function %q has arguments", o.Signature(), desc.Name)
+		}
+		for j := 0; j < i; j++ {
+			if desc.Overloads[j].SameArgTypes(o.ArgTypes()) {
+				return fmt.Errorf("duplicate overload %s of function %q", o.Signature(), desc.Name)
+			}
+		}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// FindOverload returns the index of the overload of the function with the
+// given argument types, or -1 if there is none.
+func (desc *FunctionDescriptor) FindOverload(argTypes []types.T) int {
+	for i := range desc.Overloads {
+		if desc.Overloads[i].SameArgTypes(argTypes) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ArgTypes returns the datum types of the arguments of the overload.
+func (o *FunctionDescriptor_Overload) ArgTypes() []types.T {
+	res := make([]types.T, len(o.Args))
+	for i := range o.Args {
+		res[i] = o.Args[i].Type.ToDatumType()
+	}
+	return res
+}
+
+// SameArgTypes returns true if the overload has arguments of the given
+// types.
+func (o *FunctionDescriptor_Overload) SameArgTypes(argTypes []types.T) bool {
+	if len(o.Args) != len(argTypes) {
+		return false
+	}
+	for i, t := range o.ArgTypes() {
+		if !t.Equivalent(argTypes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Signature returns the argument types of the overload formatted as in
+// "(INT8, STRING)".
+func (o *FunctionDescriptor_Overload) Signature() string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i := range o.Args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(o.Args[i].Type.SQLString())
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
 // GetID returns the ID of the descriptor.
 func (desc *Descriptor) GetID() ID {
 	switch t := desc.Union.(type) {
@@ -2206,6 +2897,10 @@ func (desc *Descriptor) GetID() ID {
 		return t.Table.ID
 	case *Descriptor_Database:
 		return t.Database.ID
+	case *Descriptor_Type:
+		return t.Type.ID
+	case *Descriptor_Function:
+		return t.Function.ID
 	default:
 		return 0
 	}
@@ -2218,6 +2913,10 @@ func (desc *Descriptor) GetName() string {
 		return t.Table.Name
 	case *Descriptor_Database:
 		return t.Database.Name
+	case *Descriptor_Type:
+		return t.Type.Name
+	case *Descriptor_Function:
+		return t.Function.Name
 	default:
 		return ""
 	}
@@ -2281,7 +2980,7 @@ func (desc TableDescriptor) GetDescMetadataKey() roachpb.Key {
 
 // GetNameMetadataKey returns the namespace key for the table.
 func (desc TableDescriptor) GetNameMetadataKey() roachpb.Key {
-	return MakeNameMetadataKey(desc.ParentID, desc.Name)
+	return MakeNameMetadataKey(desc.GetNamespaceParentID(), desc.Name)
 }
 
 // SQLString returns the SQL statement describing the column.
@@ -2302,7 +3001,11 @@ func (desc *ColumnDescriptor) SQLString() string {
 	if desc.IsComputed() {
 		f.WriteString(" AS (")
 		f.WriteString(*desc.ComputeExpr)
-		f.WriteString(") STORED")
+		if desc.Virtual {
+			f.WriteString(") VIRTUAL")
+		} else {
+			f.WriteString(") STORED")
+		}
 	}
 	return f.CloseAndGetString()
 }
@@ -2380,6 +3083,15 @@ var ForeignKeyReferenceActionValue = [...]ForeignKeyReference_Action{
 	tree.Cascade:    ForeignKeyReference_CASCADE,
 }
 
+// ForeignKeyReferenceMatchValue allows the conversion between a
+// tree.CompositeKeyMatchMethod and a ForeignKeyReference_Match.
+var ForeignKeyReferenceMatchValue = [...]ForeignKeyReference_Match{
+	tree.MatchUnspecified: ForeignKeyReference_UNSPECIFIED,
+	tree.MatchSimple:      ForeignKeyReference_SIMPLE,
+	tree.MatchFull:        ForeignKeyReference_FULL,
+	tree.MatchPartial:     ForeignKeyReference_PARTIAL,
+}
+
 var _ opt.Column = &ColumnDescriptor{}
 
 // IsNullable is part of the opt.Column interface.
@@ -2407,6 +3119,62 @@ func (desc *ColumnDescriptor) IsComputed() bool {
 	return desc.ComputeExpr != nil
 }
 
+// ComputedExprStr is part of the opt.Column interface.
+func (desc *ColumnDescriptor) ComputedExprStr() string {
+	if desc.ComputeExpr == nil {
+		return ""
+	}
+	return *desc.ComputeExpr
+}
+
+// IsIndexExpr returns whether the given column is a hidden computed column
+// that stores the result of an index expression.
+func (desc *ColumnDescriptor) IsIndexExpr() bool {
+	return desc.Hidden && desc.IsComputed()
+}
+
+// IsVirtual returns whether the column is a computed column that is not
+// stored in the primary index.
+func (desc *ColumnDescriptor) IsVirtual() bool {
+	return desc.Virtual && desc.IsComputed()
+}
+
+// ColumnsUsed returns the IDs of the columns referenced by the expression of
+// a computed column.
+func (desc *ColumnDescriptor) ColumnsUsed(table *TableDescriptor) ([]ColumnID, error) {
+	if !desc.IsComputed() {
+		return nil, nil
+	}
+	parsed, err := parser.ParseExpr(*desc.ComputeExpr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse computed column expression %s", *desc.ComputeExpr)
+	}
+
+	var colIDs []ColumnID
+	visitFn := func(expr tree.Expr) (err error, recurse bool, newExpr tree.Expr) {
+		if vBase, ok := expr.(tree.VarName); ok {
+			v, err := vBase.NormalizeVarName()
+			if err != nil {
+				return err, false, nil
+			}
+			if c, ok := v.(*tree.ColumnItem); ok {
+				col, err := table.FindActiveColumnByName(string(c.ColumnName))
+				if err != nil {
+					return errors.Errorf("column %q not found for computed column %q",
+						c.ColumnName, desc.Name), false, nil
+				}
+				colIDs = append(colIDs, col.ID)
+			}
+			return nil, false, v
+		}
+		return nil, true, expr
+	}
+	if _, err := tree.SimpleVisit(parsed, visitFn); err != nil {
+		return nil, err
+	}
+	return colIDs, nil
+}
+
 // CheckCanBeFKRef returns whether the given column is computed.
 func (desc *ColumnDescriptor) CheckCanBeFKRef() error {
 	if desc.IsComputed() {
@@ -2492,6 +3260,9 @@ func (desc *TableDescriptor) FindAllReferences() (map[ID]struct{}, error) {
 		for _, id := range c.UsesSequenceIds {
 			refs[id] = struct{}{}
 		}
+		for _, id := range c.OwnsSequenceIds {
+			refs[id] = struct{}{}
+		}
 	}
 
 	for _, dest := range desc.DependsOn {
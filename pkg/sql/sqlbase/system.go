@@ -184,6 +184,19 @@ CREATE TABLE system.role_members (
   INDEX ("role"),
   INDEX ("member")
 );`
+
+	// notifications holds the notifications sent with NOTIFY until they are
+	// delivered to the listening sessions on every node.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	"createdAt" TIMESTAMP NOT NULL DEFAULT now(),
+	id          INT       NOT NULL DEFAULT unique_rowid(),
+	channel     STRING    NOT NULL,
+	payload     STRING    NOT NULL,
+	"nodeID"    INT       NOT NULL,
+	PRIMARY KEY ("createdAt", id),
+	FAMILY ("createdAt", id, channel, payload, "nodeID")
+);`
 )
 
 func pk(name string) IndexDescriptor {
@@ -223,6 +236,7 @@ var SystemAllowedPrivileges = map[ID]privilege.List{
 	keys.TableStatisticsTableID: privilege.ReadWriteData,
 	keys.LocationsTableID:       privilege.ReadWriteData,
 	keys.RoleMembersTableID:     privilege.ReadWriteData,
+	keys.NotificationsTableID:   privilege.ReadWriteData,
 }
 
 // Helpers used to make some of the TableDescriptor literals below more concise.
@@ -792,6 +806,43 @@ var (
 		FormatVersion:  InterleavedFormatVersion,
 		NextMutationID: 1,
 	}
+
+	// NotificationsTable is the descriptor for the notifications table.
+	NotificationsTable = TableDescriptor{
+		Name:     "notifications",
+		ID:       keys.NotificationsTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "createdAt", ID: 1, Type: colTypeTimestamp, DefaultExpr: &nowString},
+			{Name: "id", ID: 2, Type: colTypeInt, DefaultExpr: &uniqueRowIDString},
+			{Name: "channel", ID: 3, Type: colTypeString},
+			{Name: "payload", ID: 4, Type: colTypeString},
+			{Name: "nodeID", ID: 5, Type: colTypeInt},
+		},
+		NextColumnID: 6,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "fam_0_createdAt_id_channel_payload_nodeID",
+				ID:          0,
+				ColumnNames: []string{"createdAt", "id", "channel", "payload", "nodeID"},
+				ColumnIDs:   []ColumnID{1, 2, 3, 4, 5},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"createdAt", "id"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2},
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemAllowedPrivileges[keys.NotificationsTableID]),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
 )
 
 // Create a kv pair for the zone config for the given key and config value.
@@ -48,8 +48,10 @@ func SanitizeVarFreeExpr(
 	// which uses the properties field.
 	defer semaCtx.Properties.Restore(semaCtx.Properties)
 
-	// Ensure that the expression doesn't contain special functions.
-	flags := tree.RejectSpecial
+	// Ensure that the expression doesn't contain special functions. The
+	// expression may be stored, and user-defined functions can only be
+	// resolved by a planner.
+	flags := tree.RejectSpecial | tree.RejectUserDefinedFunctions
 	if !allowImpure {
 		flags |= tree.RejectImpureFunctions
 	}
@@ -106,6 +108,25 @@ func MakeColumnDefDescs(
 		return nil, nil, nil, errors.New("unexpected column REFERENCED constraint")
 	}
 
+	if d.IsVirtual() {
+		// Virtual computed columns are not stored in the primary index, so
+		// they can't be part of it or of a column family. Since their values
+		// are not validated when they are added, they can't be NOT NULL
+		// either.
+		if d.PrimaryKey {
+			return nil, nil, nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"virtual computed column %q cannot be part of the primary key", string(d.Name))
+		}
+		if d.Nullable.Nullability == tree.NotNull {
+			return nil, nil, nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"virtual computed column %q cannot be NOT NULL", string(d.Name))
+		}
+		if d.HasColumnFamily() {
+			return nil, nil, nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"virtual computed column %q cannot be part of a column family", string(d.Name))
+		}
+	}
+
 	col := &ColumnDescriptor{
 		Name:     string(d.Name),
 		Nullable: d.Nullable.Nullability != tree.NotNull && !d.PrimaryKey,
@@ -143,6 +164,7 @@ func MakeColumnDefDescs(
 	if d.IsComputed() {
 		s := tree.Serialize(d.Computed.Expr)
 		col.ComputeExpr = &s
+		col.Virtual = d.Computed.Virtual
 	}
 
 	var idx *IndexDescriptor
@@ -349,6 +371,10 @@ func (desc *TableDescriptor) collectConstraintInfo(
 	}
 
 	for _, c := range desc.Checks {
+		if c.IsNonNullConstraint {
+			// NOT NULL constraints being added are not visible to users.
+			continue
+		}
 		if _, ok := info[c.Name]; ok {
 			return nil, errors.Errorf("duplicate constraint name: %q", c.Name)
 		}
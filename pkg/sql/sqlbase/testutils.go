@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -100,6 +101,8 @@ func RandDatum(rng *rand.Rand, typ ColumnType, nullOk bool) tree.Datum {
 		return tree.NewDDate(tree.DDate(rng.Intn(10000)))
 	case ColumnType_TIME:
 		return tree.MakeDTime(timeofday.Random(rng))
+	case ColumnType_TIMETZ:
+		return tree.NewDTimeTZ(timetz.Random(rng))
 	case ColumnType_TIMESTAMP:
 		return &tree.DTimestamp{Time: timeutil.Unix(rng.Int63n(1000000), rng.Int63n(1000000))}
 	case ColumnType_INTERVAL:
@@ -174,6 +177,11 @@ func RandDatum(rng *rand.Rand, typ ColumnType, nullOk bool) tree.Datum {
 	case ColumnType_OID:
 		// int64(rng.Uint64()) to get negative numbers, too
 		return tree.NewDOid(tree.DInt(int64(rng.Uint64())))
+	case ColumnType_ENUM:
+		if len(typ.EnumMembers) == 0 {
+			return tree.DNull
+		}
+		return tree.NewDEnum(typ.enumType(), rng.Intn(len(typ.EnumMembers)))
 	case ColumnType_NULL:
 		return tree.DNull
 	case ColumnType_ARRAY:
@@ -197,7 +205,7 @@ var (
 func init() {
 	for k := range ColumnType_SemanticType_name {
 		columnSemanticTypes = append(columnSemanticTypes, ColumnType_SemanticType(k))
-		if ColumnType_SemanticType(k) != ColumnType_ARRAY {
+		if k := ColumnType_SemanticType(k); k != ColumnType_ARRAY && k != ColumnType_ENUM {
 			arrayElemSemanticTypes = append(arrayElemSemanticTypes, ColumnType_SemanticType(k))
 		}
 	}
@@ -225,6 +233,15 @@ func RandColumnType(rng *rand.Rand) ColumnType {
 			typ.ArrayContents = &s
 		}
 	}
+	if typ.SemanticType == ColumnType_ENUM {
+		// Generate enums with between 1 and 5 members.
+		typ.EnumTypeID = ID(1 + rng.Intn(100))
+		typ.EnumTypeName = fmt.Sprintf("enum%d", typ.EnumTypeID)
+		typ.EnumMembers = make([]EnumMember, 1+rng.Intn(5))
+		for i := range typ.EnumMembers {
+			typ.EnumMembers[i].Label = fmt.Sprintf("v%d", i)
+		}
+	}
 	if typ.SemanticType == ColumnType_TUPLE {
 		// Generate tuples between 0 and 4 datums in length
 		len := rng.Intn(5)
@@ -93,7 +93,7 @@ func GetKeysForTableDescriptor(
 	tableDesc *sqlbase.TableDescriptor,
 ) (zoneKey roachpb.Key, nameKey roachpb.Key, descKey roachpb.Key) {
 	zoneKey = config.MakeZoneKey(uint32(tableDesc.ID))
-	nameKey = sqlbase.MakeNameMetadataKey(tableDesc.ParentID, tableDesc.GetName())
+	nameKey = sqlbase.MakeNameMetadataKey(tableDesc.GetNamespaceParentID(), tableDesc.GetName())
 	descKey = sqlbase.MakeDescMetadataKey(tableDesc.ID)
 	return
 }
@@ -171,12 +171,29 @@ type TableCollection struct {
 	// an uncommitted transaction.
 	uncommittedDatabases []uncommittedDatabase
 
+	// uncommittedFunctions are the namespace keys of the user-defined
+	// functions created, modified or dropped within the transaction. They
+	// are not looked up in the database cache.
+	uncommittedFunctions []tableKey
+
 	// allDescriptors is a slice of all available descriptors. The descriptors
 	// are cached to avoid repeated lookups by users like virtual tables. The
 	// cache is purged whenever events would cause a scan of all descriptors to
 	// return different values, such as when the txn timestamp changes or when
 	// new descriptors are written in the txn.
 	allDescriptors []sqlbase.DescriptorProto
+
+	// temporarySessionID and temporarySchemaName are the ID and the name of
+	// the temporary schema of the session using this collection, if any. See
+	// temporary_schema.go.
+	temporarySessionID  ClusterWideID
+	temporarySchemaName string
+
+	// temporarySchemaIDs maps database IDs to the IDs of the temporary
+	// schema of the session in these databases. The IDs are allocated when
+	// the first temporary table is created in a database, and are kept for
+	// the lifetime of the session.
+	temporarySchemaIDs map[sqlbase.ID]sqlbase.ID
 }
 
 type dbCacheSubscriber interface {
@@ -209,17 +226,25 @@ func (tc *TableCollection) getTableVersion(
 		log.Infof(ctx, "planner acquiring lease on table '%s'", tn)
 	}
 
-	if tn.SchemaName != tree.PublicSchemaName {
+	isTemporary := tc.isTemporarySchema(tn.Schema())
+	if tn.SchemaName != tree.PublicSchemaName && !isTemporary {
 		if flags.required {
 			return nil, nil, sqlbase.NewUnsupportedSchemaUsageError(tree.ErrString(tn))
 		}
 		return nil, nil, nil
 	}
+	if isTemporary && len(tc.temporarySchemaIDs) == 0 {
+		// Fast path: the session has no temporary tables.
+		if flags.required {
+			return nil, nil, sqlbase.NewUndefinedRelationError(tn)
+		}
+		return nil, nil, nil
+	}
 
 	// We don't go through the normal lease mechanism for system tables
 	// that are not the role members table.
-	if flags.avoidCached || testDisableTableLeases || (tn.Catalog() == sqlbase.SystemDB.Name &&
-		tn.TableName.String() != sqlbase.RoleMembersTable.Name) {
+	if !isTemporary && (flags.avoidCached || testDisableTableLeases ||
+		(tn.Catalog() == sqlbase.SystemDB.Name && tn.TableName.String() != sqlbase.RoleMembersTable.Name)) {
 		// TODO(vivek): Ideally we'd avoid caching for only the
 		// system.descriptor and system.lease tables, because they are
 		// used for acquiring leases, creating a chicken&egg problem.
@@ -248,21 +273,38 @@ func (tc *TableCollection) getTableVersion(
 		}
 	}
 
+	// The names of temporary tables are stored under the temporary schema
+	// of the session rather than under the database.
+	parentID := dbID
+	if isTemporary {
+		if parentID = tc.temporarySchemaIDs[dbID]; parentID == 0 {
+			if flags.required {
+				return nil, nil, sqlbase.NewUndefinedRelationError(tn)
+			}
+			return nil, nil, nil
+		}
+	}
+
 	if refuseFurtherLookup, table, err := tc.getUncommittedTable(
-		dbID, tn, flags.required); refuseFurtherLookup || err != nil {
+		parentID, tn, flags.required); refuseFurtherLookup || err != nil {
 		return nil, nil, err
 	} else if table != nil {
 		log.VEventf(ctx, 2, "found uncommitted table %d", table.ID)
 		return table, nil, nil
 	}
 
+	if isTemporary {
+		table, err := getTemporaryTableDesc(ctx, flags.txn, parentID, tn, flags.required)
+		return table, nil, err
+	}
+
 	// First, look to see if we already have the table.
 	// This ensures that, once a SQL transaction resolved name N to id X, it will
 	// continue to use N to refer to X even if N is renamed during the
 	// transaction.
 	for _, table := range tc.leasedTables {
 		if table.Name == string(tn.TableName) &&
-			table.ParentID == dbID {
+			table.GetNamespaceParentID() == dbID {
 			log.VEventf(ctx, 2, "found table in table collection for table '%s'", tn)
 			return table, nil, nil
 		}
@@ -383,6 +425,7 @@ func (tc *TableCollection) releaseTables(ctx context.Context) {
 	tc.uncommittedTables = nil
 	tc.createdTables = nil
 	tc.uncommittedDatabases = nil
+	tc.uncommittedFunctions = nil
 	tc.releaseAllDescriptors()
 }
 
@@ -505,16 +548,60 @@ func (tc *TableCollection) getUncommittedDatabaseID(
 	return false, 0, nil
 }
 
+// addUncommittedFunction records that the function with the given
+// descriptor was created, modified or dropped within the transaction.
+func (tc *TableCollection) addUncommittedFunction(desc *sqlbase.FunctionDescriptor) {
+	tc.uncommittedFunctions = append(tc.uncommittedFunctions,
+		tableKey{parentID: desc.ParentID, name: desc.Name})
+	tc.releaseAllDescriptors()
+}
+
+// getFunctionDesc looks up the descriptor of the user-defined function with
+// the given name in the database with the given ID. Like databases, functions
+// are not leased: they are looked up in the database cache, unless avoidCached
+// is set or the function was changed within the transaction, and in the KV
+// store if they are not found there. Returns nil if the function does not
+// exist.
+func (tc *TableCollection) getFunctionDesc(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, name string, avoidCached bool,
+) (*sqlbase.FunctionDescriptor, error) {
+	key := tableKey{parentID: dbID, name: name}
+	if !(avoidCached || testDisableTableLeases || tc.isUncommittedFunction(key)) {
+		desc, err := tc.databaseCache.getCachedFunctionDesc(key)
+		if err != nil || desc != nil {
+			return desc, err
+		}
+	}
+
+	desc := &sqlbase.FunctionDescriptor{}
+	found, err := getDescriptor(ctx, txn, key, desc)
+	if err != nil || !found {
+		return nil, err
+	}
+	return desc, nil
+}
+
+func (tc *TableCollection) isUncommittedFunction(key tableKey) bool {
+	for _, k := range tc.uncommittedFunctions {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // getUncommittedTable returns a table for the requested tablename
 // if the requested tablename is for a table modified within the transaction
-// affiliated with the LeaseCollection.
+// affiliated with the LeaseCollection. The parentID is the ID under which
+// the table name is stored in the namespace table; see
+// TableDescriptor.GetNamespaceParentID().
 //
 // The first return value "refuseFurtherLookup" is true when there is
 // a known deletion of that table, so it would be invalid to miss the
 // cache and go to KV (where the descriptor prior to the DROP may
 // still exist).
 func (tc *TableCollection) getUncommittedTable(
-	dbID sqlbase.ID, tn *tree.TableName, required bool,
+	parentID sqlbase.ID, tn *tree.TableName, required bool,
 ) (refuseFurtherLookup bool, table *sqlbase.TableDescriptor, err error) {
 	// Walk latest to earliest so that a DROP TABLE followed by a CREATE TABLE
 	// with the same name will result in the CREATE TABLE being seen.
@@ -527,7 +614,7 @@ func (tc *TableCollection) getUncommittedTable(
 		// effect of it.
 		for _, drain := range table.DrainingNames {
 			if drain.Name == string(tn.TableName) &&
-				drain.ParentID == dbID {
+				drain.ParentID == parentID {
 				// Table name has gone away.
 				if required {
 					// If it's required here, say it doesn't exist.
@@ -541,7 +628,7 @@ func (tc *TableCollection) getUncommittedTable(
 
 		// Do we know about a table with this name?
 		if table.Name == string(tn.TableName) &&
-			table.ParentID == dbID {
+			table.GetNamespaceParentID() == parentID {
 			// Can we see this table?
 			if err = filterTableState(table); err != nil {
 				if !required {
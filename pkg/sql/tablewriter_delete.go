@@ -35,14 +35,19 @@ type tableDeleter struct {
 
 	rd    sqlbase.RowDeleter
 	alloc *sqlbase.DatumAlloc
+
+	// evalCtx is used to compute virtual computed columns when rows are
+	// scanned before being deleted.
+	evalCtx *tree.EvalContext
 }
 
 // walkExprs is part of the tableWriter interface.
 func (td *tableDeleter) walkExprs(_ func(desc string, index int, expr tree.TypedExpr)) {}
 
 // init is part of the tableWriter interface.
-func (td *tableDeleter) init(txn *client.Txn, _ *tree.EvalContext) error {
+func (td *tableDeleter) init(txn *client.Txn, evalCtx *tree.EvalContext) error {
 	td.tableWriterBase.init(txn)
+	td.evalCtx = evalCtx
 	return nil
 }
 
@@ -211,7 +216,8 @@ func (td *tableDeleter) deleteAllRowsScan(
 		ValNeededForCol: valNeededForCol,
 	}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, tableArgs,
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, td.evalCtx,
+		tableArgs,
 	); err != nil {
 		return resume, err
 	}
@@ -322,7 +328,8 @@ func (td *tableDeleter) deleteIndexScan(
 		ValNeededForCol: valNeededForCol,
 	}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, tableArgs,
+		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, td.alloc, td.evalCtx,
+		tableArgs,
 	); err != nil {
 		return resume, err
 	}
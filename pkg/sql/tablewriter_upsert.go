@@ -263,6 +263,7 @@ type tableUpserter struct {
 	fetchCols             []sqlbase.ColumnDescriptor
 	fetchColIDtoRowIndex  map[sqlbase.ColumnID]int
 	fetcher               sqlbase.RowFetcher
+	partialIndexes        sqlbase.PartialIndexPredicates
 }
 
 // init is part of the tableWriter interface.
@@ -277,6 +278,7 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 	}
 
 	tableDesc := tu.tableDesc()
+	tu.partialIndexes = sqlbase.MakePartialIndexPredicates(tableDesc)
 
 	requestedCols := tableDesc.Columns
 
@@ -324,7 +326,8 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 	}
 
 	return tu.fetcher.Init(
-		false /* reverse */, false /*returnRangeInfo*/, false /* isCheck */, tu.alloc, tableArgs,
+		false /* reverse */, false /*returnRangeInfo*/, false /* isCheck */, tu.alloc, tu.evalCtx,
+		tableArgs,
 	)
 }
 
@@ -757,8 +760,20 @@ func (tu *tableUpserter) upsertRowPKs(
 	// case, some spots in the slice will be nil (indicating no conflict) and the
 	// others will be conflicting rows.
 	b := tu.txn.NewBatch()
+	// resultRows contains, for each lookup in the batch, the position of the
+	// insert row that it was issued for.
+	var resultRows []int
 	for i := 0; i < tu.insertRows.Len(); i++ {
 		insertRow := tu.insertRows.At(i)
+		// A row that does not satisfy the predicate of a partial conflict index
+		// cannot conflict on it.
+		if ok, err := tu.partialIndexes.Satisfies(
+			&tu.conflictIndex, tu.ri.InsertColIDtoRowIndex, insertRow,
+		); err != nil {
+			return nil, nil, err
+		} else if !ok {
+			continue
+		}
 		entries, err := sqlbase.EncodeSecondaryIndex(
 			tableDesc, &tu.conflictIndex, tu.ri.InsertColIDtoRowIndex, insertRow)
 		if err != nil {
@@ -770,6 +785,7 @@ func (tu *tableUpserter) upsertRowPKs(
 				log.VEventf(ctx, 2, "Get %s", entry.Key)
 			}
 			b.Get(entry.Key)
+			resultRows = append(resultRows, i)
 		}
 	}
 
@@ -777,7 +793,8 @@ func (tu *tableUpserter) upsertRowPKs(
 		return nil, nil, err
 	}
 	conflictingPKs := make(map[int]roachpb.Key)
-	for i, result := range b.Results {
+	for j, result := range b.Results {
+		i := resultRows[j]
 		if len(result.Rows) == 1 {
 			if result.Rows[0].Value != nil {
 				upsertRowPK, err := sqlbase.ExtractIndexKey(tu.alloc, tableDesc, result.Rows[0])
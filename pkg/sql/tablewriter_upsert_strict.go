@@ -35,6 +35,9 @@ type strictTableUpserter struct {
 
 	// internal state
 	conflictIndexes []sqlbase.IndexDescriptor
+	// partialIndexes determines which rows can conflict on the partial
+	// indexes among conflictIndexes.
+	partialIndexes sqlbase.PartialIndexPredicates
 }
 
 func (tu *strictTableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error {
@@ -100,6 +103,7 @@ func (tu *strictTableUpserter) getUniqueIndexes() (err error) {
 			tu.conflictIndexes = append(tu.conflictIndexes, index)
 		}
 	}
+	tu.partialIndexes = sqlbase.MakePartialIndexPredicates(tableDesc)
 	return nil
 }
 
@@ -117,6 +121,9 @@ func (tu *strictTableUpserter) getConflictingRows(
 	seenKeys := make(map[string]struct{})
 
 	b := tu.txn.NewBatch()
+	// resultRows contains, for each lookup in the batch, the position of the
+	// insert row that it was issued for.
+	var resultRows []int
 
 	for i := 0; i < tu.insertRows.Len(); i++ {
 		row := tu.insertRows.At(i)
@@ -137,6 +144,7 @@ func (tu *strictTableUpserter) getConflictingRows(
 		}
 
 		b.Get(roachpb.Key(upsertRowPK))
+		resultRows = append(resultRows, i)
 		seenKeys[string(upsertRowPK)] = struct{}{}
 
 		// Otherwise, check the primary key against the table.
@@ -144,9 +152,19 @@ func (tu *strictTableUpserter) getConflictingRows(
 		// For each secondary index that has a unique constraint, do something similar:
 		// check if the secondary index key has already been seen among the insert rows,
 		// and if not, mark the key to be checked against the table.
-		for _, idx := range tu.conflictIndexes {
+		for j := range tu.conflictIndexes {
+			idx := &tu.conflictIndexes[j]
+			// A row that does not satisfy the predicate of a partial index
+			// cannot conflict on it.
+			if ok, err := tu.partialIndexes.Satisfies(
+				idx, tu.ri.InsertColIDtoRowIndex, row,
+			); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
 			entries, err := sqlbase.EncodeSecondaryIndex(
-				tableDesc, &idx, tu.ri.InsertColIDtoRowIndex, row)
+				tableDesc, idx, tu.ri.InsertColIDtoRowIndex, row)
 			if err != nil {
 				return nil, err
 			}
@@ -156,6 +174,7 @@ func (tu *strictTableUpserter) getConflictingRows(
 					conflictingRows[i] = struct{}{}
 				}
 				b.Get(entry.Key)
+				resultRows = append(resultRows, i)
 				seenKeys[string(entry.Key)] = struct{}{}
 			}
 		}
@@ -166,12 +185,8 @@ func (tu *strictTableUpserter) getConflictingRows(
 		return nil, err
 	}
 
-	numIndexes := 1 + len(tu.conflictIndexes)
 	for i, result := range b.Results {
-		// There are (1 + len(tu.conflictIndexes)) * len(tu.InsertRows) results.
-		// All the results that correspond to a particular insertRow are next to each other.
-		// By this logic, the division computes the insertRow that is being queried for.
-		insertRowIndex := i / numIndexes
+		insertRowIndex := resultRows[i]
 
 		for _, row := range result.Rows {
 			// If any of the result values are not nil, then that means that the insert row is in conflict and should be marked as such.
@@ -0,0 +1,328 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+//
+// This file contains the support for temporary tables.
+//
+// Every session has a temporary schema named pg_temp_<session ID>,
+// which can also be referred to as pg_temp by the session. The
+// temporary schema is never stored: when the session creates its first
+// temporary table in a database, a descriptor ID is allocated for the
+// temporary schema of the session in that database, and the names of
+// the temporary tables are stored in the namespace table under that ID
+// instead of the ID of the database. Only the session knows the ID, so
+// the temporary tables are invisible to the other sessions.
+//
+// The temporary tables are dropped when the session ends. The tables of
+// sessions that could not clean up after themselves, for example
+// because their node crashed, are dropped by the TemporaryObjectCleaner.
+//
+
+var temporaryObjectCleanupInterval = settings.RegisterNonNegativeDurationSetting(
+	"sql.temp_object_cleaner.cleanup_interval",
+	"how often to clean up temporary tables of sessions that are gone",
+	30*time.Minute,
+)
+
+// temporarySchemaName returns the name of the temporary schema of the
+// session with the given ID.
+func temporarySchemaName(sessionID ClusterWideID) string {
+	return fmt.Sprintf("%s_%d_%d", sessiondata.PgTempSchemaName, sessionID.Hi, sessionID.Lo)
+}
+
+// initTemporarySchema sets up the temporary schema of the session.
+func (ex *connExecutor) initTemporarySchema() {
+	tc := &ex.extraTxnState.tables
+	tc.temporarySessionID = ex.sessionID
+	tc.temporarySchemaName = temporarySchemaName(ex.sessionID)
+	ex.sessionData.SearchPath = ex.sessionData.SearchPath.WithTemporarySchemaName(tc.temporarySchemaName)
+}
+
+// isTemporarySchema returns true if scName refers to the temporary schema
+// of the session using the collection.
+func (tc *TableCollection) isTemporarySchema(scName string) bool {
+	return tc.temporarySchemaName != "" &&
+		(scName == tc.temporarySchemaName || scName == sessiondata.PgTempSchemaName)
+}
+
+// getTemporaryTableDesc looks up a temporary table by name in the
+// temporary schema with the given ID. Temporary tables are never cached.
+func getTemporaryTableDesc(
+	ctx context.Context, txn *client.Txn, schemaID sqlbase.ID, tn *tree.TableName, required bool,
+) (*sqlbase.TableDescriptor, error) {
+	desc := &sqlbase.TableDescriptor{}
+	found, err := getDescriptor(ctx, txn, tableKey{parentID: schemaID, name: tn.Table()}, desc)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		// We keep the descriptor if it is being added, like
+		// UncachedPhysicalAccessor does.
+		if err := filterTableState(desc); err == nil || err == errTableAdding {
+			return desc, nil
+		}
+	}
+	if required {
+		return nil, sqlbase.NewUndefinedRelationError(tn)
+	}
+	return nil, nil
+}
+
+// getOrCreateTemporarySchemaID returns the ID of the temporary schema of
+// the session in the given database, allocating it if needed.
+func (p *planner) getOrCreateTemporarySchemaID(
+	ctx context.Context, dbID sqlbase.ID,
+) (sqlbase.ID, error) {
+	tc := p.Tables()
+	if tc.temporarySchemaName == "" {
+		return 0, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"temporary tables are not supported outside of a SQL session")
+	}
+	if id := tc.temporarySchemaIDs[dbID]; id != 0 {
+		return id, nil
+	}
+	id, err := GenerateUniqueDescID(ctx, p.ExecCfg().DB)
+	if err != nil {
+		return 0, err
+	}
+	if tc.temporarySchemaIDs == nil {
+		tc.temporarySchemaIDs = make(map[sqlbase.ID]sqlbase.ID)
+	}
+	tc.temporarySchemaIDs[dbID] = id
+	return id, nil
+}
+
+// resolveTemporaryTableTarget determines the database where a temporary
+// table is to be created and qualifies its name with the temporary schema
+// of the session.
+func (p *planner) resolveTemporaryTableTarget(
+	ctx context.Context, tn *tree.TableName,
+) (*DatabaseDescriptor, error) {
+	if tn.ExplicitSchema && !p.Tables().isTemporarySchema(tn.Schema()) {
+		// CockroachDB v1.1 compatibility: "db.t" designates the table t in
+		// the database db.
+		if tn.ExplicitCatalog || tn.Schema() == tree.PublicSchema {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"cannot create temporary relation in non-temporary schema")
+		}
+		tn.CatalogName = tn.SchemaName
+		tn.ExplicitCatalog = true
+	}
+	dbName := p.CurrentDatabase()
+	if tn.ExplicitCatalog {
+		dbName = tn.Catalog()
+	}
+	if dbName == "" {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidSchemaNameError,
+			"no schema has been selected to create %q in",
+			tree.ErrString(tn)).SetHintf("verify that the current database and search_path are valid")
+	}
+	dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, dbName, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	tn.CatalogName = tree.Name(dbName)
+	tn.SchemaName = tree.Name(p.Tables().temporarySchemaName)
+	tn.ExplicitSchema = true
+	return dbDesc, nil
+}
+
+// checkTemporaryForeignKeyTargets verifies that the foreign keys of a
+// table only reference tables with the same persistence. The other
+// sessions cannot see the temporary tables, so they could not check nor
+// cascade the constraints.
+func checkTemporaryForeignKeyTargets(
+	temporary bool, targets map[sqlbase.ID]*sqlbase.TableDescriptor,
+) error {
+	for _, target := range targets {
+		if target.IsTemporary() == temporary {
+			continue
+		}
+		if temporary {
+			return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+				"constraints on temporary tables may reference only temporary tables")
+		}
+		return pgerror.NewErrorf(pgerror.CodeInvalidTableDefinitionError,
+			"constraints on permanent tables may reference only permanent tables")
+	}
+	return nil
+}
+
+// getTemporaryTableIDs returns the IDs of the tables in the given
+// temporary schemas.
+func getTemporaryTableIDs(
+	ctx context.Context, txn *client.Txn, schemaIDs map[sqlbase.ID]sqlbase.ID,
+) ([]sqlbase.ID, error) {
+	var ids []sqlbase.ID
+	for _, schemaID := range schemaIDs {
+		prefix := sqlbase.MakeNameMetadataKey(schemaID, "")
+		kvs, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			ids = append(ids, sqlbase.ID(kv.ValueInt()))
+		}
+	}
+	return ids, nil
+}
+
+// dropTemporaryTables drops the temporary tables with the given IDs. The
+// tables that have already been dropped are skipped.
+func (p *planner) dropTemporaryTables(ctx context.Context, ids []sqlbase.ID) error {
+	params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+	for _, id := range ids {
+		// Dropping a table modifies the tables referencing it, so the
+		// descriptors are read one at a time.
+		table, err := sqlbase.GetTableDescFromID(ctx, p.txn, id)
+		if err != nil {
+			return err
+		}
+		if table.Dropped() {
+			continue
+		}
+		if _, err := p.dropTableImpl(params, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropTemporaryTablesInNewTxn drops the temporary tables returned by
+// getTableIDs in a new transaction. It is used for the sessions that are
+// gone; the data of the tables is cleaned up asynchronously by the schema
+// change manager.
+func dropTemporaryTablesInNewTxn(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	getTableIDs func(context.Context, *client.Txn) ([]sqlbase.ID, error),
+) error {
+	return execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		ids, err := getTableIDs(ctx, txn)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		p, cleanup := newInternalPlanner(
+			"drop-temporary-tables", txn, security.RootUser, &MemoryMetrics{}, execCfg)
+		defer cleanup()
+		// The schema changes are left to the schema change manager.
+		p.extendedEvalCtx.SchemaChangers = &schemaChangerCollection{}
+		return p.dropTemporaryTables(ctx, ids)
+	})
+}
+
+// dropSessionTemporaryTables drops the temporary tables of a session that
+// is ending.
+func dropSessionTemporaryTables(
+	ctx context.Context, execCfg *ExecutorConfig, schemaIDs map[sqlbase.ID]sqlbase.ID,
+) error {
+	return dropTemporaryTablesInNewTxn(ctx, execCfg,
+		func(ctx context.Context, txn *client.Txn) ([]sqlbase.ID, error) {
+			return getTemporaryTableIDs(ctx, txn, schemaIDs)
+		})
+}
+
+// TemporaryObjectCleaner periodically drops the temporary tables of the
+// sessions that did not drop them when they ended, because their node
+// crashed or the cleanup failed.
+type TemporaryObjectCleaner struct {
+	execCfg *ExecutorConfig
+	// isLive returns whether the node with the given ID is live.
+	isLive func(roachpb.NodeID) (bool, error)
+}
+
+// NewTemporaryObjectCleaner returns a TemporaryObjectCleaner.
+func NewTemporaryObjectCleaner(
+	execCfg *ExecutorConfig, isLive func(roachpb.NodeID) (bool, error),
+) *TemporaryObjectCleaner {
+	return &TemporaryObjectCleaner{execCfg: execCfg, isLive: isLive}
+}
+
+// Start starts a goroutine that cleans up the temporary tables every
+// sql.temp_object_cleaner.cleanup_interval.
+func (c *TemporaryObjectCleaner) Start(stopper *stop.Stopper) {
+	stopper.RunWorker(c.execCfg.AmbientCtx.AnnotateCtx(context.Background()), func(ctx context.Context) {
+		for {
+			select {
+			case <-time.After(temporaryObjectCleanupInterval.Get(&c.execCfg.Settings.SV)):
+				if err := c.cleanup(ctx); err != nil {
+					log.Warningf(ctx, "failed to clean up temporary tables: %v", err)
+				}
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+// cleanup drops the temporary tables of the sessions that are gone.
+func (c *TemporaryObjectCleaner) cleanup(ctx context.Context) error {
+	return dropTemporaryTablesInNewTxn(ctx, c.execCfg,
+		func(ctx context.Context, txn *client.Txn) ([]sqlbase.ID, error) {
+			descs, err := GetAllDescriptors(ctx, txn)
+			if err != nil {
+				return nil, err
+			}
+			var ids []sqlbase.ID
+			for _, desc := range descs {
+				table, ok := desc.(*sqlbase.TableDescriptor)
+				if !ok || !table.IsTemporary() || table.Dropped() {
+					continue
+				}
+				if c.isSessionGone(ctx, BytesToClusterWideID(table.TemporarySessionID)) {
+					log.Infof(ctx, "dropping temporary table %q (%d) of session %s",
+						table.Name, table.ID, BytesToClusterWideID(table.TemporarySessionID))
+					ids = append(ids, table.ID)
+				}
+			}
+			return ids, nil
+		})
+}
+
+// isSessionGone returns true if the session with the given ID has ended.
+// The sessions of this node are looked up in the session registry; the
+// sessions of the other nodes are considered gone when their node is not
+// live.
+func (c *TemporaryObjectCleaner) isSessionGone(ctx context.Context, sessionID ClusterWideID) bool {
+	nodeID := roachpb.NodeID(sessionID.GetNodeID())
+	if nodeID == c.execCfg.NodeID.Get() {
+		return !c.execCfg.SessionRegistry.isRegistered(sessionID)
+	}
+	live, err := c.isLive(nodeID)
+	if err != nil {
+		// Be conservative: the session may still be running.
+		log.VEventf(ctx, 2, "unable to determine liveness of node %d: %v", nodeID, err)
+		return false
+	}
+	return !live
+}
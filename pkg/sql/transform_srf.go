@@ -110,6 +110,10 @@ func (v *srfExtractionVisitor) VisitPost(expr tree.Expr) tree.Expr {
 func (v *srfExtractionVisitor) lookupSRF(t *tree.FuncExpr) (*tree.FunctionDefinition, error) {
 	fd, err := t.Func.Resolve(v.searchPath)
 	if err != nil {
+		if tree.MaybeUserDefinedFunction(err) {
+			// User-defined functions are never generators.
+			return nil, nil
+		}
 		return nil, err
 	}
 	if fd.Class != tree.GeneratorClass {
@@ -0,0 +1,207 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
+)
+
+// maxTriggerDepth is the maximum nesting of triggers. The statements of a
+// trigger function can fire other triggers, including the trigger itself.
+const maxTriggerDepth = 16
+
+// contextTriggerDepthKey is an empty type for the handle associated with
+// the trigger depth value (see context.Value).
+type contextTriggerDepthKey struct{}
+
+// triggerDepthFromCtx returns the number of triggers that are running the
+// statement of the given context.
+func triggerDepthFromCtx(ctx context.Context) int {
+	depth := ctx.Value(contextTriggerDepthKey{})
+	if depth == nil {
+		return 0
+	}
+	return depth.(int)
+}
+
+// rowTriggers runs the triggers of a table that fire on an event, for
+// each row written by a mutation. The statements of the trigger functions
+// are run by the internal executor in the transaction of the mutation,
+// with the columns of the old and new rows substituted for the references
+// to OLD and NEW.
+//
+// Only the rows written by INSERT, UPDATE and DELETE statements fire
+// triggers: the rows written by UPSERT, by foreign key actions or by
+// TRUNCATE do not.
+type rowTriggers struct {
+	tableDesc     *sqlbase.TableDescriptor
+	before, after []triggerFunction
+
+	ie    *InternalExecutor
+	txn   *client.Txn
+	sargs SessionArgs
+}
+
+// triggerFunction is a trigger with the parsed statements of its function.
+type triggerFunction struct {
+	name  string
+	stmts tree.StatementList
+}
+
+// makeRowTriggers returns the triggers of the table that fire on the given
+// event, or nil if there are none.
+func (p *planner) makeRowTriggers(
+	ctx context.Context,
+	tn *tree.TableName,
+	tableDesc *sqlbase.TableDescriptor,
+	event sqlbase.TriggerDescriptor_Event,
+) (*rowTriggers, error) {
+	if !tableDesc.HasTriggers(event) {
+		return nil, nil
+	}
+	rt := &rowTriggers{
+		tableDesc: tableDesc,
+		ie:        p.ExtendedEvalContext().ExecCfg.InternalExecutor,
+		txn:       p.txn,
+		sargs:     SessionArgs{User: p.SessionData().User, Database: tn.Catalog()},
+	}
+	for i := range tableDesc.Triggers {
+		t := &tableDesc.Triggers[i]
+		if !t.FiresOn(event) {
+			continue
+		}
+		funcDesc := &sqlbase.FunctionDescriptor{}
+		if err := getDescriptorByID(ctx, p.txn, t.FunctionID, funcDesc); err != nil {
+			return nil, errors.Wrapf(err, "trigger %q", t.Name)
+		}
+		idx := funcDesc.FindOverload(nil /* argTypes */)
+		if idx < 0 || !funcDesc.Overloads[idx].ReturnsTrigger {
+			return nil, pgerror.NewErrorf(pgerror.CodeInternalError,
+				"programming error: function %q of trigger %q is not a trigger function", funcDesc.Name, t.Name)
+		}
+		stmts, err := parseTriggerFunctionBody(funcDesc.Overloads[idx].Body)
+		if err != nil {
+			return nil, err
+		}
+		f := triggerFunction{name: t.Name, stmts: stmts}
+		if t.Timing == sqlbase.TriggerDescriptor_BEFORE {
+			rt.before = append(rt.before, f)
+		} else {
+			rt.after = append(rt.after, f)
+		}
+	}
+	return rt, nil
+}
+
+// run runs the triggers with the given timing for a row. oldRow is nil for
+// an INSERT and newRow is nil for a DELETE; colIDtoRowIndex maps the IDs of
+// the columns of the table to their position in the rows.
+func (rt *rowTriggers) run(
+	ctx context.Context,
+	timing sqlbase.TriggerDescriptor_Timing,
+	oldRow, newRow tree.Datums,
+	colIDtoRowIndex map[sqlbase.ColumnID]int,
+) error {
+	triggers := rt.before
+	if timing == sqlbase.TriggerDescriptor_AFTER {
+		triggers = rt.after
+	}
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	depth := triggerDepthFromCtx(ctx) + 1
+	if depth > maxTriggerDepth {
+		return pgerror.NewErrorf(pgerror.CodeStatementTooComplexError,
+			"trigger recursion depth exceeded (maximum %d)", maxTriggerDepth).SetHintf(
+			"Check that the triggers of table %q do not fire each other indefinitely.",
+			rt.tableDesc.Name)
+	}
+	ctx = context.WithValue(ctx, contextTriggerDepthKey{}, depth)
+
+	for _, t := range triggers {
+		for _, stmt := range t.stmts {
+			stmt, err := rt.substituteRows(stmt, oldRow, newRow, colIDtoRowIndex)
+			if err != nil {
+				return err
+			}
+			if _, err := rt.ie.ExecWithSessionArgs(
+				ctx, fmt.Sprintf("trigger %s", t.name), rt.txn, rt.sargs,
+				tree.AsStringWithFlags(stmt, tree.FmtParsable),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// substituteRows replaces the references to the columns of the old and new
+// rows in a statement of a trigger function, of the form OLD.<colname> and
+// NEW.<colname>, with their values. Like in postgres, the row that does not
+// exist for the event is NULL.
+func (rt *rowTriggers) substituteRows(
+	stmt tree.Statement, oldRow, newRow tree.Datums, colIDtoRowIndex map[sqlbase.ColumnID]int,
+) (tree.Statement, error) {
+	var visit tree.SimpleVisitFn
+	visit = func(expr tree.Expr) (error, bool, tree.Expr) {
+		switch t := expr.(type) {
+		case *tree.Subquery:
+			sel, err := tree.SimpleStmtVisit(t.Select, visit)
+			if err != nil {
+				return err, false, expr
+			}
+			newSubquery := *t
+			newSubquery.Select = sel.(tree.SelectStatement)
+			return nil, false, &newSubquery
+
+		case *tree.UnresolvedName:
+			if t.NumParts != 2 || t.Star {
+				return nil, false, expr
+			}
+			var row tree.Datums
+			switch t.Parts[1] {
+			case "new":
+				row = newRow
+			case "old":
+				row = oldRow
+			default:
+				return nil, false, expr
+			}
+			col, err := rt.tableDesc.FindActiveColumnByName(t.Parts[0])
+			if err != nil {
+				return pgerror.NewErrorf(pgerror.CodeUndefinedColumnError,
+					"record %q has no field %q", t.Parts[1], t.Parts[0]), false, expr
+			}
+			if row == nil {
+				return nil, false, tree.DNull
+			}
+			idx, ok := colIDtoRowIndex[col.ID]
+			if !ok {
+				return nil, false, tree.DNull
+			}
+			return nil, false, row[idx]
+		}
+		return nil, true, expr
+	}
+	return tree.SimpleStmtVisit(stmt, visit)
+}
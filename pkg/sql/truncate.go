@@ -229,7 +229,7 @@ func (p *planner) truncateTable(ctx context.Context, id sqlbase.ID, traceKV bool
 	}
 	newTableDesc.Mutations = nil
 
-	tKey := tableKey{parentID: newTableDesc.ParentID, name: newTableDesc.Name}
+	tKey := tableKey{parentID: newTableDesc.GetNamespaceParentID(), name: newTableDesc.Name}
 	key := tKey.Key()
 	if err := p.createDescriptorWithID(
 		ctx, key, newID, &newTableDesc, p.ExtendedEvalContext().Settings); err != nil {
@@ -337,6 +337,10 @@ func reassignReferencedTables(
 			}
 			table.DependedOnBy = append(table.DependedOnBy, ref)
 		}
+		if table.SequenceOpts != nil && table.SequenceOpts.OwnerTableID == oldID {
+			table.SequenceOpts.OwnerTableID = newID
+			changed = true
+		}
 	}
 	return changed, nil
 }
@@ -369,7 +373,8 @@ func truncateTableInChunks(
 				return err
 			}
 			td := tableDeleter{rd: rd, alloc: alloc}
-			if err := td.init(txn, nil /* *tree.EvalContext */); err != nil {
+			evalCtx := createSchemaChangeEvalCtx(db.Clock().Now(), &SessionTracing{})
+			if err := td.init(txn, &evalCtx.EvalContext); err != nil {
 				return err
 			}
 			resume, err = td.deleteAllRows(ctx, resumeAt, chunkSize, noAutoCommit, traceKV)
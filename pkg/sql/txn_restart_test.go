@@ -1640,9 +1640,9 @@ func TestRollbackToSavepointFromUnusualStates(t *testing.T) {
 		}
 	}
 
-	// ROLLBACK TO SAVEPOINT with a wrong name
+	// ROLLBACK TO SAVEPOINT outside of a transaction
 	_, err := sqlDB.Exec("ROLLBACK TO SAVEPOINT foo")
-	if !testutils.IsError(err, "SAVEPOINT not supported except for COCKROACH_RESTART") {
+	if !testutils.IsError(err, "there is no transaction in progress") {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -96,6 +96,17 @@ type txnState struct {
 	// The schema change closures to run when this txn is done.
 	schemaChangers schemaChangerCollection
 
+	// savepoints is the stack of the active savepoints of the transaction,
+	// other than cockroach_restart. While it is not empty, an error in an
+	// explicit transaction doesn't roll back the KV transaction, so that the
+	// writes performed before a savepoint can be kept by a ROLLBACK TO
+	// SAVEPOINT.
+	savepoints savepointStack
+
+	// numDDL is the number of schema changing statements executed in the
+	// transaction.
+	numDDL int
+
 	// adv is overwritten after every transition. It represents instructions for
 	// for moving the cursor over the stream of input statements to the next
 	// statement to be executed.
@@ -218,6 +229,8 @@ func (ts *txnState) resetForNewSQLTxn(
 
 	// Discard the old schemaChangers, if any.
 	ts.schemaChangers = schemaChangerCollection{}
+	ts.savepoints = nil
+	ts.numDDL = 0
 }
 
 // finishSQLTxn finalizes a transaction's results and closes the root span for
@@ -252,6 +265,21 @@ func (ts *txnState) finishSQLTxn() {
 	ts.Ctx = nil
 	ts.mu.txn = nil
 	ts.recordingThreshold = 0
+	ts.savepoints = nil
+}
+
+// rollbackKVTxnKeptForSavepoints rolls back the KV txn if it was kept open
+// after an error because the transaction had savepoints, and discards the
+// savepoints. It is a no-op if the transaction has no savepoints, in which
+// case the KV txn was already rolled back when the error occurred.
+func (ts *txnState) rollbackKVTxnKeptForSavepoints() {
+	if len(ts.savepoints) == 0 {
+		return
+	}
+	ts.savepoints = nil
+	if err := ts.mu.txn.Rollback(ts.Ctx); err != nil {
+		log.Eventf(ts.Ctx, "failure rolling back transaction: %s", err)
+	}
 }
 
 // finishExternalTxn is a stripped-down version of finishSQLTxn used by
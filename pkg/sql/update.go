@@ -59,6 +59,10 @@ func (p *planner) Update(
 	if n.Where == nil && p.SessionData().SafeUpdates {
 		return nil, pgerror.NewDangerousStatementErrorf("UPDATE without WHERE clause")
 	}
+	if len(n.From) > 0 && (n.OrderBy != nil || n.Limit != nil) {
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"ORDER BY and LIMIT are not supported with UPDATE ... FROM")
+	}
 
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
@@ -88,6 +92,12 @@ func (p *planner) Update(
 		return nil, err
 	}
 
+	// Determine which triggers fire on the update.
+	triggers, err := p.makeRowTriggers(ctx, tn, desc, sqlbase.TriggerDescriptor_UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
 	// Determine what are the foreign key tables that are involved in the update.
 	fkTables, err := sqlbase.TablesNeededForFKs(
 		ctx,
@@ -187,7 +197,7 @@ func (p *planner) Update(
 	rowsNeeded := resultsNeeded(n.Returning)
 
 	var requestedCols []sqlbase.ColumnDescriptor
-	if rowsNeeded || len(desc.Checks) > 0 {
+	if rowsNeeded || len(desc.Checks) > 0 || triggers != nil {
 		// TODO(dan): This could be made tighter, just the rows needed for RETURNING
 		// exprs.
 		// TODO(nvanbenschoten): This could be made tighter, just the rows needed for
@@ -217,12 +227,14 @@ func (p *planner) Update(
 
 	// We construct a query containing the columns being updated, and
 	// then later merge the values they are being updated with into that
-	// renderNode to ideally reuse some of the queries.
+	// renderNode to ideally reuse some of the queries. The tables of the
+	// FROM clause, if any, are joined with the updated table so that the
+	// SET expressions can refer to their columns.
 	rows, err := p.SelectClause(ctx, &tree.SelectClause{
-		Exprs: sqlbase.ColumnsSelectors(ru.FetchCols, true /* forUpdateOrDelete */),
-		From:  &tree.From{Tables: []tree.TableExpr{n.Table}},
+		Exprs: mutationSourceSelectors(ru.FetchCols, alias, len(n.From) > 0),
+		From:  &tree.From{Tables: append(tree.TableExprs{n.Table}, n.From...)},
 		Where: n.Where,
-	}, n.OrderBy, n.Limit, nil /* with */, nil /*desiredTypes*/, publicAndNonPublicColumns)
+	}, n.OrderBy, n.Limit, nil /* with */, nil /* locking */, nil /*desiredTypes*/, publicAndNonPublicColumns)
 	if err != nil {
 		return nil, err
 	}
@@ -338,6 +350,10 @@ func (p *planner) Update(
 		updateColsIdx[col.ID] = i
 	}
 
+	if len(n.From) > 0 {
+		rows = distinctMutationRows(rows, desc, ru.FetchColIDtoRowIndex)
+	}
+
 	un := updateNodePool.Get().(*updateNode)
 	*un = updateNode{
 		source:  rows,
@@ -345,6 +361,7 @@ func (p *planner) Update(
 		run: updateRun{
 			tu:           tableUpdater{ru: ru},
 			checkHelper:  fkTables[desc.ID].CheckHelper,
+			triggers:     triggers,
 			rowsNeeded:   rowsNeeded,
 			computedCols: computedCols,
 			computeExprs: computeExprs,
@@ -374,6 +391,9 @@ type updateRun struct {
 	checkHelper *sqlbase.CheckHelper
 	rowsNeeded  bool
 
+	// triggers are the triggers that fire on the updated rows, if any.
+	triggers *rowTriggers
+
 	// rowCount is the number of rows in the current batch.
 	rowCount int
 
@@ -640,12 +660,39 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		}
 	}
 
+	// Run the BEFORE triggers, if any.
+	colIDtoRowIndex := u.run.tu.ru.FetchColIDtoRowIndex
+	if u.run.triggers != nil {
+		newRow := append(tree.Datums(nil), oldValues...)
+		for i, val := range u.run.updateValues {
+			newRow[colIDtoRowIndex[u.run.tu.ru.UpdateCols[i].ID]] = val
+		}
+		if err := u.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_BEFORE, oldValues, newRow, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// Queue the insert in the KV batch.
 	newValues, err := u.run.tu.rowForUpdate(params.ctx, oldValues, u.run.updateValues, u.run.traceKV)
 	if err != nil {
 		return err
 	}
 
+	// Run the AFTER triggers, if any. The row is written first so that the
+	// triggers can observe it.
+	if u.run.triggers != nil {
+		if err := u.run.tu.flushAndStartNewBatch(params.ctx); err != nil {
+			return err
+		}
+		if err := u.run.triggers.run(
+			params.ctx, sqlbase.TriggerDescriptor_AFTER, oldValues, newValues, colIDtoRowIndex,
+		); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if u.run.rows != nil {
 		if _, err := u.run.rows.AddRow(params.ctx, newValues); err != nil {
@@ -805,3 +852,35 @@ func checkHasNoComputedCols(cols []sqlbase.ColumnDescriptor) error {
 	}
 	return nil
 }
+
+// mutationSourceSelectors returns the selectors for the columns of the table
+// modified by an UPDATE or DELETE statement. If the statement joins the table
+// with other tables (UPDATE ... FROM or DELETE ... USING), the selectors are
+// qualified with the name of the table so that they don't clash with the
+// columns of the other tables.
+func mutationSourceSelectors(
+	cols []sqlbase.ColumnDescriptor, alias *tree.TableName, joined bool,
+) tree.SelectExprs {
+	exprs := sqlbase.ColumnsSelectors(cols, true /* forUpdateOrDelete */)
+	if joined {
+		for i := range exprs {
+			exprs[i].Expr.(*tree.ColumnItem).TableName = tree.MakeUnresolvedName(alias.Table())
+		}
+	}
+	return exprs
+}
+
+// distinctMutationRows ensures that each row of the table modified by an
+// UPDATE ... FROM or DELETE ... USING statement is only processed once, even
+// if it matches multiple rows of the other tables. Like in Postgres, only the
+// first matching row is used. The source must render the fetched columns of
+// the table as described by fetchColIDtoRowIndex.
+func distinctMutationRows(
+	source planNode, desc *sqlbase.TableDescriptor, fetchColIDtoRowIndex map[sqlbase.ColumnID]int,
+) planNode {
+	d := &distinctNode{plan: source}
+	for _, colID := range desc.PrimaryIndex.ColumnIDs {
+		d.distinctOnColIdxs.Add(fetchColIDtoRowIndex[colID])
+	}
+	return d
+}
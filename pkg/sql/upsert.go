@@ -16,9 +16,11 @@ package sql
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -61,10 +63,22 @@ func (p *planner) newUpsertNode(
 	fkTables sqlbase.TableLookupsByID,
 	desiredTypes []types.T,
 ) (res batchedPlanNode, err error) {
+	var arbiterPred tree.TypedExpr
+	if n.OnConflict.ArbiterPredicate != nil {
+		arbiterPred, err = p.analyzeArbiterPredicate(ctx, alias, desc, n.OnConflict.ArbiterPredicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Extract the index that will detect upsert conflicts
 	// (conflictIndex) and the assignment expressions to use when
 	// conflicts are detected (updateExprs).
-	updateExprs, conflictIndex, err := upsertExprsAndIndex(desc, *n.OnConflict, ri.InsertCols)
+	updateExprs, conflictIndex, err := upsertExprsAndIndex(desc, *n.OnConflict, ri.InsertCols,
+		func(index *sqlbase.IndexDescriptor) (bool, error) {
+			return p.arbiterImpliesPredicate(ctx, desc, arbiterPred, index)
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +163,7 @@ func (p *planner) newUpsertNode(
 		// SQL expressions. As described above, this also performs a
 		// semantic check, so it cannot be skipped on the fast path below.
 		helper, err := p.newUpsertHelper(
-			ctx, tn, desc,
+			ctx, alias, desc,
 			ri.InsertCols,
 			updateCols,
 			updateExprs,
@@ -652,6 +666,55 @@ func (p *planner) newUpsertHelper(
 	return helper, nil
 }
 
+// analyzeArbiterPredicate resolves and type checks the predicate of the
+// conflict target of an INSERT ... ON CONFLICT clause. Like in Postgres, the
+// predicate is only used to infer the partial unique indexes that can be used
+// as the conflict index (see arbiterImpliesPredicate); it does not filter the
+// inserted rows. The IndexedVars of the result refer to the table columns.
+func (p *planner) analyzeArbiterPredicate(
+	ctx context.Context, tn *tree.TableName, tableDesc *sqlbase.TableDescriptor, pred tree.Expr,
+) (tree.TypedExpr, error) {
+	helper := &upsertHelper{p: p}
+	helper.sourceInfo = sqlbase.NewSourceInfoForSingleTable(
+		*tn, sqlbase.ResultColumnsFromColDescs(tableDesc.Columns),
+	)
+	ivarHelper := tree.MakeIndexedVarHelper(helper, len(helper.sourceInfo.SourceColumns))
+
+	defer p.semaCtx.Properties.Restore(p.semaCtx.Properties)
+	p.semaCtx.Properties.Require("ON CONFLICT...WHERE", tree.RejectSpecial|tree.RejectSubqueries)
+
+	return p.analyzeExpr(
+		ctx, pred, sqlbase.MultiSourceInfo{helper.sourceInfo}, ivarHelper, types.Bool,
+		true /* requireType */, "ON CONFLICT...WHERE")
+}
+
+// arbiterImpliesPredicate returns true if the given predicate of the conflict
+// target of an INSERT ... ON CONFLICT clause implies the predicate of the given
+// partial unique index, in which case the index can detect the conflicts. It
+// returns false if there is no arbiter predicate.
+func (p *planner) arbiterImpliesPredicate(
+	ctx context.Context,
+	tableDesc *sqlbase.TableDescriptor,
+	arbiterPred tree.TypedExpr,
+	index *sqlbase.IndexDescriptor,
+) (bool, error) {
+	if arbiterPred == nil {
+		return false, nil
+	}
+	var optimizer xform.Optimizer
+	optimizer.Init(p.EvalContext())
+	md := optimizer.Memo().Metadata()
+	for i := range tableDesc.Columns {
+		md.AddColumn(tableDesc.Columns[i].Name, tableDesc.Columns[i].Type.ToDatumType())
+	}
+	bld := optbuilder.NewScalar(ctx, &p.semaCtx, p.EvalContext(), optimizer.Factory())
+	bld.AllowUnsupportedExpr = true
+	if err := bld.Build(arbiterPred); err != nil {
+		return false, err
+	}
+	return p.filterImpliesPredicate(ctx, &optimizer, optimizer.Memo().RootGroup(), tableDesc, index)
+}
+
 func (uh *upsertHelper) walkExprs(walk func(desc string, index int, expr tree.TypedExpr)) {
 	for i, evalExpr := range uh.evalExprs {
 		walk("eval", i, evalExpr)
@@ -715,11 +778,14 @@ func (uh *upsertHelper) shouldUpdate(insertRow tree.Datums, existingRow tree.Dat
 }
 
 // upsertExprsAndIndex returns the upsert conflict index and the (possibly
-// synthetic) SET expressions used when a row conflicts.
+// synthetic) SET expressions used when a row conflicts. impliesPredicate
+// reports whether the predicate of the conflict target implies the predicate
+// of a partial unique index.
 func upsertExprsAndIndex(
 	tableDesc *sqlbase.TableDescriptor,
 	onConflict tree.OnConflict,
 	insertCols []sqlbase.ColumnDescriptor,
+	impliesPredicate func(*sqlbase.IndexDescriptor) (bool, error),
 ) (tree.UpdateExprs, *sqlbase.IndexDescriptor, error) {
 	if onConflict.IsUpsertAlias() {
 		// Construct a fake set of UPDATE SET expressions. This enables sharing
@@ -755,21 +821,42 @@ func upsertExprsAndIndex(
 		return updateExprs, conflictIndex, nil
 	}
 
-	if onConflict.DoNothing && len(onConflict.Columns) == 0 {
-		return onConflict.Exprs, nil, nil
+	if len(onConflict.Columns) == 0 && onConflict.Constraint == "" {
+		if onConflict.DoNothing {
+			return onConflict.Exprs, nil, nil
+		}
+		return nil, nil, pgerror.NewError(pgerror.CodeSyntaxError,
+			"ON CONFLICT DO UPDATE requires inference specification or constraint name")
 	}
 
 	// General case: INSERT with an ON CONFLICT clause.
 
+	if onConflict.Constraint != "" {
+		conflictIndex, err := conflictIndexForConstraint(tableDesc, onConflict.Constraint)
+		if err != nil {
+			return nil, nil, err
+		}
+		return onConflict.Exprs, conflictIndex, nil
+	}
+
+	// Like in Postgres, the conflict target is matched against the unique
+	// indexes regardless of the order in which its columns are listed.
+	targetCols := make(map[string]struct{}, len(onConflict.Columns))
+	for _, colName := range onConflict.Columns {
+		if _, err := tableDesc.FindActiveColumnByName(string(colName)); err != nil {
+			return nil, nil, err
+		}
+		targetCols[string(colName)] = struct{}{}
+	}
 	indexMatch := func(index sqlbase.IndexDescriptor) bool {
 		if !index.Unique {
 			return false
 		}
-		if len(index.ColumnNames) != len(onConflict.Columns) {
+		if len(index.ColumnNames) != len(targetCols) {
 			return false
 		}
-		for i, colName := range index.ColumnNames {
-			if colName != string(onConflict.Columns[i]) {
+		for _, colName := range index.ColumnNames {
+			if _, ok := targetCols[colName]; !ok {
 				return false
 			}
 		}
@@ -780,9 +867,56 @@ func upsertExprsAndIndex(
 		return onConflict.Exprs, &tableDesc.PrimaryIndex, nil
 	}
 	for i := range tableDesc.Indexes {
-		if indexMatch(tableDesc.Indexes[i]) {
+		if indexMatch(tableDesc.Indexes[i]) && !tableDesc.Indexes[i].IsPartial() {
 			return onConflict.Exprs, &tableDesc.Indexes[i], nil
 		}
 	}
-	return nil, nil, fmt.Errorf("there is no unique or exclusion constraint matching the ON CONFLICT specification")
+	// Partial unique indexes only detect conflicts among the rows that satisfy
+	// their predicate. Like in Postgres, they are only used if the predicate of
+	// the conflict target implies theirs.
+	for i := range tableDesc.Indexes {
+		index := &tableDesc.Indexes[i]
+		if !indexMatch(*index) || !index.IsPartial() {
+			continue
+		}
+		implied, err := impliesPredicate(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		if implied {
+			return onConflict.Exprs, index, nil
+		}
+	}
+	return nil, nil, pgerror.NewError(pgerror.CodeInvalidColumnReferenceError,
+		"there is no unique or exclusion constraint matching the ON CONFLICT specification")
 }
+
+// conflictIndexForConstraint returns the index that enforces the named unique
+// constraint, for use as the conflict index of an `ON CONFLICT ON CONSTRAINT`
+// clause. The primary key is a valid conflict target too.
+func conflictIndexForConstraint(
+	tableDesc *sqlbase.TableDescriptor, name tree.Name,
+) (*sqlbase.IndexDescriptor, error) {
+	if tableDesc.PrimaryIndex.Name == string(name) {
+		return &tableDesc.PrimaryIndex, nil
+	}
+	for i := range tableDesc.Indexes {
+		index := &tableDesc.Indexes[i]
+		if index.Unique && !index.IsPartial() && index.Name == string(name) {
+			return index, nil
+		}
+		if index.ForeignKey.IsSet() && index.ForeignKey.Name == string(name) {
+			return nil, errConstraintWithoutIndex
+		}
+	}
+	for _, check := range tableDesc.Checks {
+		if check.Name == string(name) {
+			return nil, errConstraintWithoutIndex
+		}
+	}
+	return nil, pgerror.NewErrorf(pgerror.CodeUndefinedObjectError,
+		"constraint %q for table %q does not exist", string(name), tableDesc.Name)
+}
+
+var errConstraintWithoutIndex = pgerror.NewError(pgerror.CodeWrongObjectTypeError,
+	"constraint in ON CONFLICT clause has no associated index")
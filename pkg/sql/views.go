@@ -66,14 +66,17 @@ func (d planDependencies) String() string {
 // analyzeViewQuery extracts the set of dependencies (tables and views
 // that this view's query depends on), together with the more detailed
 // information about which indexes and columns are needed from each
-// dependency. The set of columns from the view query's results is
-// also returned.
+// dependency. The IDs of the user-defined functions used by the query
+// and the set of columns from the view query's results are also
+// returned.
 func (p *planner) analyzeViewQuery(
 	ctx context.Context, viewSelect *tree.Select,
-) (planDependencies, sqlbase.ResultColumns, error) {
+) (planDependencies, map[sqlbase.ID]struct{}, sqlbase.ResultColumns, error) {
 	// Request dependency tracking.
 	defer func(prev planDependencies) { p.curPlan.deps = prev }(p.curPlan.deps)
 	p.curPlan.deps = make(planDependencies)
+	defer func(prev map[sqlbase.ID]struct{}) { p.curPlan.funcDeps = prev }(p.curPlan.funcDeps)
+	p.curPlan.funcDeps = make(map[sqlbase.ID]struct{})
 
 	// Request star detection
 	defer func(prev bool) { p.curPlan.hasStar = prev }(p.curPlan.hasStar)
@@ -82,15 +85,15 @@ func (p *planner) analyzeViewQuery(
 	// Now generate the source plan.
 	sourcePlan, err := p.Select(ctx, viewSelect, []types.T{})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	// The plan will not be needed further.
 	defer sourcePlan.Close(ctx)
 
 	// TODO(a-robinson): Support star expressions as soon as we can (#10028).
 	if p.curPlan.hasStar {
-		return nil, nil, fmt.Errorf("views do not currently support * expressions")
+		return nil, nil, nil, fmt.Errorf("views do not currently support * expressions")
 	}
 
-	return p.curPlan.deps, planColumns(sourcePlan), nil
+	return p.curPlan.deps, p.curPlan.funcDeps, planColumns(sourcePlan), nil
 }
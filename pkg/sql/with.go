@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 )
 
@@ -56,6 +57,16 @@ type cteSource struct {
 	// alias holds the name of the CTE and the renaming of its columns, if
 	// present.
 	alias tree.AliasClause
+	// recursiveRef, if set, indicates that this entry stands for the
+	// self-reference in the recursive term of a recursive CTE. References are
+	// planned as a scanBufferNode reading the rows of the given node's
+	// previous iteration.
+	recursiveRef *recursiveCTENode
+	// invalidRefErr, if set, is the error returned when the CTE is referenced.
+	// It is used to reject references that are invalid in the current context,
+	// e.g. a recursive reference within the non-recursive term of a recursive
+	// CTE.
+	invalidRefErr error
 }
 
 func (e cteNameEnvironment) push(frame cteNameEnvironmentFrame) cteNameEnvironment {
@@ -85,7 +96,13 @@ func (p *planner) initWith(ctx context.Context, with *tree.With) (func(p *planne
 					"WITH query name %s specified more than once",
 					cte.Name.Alias)
 			}
-			ctePlan, err := p.newPlan(ctx, cte.Stmt, nil)
+			var ctePlan planNode
+			var err error
+			if with.Recursive {
+				ctePlan, err = p.newRecursiveCTEPlan(ctx, frame, cte)
+			} else {
+				ctePlan, err = p.newPlan(ctx, cte.Stmt, nil)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -111,6 +128,25 @@ func (p *planner) getCTEDataSource(tn *tree.TableName) (planDataSource, bool, er
 	for i := range p.curPlan.cteNameEnvironment {
 		frame := p.curPlan.cteNameEnvironment[len(p.curPlan.cteNameEnvironment)-1-i]
 		if cteSource, ok := frame[tn.TableName]; ok {
+			if cteSource.invalidRefErr != nil {
+				return planDataSource{}, false, cteSource.invalidRefErr
+			}
+			if cteSource.recursiveRef != nil {
+				if cteSource.used {
+					return planDataSource{}, false, pgerror.NewErrorf(pgerror.CodeInvalidRecursionError,
+						"recursive reference to query %q must not appear more than once",
+						tree.ErrString(tn))
+				}
+				cteSource.used = true
+				frame[tn.TableName] = cteSource
+				dataSource := planDataSource{
+					info: sqlbase.NewSourceInfoForSingleTable(*tn, cteSource.recursiveRef.columns),
+					plan: &scanBufferNode{buffer: cteSource.recursiveRef, label: cteSource.recursiveRef.label},
+				}
+				var err error
+				dataSource, err = renameSource(dataSource, cteSource.alias, false)
+				return dataSource, err == nil, err
+			}
 			if cteSource.used {
 				// TODO(jordan): figure out how to lift this restriction.
 				// CTE expressions that are used more than once will need to be
@@ -137,3 +173,123 @@ func (p *planner) getCTEDataSource(tn *tree.TableName) (planDataSource, bool, er
 	}
 	return planDataSource{}, false, nil
 }
+
+// newRecursiveCTEPlan plans a common table expression defined in a WITH
+// RECURSIVE clause. frame is the environment frame of the enclosing WITH
+// clause, to which the CTE will be added by the caller.
+//
+// A recursive CTE has the form:
+//
+//   <non-recursive term> UNION [ALL] <recursive term>
+//
+// where only the recursive term can refer to the CTE itself. If the recursive
+// term does not refer to the CTE, the result is a regular union; otherwise it
+// is a recursiveCTENode.
+func (p *planner) newRecursiveCTEPlan(
+	ctx context.Context, frame cteNameEnvironmentFrame, cte *tree.CTE,
+) (planNode, error) {
+	name := cte.Name.Alias
+	union := cte.RecursiveUnion()
+	if union == nil {
+		// This is not of the recursive form; it can be planned as a regular CTE
+		// as long as it does not refer to itself.
+		frame[name] = cteSource{invalidRefErr: pgerror.NewErrorf(pgerror.CodeInvalidRecursionError,
+			"recursive query %q does not have the form non-recursive-term UNION [ALL] recursive-term",
+			tree.ErrString(&name))}
+		defer delete(frame, name)
+		return p.newPlan(ctx, cte.Stmt, nil)
+	}
+
+	frame[name] = cteSource{invalidRefErr: pgerror.NewErrorf(pgerror.CodeInvalidRecursionError,
+		"recursive reference to query %q must not appear within its non-recursive term",
+		tree.ErrString(&name))}
+	initial, err := p.newPlan(ctx, union.Left, nil)
+	delete(frame, name)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &recursiveCTENode{
+		initial:  initial,
+		label:    string(name),
+		unionAll: union.All,
+		columns:  planColumns(initial),
+	}
+	if len(n.columns) == 0 {
+		initial.Close(ctx)
+		return nil, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"WITH clause %q does not have a RETURNING clause", tree.ErrString(&name))
+	}
+
+	// The recursive term may only refer to the CTE being defined. References to
+	// other CTEs would require planning them again for every iteration, which
+	// is not supported.
+	env := make(cteNameEnvironment, 0, len(p.curPlan.cteNameEnvironment)+1)
+	for _, f := range p.curPlan.cteNameEnvironment {
+		blocked := make(cteNameEnvironmentFrame, len(f))
+		for cteName := range f {
+			blocked[cteName] = cteSource{invalidRefErr: pgerror.Unimplemented("recursive cte",
+				"referencing CTE %q from the recursive term of a recursive CTE is not supported",
+				tree.ErrString(&cteName))}
+		}
+		env = env.push(blocked)
+	}
+	recursiveEnv := func() cteNameEnvironment {
+		return env.push(cteNameEnvironmentFrame{
+			name: cteSource{recursiveRef: n, alias: cte.Name},
+		})
+	}
+
+	// Plan the recursive term once to validate it, check whether it actually
+	// refers to the CTE, and to be able to describe it with EXPLAIN.
+	numSubqueries := len(p.curPlan.subqueryPlans)
+	savedEnv := p.curPlan.cteNameEnvironment
+	curEnv := recursiveEnv()
+	p.curPlan.cteNameEnvironment = curEnv
+	recursive, err := p.newPlan(ctx, union.Right, nil)
+	p.curPlan.cteNameEnvironment = savedEnv
+	if err != nil {
+		initial.Close(ctx)
+		return nil, err
+	}
+	if !curEnv[len(curEnv)-1][name].used {
+		// The CTE is not actually recursive.
+		return p.newUnionNode(tree.UnionOp, union.All, initial, recursive)
+	}
+	n.recursive = recursive
+	if len(p.curPlan.subqueryPlans) != numSubqueries {
+		n.Close(ctx)
+		return nil, pgerror.Unimplemented("recursive cte",
+			"subqueries are not supported in the recursive term of a recursive CTE")
+	}
+
+	recursiveCols := planColumns(recursive)
+	if len(recursiveCols) != len(n.columns) {
+		n.Close(ctx)
+		return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
+			"each UNION query must have the same number of columns: %d vs %d",
+			len(n.columns), len(recursiveCols))
+	}
+	for i := range n.columns {
+		l, r := n.columns[i].Typ, recursiveCols[i].Typ
+		if !(l.Equivalent(r) || r == types.Unknown) {
+			n.Close(ctx)
+			return nil, pgerror.NewErrorf(pgerror.CodeDatatypeMismatchError,
+				"recursive query %q column %d has type %s in non-recursive term but type %s overall",
+				tree.ErrString(&name), i+1, l, r)
+		}
+	}
+
+	n.genIterationFn = func(params runParams, _ *recursiveCTENode) (planNode, error) {
+		p := params.p
+		savedEnv := p.curPlan.cteNameEnvironment
+		p.curPlan.cteNameEnvironment = recursiveEnv()
+		defer func() { p.curPlan.cteNameEnvironment = savedEnv }()
+		plan, err := p.newPlan(params.ctx, union.Right, nil)
+		if err != nil {
+			return nil, err
+		}
+		return p.optimizePlan(params.ctx, plan, allColumns(plan))
+	}
+	return n, nil
+}
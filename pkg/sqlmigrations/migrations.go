@@ -190,6 +190,12 @@ var backwardCompatibleMigrations = []migrationDescriptor{
 		name:   "add progress to system.jobs",
 		workFn: addJobsProgress,
 	},
+	{
+		// Introduced in v2.1.
+		name:             "create system.notifications table",
+		workFn:           createNotificationsTable,
+		newDescriptorIDs: staticIDs(keys.NotificationsTableID),
+	},
 }
 
 func staticIDs(ids ...sqlbase.ID) func(ctx context.Context, db db) ([]sqlbase.ID, error) {
@@ -503,6 +509,10 @@ func createSystemTable(ctx context.Context, r runner, desc sqlbase.TableDescript
 	return err
 }
 
+func createNotificationsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.NotificationsTable)
+}
+
 var reportingOptOut = envutil.EnvOrDefaultBool("COCKROACH_SKIP_ENABLING_DIAGNOSTIC_REPORTING", false)
 
 func runStmtAsRootWithRetry(
@@ -43,14 +43,17 @@ func ResolveIntentRange(
 	h := cArgs.Header
 	ms := cArgs.Stats
 
-	if h.Txn != nil {
+	// A transaction may only resolve its own intents when rolling back to a
+	// savepoint.
+	if h.Txn != nil && (len(args.IgnoredSeqNums) == 0 || h.Txn.ID != args.IntentTxn.ID) {
 		return result.Result{}, ErrTransactionUnsupported
 	}
 
 	intent := roachpb.Intent{
-		Span:   args.Span(),
-		Txn:    args.IntentTxn,
-		Status: args.Status,
+		Span:           args.Span(),
+		Txn:            args.IntentTxn,
+		Status:         args.Status,
+		IgnoredSeqNums: args.IgnoredSeqNums,
 	}
 
 	// Use a time-bounded iterator as an optimization if indicated.
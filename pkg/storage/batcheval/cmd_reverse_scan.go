@@ -47,23 +47,60 @@ func ReverseScan(
 	case roachpb.BATCH_RESPONSE:
 		var kvData []byte
 		var numKvs int64
-		kvData, numKvs, resumeSpan, intents, err = engine.MVCCReverseScanToBytes(
-			ctx, batch, args.Key, args.EndKey, cArgs.MaxKeys,
-			h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+		scan := func(span roachpb.Span, max int64) (*roachpb.Span, int64, error) {
+			data, n, resumeSpan, scanIntents, err := engine.MVCCReverseScanToBytes(
+				ctx, batch, span.Key, span.EndKey, max,
+				h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+			if err != nil {
+				return nil, 0, err
+			}
+			if kvData == nil {
+				kvData = data
+			} else {
+				kvData = append(kvData, data...)
+			}
+			numKvs += n
+			intents = append(intents, scanIntents...)
+			return resumeSpan, n, nil
+		}
+		resumeSpan, err = scanWithWaitPolicy(h, args.Span(), cArgs.MaxKeys, true /* reverse */, scan)
 		if err != nil {
 			return result.Result{}, err
 		}
 		reply.NumKeys = numKvs
 		reply.BatchResponse = kvData
+		if args.KeyLocking && h.Txn != nil {
+			if err := lockBatchResponse(ctx, batch, cArgs, kvData); err != nil {
+				return result.Result{}, err
+			}
+		}
 	case roachpb.KEY_VALUES:
 		var rows []roachpb.KeyValue
-		rows, resumeSpan, intents, err = engine.MVCCReverseScan(ctx, batch, args.Key, args.EndKey,
-			cArgs.MaxKeys, h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+		scan := func(span roachpb.Span, max int64) (*roachpb.Span, int64, error) {
+			kvs, resumeSpan, scanIntents, err := engine.MVCCReverseScan(ctx, batch, span.Key, span.EndKey,
+				max, h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+			if err != nil {
+				return nil, 0, err
+			}
+			if rows == nil {
+				rows = kvs
+			} else {
+				rows = append(rows, kvs...)
+			}
+			intents = append(intents, scanIntents...)
+			return resumeSpan, int64(len(kvs)), nil
+		}
+		resumeSpan, err = scanWithWaitPolicy(h, args.Span(), cArgs.MaxKeys, true /* reverse */, scan)
 		if err != nil {
 			return result.Result{}, err
 		}
 		reply.NumKeys = int64(len(rows))
 		reply.Rows = rows
+		if args.KeyLocking && h.Txn != nil {
+			if err := lockKeyValues(ctx, batch, cArgs, rows); err != nil {
+				return result.Result{}, err
+			}
+		}
 	default:
 		panic(fmt.Sprintf("Unknown scanFormat %d", args.ScanFormat))
 	}
@@ -0,0 +1,185 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+)
+
+// scanFunc scans the given span, returning at most max keys. It accumulates
+// the results itself and returns the resume span and the number of keys it
+// returned.
+type scanFunc func(span roachpb.Span, max int64) (*roachpb.Span, int64, error)
+
+// scanWithWaitPolicy runs the scan of the given span according to the wait
+// policy of the batch. With the SKIP_LOCKED policy, the rows holding intents
+// of other transactions are skipped instead of returning a WriteIntentError
+// for them. Skipping is done at the granularity of SQL rows, so that the
+// column families of a locked row are never returned partially.
+func scanWithWaitPolicy(
+	h roachpb.Header, span roachpb.Span, max int64, reverse bool, scan scanFunc,
+) (*roachpb.Span, error) {
+	if h.WaitPolicy != roachpb.SKIP_LOCKED || h.ReadConsistency != roachpb.CONSISTENT {
+		resumeSpan, _, err := scan(span, max)
+		return resumeSpan, err
+	}
+
+	for {
+		resumeSpan, _, err := scan(span, max)
+		wiErr, ok := err.(*roachpb.WriteIntentError)
+		if !ok {
+			return resumeSpan, err
+		}
+
+		// Find the span of the row holding the first conflicting intent in scan
+		// order. The scan stopped there, so the part of the span before it
+		// doesn't contain any conflicting intents.
+		conflict := wiErr.Intents[0].Key
+		for _, intent := range wiErr.Intents[1:] {
+			if (intent.Key.Compare(conflict) < 0) != reverse {
+				conflict = intent.Key
+			}
+		}
+		row := rowSpan(conflict)
+		if row.Key.Compare(span.Key) < 0 {
+			row.Key = span.Key
+		}
+		if row.EndKey.Compare(span.EndKey) > 0 {
+			row.EndKey = span.EndKey
+		}
+
+		var before, after roachpb.Span
+		if reverse {
+			before = roachpb.Span{Key: row.EndKey, EndKey: span.EndKey}
+			after = roachpb.Span{Key: span.Key, EndKey: row.Key}
+		} else {
+			before = roachpb.Span{Key: span.Key, EndKey: row.Key}
+			after = roachpb.Span{Key: row.EndKey, EndKey: span.EndKey}
+		}
+
+		if before.Key.Compare(before.EndKey) < 0 {
+			resumeSpan, numKeys, err := scan(before, max)
+			if err != nil {
+				return nil, err
+			}
+			if resumeSpan != nil {
+				// The limit was reached before the locked row. The resume span
+				// must cover the rest of the original span.
+				if reverse {
+					resumeSpan.Key = span.Key
+				} else {
+					resumeSpan.EndKey = span.EndKey
+				}
+				return resumeSpan, nil
+			}
+			max -= numKeys
+		}
+
+		if after.Key.Compare(after.EndKey) >= 0 {
+			return nil, nil
+		}
+		if max == 0 {
+			return &after, nil
+		}
+		span = after
+	}
+}
+
+// rowSpan returns the span of the SQL row to which the given key belongs. Keys
+// outside of the SQL keyspace are rows of their own.
+func rowSpan(key roachpb.Key) roachpb.Span {
+	rowKey, err := keys.EnsureSafeSplitKey(key)
+	if err != nil {
+		rowKey = key
+	}
+	if len(rowKey) == len(key) {
+		return roachpb.Span{Key: key, EndKey: key.Next()}
+	}
+	return roachpb.Span{Key: rowKey, EndKey: rowKey.PrefixEnd()}
+}
+
+// keyLocker acquires locks on the keys returned by a locking scan on behalf of
+// the scan's transaction. A lock is an intent that rewrites the current value
+// of its key, which makes conflicting transactions wait on it just like on any
+// other write.
+type keyLocker struct {
+	ctx   context.Context
+	batch engine.ReadWriter
+	cArgs CommandArgs
+	// wtoErr is the WriteTooOldError with the highest timestamp encountered
+	// while locking. The remaining keys are locked regardless so that the
+	// scan can be retried at a timestamp that succeeds.
+	wtoErr *roachpb.WriteTooOldError
+}
+
+func (l *keyLocker) lockKey(key roachpb.Key, rawValue []byte) error {
+	h := l.cArgs.Header
+	err := engine.MVCCPut(l.ctx, l.batch, l.cArgs.Stats, key, h.Timestamp,
+		roachpb.Value{RawBytes: rawValue}, h.Txn)
+	if wtoErr, ok := err.(*roachpb.WriteTooOldError); ok {
+		if l.wtoErr == nil {
+			l.wtoErr = wtoErr
+		} else {
+			l.wtoErr.ActualTimestamp.Forward(wtoErr.ActualTimestamp)
+		}
+		return nil
+	}
+	return err
+}
+
+func (l *keyLocker) err() error {
+	if l.wtoErr != nil {
+		return l.wtoErr
+	}
+	return nil
+}
+
+// lockKeyValues locks the keys of the given rows.
+func lockKeyValues(
+	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, rows []roachpb.KeyValue,
+) error {
+	l := keyLocker{ctx: ctx, batch: batch, cArgs: cArgs}
+	for _, kv := range rows {
+		if err := l.lockKey(kv.Key, kv.Value.RawBytes); err != nil {
+			return err
+		}
+	}
+	return l.err()
+}
+
+// lockBatchResponse locks the keys of the given rows in the BATCH_RESPONSE
+// format.
+func lockBatchResponse(
+	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, kvData []byte,
+) error {
+	l := keyLocker{ctx: ctx, batch: batch, cArgs: cArgs}
+	for len(kvData) > 0 {
+		var key engine.MVCCKey
+		var rawValue []byte
+		var err error
+		key, rawValue, kvData, err = engine.MVCCScanDecodeKeyValue(kvData)
+		if err != nil {
+			return err
+		}
+		if err := l.lockKey(key.Key, rawValue); err != nil {
+			return err
+		}
+	}
+	return l.err()
+}
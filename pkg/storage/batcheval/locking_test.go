@@ -0,0 +1,103 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package batcheval
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestScanWithWaitPolicy verifies that the SKIP_LOCKED wait policy skips the
+// keys holding conflicting intents and respects the key limit.
+func TestScanWithWaitPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	locked := map[string]bool{"b": true, "d": true}
+
+	testCases := []struct {
+		reverse    bool
+		max        int64
+		expected   []string
+		resumeSpan *roachpb.Span
+	}{
+		{false, math.MaxInt64, []string{"a", "c", "e"}, nil},
+		{true, math.MaxInt64, []string{"e", "c", "a"}, nil},
+		{false, 2, []string{"a", "c"}, &roachpb.Span{Key: roachpb.Key("d"), EndKey: roachpb.Key("f")}},
+		{true, 1, []string{"e"}, &roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("d").Next()}},
+	}
+	for _, tc := range testCases {
+		var result []string
+		// scan mimics an MVCC scan, which fails with a WriteIntentError if it
+		// encounters an intent.
+		scan := func(span roachpb.Span, max int64) (*roachpb.Span, int64, error) {
+			var found []string
+			var resumeSpan *roachpb.Span
+			for i := range keys {
+				k := keys[i]
+				if tc.reverse {
+					k = keys[len(keys)-1-i]
+				}
+				key := roachpb.Key(k)
+				if !span.ContainsKey(key) {
+					continue
+				}
+				if int64(len(found)) == max {
+					if tc.reverse {
+						resumeSpan = &roachpb.Span{Key: span.Key, EndKey: key.Next()}
+					} else {
+						resumeSpan = &roachpb.Span{Key: key, EndKey: span.EndKey}
+					}
+					break
+				}
+				if locked[k] {
+					return nil, 0, &roachpb.WriteIntentError{
+						Intents: []roachpb.Intent{{Span: roachpb.Span{Key: key}}},
+					}
+				}
+				found = append(found, k)
+			}
+			result = append(result, found...)
+			return resumeSpan, int64(len(found)), nil
+		}
+
+		h := roachpb.Header{WaitPolicy: roachpb.SKIP_LOCKED}
+		span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("f")}
+		resumeSpan, err := scanWithWaitPolicy(h, span, tc.max, tc.reverse, scan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("reverse=%t max=%d: expected %v, got %v", tc.reverse, tc.max, tc.expected, result)
+		}
+		if !reflect.DeepEqual(resumeSpan, tc.resumeSpan) {
+			t.Errorf("reverse=%t max=%d: expected resume span %v, got %v",
+				tc.reverse, tc.max, tc.resumeSpan, resumeSpan)
+		}
+	}
+
+	// Without the SKIP_LOCKED policy, the intents are returned as errors.
+	scan := func(span roachpb.Span, max int64) (*roachpb.Span, int64, error) {
+		return nil, 0, &roachpb.WriteIntentError{}
+	}
+	span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("f")}
+	if _, err := scanWithWaitPolicy(roachpb.Header{}, span, math.MaxInt64, false, scan); err == nil {
+		t.Fatal("expected a WriteIntentError")
+	}
+}
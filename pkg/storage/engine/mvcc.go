@@ -1216,6 +1216,7 @@ func mvccPutInternal(
 	var meta *enginepb.MVCCMetadata
 	var maybeTooOldErr error
 	var prevValSize int64
+	var intentHistory []enginepb.MVCCMetadata_SequencedIntent
 	if ok {
 		// There is existing metadata for this key; ensure our write is permitted.
 		meta = &buf.meta
@@ -1245,6 +1246,23 @@ func mvccPutInternal(
 				ctx, iter, metaKey, value, ok, timestamp, txn, buf, valueFn); err != nil {
 				return err
 			}
+			// If the transaction has savepoints, it may later roll back the
+			// write we are about to perform. Remember the value of the intent
+			// we are replacing so that it can be restored in that case.
+			if txn.HasSavepoints && txn.Epoch == meta.Txn.Epoch {
+				intentKey := MVCCKey{Key: key, Timestamp: metaTimestamp}
+				iter.Seek(intentKey)
+				if ok, err := iter.Valid(); err != nil {
+					return err
+				} else if !ok || !iter.UnsafeKey().Equal(intentKey) {
+					return errors.Errorf("intent value missing for %s", intentKey)
+				}
+				prevIntentVal := append([]byte(nil), iter.UnsafeValue()...)
+				intentHistory = append(
+					append([]enginepb.MVCCMetadata_SequencedIntent(nil), meta.IntentHistory...),
+					enginepb.MVCCMetadata_SequencedIntent{Sequence: meta.Txn.Sequence, Value: prevIntentVal},
+				)
+			}
 			// We are replacing our own write intent. If we are writing at
 			// the same timestamp (see comments in else block) we can
 			// overwrite the existing intent; otherwise we must manually
@@ -1328,8 +1346,9 @@ func mvccPutInternal(
 			txnMeta = &txn.TxnMeta
 		}
 		buf.newMeta = enginepb.MVCCMetadata{
-			Txn:       txnMeta,
-			Timestamp: hlc.LegacyTimestamp(timestamp),
+			Txn:           txnMeta,
+			Timestamp:     hlc.LegacyTimestamp(timestamp),
+			IntentHistory: intentHistory,
 		}
 	}
 	newMeta := &buf.newMeta
@@ -2235,6 +2254,20 @@ func mvccResolveWriteIntent(
 		return false, nil
 	}
 
+	// A pending transaction may roll back its own writes to a savepoint. If
+	// all of the writes of the intent are rolled back, the intent is removed
+	// below like an aborted one.
+	rollback := intent.Status == roachpb.PENDING && len(intent.IgnoredSeqNums) > 0 &&
+		meta.Txn.Epoch == intent.Txn.Epoch
+	if rollback {
+		removeIntent, updated, err := mvccRollbackIntentHistory(
+			engine, ms, metaKey, meta, origMetaKeySize, origMetaValSize, intent.IgnoredSeqNums, buf,
+		)
+		if err != nil || !removeIntent {
+			return updated, err
+		}
+	}
+
 	// A commit in an older epoch or timestamp is prevented by the
 	// abort span under normal operation. Replays of EndTransaction
 	// commands which occur after the transaction record has been erased
@@ -2275,7 +2308,7 @@ func mvccResolveWriteIntent(
 	// used for resolving), but that costs latency.
 	// TODO(tschottdorf): various epoch-related scenarios here deserve more
 	// testing.
-	pushed := intent.Status == roachpb.PENDING &&
+	pushed := intent.Status == roachpb.PENDING && !rollback &&
 		hlc.Timestamp(meta.Timestamp).Less(intent.Txn.Timestamp) &&
 		meta.Txn.Epoch >= intent.Txn.Epoch
 
@@ -2365,7 +2398,7 @@ func mvccResolveWriteIntent(
 	// This method shouldn't be called in this instance, but there's
 	// nothing to do if meta's epoch is greater than or equal txn's
 	// epoch and the state is still PENDING.
-	if intent.Status == roachpb.PENDING && meta.Txn.Epoch >= intent.Txn.Epoch {
+	if intent.Status == roachpb.PENDING && meta.Txn.Epoch >= intent.Txn.Epoch && !rollback {
 		return false, nil
 	}
 
@@ -2436,6 +2469,78 @@ func mvccResolveWriteIntent(
 	return true, nil
 }
 
+// mvccRollbackIntentHistory rolls back the writes to the provided intent that
+// were performed at one of the ignored sequence numbers, restoring the latest
+// value in the intent's history that was not rolled back. It returns whether
+// all of the intent's writes were rolled back, in which case the caller is
+// responsible for removing the intent, and whether the intent was updated.
+func mvccRollbackIntentHistory(
+	engine ReadWriter,
+	ms *enginepb.MVCCStats,
+	metaKey MVCCKey,
+	meta *enginepb.MVCCMetadata,
+	origMetaKeySize, origMetaValSize int64,
+	ignoredSeqNums []enginepb.IgnoredSeqNumRange,
+	buf *putBuffer,
+) (removeIntent bool, updated bool, _ error) {
+	var history []enginepb.MVCCMetadata_SequencedIntent
+	for _, h := range meta.IntentHistory {
+		if !txnSeqIsIgnored(h.Sequence, ignoredSeqNums) {
+			history = append(history, h)
+		}
+	}
+
+	buf.newMeta = *meta
+	if txnSeqIsIgnored(meta.Txn.Sequence, ignoredSeqNums) {
+		if len(history) == 0 {
+			return true, false, nil
+		}
+		// Restore the latest value that was not rolled back. It is written at
+		// the timestamp of the intent, replacing the current value.
+		restored := history[len(history)-1]
+		history = history[:len(history)-1]
+		txnMeta := *meta.Txn
+		txnMeta.Sequence = restored.Sequence
+		buf.newMeta.Txn = &txnMeta
+		buf.newMeta.ValBytes = int64(len(restored.Value))
+		buf.newMeta.Deleted = len(restored.Value) == 0
+		versionKey := MVCCKey{Key: metaKey.Key, Timestamp: hlc.Timestamp(meta.Timestamp)}
+		if err := engine.Put(versionKey, restored.Value); err != nil {
+			return false, false, err
+		}
+	} else if len(history) == len(meta.IntentHistory) {
+		// Nothing to roll back.
+		return false, false, nil
+	}
+	buf.newMeta.IntentHistory = history
+
+	metaKeySize, metaValSize, err := buf.putMeta(engine, metaKey, &buf.newMeta)
+	if err != nil {
+		return false, false, err
+	}
+	if ms != nil {
+		ms.Add(updateStatsOnPut(metaKey.Key, 0 /* prevValSize */, origMetaKeySize, origMetaValSize,
+			metaKeySize, metaValSize, meta, &buf.newMeta))
+	}
+	engine.LogLogicalOp(MVCCUpdateIntentOpType, MVCCLogicalOpDetails{
+		Txn:       *buf.newMeta.Txn,
+		Key:       metaKey.Key,
+		Timestamp: hlc.Timestamp(meta.Timestamp),
+	})
+	return false, true, nil
+}
+
+// txnSeqIsIgnored returns whether the sequence number is contained in one of
+// the ignored sequence number ranges.
+func txnSeqIsIgnored(seq int32, ignoredSeqNums []enginepb.IgnoredSeqNumRange) bool {
+	for _, r := range ignoredSeqNums {
+		if seq >= r.Start && seq <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
 // IterAndBuf used to pass iterators and buffers between MVCC* calls, allowing
 // reuse without the callers needing to know the particulars.
 type IterAndBuf struct {
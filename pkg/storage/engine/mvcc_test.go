@@ -2673,6 +2673,69 @@ func TestMVCCResolveTxn(t *testing.T) {
 	}
 }
 
+// TestMVCCResolveIntentIgnoredSeqNums verifies that resolving the intent of a
+// pending transaction with ignored sequence numbers restores the value written
+// at the most recent sequence number that isn't ignored, or removes the intent
+// if there is none.
+func TestMVCCResolveIntentIgnoredSeqNums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	engine := createTestEngine()
+	defer engine.Close()
+
+	ctx := context.Background()
+	txn := makeTxn(*txn1, hlc.Timestamp{Logical: 1})
+	txn.HasSavepoints = true
+	for i, value := range []roachpb.Value{value1, value2, value3} {
+		txn.Sequence = int32(i + 1)
+		if err := MVCCPut(ctx, engine, nil, testKey1, txn.Timestamp, value, txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		ignored  enginepb.IgnoredSeqNumRange
+		expected *roachpb.Value
+	}{
+		{enginepb.IgnoredSeqNumRange{Start: 3, End: 3}, &value2},
+		{enginepb.IgnoredSeqNumRange{Start: 2, End: 5}, &value1},
+		{enginepb.IgnoredSeqNumRange{Start: 1, End: 5}, nil},
+	}
+	for _, tc := range testCases {
+		intent := roachpb.Intent{
+			Span:           roachpb.Span{Key: testKey1},
+			Txn:            txn.TxnMeta,
+			Status:         roachpb.PENDING,
+			IgnoredSeqNums: []enginepb.IgnoredSeqNumRange{tc.ignored},
+		}
+		if err := MVCCResolveWriteIntent(ctx, engine, nil, intent); err != nil {
+			t.Fatal(err)
+		}
+		value, _, err := MVCCGet(ctx, engine, testKey1, txn.Timestamp, true, txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.expected == nil {
+			if value != nil {
+				t.Fatalf("%v: expected no value, got %s", tc.ignored, value.RawBytes)
+			}
+			continue
+		}
+		if value == nil || !bytes.Equal(tc.expected.RawBytes, value.RawBytes) {
+			t.Fatalf("%v: expected value %s, got %v", tc.ignored, tc.expected.RawBytes, value)
+		}
+	}
+
+	// The intent was removed along with the last value.
+	var meta enginepb.MVCCMetadata
+	ok, _, _, err := engine.GetProto(MakeMVCCMetadataKey(testKey1), &meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatalf("expected the intent to be removed, found %+v", meta)
+	}
+}
+
 // TestMVCCResolveNewerIntent verifies that resolving a newer intent
 // than the committing transaction aborts the intent.
 func TestMVCCResolveNewerIntent(t *testing.T) {
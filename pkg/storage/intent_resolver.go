@@ -410,9 +410,11 @@ func (ir *intentResolver) processWriteIntentError(
 	}
 
 	// Possibly queue this processing if the write intent error is for a
-	// single intent affecting a unitary key.
+	// single intent affecting a unitary key. Requests with the ERROR wait
+	// policy never wait behind other pushers.
 	var cleanup func(*roachpb.WriteIntentError, *enginepb.TxnMeta)
-	if len(wiErr.Intents) == 1 && len(wiErr.Intents[0].Span.EndKey) == 0 {
+	if len(wiErr.Intents) == 1 && len(wiErr.Intents[0].Span.EndKey) == 0 &&
+		h.WaitPolicy != roachpb.ERROR {
 		var done bool
 		// Note that the write intent error may be mutated here in the event
 		// that this pusher is queued to wait for a different transaction
@@ -426,6 +428,11 @@ func (ir *intentResolver) processWriteIntentError(
 		ctx, wiErr.Intents, h, pushType, false, /* skipIfInFlight */
 	)
 	if pErr != nil {
+		if _, ok := pErr.GetDetail().(*roachpb.TransactionPushError); ok && h.WaitPolicy == roachpb.ERROR {
+			// The conflicting transaction is still active. Instead of waiting
+			// for it, report the lock as unavailable.
+			pErr = roachpb.NewError(roachpb.NewLockNotAvailableError(wiErr.Intents[0]))
+		}
 		return cleanup, pErr
 	}
 
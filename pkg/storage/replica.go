@@ -6373,6 +6373,11 @@ func evaluateBatch(
 						// will succeed on a retry, so better to short circuit and return the
 						// write too old error.
 						returnWriteTooOldErr = true
+					case *roachpb.ScanRequest, *roachpb.ReverseScanRequest:
+						// Locking scans are also an exception. The rows they
+						// return were read below the newer values they locked,
+						// so they have to be read again at a higher timestamp.
+						returnWriteTooOldErr = true
 					}
 				}
 				if ba.Txn != nil {
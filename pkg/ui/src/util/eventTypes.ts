@@ -18,22 +18,44 @@ export const DROP_TABLE = "drop_table";
 export const TRUNCATE_TABLE = "truncate_table";
 // Recorded when a table is altered.
 export const ALTER_TABLE = "alter_table";
+// Recorded when a table is commented.
+export const COMMENT_ON_TABLE = "comment_on_table";
+// Recorded when a column is commented.
+export const COMMENT_ON_COLUMN = "comment_on_column";
 // Recorded when an index is created.
 export const CREATE_INDEX = "create_index";
 // Recorded when an index is dropped.
 export const DROP_INDEX = "drop_index";
 // Recorded when an index is altered.
 export const ALTER_INDEX = "alter_index";
+// Recorded when an index is commented.
+export const COMMENT_ON_INDEX = "comment_on_index";
 // Recorded when a view is created.
 export const CREATE_VIEW = "create_view";
 // Recorded when a view is dropped.
 export const DROP_VIEW = "drop_view";
+// Recorded when the refresh of a materialized view is started.
+export const REFRESH_MATERIALIZED_VIEW = "refresh_materialized_view";
 // Recorded when a sequence is created.
 export const CREATE_SEQUENCE = "create_sequence";
 // Recorded when a sequence is altered.
 export const ALTER_SEQUENCE = "alter_sequence";
 // Recorded when a sequence is dropped.
 export const DROP_SEQUENCE = "drop_sequence";
+// Recorded when a type is created.
+export const CREATE_TYPE = "create_type";
+// Recorded when a type is dropped.
+export const DROP_TYPE = "drop_type";
+// Recorded when a type is altered.
+export const ALTER_TYPE = "alter_type";
+// Recorded when a function is created.
+export const CREATE_FUNCTION = "create_function";
+// Recorded when a function is dropped.
+export const DROP_FUNCTION = "drop_function";
+// Recorded when a trigger is created.
+export const CREATE_TRIGGER = "create_trigger";
+// Recorded when a trigger is dropped.
+export const DROP_TRIGGER = "drop_trigger";
 // Recorded when an in-progress schema change encounters a problem and is
 // reversed.
 export const REVERSE_SCHEMA_CHANGE = "reverse_schema_change";
@@ -60,9 +82,10 @@ export const REMOVE_ZONE_CONFIG = "remove_zone_config";
 export const nodeEvents = [NODE_JOIN, NODE_RESTART, NODE_DECOMMISSIONED, NODE_RECOMMISSIONED];
 export const databaseEvents = [CREATE_DATABASE, DROP_DATABASE];
 export const tableEvents = [
-  CREATE_TABLE, DROP_TABLE, TRUNCATE_TABLE, ALTER_TABLE, CREATE_INDEX,
-  ALTER_INDEX, DROP_INDEX, CREATE_VIEW, DROP_VIEW, REVERSE_SCHEMA_CHANGE,
-  FINISH_SCHEMA_CHANGE, FINISH_SCHEMA_CHANGE_ROLLBACK,
+  CREATE_TABLE, DROP_TABLE, TRUNCATE_TABLE, ALTER_TABLE, COMMENT_ON_TABLE,
+  COMMENT_ON_COLUMN, CREATE_INDEX, ALTER_INDEX, DROP_INDEX, COMMENT_ON_INDEX,
+  CREATE_VIEW, DROP_VIEW, REFRESH_MATERIALIZED_VIEW, CREATE_TRIGGER, DROP_TRIGGER,
+  REVERSE_SCHEMA_CHANGE, FINISH_SCHEMA_CHANGE, FINISH_SCHEMA_CHANGE_ROLLBACK,
 ];
 export const settingsEvents = [SET_CLUSTER_SETTING, SET_ZONE_CONFIG, REMOVE_ZONE_CONFIG];
 export const allEvents = [...nodeEvents, ...databaseEvents, ...tableEvents, ...settingsEvents];
@@ -26,22 +26,44 @@ export function getEventDescription(e: Event$Properties): string {
       return `Table Truncated: User ${info.User} truncated table ${info.TableName}`;
     case eventTypes.ALTER_TABLE:
       return `Schema Change: User ${info.User} began a schema change to alter table ${info.TableName} with ID ${info.MutationID}`;
+    case eventTypes.COMMENT_ON_TABLE:
+      return `Table Commented: User ${info.User} commented on table ${info.TableName}`;
+    case eventTypes.COMMENT_ON_COLUMN:
+      return `Column Commented: User ${info.User} commented on column ${info.ColumnName} of table ${info.TableName}`;
     case eventTypes.CREATE_INDEX:
       return `Schema Change: User ${info.User} began a schema change to create an index ${info.IndexName} on table ${info.TableName} with ID ${info.MutationID}`;
     case eventTypes.DROP_INDEX:
       return `Schema Change: User ${info.User} began a schema change to drop index ${info.IndexName} on table ${info.TableName} with ID ${info.MutationID}`;
     case eventTypes.ALTER_INDEX:
       return `Schema Change: User ${info.User} began a schema change to alter index ${info.IndexName} on table ${info.TableName} with ID ${info.MutationID}`;
+    case eventTypes.COMMENT_ON_INDEX:
+      return `Index Commented: User ${info.User} commented on index ${info.IndexName} of table ${info.TableName}`;
     case eventTypes.CREATE_VIEW:
       return `View Created: User ${info.User} created view ${info.ViewName}`;
     case eventTypes.DROP_VIEW:
       return `View Dropped: User ${info.User} dropped view ${info.ViewName}`;
+    case eventTypes.REFRESH_MATERIALIZED_VIEW:
+      return `Materialized View Refreshed: User ${info.User} refreshed materialized view ${info.ViewName}`;
     case eventTypes.CREATE_SEQUENCE:
       return `Sequence Created: User ${info.User} created sequence ${info.SequenceName}`;
     case eventTypes.ALTER_SEQUENCE:
       return `Sequence Altered: User ${info.User} altered sequence ${info.SequenceName}`;
     case eventTypes.DROP_SEQUENCE:
       return `Sequence Dropped: User ${info.User} dropped sequence ${info.SequenceName}`;
+    case eventTypes.CREATE_TYPE:
+      return `Type Created: User ${info.User} created type ${info.TypeName}`;
+    case eventTypes.DROP_TYPE:
+      return `Type Dropped: User ${info.User} dropped type ${info.TypeName}`;
+    case eventTypes.ALTER_TYPE:
+      return `Type Altered: User ${info.User} altered type ${info.TypeName}`;
+    case eventTypes.CREATE_FUNCTION:
+      return `Function Created: User ${info.User} created function ${info.FunctionName}`;
+    case eventTypes.DROP_FUNCTION:
+      return `Function Dropped: User ${info.User} dropped function ${info.FunctionName}`;
+    case eventTypes.CREATE_TRIGGER:
+      return `Trigger Created: User ${info.User} created trigger ${info.TriggerName} on table ${info.TableName}`;
+    case eventTypes.DROP_TRIGGER:
+      return `Trigger Dropped: User ${info.User} dropped trigger ${info.TriggerName} on table ${info.TableName}`;
     case eventTypes.REVERSE_SCHEMA_CHANGE:
       return `Schema Change Reversed: Schema change with ID ${info.MutationID} was reversed.`;
     case eventTypes.FINISH_SCHEMA_CHANGE:
@@ -77,9 +99,13 @@ export interface EventInfo {
   DatabaseName?: string;
   TableName?: string;
   IndexName?: string;
+  ColumnName?: string;
   MutationID?: string;
   ViewName?: string;
   SequenceName?: string;
+  TypeName?: string;
+  FunctionName?: string;
+  TriggerName?: string;
   SettingName?: string;
   Value?: string;
   Target?: string;
@@ -33,6 +33,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -952,6 +954,58 @@ func decodeTime(b []byte) (r []byte, sec int64, nsec int64, err error) {
 	return b, sec, nsec, nil
 }
 
+// timeTZOffsetRange is the number of distinct time zone offsets of a
+// timetz.TimeTZ.
+const timeTZOffsetRange = 2*timetz.MaxOffsetSecs + 1
+
+// EncodeTimeTZAscending encodes a timetz.TimeTZ value, appends it to the
+// supplied buffer, and returns the final buffer. The encoding is guaranteed
+// to be ordered such that if t1.Compare(t2) < 0 (or = 0 or > 0) then
+// bytes.Compare will order them the same way after encoding. The UTC time
+// and the offset are combined into a single varint, ordered by UTC time
+// and then by offset, easternmost first.
+func EncodeTimeTZAscending(b []byte, t timetz.TimeTZ) []byte {
+	return EncodeVarintAscending(b, encodeTimeTZ(t))
+}
+
+// EncodeTimeTZDescending is the descending version of EncodeTimeTZAscending.
+func EncodeTimeTZDescending(b []byte, t timetz.TimeTZ) []byte {
+	return EncodeVarintDescending(b, encodeTimeTZ(t))
+}
+
+// DecodeTimeTZAscending decodes a timetz.TimeTZ value which was encoded
+// using EncodeTimeTZAscending. The remainder of the input buffer and the
+// decoded value are returned.
+func DecodeTimeTZAscending(b []byte) ([]byte, timetz.TimeTZ, error) {
+	b, v, err := DecodeVarintAscending(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, decodeTimeTZ(v), nil
+}
+
+// DecodeTimeTZDescending is the descending version of DecodeTimeTZAscending.
+func DecodeTimeTZDescending(b []byte) ([]byte, timetz.TimeTZ, error) {
+	b, v, err := DecodeVarintDescending(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, decodeTimeTZ(v), nil
+}
+
+func encodeTimeTZ(t timetz.TimeTZ) int64 {
+	return t.UTCMicros()*timeTZOffsetRange + int64(timetz.MaxOffsetSecs-t.OffsetSecs)
+}
+
+func decodeTimeTZ(v int64) timetz.TimeTZ {
+	utcMicros, rem := v/timeTZOffsetRange, v%timeTZOffsetRange
+	if rem < 0 {
+		utcMicros, rem = utcMicros-1, rem+timeTZOffsetRange
+	}
+	offsetSecs := timetz.MaxOffsetSecs - int32(rem)
+	return timetz.MakeTimeTZ(timeofday.TimeOfDay(utcMicros+int64(offsetSecs)*1e6), offsetSecs)
+}
+
 // EncodeDurationAscending encodes a duration.Duration value, appends it to the
 // supplied buffer, and returns the final buffer. The encoding is guaranteed to
 // be ordered such that if t1.Compare(t2) < 0 (or = 0 or > 0) then bytes.Compare
@@ -1198,6 +1252,7 @@ const (
 	Tuple        Type = 16
 	BitArray     Type = 17
 	BitArrayDesc Type = 18 // BitArray encoded descendingly
+	TimeTZ       Type = 19
 )
 
 // PeekType peeks at the type of the value encoded at the start of b.
@@ -1792,6 +1847,20 @@ func EncodeUntaggedTimeValue(appendTo []byte, t time.Time) []byte {
 	return EncodeNonsortingStdlibVarint(appendTo, int64(t.Nanosecond()))
 }
 
+// EncodeTimeTZValue encodes a timetz.TimeTZ value with its value tag, appends
+// it to the supplied buffer, and returns the final buffer.
+func EncodeTimeTZValue(appendTo []byte, colID uint32, t timetz.TimeTZ) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, TimeTZ)
+	return EncodeUntaggedTimeTZValue(appendTo, t)
+}
+
+// EncodeUntaggedTimeTZValue encodes a timetz.TimeTZ value, appends it to the
+// supplied buffer, and returns the final buffer.
+func EncodeUntaggedTimeTZValue(appendTo []byte, t timetz.TimeTZ) []byte {
+	appendTo = EncodeNonsortingStdlibVarint(appendTo, int64(t.TimeOfDay))
+	return EncodeNonsortingStdlibVarint(appendTo, int64(t.OffsetSecs))
+}
+
 // EncodeDecimalValue encodes an apd.Decimal value with its value tag, appends
 // it to the supplied buffer, and returns the final buffer.
 func EncodeDecimalValue(appendTo []byte, colID uint32, d *apd.Decimal) []byte {
@@ -2025,6 +2094,30 @@ func DecodeUntaggedTimeValue(b []byte) (remaining []byte, t time.Time, err error
 	return b, timeutil.Unix(sec, nsec), nil
 }
 
+// DecodeTimeTZValue decodes a value encoded by EncodeTimeTZValue.
+func DecodeTimeTZValue(b []byte) (remaining []byte, t timetz.TimeTZ, err error) {
+	b, err = decodeValueTypeAssert(b, TimeTZ)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return DecodeUntaggedTimeTZValue(b)
+}
+
+// DecodeUntaggedTimeTZValue decodes a value encoded by
+// EncodeUntaggedTimeTZValue.
+func DecodeUntaggedTimeTZValue(b []byte) (remaining []byte, t timetz.TimeTZ, err error) {
+	var micros, offsetSecs int64
+	b, _, micros, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	b, _, offsetSecs, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, timetz.MakeTimeTZ(timeofday.TimeOfDay(micros), int32(offsetSecs)), nil
+}
+
 // DecodeDecimalValue decodes a value encoded by EncodeDecimalValue.
 func DecodeDecimalValue(b []byte) (remaining []byte, d apd.Decimal, err error) {
 	b, err = decodeValueTypeAssert(b, Decimal)
@@ -2216,7 +2309,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 	case Decimal:
 		_, n, i, err := DecodeNonsortingStdlibUvarint(b)
 		return dataOffset + n + int(i), err
-	case Time:
+	case Time, TimeTZ:
 		n, err := getMultiNonsortingVarintLen(b, 2)
 		return dataOffset + n, err
 	case Duration:
@@ -2307,6 +2400,13 @@ func PrettyPrintValueEncoded(b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, t.UTC().Format(time.RFC3339Nano), nil
+	case TimeTZ:
+		var t timetz.TimeTZ
+		b, t, err = DecodeTimeTZValue(b)
+		if err != nil {
+			return b, "", err
+		}
+		return b, t.String(), nil
 	case Duration:
 		var d duration.Duration
 		b, d, err = DecodeDurationValue(b)
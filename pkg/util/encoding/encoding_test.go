@@ -30,6 +30,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -1670,6 +1672,63 @@ func TestValueEncodeDecodeTime(t *testing.T) {
 	}
 }
 
+func TestValueEncodeDecodeTimeTZ(t *testing.T) {
+	rng, seed := randutil.NewPseudoRand()
+	tests := make([]timetz.TimeTZ, 1000)
+	for i := range tests {
+		tests[i] = timetz.Random(rng)
+	}
+	for _, test := range tests {
+		buf := EncodeTimeTZValue(nil, NoColumnID, test)
+		_, x, err := DecodeTimeTZValue(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x != test {
+			t.Errorf("seed %d: expected %v got %v", seed, test, x)
+		}
+	}
+}
+
+func TestEncodeDecodeTimeTZ(t *testing.T) {
+	rng, seed := randutil.NewPseudoRand()
+	values := []timetz.TimeTZ{timetz.Min, timetz.Max}
+	for i := 0; i < 1000; i++ {
+		values = append(values, timetz.Random(rng))
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		for i := range values {
+			a, b := values[i], values[(i+1)%len(values)]
+			var encA, encB []byte
+			if dir == Ascending {
+				encA, encB = EncodeTimeTZAscending(nil, a), EncodeTimeTZAscending(nil, b)
+			} else {
+				encA, encB = EncodeTimeTZDescending(nil, a), EncodeTimeTZDescending(nil, b)
+			}
+			var decoded timetz.TimeTZ
+			var err error
+			if dir == Ascending {
+				_, decoded, err = DecodeTimeTZAscending(encA)
+			} else {
+				_, decoded, err = DecodeTimeTZDescending(encA)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded != a {
+				t.Errorf("seed %d: expected %v got %v", seed, a, decoded)
+			}
+			expected := a.Compare(b)
+			if dir == Descending {
+				expected = -expected
+			}
+			if c := bytes.Compare(encA, encB); c != expected {
+				t.Errorf("seed %d: expected %v vs %v to compare %d, got %d", seed, a, b, expected, c)
+			}
+		}
+	}
+}
+
 func TestValueEncodeDecodeBitArray(t *testing.T) {
 	rng, seed := randutil.NewPseudoRand()
 	rd := randData{rng}
@@ -2129,6 +2188,8 @@ func TestPrettyPrintValueEncoded(t *testing.T) {
 		{EncodeDecimalValue(nil, NoColumnID, apd.New(628, -2)), "6.28"},
 		{EncodeTimeValue(nil, NoColumnID,
 			time.Date(2016, 6, 29, 16, 2, 50, 5, time.UTC)), "2016-06-29T16:02:50.000000005Z"},
+		{EncodeTimeTZValue(nil, NoColumnID,
+			timetz.MakeTimeTZ(timeofday.New(16, 2, 50, 5), -8*60*60)), "16:02:50.000005-08"},
 		{EncodeDurationValue(nil, NoColumnID,
 			duration.Duration{Months: 1, Days: 2, Nanos: 3}), "1mon2d3ns"},
 		{EncodeBytesValue(nil, NoColumnID, []byte{0x1, 0x2, 0xF, 0xFF}), "0x01020fff"},
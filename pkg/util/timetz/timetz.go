@@ -0,0 +1,150 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package timetz
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+const (
+	// MaxOffsetSecs is the maximum absolute time zone offset, as in
+	// postgres (15:59).
+	MaxOffsetSecs = (15*60 + 59) * 60
+
+	microsecondsPerSecond = 1e6
+	nanosPerMicro         = 1000
+)
+
+var (
+	// Min is the minimum TimeTZ value: midnight at the easternmost offset.
+	Min = MakeTimeTZ(timeofday.Min, MaxOffsetSecs)
+	// Max is the maximum TimeTZ value: 1 microsecond before midnight at the
+	// westernmost offset.
+	Max = MakeTimeTZ(timeofday.Max, -MaxOffsetSecs)
+)
+
+// TimeTZ represents a time of day (no date) with a time zone offset, as in
+// the TIME WITH TIME ZONE type of postgres. As in postgres, the offset is
+// fixed: it is not a time zone, and it is not adjusted for daylight
+// savings time.
+type TimeTZ struct {
+	timeofday.TimeOfDay
+	// OffsetSecs is the offset of the time zone in seconds east of UTC.
+	OffsetSecs int32
+}
+
+// MakeTimeTZ creates a TimeTZ from a TimeOfDay and an offset in seconds
+// east of UTC.
+func MakeTimeTZ(t timeofday.TimeOfDay, offsetSecs int32) TimeTZ {
+	return TimeTZ{TimeOfDay: t, OffsetSecs: offsetSecs}
+}
+
+// MakeTimeTZFromTime constructs a TimeTZ from a time.Time, ignoring the
+// date. The offset is the offset of the time zone of t at that time.
+func MakeTimeTZFromTime(t time.Time) TimeTZ {
+	_, offset := t.Zone()
+	return MakeTimeTZ(timeofday.FromTime(t), int32(offset))
+}
+
+// MakeTimeTZFromLocation constructs a TimeTZ from a TimeOfDay in the
+// given location. Since a time of day has no date, the offset of loc is
+// taken on the Unix epoch.
+func MakeTimeTZFromLocation(t timeofday.TimeOfDay, loc *time.Location) TimeTZ {
+	_, offset := time.Date(1970, 1, 1, t.Hour(), t.Minute(), t.Second(), 0, loc).Zone()
+	return MakeTimeTZ(t, int32(offset))
+}
+
+// ToTime converts a TimeTZ to a time.Time with a fixed time zone, using
+// the Unix epoch as the date.
+func (t TimeTZ) ToTime() time.Time {
+	loc := time.FixedZone("", int(t.OffsetSecs))
+	return timeutil.Unix(0, t.UTCMicros()*nanosPerMicro).In(loc)
+}
+
+// UTCMicros returns the time of day in UTC, in microseconds since
+// midnight. The result is not wrapped into a single day: it is negative
+// or exceeds a day when the offset moves the time to the previous or the
+// next day.
+func (t TimeTZ) UTCMicros() int64 {
+	return int64(t.TimeOfDay) - int64(t.OffsetSecs)*microsecondsPerSecond
+}
+
+// Compare returns -1, 0 or 1 if t is respectively less than, equal to or
+// greater than other. As in postgres, values are ordered by their UTC
+// time first, and values that represent the same UTC time are ordered
+// by their offset, westernmost last.
+func (t TimeTZ) Compare(other TimeTZ) int {
+	if a, b := t.UTCMicros(), other.UTCMicros(); a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	if t.OffsetSecs > other.OffsetSecs {
+		return -1
+	} else if t.OffsetSecs < other.OffsetSecs {
+		return 1
+	}
+	return 0
+}
+
+// Add adds a Duration to a TimeTZ, wrapping into the next day if
+// necessary. The offset is unchanged.
+func (t TimeTZ) Add(d duration.Duration) TimeTZ {
+	return MakeTimeTZ(t.TimeOfDay.Add(d), t.OffsetSecs)
+}
+
+// ToOffset returns the same instant as t, at the given offset.
+func (t TimeTZ) ToOffset(offsetSecs int32) TimeTZ {
+	return MakeTimeTZ(
+		timeofday.FromInt(t.UTCMicros()+int64(offsetSecs)*microsecondsPerSecond), offsetSecs)
+}
+
+func (t TimeTZ) String() string {
+	return t.TimeOfDay.String() + FormatOffset(t.OffsetSecs)
+}
+
+// FormatOffset formats an offset in seconds east of UTC as postgres does:
+// the minutes and the seconds are omitted when they are zero.
+func FormatOffset(offsetSecs int32) string {
+	sign := '+'
+	if offsetSecs < 0 {
+		sign = '-'
+		offsetSecs = -offsetSecs
+	}
+	hours, mins, secs := offsetSecs/3600, (offsetSecs/60)%60, offsetSecs%60
+	var b strings.Builder
+	fmt.Fprintf(&b, "%c%02d", sign, hours)
+	if mins != 0 || secs != 0 {
+		fmt.Fprintf(&b, ":%02d", mins)
+	}
+	if secs != 0 {
+		fmt.Fprintf(&b, ":%02d", secs)
+	}
+	return b.String()
+}
+
+// Random generates a random TimeTZ, with an offset that is a whole number
+// of minutes.
+func Random(rng *rand.Rand) TimeTZ {
+	offsetMins := rng.Int31n(2*MaxOffsetSecs/60+1) - MaxOffsetSecs/60
+	return MakeTimeTZ(timeofday.Random(rng), offsetMins*60)
+}
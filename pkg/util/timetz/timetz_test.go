@@ -0,0 +1,108 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package timetz
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+)
+
+func TestString(t *testing.T) {
+	testData := []struct {
+		t   TimeTZ
+		exp string
+	}{
+		{MakeTimeTZ(timeofday.New(1, 2, 3, 0), 0), "01:02:03+00"},
+		{MakeTimeTZ(timeofday.New(1, 2, 3, 456000), -8*3600), "01:02:03.456-08"},
+		{MakeTimeTZ(timeofday.New(1, 2, 3, 0), 5*3600+30*60), "01:02:03+05:30"},
+		{MakeTimeTZ(timeofday.New(1, 2, 3, 0), -(3600 + 2*60 + 3)), "01:02:03-01:02:03"},
+	}
+	for i, td := range testData {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			actual := td.t.String()
+			if actual != td.exp {
+				t.Errorf("expected %s, got %s", td.exp, actual)
+			}
+		})
+	}
+}
+
+func TestFromAndToTime(t *testing.T) {
+	testData := []struct {
+		s   string
+		exp string
+	}{
+		{"2017-01-01T12:00:00.5Z", "1970-01-01T12:00:00.5Z"},
+		{"2017-01-01T12:00:00-05:00", "1970-01-01T12:00:00-05:00"},
+		{"2017-01-01T01:00:00+05:30", "1970-01-01T01:00:00+05:30"},
+	}
+	for _, td := range testData {
+		t.Run(td.s, func(t *testing.T) {
+			fromTime, err := time.Parse(time.RFC3339Nano, td.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := MakeTimeTZFromTime(fromTime).ToTime().Format(time.RFC3339Nano)
+			if actual != td.exp {
+				t.Errorf("expected %s, got %s", td.exp, actual)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	noon := timeofday.New(12, 0, 0, 0)
+	testData := []struct {
+		a, b TimeTZ
+		exp  int
+	}{
+		{MakeTimeTZ(noon, 0), MakeTimeTZ(noon, 0), 0},
+		{MakeTimeTZ(noon, 0), MakeTimeTZ(timeofday.New(12, 0, 0, 1), 0), -1},
+		// 12:00+01 is 11:00 UTC.
+		{MakeTimeTZ(noon, 3600), MakeTimeTZ(noon, 0), -1},
+		// The same instant at different offsets: the westernmost is last.
+		{MakeTimeTZ(timeofday.New(13, 0, 0, 0), 3600), MakeTimeTZ(noon, 0), -1},
+		{MakeTimeTZ(noon, 0), MakeTimeTZ(timeofday.New(13, 0, 0, 0), 3600), 1},
+	}
+	for i, td := range testData {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if actual := td.a.Compare(td.b); actual != td.exp {
+				t.Errorf("expected %d, got %d", td.exp, actual)
+			}
+		})
+	}
+}
+
+func TestToOffset(t *testing.T) {
+	testData := []struct {
+		t      TimeTZ
+		offset int32
+		exp    string
+	}{
+		{MakeTimeTZ(timeofday.New(12, 0, 0, 0), 0), -8 * 3600, "04:00:00-08"},
+		{MakeTimeTZ(timeofday.New(1, 0, 0, 0), 0), -8 * 3600, "17:00:00-08"},
+		{MakeTimeTZ(timeofday.New(23, 0, 0, 0), -3600), 3600, "01:00:00+01"},
+	}
+	for i, td := range testData {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if actual := td.t.ToOffset(td.offset).String(); actual != td.exp {
+				t.Errorf("expected %s, got %s", td.exp, actual)
+			}
+		})
+	}
+}